@@ -0,0 +1,27 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"readmodels/cmd/provider"
+	database "readmodels/internal/db"
+)
+
+// Run executes the administrative subcommand given in args, e.g.
+// `readModels migrate status`.
+func Run(ctx context.Context, provider *provider.Provider, database *database.Database, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrate(ctx, database, args[1:])
+	case "reconcile":
+		return runReconcile(provider, database, args[1:])
+	case "consumer":
+		return runConsumer(provider, args[1:])
+	case "search":
+		return runSearch(provider, database, args[1:])
+	case "users":
+		return runUsers(ctx, provider, database, args[1:])
+	default:
+		return fmt.Errorf("unknown command %s", args[0])
+	}
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"readmodels/cmd/provider"
+	"readmodels/infrastructure/kafka"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+const consumerUsage = "usage: consumer reset-offsets --topic <topic> (--to-earliest | --to-latest | --to-offset <offset> | --to-datetime <RFC3339>) [--accept-double-counting] [--confirm]"
+
+func runConsumer(provider *provider.Provider, args []string) error {
+	if len(args) == 0 || args[0] != "reset-offsets" {
+		return errors.New(consumerUsage)
+	}
+
+	flags := flag.NewFlagSet("consumer reset-offsets", flag.ContinueOnError)
+	topic := flags.String("topic", "", "topic whose offsets are reset")
+	toEarliest := flags.Bool("to-earliest", false, "reset to the first available message")
+	toLatest := flags.Bool("to-latest", false, "reset to the end of the topic, skipping pending messages")
+	toOffset := flags.Int64("to-offset", -1, "reset every partition to the given offset")
+	toDatetime := flags.String("to-datetime", "", "reset to the first message published at or after the given time")
+	acceptDoubleCounting := flags.Bool("accept-double-counting", false, "allow rewinding, which projects the replayed events again and counts their likes, superlikes, comments and reviews twice")
+	confirm := flags.Bool("confirm", false, "apply the reset instead of only printing it")
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+	if *topic == "" {
+		return errors.New(consumerUsage)
+	}
+
+	target := kafka.OffsetTarget{
+		Earliest: *toEarliest,
+		Latest:   *toLatest,
+	}
+	if *toOffset >= 0 {
+		target.Offset = toOffset
+	}
+	if *toDatetime != "" {
+		timestamp, err := time.Parse(time.RFC3339, *toDatetime)
+		if err != nil {
+			return fmt.Errorf("invalid datetime %s, expected RFC3339 like 2024-01-02T15:04:05Z", *toDatetime)
+		}
+		target.Timestamp = &timestamp
+	}
+
+	resetter, err := provider.ProvideOffsetResetter()
+	if err != nil {
+		return err
+	}
+	defer resetter.Close()
+	if *acceptDoubleCounting {
+		resetter.AllowReplays()
+	}
+
+	resets, err := resetter.Plan(*topic, target)
+	if err != nil {
+		return err
+	}
+	err = printOffsetResets(resets)
+	if err != nil {
+		return err
+	}
+	if !*acceptDoubleCounting && slices.ContainsFunc(resets, (*kafka.OffsetReset).Rewinds) {
+		fmt.Println("The reset rewinds the group and the projections aren't idempotent: the replayed events would be counted twice.")
+		fmt.Println("Rerun with --accept-double-counting to reset anyway, then run reconcile to correct the counters")
+		return nil
+	}
+
+	if !*confirm {
+		fmt.Println("Nothing was changed, rerun with --confirm to reset the offsets")
+		return nil
+	}
+
+	err = resetter.Apply(resets)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Reset the offsets of %d partitions\n", len(resets))
+	return nil
+}
+
+func printOffsetResets(resets []*kafka.OffsetReset) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "TOPIC\tPARTITION\tCURRENT\tTARGET")
+	for _, reset := range resets {
+		current := "-"
+		if reset.Current >= 0 {
+			current = fmt.Sprint(reset.Current)
+		}
+		fmt.Fprintf(writer, "%s\t%d\t%s\t%d\n", reset.Topic, reset.Partition, current, reset.Target)
+	}
+	return writer.Flush()
+}
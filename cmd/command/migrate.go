@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	database "readmodels/internal/db"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = "usage: migrate status | migrate up [--dry-run] | migrate to <version> [--dry-run]"
+
+func runMigrate(ctx context.Context, db *database.Database, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "print pending migrations without applying them")
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	migrator := database.NewMigrator(db.Client, database.Migrations())
+
+	switch args[0] {
+	case "status":
+		return printMigrationStatus(migrator)
+	case "up":
+		applied, err := migrator.Up(ctx, *dryRun)
+		if err != nil {
+			return err
+		}
+		printAppliedMigrations(applied, *dryRun)
+		return nil
+	case "to":
+		if flags.NArg() != 1 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.Atoi(flags.Arg(0))
+		if err != nil {
+			return fmt.Errorf("invalid migration version %s", flags.Arg(0))
+		}
+		applied, err := migrator.To(ctx, version, *dryRun)
+		if err != nil {
+			return err
+		}
+		printAppliedMigrations(applied, *dryRun)
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+func printMigrationStatus(migrator *database.Migrator) error {
+	statuses, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tSTATUS\tAPPLIED AT\tDESCRIPTION")
+	for _, status := range statuses {
+		state := "pending"
+		appliedAt := "-"
+		if status.Applied {
+			state = "applied"
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", status.Version, state, appliedAt, status.Description)
+	}
+
+	return writer.Flush()
+}
+
+func printAppliedMigrations(migrations []database.Migration, dryRun bool) {
+	if len(migrations) == 0 {
+		fmt.Println("No pending migrations")
+		return
+	}
+
+	action := "Applied"
+	if dryRun {
+		action = "Would apply"
+	}
+	for _, migration := range migrations {
+		fmt.Printf("%s migration %d: %s\n", action, migration.Version, migration.Description)
+		for _, step := range migration.Steps {
+			fmt.Printf("  - %s\n", step.Describe())
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"readmodels/cmd/provider"
+	database "readmodels/internal/db"
+	"strings"
+	"text/tabwriter"
+)
+
+func runReconcile(provider *provider.Provider, db *database.Database, args []string) error {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "print drifted counters without fixing them")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	corrections, err := provider.ProvideReconciliationService(db).Reconcile(*dryRun)
+	if err != nil {
+		return err
+	}
+
+	if len(corrections) == 0 {
+		fmt.Println("All counters are consistent")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "TABLE\tKEY\tCOUNTER\tSTORED\tACTUAL")
+	for _, correction := range corrections {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%d\n", correction.Table, correction.Key, correction.Counter, correction.Stored, correction.Actual)
+	}
+	err = writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		fmt.Printf("Would fix %d counters\n", len(corrections))
+	} else {
+		fmt.Printf("Fixed %d counters\n", len(corrections))
+		if strings.TrimSpace(os.Getenv("REDIS_ADDRESS")) == "" {
+			// The command only clears its own in-process cache
+			fmt.Println("Running instances serve the old counters until their cached entries expire")
+		}
+	}
+	return nil
+}
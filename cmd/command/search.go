@@ -0,0 +1,76 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"readmodels/cmd/provider"
+	"readmodels/infrastructure/bleve"
+	"readmodels/infrastructure/kafka"
+	database "readmodels/internal/db"
+)
+
+const searchUsage = "usage: search rebuild"
+
+// runSearch rebuilds the search index of this host from the database. The
+// index can only be opened by one process and the offsets of its consumer
+// group can't be moved while it has members, so the service using it has to
+// be stopped first.
+func runSearch(provider *provider.Provider, db *database.Database, args []string) error {
+	if len(args) == 0 || args[0] != "rebuild" {
+		return errors.New(searchUsage)
+	}
+
+	flags := flag.NewFlagSet("search rebuild", flag.ContinueOnError)
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	searchIndex, err := provider.ProvideSearchIndex()
+	if err != nil {
+		return fmt.Errorf("couldn't open the search index, stop the service using it before rebuilding: %w", err)
+	}
+	defer searchIndex.Close()
+
+	posts, users, err := RebuildSearchIndex(provider, db, searchIndex)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Indexed %d posts and %d users\n", posts, users)
+	return nil
+}
+
+// RebuildSearchIndex fills the search index from the database and moves its
+// consumer group to the end of the topics as they were before reading it.
+// The events published during the rebuild are consumed again afterwards,
+// which only rewrites the same documents.
+func RebuildSearchIndex(provider *provider.Provider, db *database.Database, searchIndex *bleve.SearchIndex) (int, int, error) {
+	searchService := provider.ProvideSearchService(db, searchIndex)
+	resetter, topics, err := provider.ProvideSearchOffsetResetter(searchService)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resetter.Close()
+
+	resets := []*kafka.OffsetReset{}
+	for _, topic := range topics {
+		topicResets, err := resetter.Plan(topic, kafka.OffsetTarget{Latest: true})
+		if err != nil {
+			return 0, 0, err
+		}
+		resets = append(resets, topicResets...)
+	}
+
+	posts, users, err := searchService.Rebuild()
+	if err != nil {
+		return 0, 0, err
+	}
+	err = resetter.Apply(resets)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return posts, users, searchIndex.MarkBuilt()
+}
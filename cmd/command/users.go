@@ -0,0 +1,108 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"readmodels/cmd/provider"
+	common_data "readmodels/internal/common/data"
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"readmodels/internal/userprofile"
+	userprofile_handler "readmodels/internal/userprofile/handlers"
+
+	"github.com/rs/zerolog/log"
+)
+
+const usersUsage = "usage: users backfill-regions [--dry-run]"
+
+const userWasRegisteredEventType = "UserWasRegisteredEvent"
+
+// runUsers fills the user type and region of the profiles created before they
+// were stored, which the region listing leaves out, from the
+// UserWasRegisteredEvent still retained in Kafka. The profiles whose event is
+// no longer retained are reported and make the command fail.
+func runUsers(ctx context.Context, provider *provider.Provider, db *database.Database, args []string) error {
+	if len(args) == 0 || args[0] != "backfill-regions" {
+		return errors.New(usersUsage)
+	}
+
+	flags := flag.NewFlagSet("users backfill-regions", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "only print the profiles without user type and region")
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	userProfileService := provider.ProvideUserProfileService(db)
+	if !*dryRun {
+		err = backfillRegions(ctx, provider, userProfileService)
+		if err != nil {
+			return err
+		}
+	}
+
+	missing, err := userProfileService.GetUserProfilesWithoutUserType()
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		fmt.Println("Every user profile has its user type and region")
+		return nil
+	}
+	for _, userProfile := range missing {
+		fmt.Println(userProfile.Username)
+	}
+	return fmt.Errorf("%d user profiles have no user type and region", len(missing))
+}
+
+func backfillRegions(ctx context.Context, provider *provider.Provider, userProfileService *userprofile.UserProfileService) error {
+	registry, err := provider.ProvideEventRegistry()
+	if err != nil {
+		return err
+	}
+	reader, err := provider.ProvideTopicReader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	updated := 0
+	failed := 0
+	events, err := reader.Read(ctx, userWasRegisteredEventType, func(data []byte) {
+		envelope, err := registry.Decode(userWasRegisteredEventType, data)
+		if err != nil {
+			log.Error().Err(err).Msgf("Skipping invalid %s", userWasRegisteredEventType)
+			return
+		}
+		var event userprofile_handler.UserWasRegisteredEvent
+		err = common_data.DeserializeData(envelope.Payload, &event)
+		if err != nil {
+			log.Error().Err(err).Msgf("Skipping invalid %s", userWasRegisteredEventType)
+			return
+		}
+
+		found, err := userProfileService.BackfillUserTypeAndRegion(&model.UserProfile{
+			Username: event.Username,
+			UserType: event.UserType,
+			Region:   event.Region,
+		})
+		if err != nil {
+			failed++
+			return
+		}
+		if found {
+			updated++
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Read %d %s, updated %d user profiles\n", events, userWasRegisteredEventType, updated)
+	if failed > 0 {
+		return fmt.Errorf("couldn't update %d user profiles", failed)
+	}
+	return nil
+}
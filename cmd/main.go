@@ -4,11 +4,15 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"readmodels/cmd/command"
 	"readmodels/cmd/provider"
+	"readmodels/infrastructure/bleve"
 	"readmodels/infrastructure/kafka"
 	"readmodels/internal/api"
 	"readmodels/internal/bus"
 	database "readmodels/internal/db"
+	"readmodels/internal/outbox"
+	"readmodels/internal/trending"
 	"strings"
 	"sync"
 	"syscall"
@@ -17,11 +21,21 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// app stops its tasks in order: the Kafka consumption first, so the
+// running handlers finish before the offsets are committed, then the Api,
+// and finally the event bus subscriptions, the outbox relay and the trending
+// recompute. The search index is closed last.
 type app struct {
 	ctx              context.Context
 	cancel           context.CancelFunc
+	consumerCtx      context.Context
+	stopConsumer     context.CancelFunc
+	apiCtx           context.Context
+	stopApi          context.CancelFunc
 	configuringTasks sync.WaitGroup
-	runningTasks     sync.WaitGroup
+	consumerTask     sync.WaitGroup
+	apiTask          sync.WaitGroup
+	backgroundTasks  sync.WaitGroup
 	env              string
 }
 
@@ -29,10 +43,17 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	env := strings.TrimSpace(os.Getenv("ENVIRONMENT"))
 
+	consumerCtx, stopConsumer := context.WithCancel(ctx)
+	apiCtx, stopApi := context.WithCancel(ctx)
+
 	app := &app{
-		ctx:    ctx,
-		cancel: cancel,
-		env:    env,
+		ctx:          ctx,
+		cancel:       cancel,
+		consumerCtx:  consumerCtx,
+		stopConsumer: stopConsumer,
+		apiCtx:       apiCtx,
+		stopApi:      stopApi,
+		env:          env,
 	}
 
 	app.configuringLog()
@@ -44,16 +65,71 @@ func main() {
 	if err != nil {
 		os.Exit(1)
 	}
-	eventBus := provider.ProvideEventBus()
-	subscriptions := provider.ProvideSubscriptions(database)
-	apiEnpoint := provider.ProvideApiEndpoint(database)
+
+	if len(os.Args) > 1 {
+		app.runCommand(provider, database, os.Args[1:])
+		return
+	}
+
+	err = provider.VerifyCache()
+	if err != nil {
+		os.Exit(1)
+	}
+	eventBus, err := provider.ProvideEventBus()
+	if err != nil {
+		os.Exit(1)
+	}
+	trendingService, err := provider.ProvideTrendingService(database)
+	if err != nil {
+		os.Exit(1)
+	}
+	erasureService, err := provider.ProvideErasureService(database, trendingService)
+	if err != nil {
+		os.Exit(1)
+	}
+	subscriptions := provider.ProvideSubscriptions(database, trendingService, erasureService)
+	searchIndex, err := provider.ProvideSearchIndex()
+	if err != nil {
+		os.Exit(1)
+	}
+	searchService := provider.ProvideSearchService(database, searchIndex)
+	searchBus, err := provider.ProvideEventBus()
+	if err != nil {
+		os.Exit(1)
+	}
+	searchSubscriptions := provider.ProvideSearchSubscriptions(searchService)
+
+	app.runConfigurationTasks(database, subscriptions, eventBus, searchSubscriptions, searchBus)
+
+	// The consumer subscribes to the topics of the event types subscribed on the bus
+	err = eventBus.Verify()
+	if err != nil {
+		log.Error().Err(err).Msg("Event subscriptions are inconsistent")
+		os.Exit(1)
+	}
 	kafkaConsumer, err := provider.ProvideKafkaConsumer(eventBus)
 	if err != nil {
 		os.Exit(1)
 	}
+	// The search bus only subscribes the events of the index, so it isn't
+	// verified against every registered schema
+	app.buildSearchIndex(provider, database, searchIndex)
+	searchConsumer, err := provider.ProvideSearchConsumer(searchBus)
+	if err != nil {
+		os.Exit(1)
+	}
+	apiEnpoint := provider.ProvideApiEndpoint(database, trendingService, searchService)
+	adminEndpoint := provider.ProvideAdminEndpoint(kafkaConsumer, eventBus)
+	outboxRelay, err := provider.ProvideOutboxRelay(database)
+	if err != nil {
+		os.Exit(1)
+	}
+	liveFeed, err := provider.ProvideLiveFeed()
+	if err != nil {
+		os.Exit(1)
+	}
 
-	app.runConfigurationTasks(database, subscriptions, eventBus)
-	app.runServerTasks(kafkaConsumer, apiEnpoint)
+	app.runServerTasks(kafkaConsumer, searchConsumer, apiEnpoint, adminEndpoint, outboxRelay, liveFeed, trendingService, searchIndex)
 }
 
 func (app *app) configuringLog() {
@@ -68,21 +144,59 @@ func (app *app) configuringLog() {
 	log.Logger = log.With().Caller().Logger()
 }
 
-func (app *app) runConfigurationTasks(database *database.Database, subscriptions *[]bus.EventSubscription, eventBus *bus.EventBus) {
-	app.configuringTasks.Add(2)
+func (app *app) runCommand(provider *provider.Provider, database *database.Database, args []string) {
+	err := command.Run(app.ctx, provider, database, args)
+	app.cancel()
+	if err != nil {
+		log.Error().Err(err).Msgf("Command %s failed", args[0])
+		os.Exit(1)
+	}
+}
+
+func (app *app) runConfigurationTasks(database *database.Database, subscriptions *[]bus.EventSubscription, eventBus *bus.EventBus, searchSubscriptions *[]bus.EventSubscription, searchBus *bus.EventBus) {
+	app.configuringTasks.Add(3)
 	go app.applyMigrations(database)
 	go app.subcribeEvents(subscriptions, eventBus) // Always subscribe event before init Kafka
+	go app.subcribeEvents(searchSubscriptions, searchBus)
 	app.configuringTasks.Wait()
 }
 
-func (app *app) runServerTasks(kafkaConsumer *kafka.KafkaConsumer, apiEnpoint *api.Api) {
-	app.runningTasks.Add(2)
+func (app *app) runServerTasks(kafkaConsumer, searchConsumer *kafka.KafkaConsumer, apiEnpoint, adminEndpoint *api.Api, outboxRelay *outbox.Relay, liveFeed *kafka.LiveFeed, trendingService *trending.TrendingService, searchIndex *bleve.SearchIndex) {
+	app.consumerTask.Add(2)
 	go app.initKafkaConsumption(kafkaConsumer)
+	go app.initKafkaConsumption(searchConsumer)
+	app.apiTask.Add(3)
 	go app.runApiEndpoint(apiEnpoint)
+	go app.runApiEndpoint(adminEndpoint)
+	go app.runLiveFeed(liveFeed)
+	app.backgroundTasks.Add(2)
+	go app.runOutboxRelay(outboxRelay)
+	go app.runTrendingRecompute(trendingService)
 
 	blockForever()
 
-	app.shutdown()
+	app.shutdown(searchIndex)
+}
+
+// buildSearchIndex fills a new copy of the search index from the database
+// before its consumer group joins, which would otherwise replay the events
+// from the oldest one still in the topics.
+func (app *app) buildSearchIndex(provider *provider.Provider, database *database.Database, searchIndex *bleve.SearchIndex) {
+	built, err := searchIndex.Built()
+	if err != nil {
+		os.Exit(1)
+	}
+	if built {
+		return
+	}
+
+	log.Info().Msg("Rebuilding the search index...")
+	posts, users, err := command.RebuildSearchIndex(provider, database, searchIndex)
+	if err != nil {
+		log.Error().Err(err).Msg("Rebuilding the search index failed")
+		os.Exit(1)
+	}
+	log.Info().Msgf("Search index rebuilt with %d posts and %d users", posts, users)
 }
 
 func (app *app) applyMigrations(database *database.Database) {
@@ -101,7 +215,10 @@ func (app *app) subcribeEvents(subscriptions *[]bus.EventSubscription, eventBus
 	log.Info().Msg("Subscribing events...")
 
 	for _, subscription := range *subscriptions {
-		eventBus.Subscribe(&subscription, app.ctx)
+		err := eventBus.Subscribe(&subscription, app.ctx)
+		if err != nil {
+			log.Panic().Err(err).Msgf("Subscribing %s failed", subscription.EventType)
+		}
 		log.Info().Msgf("%s subscribed", subscription.EventType)
 	}
 
@@ -109,9 +226,9 @@ func (app *app) subcribeEvents(subscriptions *[]bus.EventSubscription, eventBus
 }
 
 func (app *app) initKafkaConsumption(kafkaConsumer *kafka.KafkaConsumer) {
-	defer app.runningTasks.Done()
+	defer app.consumerTask.Done()
 
-	err := kafkaConsumer.InitConsumption(app.ctx)
+	err := kafkaConsumer.InitConsumption(app.consumerCtx)
 	if err != nil {
 		log.Panic().Err(err).Msg("Kafka Consumption failed")
 	}
@@ -119,24 +236,65 @@ func (app *app) initKafkaConsumption(kafkaConsumer *kafka.KafkaConsumer) {
 }
 
 func (app *app) runApiEndpoint(apiEnpoint *api.Api) {
-	defer app.runningTasks.Done()
+	defer app.apiTask.Done()
 
-	err := apiEnpoint.Run(app.ctx)
+	err := apiEnpoint.Run(app.apiCtx)
 	if err != nil {
 		log.Panic().Err(err).Msg("Closing Readmodels Api failed")
 	}
 	log.Info().Msg("Readmodels Api stopped")
 }
 
+// runLiveFeed feeds the live streams of the Api, a failure only leaves them
+// without changes.
+func (app *app) runLiveFeed(liveFeed *kafka.LiveFeed) {
+	defer app.apiTask.Done()
+
+	err := liveFeed.Run(app.apiCtx)
+	if err != nil {
+		log.Error().Err(err).Msg("Live feed failed")
+		return
+	}
+	log.Info().Msg("Live feed stopped")
+}
+
+func (app *app) runOutboxRelay(outboxRelay *outbox.Relay) {
+	defer app.backgroundTasks.Done()
+
+	outboxRelay.Run(app.ctx)
+	log.Info().Msg("Outbox relay stopped")
+}
+
+func (app *app) runTrendingRecompute(trendingService *trending.TrendingService) {
+	defer app.backgroundTasks.Done()
+
+	trendingService.Run(app.ctx)
+	log.Info().Msg("Trending recompute stopped")
+}
+
 func blockForever() {
 	signalCh := make(chan os.Signal, 1)
 	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
 	<-signalCh
 }
 
-func (app *app) shutdown() {
-	app.cancel()
+func (app *app) shutdown(searchIndex *bleve.SearchIndex) {
 	log.Info().Msg("Shutting down Readmodels Service...")
-	app.runningTasks.Wait()
+
+	// The consumer stops fetching, waits for the running handlers and commits
+	// the offsets before leaving the group
+	app.stopConsumer()
+	app.consumerTask.Wait()
+
+	app.stopApi()
+	app.apiTask.Wait()
+
+	app.cancel()
+	app.backgroundTasks.Wait()
+
+	err := searchIndex.Close()
+	if err != nil {
+		log.Error().Err(err).Msg("Closing the search index failed")
+	}
 	log.Info().Msg("Readmodels Service stopped")
 }
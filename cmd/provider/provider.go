@@ -2,20 +2,44 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
 	awsClients "readmodels/infrastructure/aws"
+	"readmodels/infrastructure/bleve"
 	"readmodels/infrastructure/kafka"
+	redisClient "readmodels/infrastructure/redis"
+	"readmodels/internal/activity"
+	activity_handler "readmodels/internal/activity/handler"
 	"readmodels/internal/api"
 	"readmodels/internal/bus"
+	"readmodels/internal/cache"
 	"readmodels/internal/comment"
 	comment_handler "readmodels/internal/comment/handler"
+	"readmodels/internal/consumer"
 	database "readmodels/internal/db"
+	"readmodels/internal/erasure"
+	erasure_handler "readmodels/internal/erasure/handler"
+	"readmodels/internal/events"
 	"readmodels/internal/follow"
+	"readmodels/internal/live"
+	"readmodels/internal/notification"
+	notification_handler "readmodels/internal/notification/handler"
+	"readmodels/internal/outbox"
 	"readmodels/internal/post"
 	post_handler "readmodels/internal/post/handler"
 	"readmodels/internal/reaction"
 	reaction_handler "readmodels/internal/reaction/handler"
+	"readmodels/internal/reconciliation"
+	"readmodels/internal/search"
+	search_handler "readmodels/internal/search/handler"
+	"readmodels/internal/trending"
+	trending_handler "readmodels/internal/trending/handler"
 	"readmodels/internal/userprofile"
 	userprofile_handler "readmodels/internal/userprofile/handlers"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -24,7 +48,10 @@ import (
 )
 
 type Provider struct {
-	env string
+	env         string
+	cache       cache.Cache
+	kafkaConfig *kafka.Config
+	liveHub     *live.Hub
 }
 
 func NewProvider(env string) *Provider {
@@ -33,102 +60,687 @@ func NewProvider(env string) *Provider {
 	}
 }
 
-func (p *Provider) ProvideApiEndpoint(database *database.Database) *api.Api {
-	return api.NewApiEndpoint(p.env, p.ProvideApiControllers(database))
+func (p *Provider) ProvideApiEndpoint(database *database.Database, trendingService *trending.TrendingService, searchService *search.SearchService) *api.Api {
+	return api.NewApiEndpoint(p.env, p.ProvideApiControllers(database, trendingService, searchService))
 }
 
-func (p *Provider) ProvideApiControllers(database *database.Database) []api.Controller {
+func (p *Provider) ProvideApiControllers(database *database.Database, trendingService *trending.TrendingService, searchService *search.SearchService) []api.Controller {
 	return []api.Controller{
-		userprofile.NewUserProfileController(userprofile.UserProfileRepository(*database)),
-		post.NewPostController(post.NewPostService(post.PostRepository(*database))),
+		userprofile.NewUserProfileController(p.provideUserProfileRepository(database)),
+		post.NewPostController(post.NewPostService(p.providePostRepository(database))),
 		follow.NewFollowController(follow.FollowRepository(*database)),
-		comment.NewCommentController(comment.NewCommentRepository(database)),
-		reaction.NewReactionController(reaction.NewReactionService(reaction.NewReactionRepository(database))),
+		comment.NewCommentController(p.provideCommentRepository(database)),
+		live.NewLiveController(p.ProvideLiveHub()),
+		reaction.NewReactionController(reaction.NewReactionService(p.provideReactionRepository(database))),
+		notification.NewNotificationController(p.provideNotificationService(database)),
+		activity.NewActivityController(p.provideActivityService(database)),
+		trending.NewTrendingController(trendingService),
+		search.NewSearchController(searchService),
 	}
 }
 
-func (p *Provider) ProvideEventBus() *bus.EventBus {
-	eventBus := bus.NewEventBus()
+// ProvideAdminEndpoint serves the operational controllers on ADMIN_ADDRESS,
+// the loopback interface by default, away from the public Api.
+func (p *Provider) ProvideAdminEndpoint(kafkaConsumer consumer.Consumer, eventBus *bus.EventBus) *api.Api {
+	return api.NewAdminEndpoint(p.env, getEnv("ADMIN_ADDRESS"), p.ProvideAdminControllers(kafkaConsumer, eventBus))
+}
+
+func (p *Provider) ProvideAdminControllers(kafkaConsumer consumer.Consumer, eventBus *bus.EventBus) []api.Controller {
+	return []api.Controller{
+		cache.NewCacheController(p.ProvideCache()),
+		consumer.NewConsumerController(kafkaConsumer),
+		bus.NewBusController(eventBus),
+	}
+}
+
+// VerifyCache checks the cache can be shared by the running instances. Without
+// REDIS_ADDRESS every instance caches in its own memory and only clears its own
+// entries, so the others serve stale data for up to the cache TTL. That is
+// only allowed in development, or with CACHE_LOCAL=true for a deployment with a
+// single instance.
+func (p *Provider) VerifyCache() error {
+	local := false
+	err := parseBoolEnv("CACHE_LOCAL", &local)
+	if err == nil && getEnv("REDIS_ADDRESS") == "" && !local && p.env != "development" {
+		err = errors.New("REDIS_ADDRESS is required, or CACHE_LOCAL=true with a single instance")
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid cache configuration")
+		return err
+	}
+
+	return nil
+}
+
+// ProvideCache returns the Redis cache at REDIS_ADDRESS, or an in-memory cache
+// of this instance when it is unset.
+func (p *Provider) ProvideCache() cache.Cache {
+	if p.cache != nil {
+		return p.cache
+	}
+
+	redisAddress := getEnv("REDIS_ADDRESS")
+	if redisAddress == "" {
+		p.cache = cache.NewLRUCache(10000, 5*time.Minute)
+	} else {
+		p.cache = redisClient.NewRedisCache(redisAddress, 5*time.Minute)
+	}
+
+	return p.cache
+}
+
+func (p *Provider) ProvideUserProfileService(database *database.Database) *userprofile.UserProfileService {
+	return userprofile.NewUserProfileService(p.provideUserProfileRepository(database))
+}
 
-	return eventBus
+func (p *Provider) provideUserProfileRepository(database *database.Database) userprofile.Repository {
+	return userprofile.NewCachedRepository(userprofile.UserProfileRepository(*database), p.ProvideCache())
 }
 
-func (p *Provider) ProvideSubscriptions(database *database.Database) *[]bus.EventSubscription {
+func (p *Provider) providePostRepository(database *database.Database) post.Repository {
+	return post.NewCachedRepository(post.PostRepository(*database), p.ProvideCache())
+}
+
+func (p *Provider) provideCommentRepository(database *database.Database) comment.Repository {
+	return comment.NewCachedRepository(comment.NewCommentRepository(database), p.ProvideCache())
+}
+
+func (p *Provider) provideReactionRepository(database *database.Database) reaction.Repository {
+	return reaction.NewCachedRepository(reaction.NewReactionRepository(database), p.ProvideCache())
+}
+
+func (p *Provider) provideErasureRepository(database *database.Database) erasure.Repository {
+	return erasure.NewCachedRepository(erasure.NewErasureRepository(database), p.ProvideCache())
+}
+
+func (p *Provider) provideNotificationService(database *database.Database) *notification.NotificationService {
+	return notification.NewNotificationService(notification.NewNotificationRepository(database))
+}
+
+func (p *Provider) provideActivityService(database *database.Database) *activity.ActivityService {
+	return activity.NewActivityService(activity.NewActivityRepository(database))
+}
+
+func (p *Provider) ProvideTrendingService(database *database.Database) (*trending.TrendingService, error) {
+	trendingConfig, err := p.ProvideTrendingConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return trending.NewTrendingService(trending.NewTrendingRepository(database), trendingConfig), nil
+}
+
+// ProvideErasureService hashes the erased users with ERASURE_HASH_KEY, which
+// is only optional in development.
+func (p *Provider) ProvideErasureService(database *database.Database, trendingService *trending.TrendingService) (*erasure.ErasureService, error) {
+	key := getEnv("ERASURE_HASH_KEY")
+	if key == "" && p.env == "development" {
+		key = "development"
+	}
+	if key == "" {
+		err := errors.New("ERASURE_HASH_KEY is required")
+		log.Error().Stack().Err(err).Msg("Invalid erasure configuration")
+		return nil, err
+	}
+
+	return erasure.NewErasureService(p.provideErasureRepository(database), p.provideActivityService(database), p.provideNotificationService(database), trendingService, []byte(key)), nil
+}
+
+// ProvideSearchIndex opens the search index under SEARCH_INDEX_PATH, which
+// defaults to data/search.
+func (p *Provider) ProvideSearchIndex() (*bleve.SearchIndex, error) {
+	path := getEnv("SEARCH_INDEX_PATH")
+	if path == "" {
+		path = "data/search"
+	}
+
+	return bleve.NewSearchIndex(path)
+}
+
+func (p *Provider) ProvideSearchService(database *database.Database, searchIndex *bleve.SearchIndex) *search.SearchService {
+	return search.NewSearchService(searchIndex, search.NewSearchRepository(database))
+}
+
+func (p *Provider) ProvideReconciliationService(database *database.Database) *reconciliation.ReconciliationService {
+	return reconciliation.NewReconciliationService(reconciliation.NewCachedRepository(reconciliation.NewReconciliationRepository(database), p.ProvideCache()))
+}
+
+func (p *Provider) ProvideEventBus() (*bus.EventBus, error) {
+	registry, err := p.ProvideEventRegistry()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error creating event registry")
+		return nil, err
+	}
+
+	kafkaConfig, err := p.ProvideKafkaConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetters, err := kafka.NewDeadLetterProducer(kafkaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	eventBus := bus.NewEventBus(registry, deadLetters)
+	eventBus.Use(bus.LoggingMiddleware())
+
+	return eventBus, nil
+}
+
+func (p *Provider) ProvideEventRegistry() (*events.Registry, error) {
+	return events.NewRegistry(
+		events.Schema{
+			Type:      "UserWasRegisteredEvent",
+			Version:   userprofile_handler.UserWasRegisteredEventVersion,
+			Payload:   userprofile_handler.UserWasRegisteredEvent{},
+			Upcasters: userprofile_handler.UserWasRegisteredEventUpcasters,
+		},
+		events.Schema{
+			Type:      "UserProfileUpdatedEvent",
+			Version:   userprofile_handler.UserProfileUpdatedEventVersion,
+			Payload:   userprofile_handler.UserProfileUpdatedEvent{},
+			Upcasters: userprofile_handler.UserProfileUpdatedEventUpcasters,
+		},
+		events.Schema{Type: "UserWasDeletedEvent", Version: 1, Payload: erasure_handler.UserWasDeletedEvent{}},
+		events.Schema{Type: "UserAFollowedUserBEvent", Version: 1, Payload: userprofile_handler.UserAFollowedUserBEvent{}},
+		events.Schema{Type: "UserAUnfollowedUserBEvent", Version: 1, Payload: userprofile_handler.UserAUnfollowedUserBEvent{}},
+		events.Schema{
+			Type:      "PostWasCreatedEvent",
+			Version:   post_handler.PostWasCreatedEventVersion,
+			Payload:   post_handler.PostWasCreatedEvent{},
+			Upcasters: post_handler.PostWasCreatedEventUpcasters,
+		},
+		events.Schema{Type: "PostWasUpdatedEvent", Version: 1, Payload: post_handler.PostWasUpdatedEvent{}},
+		events.Schema{Type: "PostsWereDeletedEvent", Version: 1, Payload: post_handler.PostsWereDeletedEvent{}},
+		events.Schema{Type: "CommentWasCreatedEvent", Version: 1, Payload: comment_handler.CommentWasCreatedEvent{}},
+		events.Schema{Type: "CommentWasUpdatedEvent", Version: 1, Payload: comment_handler.CommentWasUpdatedEvent{}},
+		events.Schema{Type: "CommentWasDeletedEvent", Version: 1, Payload: comment_handler.CommentWasDeletedEvent{}},
+		events.Schema{Type: "UserLikedPostEvent", Version: 1, Payload: reaction_handler.UserLikedPostEvent{}},
+		events.Schema{Type: "UserUnlikedPostEvent", Version: 1, Payload: reaction_handler.UserUnlikedPostEvent{}},
+		events.Schema{Type: "UserSuperlikedPostEvent", Version: 1, Payload: reaction_handler.UserSuperlikedPostEvent{}},
+		events.Schema{Type: "UserUnsuperlikedPostEvent", Version: 1, Payload: reaction_handler.UserUnsuperlikedPostEvent{}},
+		events.Schema{Type: "ReviewWasCreatedEvent", Version: 1, Payload: reaction_handler.ReviewWasCreatedEvent{}},
+	)
+}
+
+// Reactions come in bursts and each one is a DynamoDB transaction on the post
+// counters, so they are handled by few workers to avoid throttling the tables.
+const (
+	reactionWorkers   = 2
+	reactionQueueSize = 500
+)
+
+func (p *Provider) ProvideSubscriptions(database *database.Database, trendingService *trending.TrendingService, erasureService *erasure.ErasureService) *[]bus.EventSubscription {
 	return &[]bus.EventSubscription{
 		{
 			EventType: "UserWasRegisteredEvent",
-			Handler:   userprofile_handler.NewUserWasRegisteredEventHandler(userprofile.UserProfileRepository(*database)),
+			Handler:   userprofile_handler.NewUserWasRegisteredEventHandler(p.provideUserProfileRepository(database)),
 		},
 		{
 			EventType: "UserProfileUpdatedEvent",
-			Handler:   userprofile_handler.NewUserProfileUpdatedEventHandler(userprofile.UserProfileRepository(*database)),
+			Handler:   userprofile_handler.NewUserProfileUpdatedEventHandler(p.provideUserProfileRepository(database)),
 		},
 		{
 			EventType: "UserAFollowedUserBEvent",
-			Handler:   userprofile_handler.NewUserAFollowedUserBEventHandler(userprofile.UserProfileRepository(*database)),
+			Handler:   userprofile_handler.NewUserAFollowedUserBEventHandler(p.provideUserProfileRepository(database)),
 		},
 		{
 			EventType: "UserAUnfollowedUserBEvent",
-			Handler:   userprofile_handler.NewUserAUnfollowedUserBEventHandler(userprofile.UserProfileRepository(*database)),
+			Handler:   userprofile_handler.NewUserAUnfollowedUserBEventHandler(p.provideUserProfileRepository(database)),
+		},
+		{
+			EventType: "UserWasDeletedEvent",
+			Handler:   erasure_handler.NewUserWasDeletedEventHandler(erasureService),
 		},
 		{
 			EventType: "PostWasCreatedEvent",
-			Handler:   post_handler.NewPostWasCreatedEventHandler(post.NewPostService(post.PostRepository(*database))),
+			Handler:   post_handler.NewPostWasCreatedEventHandler(post.NewPostService(p.providePostRepository(database))),
+		},
+		{
+			EventType: "PostWasUpdatedEvent",
+			Handler:   post_handler.NewPostWasUpdatedEventHandler(post.NewPostService(p.providePostRepository(database))),
 		},
 		{
 			EventType: "PostsWereDeletedEvent",
-			Handler:   post_handler.NewPostsWereDeletedEventHandler(post.PostRepository(*database)),
+			Handler:   post_handler.NewPostsWereDeletedEventHandler(p.providePostRepository(database)),
 		},
 		{
 			EventType: "CommentWasCreatedEvent",
-			Handler:   comment_handler.NewCommentWasCreatedEventHandler(comment.NewCommentService(comment.NewCommentRepository(database))),
+			Handler:   comment_handler.NewCommentWasCreatedEventHandler(comment.NewCommentService(p.provideCommentRepository(database))),
 		},
 		{
 			EventType: "CommentWasUpdatedEvent",
-			Handler:   comment_handler.NewCommentWasUpdatedEventHandler(comment.NewCommentRepository(database)),
+			Handler:   comment_handler.NewCommentWasUpdatedEventHandler(p.provideCommentRepository(database)),
+		},
+		{
+			EventType: "CommentWasDeletedEvent",
+			Handler:   comment_handler.NewCommentWasDeletedEventHandler(comment.NewCommentService(p.provideCommentRepository(database))),
+		},
+		{
+			EventType: "UserLikedPostEvent",
+			Handler:   reaction_handler.NewUserLikedPostEventHandler(reaction.NewReactionService(p.provideReactionRepository(database))),
+			Workers:   reactionWorkers,
+			QueueSize: reactionQueueSize,
+		},
+		{
+			EventType: "UserUnlikedPostEvent",
+			Handler:   reaction_handler.NewUserUnlikedPostEventHandler(reaction.NewReactionService(p.provideReactionRepository(database))),
+			Workers:   reactionWorkers,
+			QueueSize: reactionQueueSize,
+		},
+		{
+			EventType: "UserSuperlikedPostEvent",
+			Handler:   reaction_handler.NewUserSuperlikedPostEventHandler(reaction.NewReactionService(p.provideReactionRepository(database))),
+			Workers:   reactionWorkers,
+			QueueSize: reactionQueueSize,
+		},
+		{
+			EventType: "UserUnsuperlikedPostEvent",
+			Handler:   reaction_handler.NewUserUnsuperlikedPostEventHandler(reaction.NewReactionService(p.provideReactionRepository(database))),
+			Workers:   reactionWorkers,
+			QueueSize: reactionQueueSize,
+		},
+		{
+			EventType: "ReviewWasCreatedEvent",
+			Handler:   reaction_handler.NewReviewWasCreatedEventHandler(reaction.NewReactionService(p.provideReactionRepository(database))),
+		},
+		{
+			EventType: "UserLikedPostEvent",
+			Handler:   notification_handler.NewUserLikedPostEventHandler(p.provideNotificationService(database)),
+		},
+		{
+			EventType: "UserUnlikedPostEvent",
+			Handler:   notification_handler.NewUserUnlikedPostEventHandler(p.provideNotificationService(database)),
+		},
+		{
+			EventType: "UserSuperlikedPostEvent",
+			Handler:   notification_handler.NewUserSuperlikedPostEventHandler(p.provideNotificationService(database)),
+		},
+		{
+			EventType: "UserUnsuperlikedPostEvent",
+			Handler:   notification_handler.NewUserUnsuperlikedPostEventHandler(p.provideNotificationService(database)),
+		},
+		{
+			EventType: "CommentWasCreatedEvent",
+			Handler:   notification_handler.NewCommentWasCreatedEventHandler(p.provideNotificationService(database)),
+		},
+		{
+			EventType: "CommentWasDeletedEvent",
+			Handler:   notification_handler.NewCommentWasDeletedEventHandler(p.provideNotificationService(database)),
+		},
+		{
+			EventType: "ReviewWasCreatedEvent",
+			Handler:   notification_handler.NewReviewWasCreatedEventHandler(p.provideNotificationService(database)),
+		},
+		{
+			EventType: "UserAFollowedUserBEvent",
+			Handler:   notification_handler.NewUserAFollowedUserBEventHandler(p.provideNotificationService(database)),
+		},
+		{
+			EventType: "UserAUnfollowedUserBEvent",
+			Handler:   notification_handler.NewUserAUnfollowedUserBEventHandler(p.provideNotificationService(database)),
+		},
+		{
+			EventType: "PostsWereDeletedEvent",
+			Handler:   notification_handler.NewPostsWereDeletedEventHandler(p.provideNotificationService(database)),
+		},
+		{
+			EventType: "UserWasDeletedEvent",
+			Handler:   notification_handler.NewUserWasDeletedEventHandler(p.provideNotificationService(database)),
+		},
+		{
+			EventType: "PostWasCreatedEvent",
+			Handler:   activity_handler.NewPostWasCreatedEventHandler(p.provideActivityService(database)),
+		},
+		{
+			EventType: "PostsWereDeletedEvent",
+			Handler:   activity_handler.NewPostsWereDeletedEventHandler(p.provideActivityService(database)),
+		},
+		{
+			EventType: "UserLikedPostEvent",
+			Handler:   activity_handler.NewUserLikedPostEventHandler(p.provideActivityService(database)),
+		},
+		{
+			EventType: "UserUnlikedPostEvent",
+			Handler:   activity_handler.NewUserUnlikedPostEventHandler(p.provideActivityService(database)),
+		},
+		{
+			EventType: "UserSuperlikedPostEvent",
+			Handler:   activity_handler.NewUserSuperlikedPostEventHandler(p.provideActivityService(database)),
+		},
+		{
+			EventType: "UserUnsuperlikedPostEvent",
+			Handler:   activity_handler.NewUserUnsuperlikedPostEventHandler(p.provideActivityService(database)),
+		},
+		{
+			EventType: "CommentWasCreatedEvent",
+			Handler:   activity_handler.NewCommentWasCreatedEventHandler(p.provideActivityService(database)),
 		},
 		{
 			EventType: "CommentWasDeletedEvent",
-			Handler:   comment_handler.NewCommentWasDeletedEventHandler(comment.NewCommentService(comment.NewCommentRepository(database))),
+			Handler:   activity_handler.NewCommentWasDeletedEventHandler(p.provideActivityService(database)),
+		},
+		{
+			EventType: "ReviewWasCreatedEvent",
+			Handler:   activity_handler.NewReviewWasCreatedEventHandler(p.provideActivityService(database)),
+		},
+		{
+			EventType: "UserAFollowedUserBEvent",
+			Handler:   activity_handler.NewUserAFollowedUserBEventHandler(p.provideActivityService(database)),
+		},
+		{
+			EventType: "UserAUnfollowedUserBEvent",
+			Handler:   activity_handler.NewUserAUnfollowedUserBEventHandler(p.provideActivityService(database)),
+		},
+		{
+			EventType: "UserWasDeletedEvent",
+			Handler:   activity_handler.NewUserWasDeletedEventHandler(p.provideActivityService(database)),
 		},
 		{
 			EventType: "UserLikedPostEvent",
-			Handler:   reaction_handler.NewUserLikedPostEventHandler(reaction.NewReactionService(reaction.NewReactionRepository(database))),
+			Handler:   trending_handler.NewUserLikedPostEventHandler(trendingService),
 		},
 		{
 			EventType: "UserUnlikedPostEvent",
-			Handler:   reaction_handler.NewUserUnlikedPostEventHandler(reaction.NewReactionService(reaction.NewReactionRepository(database))),
+			Handler:   trending_handler.NewUserUnlikedPostEventHandler(trendingService),
 		},
 		{
 			EventType: "UserSuperlikedPostEvent",
-			Handler:   reaction_handler.NewUserSuperlikedPostEventHandler(reaction.NewReactionService(reaction.NewReactionRepository(database))),
+			Handler:   trending_handler.NewUserSuperlikedPostEventHandler(trendingService),
 		},
 		{
 			EventType: "UserUnsuperlikedPostEvent",
-			Handler:   reaction_handler.NewUserUnsuperlikedPostEventHandler(reaction.NewReactionService(reaction.NewReactionRepository(database))),
+			Handler:   trending_handler.NewUserUnsuperlikedPostEventHandler(trendingService),
+		},
+		{
+			EventType: "CommentWasCreatedEvent",
+			Handler:   trending_handler.NewCommentWasCreatedEventHandler(trendingService),
+		},
+		{
+			EventType: "CommentWasDeletedEvent",
+			Handler:   trending_handler.NewCommentWasDeletedEventHandler(trendingService),
 		},
 		{
 			EventType: "ReviewWasCreatedEvent",
-			Handler:   reaction_handler.NewReviewWasCreatedEventHandler(reaction.NewReactionService(reaction.NewReactionRepository(database))),
+			Handler:   trending_handler.NewReviewWasCreatedEventHandler(trendingService),
+		},
+		{
+			EventType: "PostsWereDeletedEvent",
+			Handler:   trending_handler.NewPostsWereDeletedEventHandler(trendingService),
+		},
+		{
+			EventType: "UserWasDeletedEvent",
+			Handler:   trending_handler.NewUserWasDeletedEventHandler(trendingService),
+		},
+	}
+}
+
+const liveMaxConnections = 1000
+
+// ProvideLiveHub returns the hub shared by the live streams and the feed.
+func (p *Provider) ProvideLiveHub() *live.Hub {
+	if p.liveHub == nil {
+		p.liveHub = live.NewHub(liveMaxConnections)
+	}
+	return p.liveHub
+}
+
+func (p *Provider) ProvideLiveFeed() (*kafka.LiveFeed, error) {
+	kafkaConfig, err := p.ProvideKafkaConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return kafka.NewLiveFeed(kafkaConfig, p.ProvideLiveHub())
+}
+
+// ProvideSearchSubscriptions feeds the search index, on its own bus since
+// every instance consumes every event for its local index.
+func (p *Provider) ProvideSearchSubscriptions(searchService *search.SearchService) *[]bus.EventSubscription {
+	return &[]bus.EventSubscription{
+		{
+			EventType: "PostWasCreatedEvent",
+			Handler:   search_handler.NewPostWasCreatedEventHandler(searchService),
+		},
+		{
+			EventType: "PostWasUpdatedEvent",
+			Handler:   search_handler.NewPostWasUpdatedEventHandler(searchService),
+		},
+		{
+			EventType: "PostsWereDeletedEvent",
+			Handler:   search_handler.NewPostsWereDeletedEventHandler(searchService),
+		},
+		{
+			EventType: "UserWasRegisteredEvent",
+			Handler:   search_handler.NewUserWasRegisteredEventHandler(searchService),
+		},
+		{
+			EventType: "UserProfileUpdatedEvent",
+			Handler:   search_handler.NewUserProfileUpdatedEventHandler(searchService),
+		},
+		{
+			EventType: "UserWasDeletedEvent",
+			Handler:   search_handler.NewUserWasDeletedEventHandler(searchService),
 		},
 	}
 }
 
 func (p *Provider) ProvideKafkaConsumer(eventBus *bus.EventBus) (*kafka.KafkaConsumer, error) {
-	var brokers []string
+	kafkaConfig, err := p.ProvideKafkaConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return kafka.NewKafkaConsumer(kafkaConfig, eventBus)
+}
+
+// ProvideSearchConsumer joins the consumer group of the copy of the search
+// index named by SEARCH_GROUP_ID, so a restarted index resumes where it
+// stopped. A new copy is rebuilt before, which moves the group past the
+// events it holds.
+func (p *Provider) ProvideSearchConsumer(eventBus *bus.EventBus) (*kafka.KafkaConsumer, error) {
+	kafkaConfig, err := p.ProvideKafkaConfig()
+	if err != nil {
+		return nil, err
+	}
+	groupId, err := p.provideSearchGroupId()
+	if err != nil {
+		return nil, err
+	}
+
+	return kafka.NewKafkaConsumerInGroup(kafkaConfig, eventBus, groupId)
+}
+
+// ProvideSearchOffsetResetter moves the offsets of the search consumer group
+// of SEARCH_GROUP_ID on the topics of the search subscriptions, which it
+// returns.
+func (p *Provider) ProvideSearchOffsetResetter(searchService *search.SearchService) (*kafka.OffsetResetter, []string, error) {
+	kafkaConfig, err := p.ProvideKafkaConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	groupId, err := p.provideSearchGroupId()
+	if err != nil {
+		return nil, nil, err
+	}
 
+	eventTypes := []string{}
+	for _, subscription := range *p.ProvideSearchSubscriptions(searchService) {
+		eventTypes = append(eventTypes, subscription.EventType)
+	}
+	topics, err := kafkaConfig.Topics(eventTypes)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error resolving the search topics")
+		return nil, nil, err
+	}
+
+	resetter, err := kafka.NewOffsetResetterInGroup(kafkaConfig, groupId)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Replayed events only rewrite the same documents of the index
+	resetter.AllowReplays()
+	return resetter, topics, nil
+}
+
+// provideSearchGroupId names the search consumer group after SEARCH_GROUP_ID,
+// which must identify the volume of the search index, like the ordinal of a
+// StatefulSet pod, so the group stays the same while the index is kept. The
+// hostname is only used in development, since pods get a new one on every
+// restart and would leave a new group behind each time.
+//
+// Groups of removed indexes aren't deleted. Once their consumers are stopped,
+// list them with kafka-consumer-groups.sh --list, whose names start with
+// readmodels-search-, and remove them with --delete --group <group>. Kafka
+// also drops a group without members after offsets.retention.minutes.
+func (p *Provider) provideSearchGroupId() (string, error) {
+	instance := getEnv("SEARCH_GROUP_ID")
+	if instance == "" && p.env != "development" {
+		err := errors.New("SEARCH_GROUP_ID is required to keep the search consumer group across restarts")
+		log.Error().Stack().Err(err).Msg("Invalid search configuration")
+		return "", err
+	}
+	if instance == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("Error getting the hostname")
+			return "", err
+		}
+		instance = hostname
+	}
+
+	return "readmodels-search-" + instance, nil
+}
+
+func (p *Provider) ProvideOutboxRelay(database *database.Database) (*outbox.Relay, error) {
+	kafkaConfig, err := p.ProvideKafkaConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	producer, err := kafka.NewOutboxProducer(kafkaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return outbox.NewRelay(database, producer), nil
+}
+
+func (p *Provider) ProvideTopicReader() (*kafka.TopicReader, error) {
+	kafkaConfig, err := p.ProvideKafkaConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return kafka.NewTopicReader(kafkaConfig)
+}
+
+func (p *Provider) ProvideOffsetResetter() (*kafka.OffsetResetter, error) {
+	kafkaConfig, err := p.ProvideKafkaConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return kafka.NewOffsetResetter(kafkaConfig)
+}
+
+// ProvideKafkaConfig reads the Kafka settings from the environment:
+//   - KAFKA_BROKERS: comma separated broker addresses, defaults to the environment brokers
+//   - KAFKA_CLIENT_ID, KAFKA_VERSION (e.g. 3.6.0)
+//   - KAFKA_SESSION_TIMEOUT, KAFKA_HEARTBEAT_INTERVAL: durations such as 10s
+//   - KAFKA_TOPIC_PREFIX: scopes the topics to the environment (e.g. "staging")
+//   - KAFKA_TOPIC_MAPPING: comma separated EventType=topic pairs for topics not named after their event
+//   - KAFKA_TLS_ENABLED, KAFKA_TLS_CA_FILE, KAFKA_TLS_CERT_FILE, KAFKA_TLS_KEY_FILE
+//   - KAFKA_TLS_INSECURE_SKIP_VERIFY: only allowed in development
+//   - KAFKA_SASL_MECHANISM (PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512), KAFKA_SASL_USERNAME, KAFKA_SASL_PASSWORD
+func (p *Provider) ProvideKafkaConfig() (*kafka.Config, error) {
+	if p.kafkaConfig != nil {
+		return p.kafkaConfig, nil
+	}
+
+	kafkaConfig := kafka.NewConfig(p.provideKafkaBrokers())
+	errs := []error{}
+	if brokers := getEnv("KAFKA_BROKERS"); brokers != "" {
+		kafkaConfig.Brokers = strings.Split(brokers, ",")
+		for i, broker := range kafkaConfig.Brokers {
+			kafkaConfig.Brokers[i] = strings.TrimSpace(broker)
+		}
+	}
+	if clientId := getEnv("KAFKA_CLIENT_ID"); clientId != "" {
+		kafkaConfig.ClientId = clientId
+	}
+	if version := getEnv("KAFKA_VERSION"); version != "" {
+		kafkaConfig.Version = version
+	}
+	errs = append(errs,
+		parseDurationEnv("KAFKA_SESSION_TIMEOUT", &kafkaConfig.SessionTimeout),
+		parseDurationEnv("KAFKA_HEARTBEAT_INTERVAL", &kafkaConfig.HeartbeatInterval),
+		parseBoolEnv("KAFKA_TLS_ENABLED", &kafkaConfig.TLS.Enabled),
+		parseBoolEnv("KAFKA_TLS_INSECURE_SKIP_VERIFY", &kafkaConfig.TLS.InsecureSkipVerify),
+	)
+	kafkaConfig.TopicPrefix = getEnv("KAFKA_TOPIC_PREFIX")
+	errs = append(errs, parseMappingEnv("KAFKA_TOPIC_MAPPING", &kafkaConfig.TopicMapping))
+	kafkaConfig.TLS.CAFile = getEnv("KAFKA_TLS_CA_FILE")
+	kafkaConfig.TLS.CertFile = getEnv("KAFKA_TLS_CERT_FILE")
+	kafkaConfig.TLS.KeyFile = getEnv("KAFKA_TLS_KEY_FILE")
+	kafkaConfig.SASL.Mechanism = strings.ToUpper(getEnv("KAFKA_SASL_MECHANISM"))
+	kafkaConfig.SASL.Username = getEnv("KAFKA_SASL_USERNAME")
+	kafkaConfig.SASL.Password = os.Getenv("KAFKA_SASL_PASSWORD")
+
+	if kafkaConfig.TLS.InsecureSkipVerify && p.env != "development" {
+		errs = append(errs, errors.New("KAFKA_TLS_INSECURE_SKIP_VERIFY is only allowed in development"))
+	}
+	errs = append(errs, kafkaConfig.Validate())
+	if err := errors.Join(errs...); err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid Kafka configuration")
+		return nil, err
+	}
+
+	p.kafkaConfig = kafkaConfig
+	return kafkaConfig, nil
+}
+
+// ProvideTrendingConfig reads the trending settings from the environment:
+//   - TRENDING_WEIGHTS: comma separated Type=weight pairs for Likes, Superlikes, Comments and Reviews
+//   - TRENDING_HALF_LIFE, TRENDING_RECOMPUTE_INTERVAL: durations such as 24h
+func (p *Provider) ProvideTrendingConfig() (*trending.Config, error) {
+	trendingConfig := trending.NewConfig()
+	weights := map[string]string{}
+	errs := []error{
+		parseMappingEnv("TRENDING_WEIGHTS", &weights),
+		parseDurationEnv("TRENDING_HALF_LIFE", &trendingConfig.HalfLife),
+		parseDurationEnv("TRENDING_RECOMPUTE_INTERVAL", &trendingConfig.RecomputeInterval),
+	}
+	for engagementType, raw := range weights {
+		weight, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("TRENDING_WEIGHTS: %s: %w", engagementType, err))
+			continue
+		}
+		trendingConfig.Weights[engagementType] = weight
+	}
+
+	errs = append(errs, trendingConfig.Validate())
+	if err := errors.Join(errs...); err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid trending configuration")
+		return nil, err
+	}
+
+	return trendingConfig, nil
+}
+
+func (p *Provider) provideKafkaBrokers() []string {
 	if p.env == "development" {
-		brokers = []string{
+		return []string{
 			"localhost:9093",
 		}
-	} else {
-		brokers = []string{
-			"172.31.0.242:9092",
-			"172.31.7.110:9092",
-		}
 	}
 
-	return kafka.NewKafkaConsumer(brokers, eventBus)
+	return []string{
+		"172.31.0.242:9092",
+		"172.31.7.110:9092",
+	}
 }
 func (p *Provider) ProvideDb(ctx context.Context) (*database.Database, error) {
 	var cfg aws.Config
@@ -144,7 +756,67 @@ func (p *Provider) ProvideDb(ctx context.Context) (*database.Database, error) {
 		return nil, err
 	}
 
-	return database.NewDatabase(awsClients.NewDynamodbClient(cfg)), nil
+	return database.NewDatabase(awsClients.NewDynamodbClient(cfg, p.ProvideTableRegistry(), outbox.NewDerivedEvents())), nil
+}
+
+// ProvideTableRegistry scopes the DynamoDB table names to the environment
+// using DYNAMODB_TABLE_PREFIX and DYNAMODB_TABLE_SUFFIX (e.g. "staging" turns
+// readmodels.comments into staging.readmodels.comments).
+func (p *Provider) ProvideTableRegistry() *database.TableRegistry {
+	return database.NewTableRegistry(
+		strings.TrimSpace(os.Getenv("DYNAMODB_TABLE_PREFIX")),
+		strings.TrimSpace(os.Getenv("DYNAMODB_TABLE_SUFFIX")),
+	)
+}
+
+func getEnv(key string) string {
+	return strings.TrimSpace(os.Getenv(key))
+}
+
+func parseDurationEnv(key string, value *time.Duration) error {
+	raw := getEnv(key)
+	if raw == "" {
+		return nil
+	}
+
+	duration, err := time.ParseDuration(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	*value = duration
+	return nil
+}
+
+func parseBoolEnv(key string, value *bool) error {
+	raw := getEnv(key)
+	if raw == "" {
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	*value = parsed
+	return nil
+}
+
+func parseMappingEnv(key string, value *map[string]string) error {
+	raw := getEnv(key)
+	if raw == "" {
+		return nil
+	}
+
+	mapping := map[string]string{}
+	for _, pair := range strings.Split(raw, ",") {
+		from, to, found := strings.Cut(pair, "=")
+		if !found {
+			return fmt.Errorf("%s: %q isn't a key=value pair", key, pair)
+		}
+		mapping[strings.TrimSpace(from)] = strings.TrimSpace(to)
+	}
+	*value = mapping
+	return nil
 }
 
 func provideAwsConfig(ctx context.Context) (aws.Config, error) {
@@ -0,0 +1,182 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	database "readmodels/internal/db"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/rs/zerolog/log"
+)
+
+// DynamoDB limits, see https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/ServiceQuotas.html
+const (
+	maxBatchGetItems    = 100
+	maxBatchWriteItems  = 25
+	maxTransactionItems = 100
+)
+
+const maxBatchAttempts = 6
+const batchRetryBaseDelay = 50 * time.Millisecond
+const batchRetryMaxDelay = time.Second
+
+// sleep waits between retries, and is replaced in tests.
+var sleep = time.Sleep
+
+// batchGetItems reads the items of the given keys in chunks of 100, retrying
+// UnprocessedKeys with exponential backoff.
+func (dc *DynamoDBClient) batchGetItems(tableName string, keys []map[string]types.AttributeValue, projection *types.KeysAndAttributes) ([]map[string]types.AttributeValue, error) {
+	physicalTableName := dc.tables.Name(tableName)
+	items := []map[string]types.AttributeValue{}
+
+	for _, chunk := range chunkKeys(keys, maxBatchGetItems) {
+		request := types.KeysAndAttributes{Keys: chunk}
+		if projection != nil {
+			request.ProjectionExpression = projection.ProjectionExpression
+			request.ExpressionAttributeNames = projection.ExpressionAttributeNames
+		}
+		requestItems := map[string]types.KeysAndAttributes{
+			physicalTableName: request,
+		}
+
+		for attempt := 0; len(requestItems) > 0; attempt++ {
+			if attempt == maxBatchAttempts {
+				pending := len(requestItems[physicalTableName].Keys)
+				return nil, database.NewUnprocessedItemsError(tableName, pending)
+			}
+			if attempt > 0 {
+				waitBackoff(attempt)
+			}
+
+			response, err := dc.client.BatchGetItem(context.TODO(), &dynamodb.BatchGetItemInput{
+				RequestItems: requestItems,
+			})
+			if err != nil {
+				log.Error().Stack().Err(err).Msgf("Couldn't get batch info from %s", tableName)
+				return nil, err
+			}
+
+			items = append(items, response.Responses[physicalTableName]...)
+			requestItems = response.UnprocessedKeys
+		}
+	}
+
+	return items, nil
+}
+
+// batchDeleteItems deletes the given keys in chunks of 25, retrying
+// UnprocessedItems with exponential backoff.
+func (dc *DynamoDBClient) batchDeleteItems(tableName string, keys []map[string]types.AttributeValue) error {
+	physicalTableName := dc.tables.Name(tableName)
+
+	for _, chunk := range chunkKeys(keys, maxBatchWriteItems) {
+		writeRequests := make([]types.WriteRequest, len(chunk))
+		for i, k := range chunk {
+			writeRequests[i] = types.WriteRequest{
+				DeleteRequest: &types.DeleteRequest{
+					Key: k,
+				},
+			}
+		}
+		requestItems := map[string][]types.WriteRequest{
+			physicalTableName: writeRequests,
+		}
+
+		for attempt := 0; len(requestItems) > 0; attempt++ {
+			if attempt == maxBatchAttempts {
+				return database.NewUnprocessedItemsError(tableName, len(requestItems[physicalTableName]))
+			}
+			if attempt > 0 {
+				waitBackoff(attempt)
+			}
+
+			response, err := dc.client.BatchWriteItem(context.TODO(), &dynamodb.BatchWriteItemInput{
+				RequestItems: requestItems,
+			})
+			if err != nil {
+				log.Error().Stack().Err(err).Msgf("Failed to batch delete items from table %s", tableName)
+				return err
+			}
+
+			requestItems = response.UnprocessedItems
+		}
+	}
+
+	return nil
+}
+
+// marshalKeys maps the keys to AttributeValues dropping duplicates, which
+// batch and transaction requests reject.
+func marshalKeys(keys []any) ([]map[string]types.AttributeValue, error) {
+	marshaledKeys := make([]map[string]types.AttributeValue, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		k, err := attributevalue.MarshalMap(key)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't map key %v to AttributeValues", key)
+			return nil, err
+		}
+		identity := keyIdentity(k, k)
+		if seen[identity] {
+			continue
+		}
+		seen[identity] = true
+		marshaledKeys = append(marshaledKeys, k)
+	}
+
+	return marshaledKeys, nil
+}
+
+func chunkKeys(keys []map[string]types.AttributeValue, size int) [][]map[string]types.AttributeValue {
+	chunks := [][]map[string]types.AttributeValue{}
+	for start := 0; start < len(keys); start += size {
+		end := start + size
+		if end > len(keys) {
+			end = len(keys)
+		}
+		chunks = append(chunks, keys[start:end])
+	}
+	return chunks
+}
+
+func keyAttributeNames(key map[string]types.AttributeValue) []string {
+	names := make([]string, 0, len(key))
+	for name := range key {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keyIdentity builds a comparable representation of the key attributes of item.
+func keyIdentity(item map[string]types.AttributeValue, key map[string]types.AttributeValue) string {
+	var identity strings.Builder
+	for _, name := range keyAttributeNames(key) {
+		var value any
+		_ = attributevalue.Unmarshal(item[name], &value)
+		identity.WriteString(fmt.Sprintf("%s=%v;", name, value))
+	}
+	return identity.String()
+}
+
+func waitBackoff(attempt int) {
+	sleep(backoffDelay(attempt))
+}
+
+// backoffDelay doubles the delay of every attempt up to batchRetryMaxDelay,
+// plus a jitter of up to batchRetryBaseDelay.
+func backoffDelay(attempt int) time.Duration {
+	delay := batchRetryMaxDelay
+	if attempt < 32 {
+		delay = min(batchRetryBaseDelay<<(attempt-1), batchRetryMaxDelay)
+	}
+	jitter := time.Duration(rand.Int63n(int64(batchRetryBaseDelay)))
+	return delay + jitter
+}
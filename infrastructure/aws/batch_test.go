@@ -0,0 +1,402 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	database "readmodels/internal/db"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubDynamoDB stores the posts of PostMetadataTable and the counter of the
+// tests, and records the requests it receives. The hooks make the next
+// requests fail or leave items unprocessed.
+type stubDynamoDB struct {
+	dynamoDBAPI
+	stored  map[string]bool
+	counter int
+
+	batchGets    []int
+	batchWrites  []int
+	transactions []int
+	counterReset bool
+
+	unprocessedGets   []int
+	unprocessedWrites []int
+	transactErrors    []error
+}
+
+func newStubDynamoDB(posts int) *stubDynamoDB {
+	stub := &stubDynamoDB{stored: map[string]bool{}, counter: posts}
+	for i := 0; i < posts; i++ {
+		stub.stored[postId(i)] = true
+	}
+	return stub
+}
+
+func (s *stubDynamoDB) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	keys := params.RequestItems[database.PostMetadataTable].Keys
+	s.batchGets = append(s.batchGets, len(keys))
+
+	processed, unprocessed := splitUnprocessed(keys, &s.unprocessedGets)
+	output := &dynamodb.BatchGetItemOutput{
+		Responses: map[string][]map[string]types.AttributeValue{database.PostMetadataTable: {}},
+	}
+	for _, k := range processed {
+		if s.stored[keyPostId(k)] {
+			output.Responses[database.PostMetadataTable] = append(output.Responses[database.PostMetadataTable], k)
+		}
+	}
+	if len(unprocessed) > 0 {
+		output.UnprocessedKeys = map[string]types.KeysAndAttributes{
+			database.PostMetadataTable: {Keys: unprocessed},
+		}
+	}
+	return output, nil
+}
+
+func (s *stubDynamoDB) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	requests := params.RequestItems[database.PostMetadataTable]
+	s.batchWrites = append(s.batchWrites, len(requests))
+
+	keys := make([]map[string]types.AttributeValue, len(requests))
+	for i, request := range requests {
+		keys[i] = request.DeleteRequest.Key
+	}
+	processed, unprocessed := splitUnprocessed(keys, &s.unprocessedWrites)
+	for _, k := range processed {
+		delete(s.stored, keyPostId(k))
+	}
+
+	output := &dynamodb.BatchWriteItemOutput{}
+	if len(unprocessed) > 0 {
+		output.UnprocessedItems = map[string][]types.WriteRequest{
+			database.PostMetadataTable: requests[len(processed):],
+		}
+	}
+	return output, nil
+}
+
+func (s *stubDynamoDB) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	s.transactions = append(s.transactions, len(params.TransactItems))
+	if len(s.transactErrors) > 0 {
+		err := s.transactErrors[0]
+		s.transactErrors = s.transactErrors[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Deletes of missing items cancel the whole transaction
+	reasons := make([]string, len(params.TransactItems))
+	failed := false
+	for i, item := range params.TransactItems {
+		reasons[i] = "None"
+		if item.Delete != nil && !s.stored[keyPostId(item.Delete.Key)] {
+			reasons[i] = "ConditionalCheckFailed"
+			failed = true
+		}
+	}
+	if failed {
+		return nil, canceled(reasons...)
+	}
+
+	for _, item := range params.TransactItems {
+		if item.Delete != nil {
+			delete(s.stored, keyPostId(item.Delete.Key))
+		}
+		if item.Update != nil {
+			var decrement int
+			_ = attributevalue.Unmarshal(item.Update.ExpressionAttributeValues[":val"], &decrement)
+			s.counter -= decrement
+		}
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func (s *stubDynamoDB) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	s.counterReset = true
+	s.counter = 0
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+// splitUnprocessed leaves the number of keys at the head of unprocessed for
+// the next attempt.
+func splitUnprocessed(keys []map[string]types.AttributeValue, unprocessed *[]int) ([]map[string]types.AttributeValue, []map[string]types.AttributeValue) {
+	if len(*unprocessed) == 0 {
+		return keys, nil
+	}
+	count := min((*unprocessed)[0], len(keys))
+	*unprocessed = (*unprocessed)[1:]
+	return keys[:len(keys)-count], keys[len(keys)-count:]
+}
+
+func postId(i int) string {
+	return fmt.Sprintf("post%03d", i)
+}
+
+func keyPostId(k map[string]types.AttributeValue) string {
+	var key database.PostMetadataKey
+	_ = attributevalue.UnmarshalMap(k, &key)
+	return key.PostId
+}
+
+func postKeys(count int) []any {
+	keys := make([]any, count)
+	for i := range keys {
+		keys[i] = &database.PostMetadataKey{PostId: postId(i)}
+	}
+	return keys
+}
+
+func marshaledPostKeys(t *testing.T, count int) []map[string]types.AttributeValue {
+	keys, err := marshalKeys(postKeys(count))
+	assert.Nil(t, err)
+	return keys
+}
+
+func setUpStub(t *testing.T, posts int) (*DynamoDBClient, *stubDynamoDB) {
+	sleep = func(time.Duration) {}
+	t.Cleanup(func() { sleep = time.Sleep })
+
+	stub := newStubDynamoDB(posts)
+	return &DynamoDBClient{client: stub}, stub
+}
+
+func canceled(reasons ...string) error {
+	cancellationReasons := make([]types.CancellationReason, len(reasons))
+	for i, reason := range reasons {
+		cancellationReasons[i] = types.CancellationReason{Code: aws.String(reason)}
+	}
+	return &types.TransactionCanceledException{CancellationReasons: cancellationReasons}
+}
+
+func TestChunkKeysSplitsAtTheLimit(t *testing.T) {
+	keys := marshaledPostKeys(t, 250)
+
+	getChunks := chunkKeys(keys, maxBatchGetItems)
+	writeChunks := chunkKeys(keys, maxBatchWriteItems)
+
+	assert.Equal(t, []int{100, 100, 50}, chunkSizes(getChunks))
+	assert.Equal(t, 10, len(writeChunks))
+	assert.Equal(t, keys[249], writeChunks[9][24])
+	assert.Empty(t, chunkKeys(nil, maxBatchGetItems))
+}
+
+func chunkSizes(chunks [][]map[string]types.AttributeValue) []int {
+	sizes := make([]int, len(chunks))
+	for i, chunk := range chunks {
+		sizes[i] = len(chunk)
+	}
+	return sizes
+}
+
+func TestBatchGetItemsReadsChunksOf100Keys(t *testing.T) {
+	client, stub := setUpStub(t, 250)
+
+	items, err := client.batchGetItems(database.PostMetadataTable, marshaledPostKeys(t, 250), nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 250, len(items))
+	assert.Equal(t, []int{100, 100, 50}, stub.batchGets)
+}
+
+func TestBatchGetItemsRetriesUnprocessedKeys(t *testing.T) {
+	client, stub := setUpStub(t, 100)
+	stub.unprocessedGets = []int{40, 10}
+
+	items, err := client.batchGetItems(database.PostMetadataTable, marshaledPostKeys(t, 100), nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 100, len(items))
+	assert.Equal(t, []int{100, 40, 10}, stub.batchGets)
+}
+
+func TestBatchGetItemsFailsWhenKeysAreLeftUnprocessed(t *testing.T) {
+	client, stub := setUpStub(t, 10)
+	stub.unprocessedGets = []int{10, 10, 10, 10, 10, 10, 10}
+
+	_, err := client.batchGetItems(database.PostMetadataTable, marshaledPostKeys(t, 10), nil)
+
+	var unprocessedError *database.UnprocessedItemsError
+	assert.ErrorAs(t, err, &unprocessedError)
+	assert.Equal(t, maxBatchAttempts, len(stub.batchGets))
+}
+
+func TestBatchDeleteItemsWritesChunksOf25Keys(t *testing.T) {
+	client, stub := setUpStub(t, 60)
+
+	err := client.batchDeleteItems(database.PostMetadataTable, marshaledPostKeys(t, 60))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{25, 25, 10}, stub.batchWrites)
+	assert.Empty(t, stub.stored)
+}
+
+func TestBatchDeleteItemsRetriesUnprocessedItems(t *testing.T) {
+	client, stub := setUpStub(t, 25)
+	stub.unprocessedWrites = []int{5, 2}
+
+	err := client.batchDeleteItems(database.PostMetadataTable, marshaledPostKeys(t, 25))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{25, 5, 2}, stub.batchWrites)
+	assert.Empty(t, stub.stored)
+}
+
+func TestBatchDeleteItemsFailsWhenItemsAreLeftUnprocessed(t *testing.T) {
+	client, stub := setUpStub(t, 5)
+	stub.unprocessedWrites = []int{5, 5, 5, 5, 5, 5, 5}
+
+	err := client.batchDeleteItems(database.PostMetadataTable, marshaledPostKeys(t, 5))
+
+	var unprocessedError *database.UnprocessedItemsError
+	assert.ErrorAs(t, err, &unprocessedError)
+	assert.Equal(t, maxBatchAttempts, len(stub.batchWrites))
+	assert.Equal(t, 5, len(stub.stored))
+}
+
+func TestBackoffDelayDoublesUpToTheCap(t *testing.T) {
+	for attempt := 1; attempt <= 64; attempt++ {
+		delay := backoffDelay(attempt)
+
+		expected := min(batchRetryBaseDelay<<min(attempt-1, 31), batchRetryMaxDelay)
+		assert.GreaterOrEqual(t, delay, expected, "attempt %d", attempt)
+		assert.Less(t, delay, expected+batchRetryBaseDelay, "attempt %d", attempt)
+	}
+}
+
+func TestWaitBackoffSleepsTheBackoffDelay(t *testing.T) {
+	var waited time.Duration
+	sleep = func(d time.Duration) { waited = d }
+	t.Cleanup(func() { sleep = time.Sleep })
+
+	waitBackoff(3)
+
+	assert.GreaterOrEqual(t, waited, 4*batchRetryBaseDelay)
+	assert.Less(t, waited, 5*batchRetryBaseDelay)
+}
+
+func TestRemoveMultipleDataAndDecreaseCounterSplitsTransactions(t *testing.T) {
+	client, stub := setUpStub(t, 150)
+
+	err := client.RemoveMultipleDataAndDecreaseCounter(database.PostMetadataTable, postKeys(150), database.PostMetadataTable, &database.PostMetadataKey{PostId: "counter"}, "Count")
+
+	assert.Nil(t, err)
+	// 98 deletes plus the counter update, leaving room for the outbox message
+	assert.Equal(t, []int{99, 53}, stub.transactions)
+	assert.Empty(t, stub.stored)
+	assert.Equal(t, 0, stub.counter)
+}
+
+func TestRemoveMultipleDataAndDecreaseCounterOnlyDecreasesByExistingItems(t *testing.T) {
+	client, stub := setUpStub(t, 10)
+	delete(stub.stored, postId(3))
+	delete(stub.stored, postId(7))
+
+	err := client.RemoveMultipleDataAndDecreaseCounter(database.PostMetadataTable, postKeys(10), database.PostMetadataTable, &database.PostMetadataKey{PostId: "counter"}, "Count")
+
+	assert.Nil(t, err)
+	// The missing items cancel the first transaction and are left out of the retry
+	assert.Equal(t, []int{11, 9}, stub.transactions)
+	assert.Empty(t, stub.stored)
+	assert.Equal(t, 2, stub.counter)
+}
+
+func TestRemoveMultipleDataAndDecreaseCounterDoesNothingWithoutItems(t *testing.T) {
+	client, stub := setUpStub(t, 0)
+	stub.counter = 5
+
+	err := client.RemoveMultipleDataAndDecreaseCounter(database.PostMetadataTable, postKeys(3), database.PostMetadataTable, &database.PostMetadataKey{PostId: "counter"}, "Count")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{4}, stub.transactions)
+	assert.Equal(t, 5, stub.counter)
+	assert.Empty(t, stub.batchGets)
+}
+
+func TestRemoveDataAndDecreaseCounterDecreasesForAStoredItem(t *testing.T) {
+	client, stub := setUpStub(t, 2)
+
+	err := client.RemoveDataAndDecreaseCounter(database.PostMetadataTable, &database.PostMetadataKey{PostId: postId(1)}, database.PostMetadataTable, &database.PostMetadataKey{PostId: "counter"}, "Count")
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{postId(0): true}, stub.stored)
+	assert.Equal(t, 1, stub.counter)
+}
+
+func TestRemoveDataAndDecreaseCounterKeepsTheCounterForAMissingItem(t *testing.T) {
+	client, stub := setUpStub(t, 2)
+
+	err := client.RemoveDataAndDecreaseCounter(database.PostMetadataTable, &database.PostMetadataKey{PostId: postId(5)}, database.PostMetadataTable, &database.PostMetadataKey{PostId: "counter"}, "Count")
+
+	assert.Nil(t, err)
+	assert.Len(t, stub.stored, 2)
+	assert.Equal(t, 2, stub.counter)
+}
+
+func TestRemoveMultipleDataAndDecreaseCounterResumesAfterAPartialFailure(t *testing.T) {
+	client, stub := setUpStub(t, 150)
+	stub.transactErrors = []error{nil, errors.New("connection reset")}
+	keys := postKeys(150)
+
+	err := client.RemoveMultipleDataAndDecreaseCounter(database.PostMetadataTable, keys, database.PostMetadataTable, &database.PostMetadataKey{PostId: "counter"}, "Count")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 52, len(stub.stored))
+	assert.Equal(t, 52, stub.counter)
+
+	err = client.RemoveMultipleDataAndDecreaseCounter(database.PostMetadataTable, keys, database.PostMetadataTable, &database.PostMetadataKey{PostId: "counter"}, "Count")
+
+	assert.Nil(t, err)
+	assert.Empty(t, stub.stored)
+	assert.Equal(t, 0, stub.counter)
+	// The retry finds the first chunk already removed
+	assert.Equal(t, []int{99, 53, 99, 53}, stub.transactions)
+}
+
+func TestRemoveMultipleDataAndDecreaseCounterRetriesCanceledTransactions(t *testing.T) {
+	client, stub := setUpStub(t, 3)
+	stub.transactErrors = []error{canceled("None", "TransactionConflict", "None", "None")}
+
+	err := client.RemoveMultipleDataAndDecreaseCounter(database.PostMetadataTable, postKeys(3), database.PostMetadataTable, &database.PostMetadataKey{PostId: "counter"}, "Count")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{4, 4}, stub.transactions)
+	assert.Equal(t, 0, stub.counter)
+}
+
+func TestRemoveMultipleDataAndDecreaseCounterGivesUpAfterMaxAttempts(t *testing.T) {
+	client, stub := setUpStub(t, 3)
+	for i := 0; i < maxBatchAttempts; i++ {
+		stub.transactErrors = append(stub.transactErrors, canceled("None", "TransactionConflict", "None", "None"))
+	}
+
+	err := client.RemoveMultipleDataAndDecreaseCounter(database.PostMetadataTable, postKeys(3), database.PostMetadataTable, &database.PostMetadataKey{PostId: "counter"}, "Count")
+
+	var tce *types.TransactionCanceledException
+	assert.ErrorAs(t, err, &tce)
+	assert.Equal(t, maxBatchAttempts, len(stub.transactions))
+	assert.Equal(t, 3, len(stub.stored))
+}
+
+func TestRemoveMultipleDataAndDecreaseCounterResetsACounterLowerThanTheItems(t *testing.T) {
+	client, stub := setUpStub(t, 3)
+	stub.transactErrors = []error{canceled("None", "None", "None", "ConditionalCheckFailed")}
+
+	err := client.RemoveMultipleDataAndDecreaseCounter(database.PostMetadataTable, postKeys(3), database.PostMetadataTable, &database.PostMetadataKey{PostId: "counter"}, "Count")
+
+	assert.Nil(t, err)
+	// The retry deletes the items without the counter update
+	assert.Equal(t, []int{4, 3}, stub.transactions)
+	assert.Empty(t, stub.stored)
+	assert.True(t, stub.counterReset)
+}
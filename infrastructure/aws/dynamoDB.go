@@ -2,10 +2,15 @@ package aws
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	database "readmodels/internal/db"
@@ -18,17 +23,45 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// dynamoDBAPI is the part of the DynamoDB SDK client used by DynamoDBClient,
+// so that it can be stubbed in tests.
+type dynamoDBAPI interface {
+	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+	CreateTable(ctx context.Context, params *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	DeleteTable(ctx context.Context, params *dynamodb.DeleteTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteTableOutput, error)
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error)
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error)
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	UpdateTable(ctx context.Context, params *dynamodb.UpdateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error)
+	UpdateTimeToLive(ctx context.Context, params *dynamodb.UpdateTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error)
+}
+
+// DynamoDBClient writes the outbox message built by derivedEvents in the same
+// transaction as every counter update. Without derivedEvents no message is
+// written.
 type DynamoDBClient struct {
-	client *dynamodb.Client
+	client        dynamoDBAPI
+	tables        *database.TableRegistry
+	derivedEvents database.DerivedEvents
 }
 
-func NewDynamodbClient(config aws.Config) *DynamoDBClient {
+func NewDynamodbClient(config aws.Config, tables *database.TableRegistry, derivedEvents database.DerivedEvents) *DynamoDBClient {
 	return &DynamoDBClient{
-		client: dynamodb.NewFromConfig(config),
+		client:        dynamodb.NewFromConfig(config),
+		tables:        tables,
+		derivedEvents: derivedEvents,
 	}
 }
 
-// Clean deletes all tables from DynamoDB.
+// Clean deletes the service tables of the client environment from DynamoDB.
 // This method is destructive and will remove all tables and data.
 // Errors are logged but not returned.
 func (dc *DynamoDBClient) Clean() {
@@ -50,7 +83,7 @@ func (dc *DynamoDBClient) Clean() {
 			return
 		}
 
-		tablesToDelete = append(tablesToDelete, response.TableNames...)
+		tablesToDelete = append(tablesToDelete, dc.ownedTables(response.TableNames)...)
 
 		// Check if we need to continue pagination
 		if response.LastEvaluatedTableName == nil {
@@ -102,7 +135,7 @@ func (dc *DynamoDBClient) Clean() {
 	}
 }
 
-// Truncate truncates all tables in DynamoDB without deleting the table structures.
+// Truncate truncates the service tables of the client environment without deleting the table structures.
 // This method removes all data from tables but preserves the table definitions.
 // Errors are logged but not returned.
 func (dc *DynamoDBClient) Truncate() {
@@ -124,7 +157,7 @@ func (dc *DynamoDBClient) Truncate() {
 			return
 		}
 
-		tablesToClean = append(tablesToClean, response.TableNames...)
+		tablesToClean = append(tablesToClean, dc.ownedTables(response.TableNames)...)
 
 		// Check if we need to continue pagination
 		if response.LastEvaluatedTableName == nil {
@@ -212,6 +245,17 @@ func (dc *DynamoDBClient) Truncate() {
 	}
 }
 
+// ownedTables filters out the tables of other services and environments
+func (dc *DynamoDBClient) ownedTables(tableNames []string) []string {
+	owned := []string{}
+	for _, tableName := range tableNames {
+		if dc.tables.Owns(tableName) {
+			owned = append(owned, tableName)
+		}
+	}
+	return owned
+}
+
 // extractKeyFromItem extracts the primary key components from an item based on the key schema
 func extractKeyFromItem(item map[string]types.AttributeValue, keySchema []types.KeySchemaElement) map[string]types.AttributeValue {
 	key := make(map[string]types.AttributeValue)
@@ -228,7 +272,7 @@ func extractKeyFromItem(item map[string]types.AttributeValue, keySchema []types.
 func (dc *DynamoDBClient) TableExists(tableName string) bool {
 	exists := true
 	_, err := dc.client.DescribeTable(
-		context.TODO(), &dynamodb.DescribeTableInput{TableName: aws.String(tableName)},
+		context.TODO(), &dynamodb.DescribeTableInput{TableName: aws.String(dc.tables.Name(tableName))},
 	)
 	if err != nil {
 		var notFoundEx *types.ResourceNotFoundException
@@ -254,11 +298,20 @@ func (dc *DynamoDBClient) CreateTable(tableName string, keys *[]database.TableAt
 			ReadCapacityUnits:  aws.Int64(1),
 			WriteCapacityUnits: aws.Int64(1),
 		},
-		TableName: aws.String(tableName),
+		TableName: aws.String(dc.tables.Name(tableName)),
 	}
 
 	table, err := dc.client.CreateTable(ctx, input)
 
+	// Another instance is creating the same table, so it is only waited for
+	var resourceInUse *types.ResourceInUseException
+	if errors.As(err, &resourceInUse) {
+		log.Info().Msgf("Table %s is already being created", tableName)
+		waiter := dynamodb.NewTableExistsWaiter(dc.client)
+		return waiter.Wait(ctx, &dynamodb.DescribeTableInput{
+			TableName: aws.String(dc.tables.Name(tableName))}, 5*time.Minute)
+	}
+
 	var tableDesc *types.TableDescription
 	if err != nil {
 		log.Fatal().Stack().Err(err).Msgf("Couldn't create table %v", tableName)
@@ -266,7 +319,7 @@ func (dc *DynamoDBClient) CreateTable(tableName string, keys *[]database.TableAt
 	} else {
 		waiter := dynamodb.NewTableExistsWaiter(dc.client)
 		err = waiter.Wait(ctx, &dynamodb.DescribeTableInput{
-			TableName: aws.String(tableName)}, 5*time.Minute)
+			TableName: aws.String(dc.tables.Name(tableName))}, 5*time.Minute)
 		if err != nil {
 			log.Warn().Err(err).Msgf("Wait for table exists failed")
 		}
@@ -282,7 +335,7 @@ func (dc *DynamoDBClient) IndexExists(tableName string, indexName string) bool {
 
 	// Obter información da táboa
 	result, err := dc.client.DescribeTable(
-		context.TODO(), &dynamodb.DescribeTableInput{TableName: aws.String(tableName)},
+		context.TODO(), &dynamodb.DescribeTableInput{TableName: aws.String(dc.tables.Name(tableName))},
 	)
 	if err != nil {
 		var notFoundEx *types.ResourceNotFoundException
@@ -343,7 +396,7 @@ func (dc *DynamoDBClient) CreateIndexesOnTable(tableName, indexName string, inde
 	}
 
 	input := &dynamodb.UpdateTableInput{
-		TableName:                   aws.String(tableName),
+		TableName:                   aws.String(dc.tables.Name(tableName)),
 		AttributeDefinitions:        *attributeDefinitions,
 		GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{gsi},
 	}
@@ -358,6 +411,37 @@ func (dc *DynamoDBClient) CreateIndexesOnTable(tableName, indexName string, inde
 	return nil
 }
 
+// EnableTimeToLive is a no-op when the time to live of the table is already
+// enabled, since DynamoDB rejects enabling it twice.
+func (dc *DynamoDBClient) EnableTimeToLive(tableName string, attributeName string, ctx context.Context) error {
+	description, err := dc.client.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(dc.tables.Name(tableName)),
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't describe the time to live of table %s", tableName)
+		return err
+	}
+	if ttl := description.TimeToLiveDescription; ttl != nil && (ttl.TimeToLiveStatus == types.TimeToLiveStatusEnabled || ttl.TimeToLiveStatus == types.TimeToLiveStatusEnabling) {
+		log.Info().Msgf("Time to live of table %s is already enabled on %s", tableName, aws.ToString(ttl.AttributeName))
+		return nil
+	}
+
+	_, err = dc.client.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(dc.tables.Name(tableName)),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String(attributeName),
+			Enabled:       aws.Bool(true),
+		},
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't enable the time to live of table %s", tableName)
+		return err
+	}
+
+	log.Info().Msgf("Time to live enabled on table %s at attribute %s", tableName, attributeName)
+	return nil
+}
+
 func (dc *DynamoDBClient) InsertData(tableName string, attributes any) error {
 	item, err := attributevalue.MarshalMap(attributes)
 	if err != nil {
@@ -365,7 +449,7 @@ func (dc *DynamoDBClient) InsertData(tableName string, attributes any) error {
 	}
 
 	_, err = dc.client.PutItem(context.TODO(), &dynamodb.PutItemInput{
-		TableName: aws.String(tableName), Item: item,
+		TableName: aws.String(dc.tables.Name(tableName)), Item: item,
 	})
 	if err != nil {
 		log.Error().Stack().Err(err).Msgf("Couldn't put item to table %s", tableName)
@@ -393,7 +477,7 @@ func (dc *DynamoDBClient) InsertDataAndIncreaseCounter(tableName string, attribu
 	// Create PutItem operation
 	putItem := types.TransactWriteItem{
 		Put: &types.Put{
-			TableName: aws.String(tableName),
+			TableName: aws.String(dc.tables.Name(tableName)),
 			Item:      item,
 		},
 	}
@@ -401,7 +485,7 @@ func (dc *DynamoDBClient) InsertDataAndIncreaseCounter(tableName string, attribu
 	// Create UpdateItem operation for counter
 	updateItem := types.TransactWriteItem{
 		Update: &types.Update{
-			TableName:        aws.String(counterTableName),
+			TableName:        aws.String(dc.tables.Name(counterTableName)),
 			Key:              counterK,
 			UpdateExpression: aws.String("set #field = if_not_exists(#field, :zero) + :val"),
 			ExpressionAttributeNames: map[string]string{
@@ -414,16 +498,22 @@ func (dc *DynamoDBClient) InsertDataAndIncreaseCounter(tableName string, attribu
 		},
 	}
 
-	// Create transaction input with both operations
-	transactionInput := &dynamodb.TransactWriteItemsInput{
-		TransactItems: []types.TransactWriteItem{
-			putItem,
-			updateItem,
-		},
+	transactItems := []types.TransactWriteItem{
+		putItem,
+		updateItem,
+	}
+	message, err := dc.outboxMessage(&database.CounterChange{
+		CounterTable: counterTableName,
+		CounterKey:   counterKey,
+		CounterField: counterFieldName,
+		Delta:        1,
+	})
+	if err != nil {
+		return err
 	}
 
 	// Execute transaction
-	_, err = dc.client.TransactWriteItems(context.TODO(), transactionInput)
+	err = dc.transactWithOutbox(transactItems, message)
 	if err != nil {
 		var tce *types.TransactionCanceledException
 		if errors.As(err, &tce) {
@@ -445,7 +535,7 @@ func (dc *DynamoDBClient) GetData(tableName string, key any, result any) error {
 	}
 
 	response, err := dc.client.GetItem(context.TODO(), &dynamodb.GetItemInput{
-		Key: k, TableName: aws.String(tableName),
+		Key: k, TableName: aws.String(dc.tables.Name(tableName)),
 	})
 	if err != nil {
 		log.Error().Stack().Err(err).Msgf("Couldn't get info about %s", tableName)
@@ -476,36 +566,17 @@ func (dc *DynamoDBClient) GetMultipleData(tableName string, keys []any, results
 		return err
 	}
 
-	keyItems := make([]map[string]types.AttributeValue, 0, len(keys))
-
-	keysAndAttributes := &types.KeysAndAttributes{
-		Keys: keyItems,
-	}
-
-	for _, key := range keys {
-		k, err := attributevalue.MarshalMap(key)
-		if err != nil {
-			log.Error().Stack().Err(err).Msgf("Couldn't map key %v to AttributeValues", key)
-			return err
-		}
-		keysAndAttributes.Keys = append(keysAndAttributes.Keys, k)
-	}
-
-	requestItems := map[string]types.KeysAndAttributes{
-		tableName: *keysAndAttributes,
+	keyItems, err := marshalKeys(keys)
+	if err != nil {
+		return err
 	}
 
-	response, err := dc.client.BatchGetItem(context.TODO(), &dynamodb.BatchGetItemInput{
-		RequestItems: requestItems,
-	})
-
+	responseItems, err := dc.batchGetItems(tableName, keyItems, nil)
 	if err != nil {
-		log.Error().Stack().Err(err).Msgf("Couldn't get batch info from %s", tableName)
 		return err
 	}
 
-	responseItems, ok := response.Responses[tableName]
-	if !ok || len(responseItems) == 0 {
+	if len(responseItems) == 0 {
 		err = database.NewNotFoundError(tableName, keys)
 		log.Error().Stack().Err(err).Msg("No items were found")
 		return err
@@ -538,68 +609,161 @@ func (dc *DynamoDBClient) GetMultipleData(tableName string, keys []any, results
 	return nil
 }
 
-func (dc *DynamoDBClient) RemoveDataAndDecreaseCounter(tableName string, key any, counterTableName string, counterKey any, counterFieldName string) error {
-	// Marshal item key
-	k, err := attributevalue.MarshalMap(key)
+func (dc *DynamoDBClient) GetAllData(tableName string, results any) error {
+	err := validateIsPointerToSlice(results)
 	if err != nil {
-		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", key)
 		return err
 	}
 
-	// Marshal counter key
-	counterK, err := attributevalue.MarshalMap(counterKey)
+	items := []map[string]types.AttributeValue{}
+	var startKey map[string]types.AttributeValue
+	for {
+		response, err := dc.client.Scan(context.TODO(), &dynamodb.ScanInput{
+			TableName:         aws.String(dc.tables.Name(tableName)),
+			ExclusiveStartKey: startKey,
+		})
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't scan table %s", tableName)
+			return err
+		}
+
+		items = append(items, response.Items...)
+
+		if response.LastEvaluatedKey == nil {
+			break
+		}
+		startKey = response.LastEvaluatedKey
+	}
+
+	err = attributevalue.UnmarshalListOfMaps(items, results)
 	if err != nil {
-		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", counterKey)
+		log.Error().Stack().Err(err).Msgf("Couldn't unmarshal items from table %s", tableName)
 		return err
 	}
 
-	// Create DeleteItem operation
-	deleteItem := types.TransactWriteItem{
-		Delete: &types.Delete{
-			TableName: aws.String(tableName),
-			Key:       k,
-		},
+	return nil
+}
+
+// ScanPage reads a page of up to limit items of the table, starting after
+// cursor, and returns the cursor of the next page, empty after the last one.
+// Cursors are opaque and only valid for the same table.
+func (dc *DynamoDBClient) ScanPage(tableName string, cursor string, limit int, results any) (string, error) {
+	err := validateIsPointerToSlice(results)
+	if err != nil {
+		return "", err
 	}
 
-	// Create UpdateItem operation for counter
-	updateItem := types.TransactWriteItem{
-		Update: &types.Update{
-			TableName:        aws.String(counterTableName),
-			Key:              counterK,
-			UpdateExpression: aws.String("set #field = #field + :val"),
+	input := &dynamodb.ScanInput{
+		TableName: aws.String(dc.tables.Name(tableName)),
+		Limit:     aws.Int32(int32(limit)),
+	}
+	if cursor != "" {
+		input.ExclusiveStartKey, err = decodeCursor(cursor)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Invalid cursor for table %s", tableName)
+			return "", err
+		}
+	}
+
+	response, err := dc.client.Scan(context.TODO(), input)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't scan table %s", tableName)
+		return "", err
+	}
+
+	err = attributevalue.UnmarshalListOfMaps(response.Items, results)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't unmarshal items from table %s", tableName)
+		return "", err
+	}
+
+	if response.LastEvaluatedKey == nil {
+		return "", nil
+	}
+	return encodeCursor(response.LastEvaluatedKey)
+}
+
+// GetAllDataByIndex reads every item of the index whose partition key
+// attributeName equals value, following all the result pages.
+func (dc *DynamoDBClient) GetAllDataByIndex(tableName string, indexName string, attributeName string, value string, results any) error {
+	err := validateIsPointerToSlice(results)
+	if err != nil {
+		return err
+	}
+
+	items := []map[string]types.AttributeValue{}
+	var startKey map[string]types.AttributeValue
+	for {
+		response, err := dc.client.Query(context.TODO(), &dynamodb.QueryInput{
+			TableName:              aws.String(dc.tables.Name(tableName)),
+			IndexName:              aws.String(indexName),
+			KeyConditionExpression: aws.String("#key = :value"),
 			ExpressionAttributeNames: map[string]string{
-				"#field": counterFieldName,
+				"#key": attributeName,
 			},
 			ExpressionAttributeValues: map[string]types.AttributeValue{
-				":val": &types.AttributeValueMemberN{Value: strconv.Itoa(-1)},
+				":value": &types.AttributeValueMemberS{Value: value},
 			},
-		},
+			ExclusiveStartKey: startKey,
+		})
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't query index %s of table %s", indexName, tableName)
+			return err
+		}
+
+		items = append(items, response.Items...)
+
+		if response.LastEvaluatedKey == nil {
+			break
+		}
+		startKey = response.LastEvaluatedKey
 	}
 
-	// Create transaction input with both operations
-	transactionInput := &dynamodb.TransactWriteItemsInput{
-		TransactItems: []types.TransactWriteItem{
-			deleteItem,
-			updateItem,
-		},
+	err = attributevalue.UnmarshalListOfMaps(items, results)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't unmarshal items from index %s of table %s", indexName, tableName)
+		return err
 	}
 
-	// Execute transaction
-	_, err = dc.client.TransactWriteItems(context.TODO(), transactionInput)
+	return nil
+}
+
+// RemoveDataAndDecreaseCounter deletes the item and decreases the counter by
+// one. Nothing is decreased when the item doesn't exist, and the counter never
+// goes below zero.
+func (dc *DynamoDBClient) RemoveDataAndDecreaseCounter(tableName string, key any, counterTableName string, counterKey any, counterFieldName string) error {
+	// Marshal item key
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", key)
+		return err
+	}
+
+	// Marshal counter key
+	counterK, err := attributevalue.MarshalMap(counterKey)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", counterKey)
+		return err
+	}
+
+	removed, err := dc.removeChunkAndDecreaseCounter(tableName, []map[string]types.AttributeValue{k}, counterTableName, counterKey, counterK, counterFieldName)
 	if err != nil {
-		var tce *types.TransactionCanceledException
-		if errors.As(err, &tce) {
-			log.Error().Stack().Err(err).Msgf("Transaction canceled: %v", tce.CancellationReasons)
-		} else {
-			log.Error().Stack().Err(err).Msgf("Failed to execute transaction")
-		}
 		return err
 	}
+	if removed == 0 {
+		log.Warn().Msgf("Item %v doesn't exist in %s, counter %s in %s was not decreased", key, tableName, counterFieldName, counterTableName)
+		return nil
+	}
 
 	log.Info().Msgf("Successfully executed transaction: removed item from %s and decreased counter %s in %s", tableName, counterFieldName, counterTableName)
 	return nil
 }
 
+// RemoveMultipleDataAndDecreaseCounter deletes the items and decreases the
+// counter by the number of items actually deleted. Keys are split into
+// transactions of at most 98 deletes plus the counter update and its outbox
+// message; each transaction only deletes items that still exist, so retrying
+// after a partial failure resumes where it stopped without decreasing twice.
 func (dc *DynamoDBClient) RemoveMultipleDataAndDecreaseCounter(tableName string, keys []any, counterTableName string, counterKey any, counterFieldName string) error {
 	if len(keys) == 0 {
 		return nil
@@ -612,91 +776,163 @@ func (dc *DynamoDBClient) RemoveMultipleDataAndDecreaseCounter(tableName string,
 		return err
 	}
 
-	// Create DynamoDB transaction items
-	transactItems := make([]types.TransactWriteItem, 0, len(keys)+1)
+	keyItems, err := marshalKeys(keys)
+	if err != nil {
+		return err
+	}
 
-	// Add delete requests for each key
-	for _, key := range keys {
-		k, err := attributevalue.MarshalMap(key)
+	totalDecrement := 0
+	for _, chunk := range chunkKeys(keyItems, maxTransactionItems-2) {
+		removed, err := dc.removeChunkAndDecreaseCounter(tableName, chunk, counterTableName, counterKey, counterK, counterFieldName)
+		totalDecrement += removed
 		if err != nil {
-			log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", key)
+			log.Error().Msgf("Removed %d of %d items from %s before failing", totalDecrement, len(keys), tableName)
 			return err
 		}
-
-		deleteItem := types.TransactWriteItem{
-			Delete: &types.Delete{
-				TableName: aws.String(tableName),
-				Key:       k,
-			},
-		}
-		transactItems = append(transactItems, deleteItem)
 	}
 
-	// Add update request for the counter - decrement by the number of items being deleted
-	totalDecrement := len(keys)
-	updateItem := types.TransactWriteItem{
-		Update: &types.Update{
-			TableName:        aws.String(counterTableName),
-			Key:              counterK,
-			UpdateExpression: aws.String("set #field = #field + :val"),
-			ExpressionAttributeNames: map[string]string{
-				"#field": counterFieldName,
-			},
-			ExpressionAttributeValues: map[string]types.AttributeValue{
-				":val": &types.AttributeValueMemberN{Value: strconv.Itoa(-totalDecrement)},
-			},
-		},
-	}
-	transactItems = append(transactItems, updateItem)
+	log.Info().Msgf("Successfully executed transactions: removed %d items from %s and decreased counter %s in %s by %d",
+		totalDecrement, tableName, counterFieldName, counterTableName, totalDecrement)
+	return nil
+}
 
-	// DynamoDB transactions are limited to 100 items
-	if len(transactItems) > 100 {
-		log.Error().Msgf("Transaction exceeds maximum item limit (100). Attempting to delete %d items.", len(keys))
-		return fmt.Errorf("transaction exceeds maximum item limit (100)")
-	}
+// removeChunkAndDecreaseCounter takes the counter key both as given to the
+// client, for the derived events, and marshalled. Every delete is conditioned
+// on its item existing, and a transaction canceled by missing items is retried
+// without them, so the counter only goes down by the items really deleted.
+func (dc *DynamoDBClient) removeChunkAndDecreaseCounter(tableName string, keys []map[string]types.AttributeValue, counterTableName string, counterKey any, counterK map[string]types.AttributeValue, counterFieldName string) (int, error) {
+	decrease := true
+	for attempt := 0; len(keys) > 0; {
+		transactItems := make([]types.TransactWriteItem, 0, len(keys)+2)
+		for _, k := range keys {
+			transactItems = append(transactItems, types.TransactWriteItem{
+				Delete: &types.Delete{
+					TableName:                aws.String(dc.tables.Name(tableName)),
+					Key:                      k,
+					ConditionExpression:      aws.String("attribute_exists(#key)"),
+					ExpressionAttributeNames: map[string]string{"#key": keyAttributeNames(k)[0]},
+				},
+			})
+		}
 
-	// Execute transaction
-	transactionInput := &dynamodb.TransactWriteItemsInput{
-		TransactItems: transactItems,
-	}
+		// Decrement by the number of items being deleted, as long as the
+		// counter doesn't go below zero. A counter reset to zero below isn't
+		// published, as its change is unknown.
+		counterIndex := len(transactItems)
+		var message *database.OutboxMessage
+		if decrease {
+			transactItems = append(transactItems, types.TransactWriteItem{
+				Update: &types.Update{
+					TableName:           aws.String(dc.tables.Name(counterTableName)),
+					Key:                 counterK,
+					UpdateExpression:    aws.String("set #field = #field - :val"),
+					ConditionExpression: aws.String("#field >= :val"),
+					ExpressionAttributeNames: map[string]string{
+						"#field": counterFieldName,
+					},
+					ExpressionAttributeValues: map[string]types.AttributeValue{
+						":val": &types.AttributeValueMemberN{Value: strconv.Itoa(len(keys))},
+					},
+				},
+			})
+			var err error
+			message, err = dc.outboxMessage(&database.CounterChange{
+				CounterTable: counterTableName,
+				CounterKey:   counterKey,
+				CounterField: counterFieldName,
+				Delta:        -len(keys),
+			})
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		err := dc.transactWithOutbox(transactItems, message)
+		if err == nil {
+			if !decrease {
+				dc.resetNegativeCounter(counterTableName, counterK, counterFieldName, len(keys))
+			}
+			return len(keys), nil
+		}
 
-	_, err = dc.client.TransactWriteItems(context.TODO(), transactionInput)
-	if err != nil {
 		var tce *types.TransactionCanceledException
-		if errors.As(err, &tce) {
-			log.Error().Stack().Err(err).Msgf("Transaction canceled: %v", tce.CancellationReasons)
-		} else {
+		if !errors.As(err, &tce) {
 			log.Error().Stack().Err(err).Msgf("Failed to execute transaction")
+			return 0, err
 		}
-		return err
+		existing := []map[string]types.AttributeValue{}
+		for i, k := range keys {
+			if !isConditionalCheckFailed(tce, i) {
+				existing = append(existing, k)
+			}
+		}
+		if len(existing) < len(keys) {
+			// Items already removed are left out of the next transaction,
+			// which doesn't count as a failed attempt as the keys shrink
+			keys = existing
+			continue
+		}
+		if decrease && isConditionalCheckFailed(tce, counterIndex) {
+			// The counter already drifted below the number of deleted items, so
+			// the items are deleted on their own and the counter is set to zero
+			log.Warn().Msgf("Counter %s in %s is lower than %d, it won't be decreased below zero", counterFieldName, counterTableName, len(keys))
+			decrease = false
+			continue
+		}
+		attempt++
+		if attempt == maxBatchAttempts {
+			log.Error().Stack().Err(err).Msgf("Failed to execute transaction")
+			return 0, err
+		}
+		// The transaction conflicted with another write
+		log.Warn().Err(err).Msgf("Transaction canceled, retrying: %v", tce.CancellationReasons)
+		waitBackoff(attempt)
 	}
 
-	log.Info().Msgf("Successfully executed transaction: removed %d items from %s and decreased counter %s in %s by %d",
-		len(keys), tableName, counterFieldName, counterTableName, totalDecrement)
-	return nil
+	return 0, nil
+}
+
+// resetNegativeCounter sets the counter to zero when it is lower than amount.
+// A missing counter item is left untouched.
+func (dc *DynamoDBClient) resetNegativeCounter(tableName string, key map[string]types.AttributeValue, counterFieldName string, amount int) {
+	_, err := dc.client.UpdateItem(context.TODO(), &dynamodb.UpdateItemInput{
+		TableName:           aws.String(dc.tables.Name(tableName)),
+		Key:                 key,
+		UpdateExpression:    aws.String("set #field = :zero"),
+		ConditionExpression: aws.String("#field < :val"),
+		ExpressionAttributeNames: map[string]string{
+			"#field": counterFieldName,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":zero": &types.AttributeValueMemberN{Value: "0"},
+			":val":  &types.AttributeValueMemberN{Value: strconv.Itoa(amount)},
+		},
+	})
+	var ccfe *types.ConditionalCheckFailedException
+	if err != nil && !errors.As(err, &ccfe) {
+		log.Error().Stack().Err(err).Msgf("Couldn't reset counter %s from table %s", counterFieldName, tableName)
+	}
+}
+
+func isConditionalCheckFailed(tce *types.TransactionCanceledException, index int) bool {
+	if index >= len(tce.CancellationReasons) {
+		return false
+	}
+	code := tce.CancellationReasons[index].Code
+	return code != nil && *code == "ConditionalCheckFailed"
 }
 
 func (dc *DynamoDBClient) RemoveMultipleData(tableName string, keys []any) error {
-	writeRequests := make([]types.WriteRequest, len(keys))
-	for i, key := range keys {
-		k, err := attributevalue.MarshalMap(key)
-		if err != nil {
-			log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", key)
-		}
-		writeRequests[i] = types.WriteRequest{
-			DeleteRequest: &types.DeleteRequest{
-				Key: k,
-			},
-		}
+	if len(keys) == 0 {
+		return nil
 	}
 
-	input := &dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]types.WriteRequest{
-			tableName: writeRequests,
-		},
+	keyItems, err := marshalKeys(keys)
+	if err != nil {
+		return err
 	}
 
-	_, err := dc.client.BatchWriteItem(context.TODO(), input)
+	err = dc.batchDeleteItems(tableName, keyItems)
 	if err != nil {
 		log.Error().Stack().Err(err).Msgf("Failed to batch delete items %v from table %s", keys, tableName)
 		return err
@@ -742,7 +978,7 @@ func (dc *DynamoDBClient) UpdateData(tableName string, key any, updateAttributes
 	}
 
 	input := &dynamodb.UpdateItemInput{
-		TableName:                 aws.String(tableName),
+		TableName:                 aws.String(dc.tables.Name(tableName)),
 		Key:                       k,
 		UpdateExpression:          aws.String(updateExp),
 		ExpressionAttributeNames:  expAttrNames,
@@ -769,7 +1005,7 @@ func (dc *DynamoDBClient) IncrementCounter(tableName string, key any, counterFie
 	}
 
 	input := &dynamodb.UpdateItemInput{
-		TableName:        aws.String(tableName),
+		TableName:        aws.String(dc.tables.Name(tableName)),
 		Key:              k,
 		UpdateExpression: aws.String(fmt.Sprintf("set #field = #field + :val")),
 		ExpressionAttributeNames: map[string]string{
@@ -780,8 +1016,31 @@ func (dc *DynamoDBClient) IncrementCounter(tableName string, key any, counterFie
 		},
 		ReturnValues: types.ReturnValueUpdatedNew,
 	}
+	// Decrements never take the counter below zero
+	if incrementValue < 0 {
+		input.ConditionExpression = aws.String("#field >= :min")
+		input.ExpressionAttributeValues[":min"] = &types.AttributeValueMemberN{Value: strconv.Itoa(-incrementValue)}
+	}
+
+	message, err := dc.outboxMessage(&database.CounterChange{
+		CounterTable: tableName,
+		CounterKey:   key,
+		CounterField: counterFieldName,
+		Delta:        incrementValue,
+	})
+	if err != nil {
+		return err
+	}
+	if message != nil {
+		return dc.incrementCounterWithOutbox(input, message, counterFieldName, tableName, incrementValue)
+	}
 
 	_, err = dc.client.UpdateItem(context.TODO(), input)
+	var ccfe *types.ConditionalCheckFailedException
+	if errors.As(err, &ccfe) {
+		log.Warn().Msgf("Counter %s from table %s is lower than %d, it was not decreased", counterFieldName, tableName, -incrementValue)
+		return nil
+	}
 	if err != nil {
 		log.Error().Stack().Err(err).Msgf("Couldn't increase counter %s from table %s", counterFieldName, tableName)
 		return err
@@ -790,40 +1049,309 @@ func (dc *DynamoDBClient) IncrementCounter(tableName string, key any, counterFie
 	return nil
 }
 
-func (dc *DynamoDBClient) GetPostsByIndexUser(username string, currentUsername string, lastPostId, lastPostCreatedAt string, limit int) ([]*database.PostMetadata, string, string, error) {
-	input := &dynamodb.QueryInput{
-		TableName:              aws.String("PostMetadata"),
-		IndexName:              aws.String("UserIndex"),
-		KeyConditionExpression: aws.String("#user = :user"),
+// incrementCounterWithOutbox runs the counter update of IncrementCounter in a
+// transaction with its outbox message.
+func (dc *DynamoDBClient) incrementCounterWithOutbox(input *dynamodb.UpdateItemInput, message *database.OutboxMessage, counterFieldName, tableName string, incrementValue int) error {
+	err := dc.transactWithOutbox([]types.TransactWriteItem{
+		{
+			Update: &types.Update{
+				TableName:                 input.TableName,
+				Key:                       input.Key,
+				UpdateExpression:          input.UpdateExpression,
+				ConditionExpression:       input.ConditionExpression,
+				ExpressionAttributeNames:  input.ExpressionAttributeNames,
+				ExpressionAttributeValues: input.ExpressionAttributeValues,
+			},
+		},
+	}, message)
+	var tce *types.TransactionCanceledException
+	if errors.As(err, &tce) && isConditionalCheckFailed(tce, 0) {
+		log.Warn().Msgf("Counter %s from table %s is lower than %d, it was not decreased", counterFieldName, tableName, -incrementValue)
+		return nil
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't increase counter %s from table %s", counterFieldName, tableName)
+		return err
+	}
+
+	return nil
+}
+
+// IncrementCounterOnce increments the counter as IncrementCounter does, in a
+// transaction with the insertion of the marker key. The counter is left
+// untouched when the marker already exists, so a retried increment is only
+// applied once.
+func (dc *DynamoDBClient) IncrementCounterOnce(markerTableName string, markerKey any, tableName string, key any, counterFieldName string, incrementValue int) error {
+	marker, err := attributevalue.MarshalMap(markerKey)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", markerKey)
+		return err
+	}
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", key)
+		return err
+	}
+
+	putMarker := &types.Put{
+		TableName:                aws.String(dc.tables.Name(markerTableName)),
+		Item:                     marker,
+		ConditionExpression:      aws.String("attribute_not_exists(#key)"),
+		ExpressionAttributeNames: map[string]string{"#key": keyAttributeNames(marker)[0]},
+	}
+	update := &types.Update{
+		TableName:        aws.String(dc.tables.Name(tableName)),
+		Key:              k,
+		UpdateExpression: aws.String("set #field = #field + :val"),
 		ExpressionAttributeNames: map[string]string{
-			"#user": "Username",
+			"#field": counterFieldName,
 		},
 		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":user": &types.AttributeValueMemberS{Value: username},
+			":val": &types.AttributeValueMemberN{Value: strconv.Itoa(incrementValue)},
 		},
-		Limit: aws.Int32(int32(limit)),
 	}
-
-	if lastPostId != "" {
-		input.ExclusiveStartKey = map[string]types.AttributeValue{
-			"Username":  &types.AttributeValueMemberS{Value: username},
-			"PostId":    &types.AttributeValueMemberS{Value: lastPostId},
-			"CreatedAt": &types.AttributeValueMemberS{Value: lastPostCreatedAt},
-		}
+	// Decrements never take the counter below zero
+	if incrementValue < 0 {
+		update.ConditionExpression = aws.String("#field >= :min")
+		update.ExpressionAttributeValues[":min"] = &types.AttributeValueMemberN{Value: strconv.Itoa(-incrementValue)}
 	}
 
-	response, err := dc.client.Query(context.TODO(), input)
+	transactItems := []types.TransactWriteItem{{Put: putMarker}, {Update: update}}
+	message, err := dc.outboxMessage(&database.CounterChange{
+		CounterTable: tableName,
+		CounterKey:   key,
+		CounterField: counterFieldName,
+		Delta:        incrementValue,
+	})
 	if err != nil {
-		log.Error().Stack().Err(err).Msgf("Couldn't get info about Posts")
-		return nil, "", "", err
+		return err
 	}
 
-	var results []*database.PostMetadata
-	for _, item := range response.Items {
-
-		var result database.PostMetadata
-		err = attributevalue.UnmarshalMap(item, &result)
-		if err != nil {
+	err = dc.transactWithOutbox(transactItems, message)
+	var tce *types.TransactionCanceledException
+	if errors.As(err, &tce) && isConditionalCheckFailed(tce, 0) {
+		log.Info().Msgf("Counter %s from table %s was already increased by %d", counterFieldName, tableName, incrementValue)
+		return nil
+	}
+	if errors.As(err, &tce) && isConditionalCheckFailed(tce, 1) {
+		// The counter is left as it is, but the marker is still inserted so
+		// that the counter isn't decreased later on a retry
+		log.Warn().Msgf("Counter %s from table %s is lower than %d, it was not decreased", counterFieldName, tableName, -incrementValue)
+		_, err = dc.client.PutItem(context.TODO(), &dynamodb.PutItemInput{
+			TableName:                putMarker.TableName,
+			Item:                     putMarker.Item,
+			ConditionExpression:      putMarker.ConditionExpression,
+			ExpressionAttributeNames: putMarker.ExpressionAttributeNames,
+		})
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return nil
+		}
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't increase counter %s from table %s", counterFieldName, tableName)
+		return err
+	}
+
+	return nil
+}
+
+// outboxMessage returns the outbox message derived from the counter change,
+// or nil when the change isn't published.
+func (dc *DynamoDBClient) outboxMessage(change *database.CounterChange) (*database.OutboxMessage, error) {
+	if dc.derivedEvents == nil {
+		return nil, nil
+	}
+
+	message, err := dc.derivedEvents.CounterChanged(change)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't build the outbox message of counter %s in %s", change.CounterField, change.CounterTable)
+		return nil, err
+	}
+	return message, nil
+}
+
+// transactWithOutbox runs the transaction with the Put of the message, when
+// it isn't nil, and the update of the sequence of its entity. The message
+// takes the sequence following the one read, which the update checks, so the
+// transaction is retried when another message of the entity was written
+// meanwhile. A cancellation is returned for the caller to inspect the
+// conditions of transactItems.
+func (dc *DynamoDBClient) transactWithOutbox(transactItems []types.TransactWriteItem, message *database.OutboxMessage) error {
+	for attempt := 1; ; attempt++ {
+		items := slices.Clip(transactItems)
+		if message != nil {
+			outboxItems, err := dc.outboxItems(message)
+			if err != nil {
+				return err
+			}
+			items = append(items, outboxItems...)
+		}
+
+		_, err := dc.client.TransactWriteItems(context.TODO(), &dynamodb.TransactWriteItemsInput{
+			TransactItems: items,
+		})
+		var tce *types.TransactionCanceledException
+		sequenceTaken := message != nil && errors.As(err, &tce) && isConditionalCheckFailed(tce, len(transactItems)+1)
+		if !sequenceTaken || attempt == maxBatchAttempts {
+			return err
+		}
+		log.Warn().Msgf("Sequence %d of %s was taken, retrying outbox message %s", message.Sequence, message.Key, message.MessageId)
+		waitBackoff(attempt)
+	}
+}
+
+// outboxItems returns the Put of the message with the next sequence of its
+// entity and the update of that sequence.
+func (dc *DynamoDBClient) outboxItems(message *database.OutboxMessage) ([]types.TransactWriteItem, error) {
+	sequenceKey := &database.OutboxMessageKey{MessageId: database.OutboxSequenceId(message.EventType, message.Key)}
+	var sequence database.OutboxSequence
+	_, err := dc.GetDataIfExists(database.OutboxTable, sequenceKey, &sequence)
+	if err != nil {
+		return nil, err
+	}
+	message.Sequence = sequence.Sequence + 1
+
+	item, err := attributevalue.MarshalMap(message)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't marshal outbox message %s to AttributeValues", message.MessageId)
+		return nil, err
+	}
+	k, err := attributevalue.MarshalMap(sequenceKey)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", sequenceKey)
+		return nil, err
+	}
+
+	sequenceUpdate := &types.Update{
+		TableName:           aws.String(dc.tables.Name(database.OutboxTable)),
+		Key:                 k,
+		UpdateExpression:    aws.String("set #sequence = :next"),
+		ConditionExpression: aws.String("attribute_not_exists(#sequence)"),
+		ExpressionAttributeNames: map[string]string{
+			"#sequence": "Sequence",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":next": &types.AttributeValueMemberN{Value: strconv.FormatInt(message.Sequence, 10)},
+		},
+	}
+	if sequence.Sequence > 0 {
+		sequenceUpdate.ConditionExpression = aws.String("#sequence = :current")
+		sequenceUpdate.ExpressionAttributeValues[":current"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(sequence.Sequence, 10)}
+	}
+
+	return []types.TransactWriteItem{
+		{
+			Put: &types.Put{
+				TableName:                aws.String(dc.tables.Name(database.OutboxTable)),
+				Item:                     item,
+				ConditionExpression:      aws.String("attribute_not_exists(#id)"),
+				ExpressionAttributeNames: map[string]string{"#id": "MessageId"},
+			},
+		},
+		{Update: sequenceUpdate},
+	}, nil
+}
+
+// AcquireLock stores a lock item owned by owner under key. The lock is only
+// granted when no other owner holds it or when the previous lock expired.
+func (dc *DynamoDBClient) AcquireLock(tableName string, key any, owner string, ttl time.Duration) (bool, error) {
+	item, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", key)
+		return false, err
+	}
+
+	now := time.Now().UTC()
+	item["LockOwner"] = &types.AttributeValueMemberS{Value: owner}
+	item["LockExpiresAt"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Add(ttl).Unix(), 10)}
+
+	_, err = dc.client.PutItem(context.TODO(), &dynamodb.PutItemInput{
+		TableName:           aws.String(dc.tables.Name(tableName)),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(#owner) OR #owner = :owner OR #expiresAt < :now"),
+		ExpressionAttributeNames: map[string]string{
+			"#owner":     "LockOwner",
+			"#expiresAt": "LockExpiresAt",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":owner": &types.AttributeValueMemberS{Value: owner},
+			":now":   &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Unix(), 10)},
+		},
+	})
+	if err != nil {
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			log.Warn().Msgf("Lock %v in table %s is held by another owner", key, tableName)
+			return false, nil
+		}
+		log.Error().Stack().Err(err).Msgf("Couldn't acquire lock %v in table %s", key, tableName)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dc *DynamoDBClient) ReleaseLock(tableName string, key any, owner string) error {
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", key)
+		return err
+	}
+
+	_, err = dc.client.DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
+		TableName:           aws.String(dc.tables.Name(tableName)),
+		Key:                 k,
+		ConditionExpression: aws.String("#owner = :owner"),
+		ExpressionAttributeNames: map[string]string{
+			"#owner": "LockOwner",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":owner": &types.AttributeValueMemberS{Value: owner},
+		},
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't release lock %v in table %s", key, tableName)
+		return err
+	}
+
+	return nil
+}
+
+func (dc *DynamoDBClient) GetPostsByIndexUser(username string, currentUsername string, lastPostId, lastPostCreatedAt string, limit int) ([]*database.PostMetadata, string, string, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(dc.tables.Name(database.PostMetadataTable)),
+		IndexName:              aws.String("UserIndex"),
+		KeyConditionExpression: aws.String("#user = :user"),
+		ExpressionAttributeNames: map[string]string{
+			"#user": "Username",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":user": &types.AttributeValueMemberS{Value: username},
+		},
+		Limit: aws.Int32(int32(limit)),
+	}
+
+	if lastPostId != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"Username":  &types.AttributeValueMemberS{Value: username},
+			"PostId":    &types.AttributeValueMemberS{Value: lastPostId},
+			"CreatedAt": &types.AttributeValueMemberS{Value: lastPostCreatedAt},
+		}
+	}
+
+	response, err := dc.client.Query(context.TODO(), input)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't get info about Posts")
+		return nil, "", "", err
+	}
+
+	var results []*database.PostMetadata
+	for _, item := range response.Items {
+
+		var result database.PostMetadata
+		err = attributevalue.UnmarshalMap(item, &result)
+		if err != nil {
 			log.Error().Stack().Err(err).Msg("Couldn't unmarshal response")
 			return nil, "", "", err
 		}
@@ -872,7 +1400,7 @@ func (dc *DynamoDBClient) GetPostsByIndexUser(username string, currentUsername s
 // CheckUserPostReviewExist verifica se un usuario específico fixo unha review do post
 func (dc DynamoDBClient) CheckUserPostReviewExist(postId string, username string) (bool, error) {
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String("readmodels.reviews"),
+		TableName:              aws.String(dc.tables.Name(database.ReviewsTable)),
 		IndexName:              aws.String("UsernamePostIndex"),
 		KeyConditionExpression: aws.String("PostId = :postId AND Username = :username"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
@@ -894,7 +1422,7 @@ func (dc DynamoDBClient) CheckUserPostReviewExist(postId string, username string
 // CheckUserPostLikeExist verifica se un usuario específico deu like a un post
 func (dc DynamoDBClient) checkUserPostLikeExist(postId string, username string) (bool, error) {
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String("readmodels.postLikes"),
+		TableName:              aws.String(dc.tables.Name(database.PostLikesTable)),
 		KeyConditionExpression: aws.String("PostId = :postId AND Username = :username"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":postId":   &types.AttributeValueMemberS{Value: postId},
@@ -915,7 +1443,7 @@ func (dc DynamoDBClient) checkUserPostLikeExist(postId string, username string)
 // CheckUserPostSuperlikeExist verifica se un usuario específico deu like a un post
 func (dc DynamoDBClient) checkUserPostSuperlikeExist(postId string, username string) (bool, error) {
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String("readmodels.postSuperlikes"),
+		TableName:              aws.String(dc.tables.Name(database.PostSuperlikesTable)),
 		KeyConditionExpression: aws.String("PostId = :postId AND Username = :username"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":postId":   &types.AttributeValueMemberS{Value: postId},
@@ -935,7 +1463,7 @@ func (dc DynamoDBClient) checkUserPostSuperlikeExist(postId string, username str
 
 func (dc *DynamoDBClient) GetCommentsByIndexPostId(postID string, lastCommentId uint64, limit int) ([]*model.Comment, uint64, error) {
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String("readmodels.comments"),
+		TableName:              aws.String(dc.tables.Name(database.CommentsTable)),
 		IndexName:              aws.String("PostIdIndex"),
 		KeyConditionExpression: aws.String("#postId = :postId"),
 		ExpressionAttributeNames: map[string]string{
@@ -989,7 +1517,7 @@ func (dc *DynamoDBClient) GetCommentsByIndexPostId(postID string, lastCommentId
 
 func (dc *DynamoDBClient) GetPostLikesByIndexPostId(postID string, lastUsername string, limit int) ([]*model.UserMetadata, string, error) {
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String("readmodels.postLikes"),
+		TableName:              aws.String(dc.tables.Name(database.PostLikesTable)),
 		KeyConditionExpression: aws.String("#postId = :postId"),
 		ExpressionAttributeNames: map[string]string{
 			"#postId": "PostId",
@@ -1039,7 +1567,7 @@ func (dc *DynamoDBClient) GetPostLikesByIndexPostId(postID string, lastUsername
 
 func (dc *DynamoDBClient) GetPostSuperlikesByIndexPostId(postID string, lastUsername string, limit int) ([]*model.UserMetadata, string, error) {
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String("readmodels.postSuperlikes"),
+		TableName:              aws.String(dc.tables.Name(database.PostSuperlikesTable)),
 		KeyConditionExpression: aws.String("#postId = :postId"),
 		ExpressionAttributeNames: map[string]string{
 			"#postId": "PostId",
@@ -1089,7 +1617,7 @@ func (dc *DynamoDBClient) GetPostSuperlikesByIndexPostId(postID string, lastUser
 
 func (dc *DynamoDBClient) GetReviewsByIndexPostId(postID string, lastReviewId uint64, limit int) ([]*model.Review, uint64, error) {
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String("readmodels.reviews"),
+		TableName:              aws.String(dc.tables.Name(database.ReviewsTable)),
 		IndexName:              aws.String("PostIdIndex"),
 		KeyConditionExpression: aws.String("#postId = :postId"),
 		ExpressionAttributeNames: map[string]string{
@@ -1199,10 +1727,480 @@ func validateIsPointerToSlice(results any) error {
 	resultsVal := reflect.ValueOf(results)
 
 	if resultsVal.Kind() != reflect.Ptr || resultsVal.Elem().Kind() != reflect.Slice {
-		err := database.NewInvalidSlicePointerError(string(resultsVal.Kind()))
+		err := database.NewInvalidSlicePointerError(resultsVal.Kind().String())
 		log.Error().Stack().Err(err).Msg("Invalid results parameter")
 		return err
 	}
 
 	return nil
 }
+
+func (dc *DynamoDBClient) GetNotificationsByIndexUsername(username string, lastNotificationId, lastUpdatedAt string, limit int) ([]*database.NotificationRecord, string, string, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(dc.tables.Name(database.NotificationsTable)),
+		IndexName:              aws.String(database.NotificationsUpdatedAtIndex),
+		KeyConditionExpression: aws.String("#user = :user"),
+		ExpressionAttributeNames: map[string]string{
+			"#user": "Username",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":user": &types.AttributeValueMemberS{Value: username},
+		},
+		ScanIndexForward: aws.Bool(false),
+		Limit:            aws.Int32(int32(limit)),
+	}
+
+	if lastNotificationId != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"Username":       &types.AttributeValueMemberS{Value: username},
+			"NotificationId": &types.AttributeValueMemberS{Value: lastNotificationId},
+			"UpdatedAt":      &types.AttributeValueMemberS{Value: lastUpdatedAt},
+		}
+	}
+
+	response, err := dc.client.Query(context.TODO(), input)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't get notifications of %s", username)
+		return nil, "", "", err
+	}
+
+	results := []*database.NotificationRecord{}
+	err = attributevalue.UnmarshalListOfMaps(response.Items, &results)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Couldn't unmarshal notifications response")
+		return nil, "", "", err
+	}
+
+	lastNotificationId = ""
+	lastUpdatedAt = ""
+	if response.LastEvaluatedKey != nil {
+		if val, ok := response.LastEvaluatedKey["NotificationId"].(*types.AttributeValueMemberS); ok {
+			lastNotificationId = val.Value
+		}
+		if val, ok := response.LastEvaluatedKey["UpdatedAt"].(*types.AttributeValueMemberS); ok {
+			lastUpdatedAt = val.Value
+		}
+	}
+
+	return results, lastNotificationId, lastUpdatedAt, nil
+}
+
+// GetActivitiesByIndexUsername returns the most recent activities of the user
+// first. When activityTypes isn't empty only those types are returned, and
+// the index is read until the page is full since the filter applies after
+// the limit.
+func (dc *DynamoDBClient) GetActivitiesByIndexUsername(username string, activityTypes []string, lastActivityId, lastOccurredAt string, limit int) ([]*database.ActivityRecord, string, string, error) {
+	expressionAttributeNames := map[string]string{
+		"#user": "Username",
+	}
+	expressionAttributeValues := map[string]types.AttributeValue{
+		":user": &types.AttributeValueMemberS{Value: username},
+	}
+	var filterExpression *string
+	if len(activityTypes) > 0 {
+		placeholders := make([]string, len(activityTypes))
+		for i, activityType := range activityTypes {
+			placeholders[i] = fmt.Sprintf(":type%d", i)
+			expressionAttributeValues[placeholders[i]] = &types.AttributeValueMemberS{Value: activityType}
+		}
+		expressionAttributeNames["#type"] = "Type"
+		filterExpression = aws.String(fmt.Sprintf("#type IN (%s)", strings.Join(placeholders, ", ")))
+	}
+
+	var startKey map[string]types.AttributeValue
+	if lastActivityId != "" {
+		startKey = map[string]types.AttributeValue{
+			"Username":   &types.AttributeValueMemberS{Value: username},
+			"ActivityId": &types.AttributeValueMemberS{Value: lastActivityId},
+			"OccurredAt": &types.AttributeValueMemberS{Value: lastOccurredAt},
+		}
+	}
+
+	items := []map[string]types.AttributeValue{}
+	for {
+		response, err := dc.client.Query(context.TODO(), &dynamodb.QueryInput{
+			TableName:                 aws.String(dc.tables.Name(database.ActivityTable)),
+			IndexName:                 aws.String(database.ActivityOccurredAtIndex),
+			KeyConditionExpression:    aws.String("#user = :user"),
+			FilterExpression:          filterExpression,
+			ExpressionAttributeNames:  expressionAttributeNames,
+			ExpressionAttributeValues: expressionAttributeValues,
+			ScanIndexForward:          aws.Bool(false),
+			Limit:                     aws.Int32(int32(limit - len(items))),
+			ExclusiveStartKey:         startKey,
+		})
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't get activities of %s", username)
+			return nil, "", "", err
+		}
+
+		items = append(items, response.Items...)
+		startKey = response.LastEvaluatedKey
+		if startKey == nil || len(items) >= limit {
+			break
+		}
+	}
+
+	results := []*database.ActivityRecord{}
+	err := attributevalue.UnmarshalListOfMaps(items, &results)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Couldn't unmarshal activities response")
+		return nil, "", "", err
+	}
+
+	lastActivityId = ""
+	lastOccurredAt = ""
+	if startKey != nil {
+		if val, ok := startKey["ActivityId"].(*types.AttributeValueMemberS); ok {
+			lastActivityId = val.Value
+		}
+		if val, ok := startKey["OccurredAt"].(*types.AttributeValueMemberS); ok {
+			lastOccurredAt = val.Value
+		}
+	}
+
+	return results, lastActivityId, lastOccurredAt, nil
+}
+
+// IncrementAndGet adds incrementValue to the number fieldName, sets the
+// updateAttributes in the same update, creating the item when missing, and
+// returns the new value of the field.
+func (dc *DynamoDBClient) IncrementAndGet(tableName string, key any, fieldName string, incrementValue float64, updateAttributes map[string]any) (float64, error) {
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", key)
+		return 0, err
+	}
+
+	assignments, expressionAttributeNames, expressionAttributeValues, err := assignmentExpressions(updateAttributes)
+	if err != nil {
+		return 0, err
+	}
+	expressionAttributeNames["#field"] = fieldName
+	expressionAttributeValues[":increment"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(incrementValue, 'g', -1, 64)}
+	updateExpression := "ADD #field :increment"
+	if len(assignments) > 0 {
+		updateExpression += " SET " + strings.Join(assignments, ", ")
+	}
+
+	response, err := dc.client.UpdateItem(context.TODO(), &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(dc.tables.Name(tableName)),
+		Key:                       k,
+		UpdateExpression:          aws.String(updateExpression),
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		ReturnValues:              types.ReturnValueUpdatedNew,
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't increase %s from table %s", fieldName, tableName)
+		return 0, err
+	}
+
+	var value float64
+	err = attributevalue.Unmarshal(response.Attributes[fieldName], &value)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't unmarshal %s from table %s", fieldName, tableName)
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// UpdateDataIfEqual sets the updateAttributes and removes the
+// removeAttributes of an existing item whose conditionFieldName still equals
+// conditionValue. It returns false, without error, when the condition fails.
+func (dc *DynamoDBClient) UpdateDataIfEqual(tableName string, key any, updateAttributes map[string]any, removeAttributes []string, conditionFieldName string, conditionValue any) (bool, error) {
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", key)
+		return false, err
+	}
+
+	assignments, expressionAttributeNames, expressionAttributeValues, err := assignmentExpressions(updateAttributes)
+	if err != nil {
+		return false, err
+	}
+	condition, err := attributevalue.Marshal(conditionValue)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v to AttributeValues", conditionValue)
+		return false, err
+	}
+	expressionAttributeNames["#condition"] = conditionFieldName
+	expressionAttributeValues[":condition"] = condition
+
+	updateExpression := ""
+	if len(assignments) > 0 {
+		updateExpression = "SET " + strings.Join(assignments, ", ")
+	}
+	if len(removeAttributes) > 0 {
+		removals := make([]string, len(removeAttributes))
+		for i, attributeName := range removeAttributes {
+			removals[i] = fmt.Sprintf("#r%d", i)
+			expressionAttributeNames[removals[i]] = attributeName
+		}
+		updateExpression = strings.TrimSpace(updateExpression + " REMOVE " + strings.Join(removals, ", "))
+	}
+
+	_, err = dc.client.UpdateItem(context.TODO(), &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(dc.tables.Name(tableName)),
+		Key:                       k,
+		UpdateExpression:          aws.String(updateExpression),
+		ConditionExpression:       aws.String("#condition = :condition"),
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+	})
+	var ccfe *types.ConditionalCheckFailedException
+	if errors.As(err, &ccfe) {
+		return false, nil
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't update item %v in table %s", key, tableName)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UpdateDataIfLower sets the attributes of an existing item when its condition
+// field is lower than conditionValue or missing. It reports false, without an
+// error, when the item is missing or its condition field isn't lower.
+func (dc *DynamoDBClient) UpdateDataIfLower(tableName string, key any, updateAttributes map[string]any, conditionFieldName string, conditionValue any) (bool, error) {
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", key)
+		return false, err
+	}
+
+	assignments, expressionAttributeNames, expressionAttributeValues, err := assignmentExpressions(updateAttributes)
+	if err != nil {
+		return false, err
+	}
+	condition, err := attributevalue.Marshal(conditionValue)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v to AttributeValues", conditionValue)
+		return false, err
+	}
+	expressionAttributeNames["#key"] = keyAttributeNames(k)[0]
+	expressionAttributeNames["#condition"] = conditionFieldName
+	expressionAttributeValues[":condition"] = condition
+
+	_, err = dc.client.UpdateItem(context.TODO(), &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(dc.tables.Name(tableName)),
+		Key:                       k,
+		UpdateExpression:          aws.String("SET " + strings.Join(assignments, ", ")),
+		ConditionExpression:       aws.String("attribute_exists(#key) AND (attribute_not_exists(#condition) OR #condition < :condition)"),
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+	})
+	var ccfe *types.ConditionalCheckFailedException
+	if errors.As(err, &ccfe) {
+		return false, nil
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't update item %v in table %s", key, tableName)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetTrendingPostsByIndex returns the posts with the highest score first,
+// only those of postType when it isn't empty.
+func (dc *DynamoDBClient) GetTrendingPostsByIndex(postType string, lastPostId, lastScore string, limit int) ([]*database.TrendingRecord, string, string, error) {
+	indexName := database.TrendingScoreIndex
+	partitionName := "Ranking"
+	partitionValue := database.TrendingRanking
+	if postType != "" {
+		indexName = database.TrendingTypeScoreIndex
+		partitionName = "PostType"
+		partitionValue = postType
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(dc.tables.Name(database.TrendingTable)),
+		IndexName:              aws.String(indexName),
+		KeyConditionExpression: aws.String("#partition = :partition"),
+		ExpressionAttributeNames: map[string]string{
+			"#partition": partitionName,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":partition": &types.AttributeValueMemberS{Value: partitionValue},
+		},
+		ScanIndexForward: aws.Bool(false),
+		Limit:            aws.Int32(int32(limit)),
+	}
+
+	if lastPostId != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"PostId":      &types.AttributeValueMemberS{Value: lastPostId},
+			partitionName: &types.AttributeValueMemberS{Value: partitionValue},
+			"Score":       &types.AttributeValueMemberN{Value: lastScore},
+		}
+	}
+
+	response, err := dc.client.Query(context.TODO(), input)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Couldn't get trending posts")
+		return nil, "", "", err
+	}
+
+	results := []*database.TrendingRecord{}
+	err = attributevalue.UnmarshalListOfMaps(response.Items, &results)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Couldn't unmarshal trending posts response")
+		return nil, "", "", err
+	}
+
+	lastPostId = ""
+	lastScore = ""
+	if response.LastEvaluatedKey != nil {
+		if val, ok := response.LastEvaluatedKey["PostId"].(*types.AttributeValueMemberS); ok {
+			lastPostId = val.Value
+		}
+		if val, ok := response.LastEvaluatedKey["Score"].(*types.AttributeValueMemberN); ok {
+			lastScore = val.Value
+		}
+	}
+
+	return results, lastPostId, lastScore, nil
+}
+
+// GetUserProfilesByIndexRegion lists the users of region, only those of
+// userType when it isn't empty.
+func (dc *DynamoDBClient) GetUserProfilesByIndexRegion(region, userType string, lastUsername, lastUserType string, limit int) ([]*model.UserProfile, string, string, error) {
+	keyConditionExpression := "#region = :region"
+	expressionAttributeNames := map[string]string{
+		"#region": "Region",
+	}
+	expressionAttributeValues := map[string]types.AttributeValue{
+		":region": &types.AttributeValueMemberS{Value: region},
+	}
+	if userType != "" {
+		keyConditionExpression += " AND #type = :type"
+		expressionAttributeNames["#type"] = "UserType"
+		expressionAttributeValues[":type"] = &types.AttributeValueMemberS{Value: userType}
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(dc.tables.Name(database.UserProfileTable)),
+		IndexName:                 aws.String(database.UserProfileRegionIndex),
+		KeyConditionExpression:    aws.String(keyConditionExpression),
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		Limit:                     aws.Int32(int32(limit)),
+	}
+
+	if lastUsername != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"Username": &types.AttributeValueMemberS{Value: lastUsername},
+			"Region":   &types.AttributeValueMemberS{Value: region},
+			"UserType": &types.AttributeValueMemberS{Value: lastUserType},
+		}
+	}
+
+	response, err := dc.client.Query(context.TODO(), input)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't get users of region %s", region)
+		return nil, "", "", err
+	}
+
+	results := []*model.UserProfile{}
+	err = attributevalue.UnmarshalListOfMaps(response.Items, &results)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Couldn't unmarshal users response")
+		return nil, "", "", err
+	}
+
+	lastUsername = ""
+	lastUserType = ""
+	if response.LastEvaluatedKey != nil {
+		if val, ok := response.LastEvaluatedKey["Username"].(*types.AttributeValueMemberS); ok {
+			lastUsername = val.Value
+		}
+		if val, ok := response.LastEvaluatedKey["UserType"].(*types.AttributeValueMemberS); ok {
+			lastUserType = val.Value
+		}
+	}
+
+	return results, lastUsername, lastUserType, nil
+}
+
+// cursorKey is a key attribute of a cursor, keys are only strings or numbers.
+type cursorKey struct {
+	S *string `json:"s,omitempty"`
+	N *string `json:"n,omitempty"`
+}
+
+// encodeCursor turns the last evaluated key of a page into an opaque cursor.
+func encodeCursor(key map[string]types.AttributeValue) (string, error) {
+	values := make(map[string]cursorKey, len(key))
+	for name, value := range key {
+		switch v := value.(type) {
+		case *types.AttributeValueMemberS:
+			values[name] = cursorKey{S: &v.Value}
+		case *types.AttributeValueMemberN:
+			values[name] = cursorKey{N: &v.Value}
+		default:
+			return "", fmt.Errorf("unsupported key attribute %s", name)
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(cursor string) (map[string]types.AttributeValue, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]cursorKey{}
+	err = json.Unmarshal(data, &values)
+	if err != nil {
+		return nil, err
+	}
+
+	key := make(map[string]types.AttributeValue, len(values))
+	for name, value := range values {
+		switch {
+		case value.S != nil:
+			key[name] = &types.AttributeValueMemberS{Value: *value.S}
+		case value.N != nil:
+			key[name] = &types.AttributeValueMemberN{Value: *value.N}
+		default:
+			return nil, fmt.Errorf("invalid key attribute %s", name)
+		}
+	}
+	return key, nil
+}
+
+// assignmentExpressions maps the attributes to SET assignments, sorted by
+// name so that the expressions are stable.
+func assignmentExpressions(attributes map[string]any) ([]string, map[string]string, map[string]types.AttributeValue, error) {
+	assignments := []string{}
+	expressionAttributeNames := map[string]string{}
+	expressionAttributeValues := map[string]types.AttributeValue{}
+	for i, attributeName := range sortedAttributeNames(attributes) {
+		av, err := attributevalue.Marshal(attributes[attributeName])
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't map %v to AttributeValues", attributes[attributeName])
+			return nil, nil, nil, err
+		}
+		expressionAttributeNames[fmt.Sprintf("#n%d", i)] = attributeName
+		expressionAttributeValues[fmt.Sprintf(":v%d", i)] = av
+		assignments = append(assignments, fmt.Sprintf("#n%d = :v%d", i, i))
+	}
+	return assignments, expressionAttributeNames, expressionAttributeValues, nil
+}
+
+func sortedAttributeNames(attributes map[string]any) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
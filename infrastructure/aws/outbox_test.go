@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	database "readmodels/internal/db"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// sequencedDynamoDB returns the next of sequences on every read of an outbox
+// sequence, and fails the transactions with the next of transactErrors.
+type sequencedDynamoDB struct {
+	dynamoDBAPI
+	sequences      []int64
+	transactErrors []error
+	transactions   []*dynamodb.TransactWriteItemsInput
+}
+
+func (s *sequencedDynamoDB) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	sequence := s.sequences[0]
+	s.sequences = s.sequences[1:]
+	if sequence == 0 {
+		return &dynamodb.GetItemOutput{}, nil
+	}
+	item, err := attributevalue.MarshalMap(&database.OutboxSequence{MessageId: "sequence", Sequence: sequence})
+	return &dynamodb.GetItemOutput{Item: item}, err
+}
+
+func (s *sequencedDynamoDB) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	s.transactions = append(s.transactions, params)
+	if len(s.transactErrors) > 0 {
+		err := s.transactErrors[0]
+		s.transactErrors = s.transactErrors[1:]
+		return nil, err
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+type fixedDerivedEvents struct{}
+
+func (fixedDerivedEvents) CounterChanged(change *database.CounterChange) (*database.OutboxMessage, error) {
+	return &database.OutboxMessage{MessageId: "message1", Status: database.OutboxStatusPending, EventType: "PostCounterChangedEvent", Key: "post1"}, nil
+}
+
+func setUpSequenced(t *testing.T, sequences []int64, transactErrors ...error) (*DynamoDBClient, *sequencedDynamoDB) {
+	sleep = func(time.Duration) {}
+	t.Cleanup(func() { sleep = time.Sleep })
+
+	stub := &sequencedDynamoDB{sequences: sequences, transactErrors: transactErrors}
+	return &DynamoDBClient{client: stub, derivedEvents: fixedDerivedEvents{}}, stub
+}
+
+func sentMessage(t *testing.T, transaction *dynamodb.TransactWriteItemsInput, index int) *database.OutboxMessage {
+	var message database.OutboxMessage
+	assert.Nil(t, attributevalue.UnmarshalMap(transaction.TransactItems[index].Put.Item, &message))
+	return &message
+}
+
+func TestOutboxMessageTakesTheFirstSequenceOfAnEntity(t *testing.T) {
+	client, stub := setUpSequenced(t, []int64{0})
+
+	err := client.IncrementCounter(database.PostMetadataTable, &database.PostMetadataKey{PostId: "post1"}, "Likes", 1)
+
+	assert.Nil(t, err)
+	transaction := stub.transactions[0]
+	assert.Len(t, transaction.TransactItems, 3)
+	assert.Equal(t, int64(1), sentMessage(t, transaction, 1).Sequence)
+	update := transaction.TransactItems[2].Update
+	assert.Equal(t, "attribute_not_exists(#sequence)", *update.ConditionExpression)
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "sequence/PostCounterChangedEvent/post1"}, update.Key["MessageId"])
+}
+
+func TestOutboxMessageRetriesWhenItsSequenceIsTaken(t *testing.T) {
+	client, stub := setUpSequenced(t, []int64{4, 5}, canceled("None", "None", "ConditionalCheckFailed"))
+
+	err := client.IncrementCounter(database.PostMetadataTable, &database.PostMetadataKey{PostId: "post1"}, "Likes", 1)
+
+	assert.Nil(t, err)
+	assert.Len(t, stub.transactions, 2)
+	assert.Equal(t, int64(6), sentMessage(t, stub.transactions[1], 1).Sequence)
+	update := stub.transactions[1].TransactItems[2].Update
+	assert.Equal(t, "#sequence = :current", *update.ConditionExpression)
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "5"}, update.ExpressionAttributeValues[":current"])
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "6"}, update.ExpressionAttributeValues[":next"])
+}
+
+func TestOutboxMessageIsNotRetriedForOtherConditions(t *testing.T) {
+	client, stub := setUpSequenced(t, []int64{4}, canceled("ConditionalCheckFailed", "None", "None"))
+
+	err := client.IncrementCounter(database.PostMetadataTable, &database.PostMetadataKey{PostId: "post1"}, "Likes", -1)
+
+	assert.Nil(t, err)
+	assert.Len(t, stub.transactions, 1)
+}
@@ -0,0 +1,90 @@
+package aws
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	database "readmodels/internal/db"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// Scan returns the stored posts in order, a page of up to Limit after the
+// ExclusiveStartKey.
+func (s *stubDynamoDB) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	postIds := []string{}
+	for postId := range s.stored {
+		postIds = append(postIds, postId)
+	}
+	sort.Strings(postIds)
+
+	start := 0
+	if params.ExclusiveStartKey != nil {
+		start = sort.SearchStrings(postIds, keyPostId(params.ExclusiveStartKey)) + 1
+	}
+	end := min(start+int(*params.Limit), len(postIds))
+
+	output := &dynamodb.ScanOutput{}
+	for _, postId := range postIds[start:end] {
+		item, err := attributevalue.MarshalMap(&database.PostMetadataKey{PostId: postId})
+		if err != nil {
+			return nil, err
+		}
+		output.Items = append(output.Items, item)
+	}
+	if end < len(postIds) {
+		output.LastEvaluatedKey = output.Items[len(output.Items)-1]
+	}
+	return output, nil
+}
+
+func TestScanPageFollowsCursorsUntilTheLastPage(t *testing.T) {
+	client, _ := setUpStub(t, 5)
+
+	pages := [][]string{}
+	cursor := ""
+	for {
+		posts := []*database.PostMetadata{}
+		next, err := client.ScanPage(database.PostMetadataTable, cursor, 2, &posts)
+		assert.Nil(t, err)
+
+		page := []string{}
+		for _, post := range posts {
+			page = append(page, post.PostId)
+		}
+		pages = append(pages, page)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	assert.Equal(t, [][]string{{"post000", "post001"}, {"post002", "post003"}, {"post004"}}, pages)
+}
+
+func TestCursorKeepsStringAndNumberKeys(t *testing.T) {
+	key := map[string]types.AttributeValue{
+		"PostId":    &types.AttributeValueMemberS{Value: "post1"},
+		"CommentId": &types.AttributeValueMemberN{Value: "12"},
+	}
+
+	cursor, err := encodeCursor(key)
+	assert.Nil(t, err)
+	decoded, err := decodeCursor(cursor)
+
+	assert.Nil(t, err)
+	assert.Equal(t, key, decoded)
+}
+
+func TestErrorOnScanPageWithInvalidCursor(t *testing.T) {
+	client, _ := setUpStub(t, 5)
+
+	posts := []*database.PostMetadata{}
+	_, err := client.ScanPage(database.PostMetadataTable, "not a cursor", 2, &posts)
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,340 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	database "readmodels/internal/db"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/rs/zerolog/log"
+)
+
+// GetDataIfExists reads the item with a consistent read, so writes that
+// already succeeded are seen, and reports whether it exists.
+func (dc *DynamoDBClient) GetDataIfExists(tableName string, key any, result any) (bool, error) {
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", key)
+		return false, err
+	}
+
+	response, err := dc.client.GetItem(context.TODO(), &dynamodb.GetItemInput{
+		TableName:      aws.String(dc.tables.Name(tableName)),
+		Key:            k,
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't get item %v from %s", key, tableName)
+		return false, err
+	}
+	if response.Item == nil {
+		return false, nil
+	}
+
+	err = attributevalue.UnmarshalMap(response.Item, result)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't unmarshal item %v from %s", key, tableName)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetDataByKeyPrefix returns the items whose partitionKey equals
+// partitionValue and whose sortKey begins with sortPrefix, in sort key order.
+// Every item of the partition is returned when sortPrefix is empty, and only
+// the first limit items when limit isn't zero.
+func (dc *DynamoDBClient) GetDataByKeyPrefix(tableName string, partitionKey, partitionValue string, sortKey, sortPrefix string, limit int, results any) error {
+	err := validateIsPointerToSlice(results)
+	if err != nil {
+		return err
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(dc.tables.Name(tableName)),
+		KeyConditionExpression: aws.String("#partition = :partition"),
+		ExpressionAttributeNames: map[string]string{
+			"#partition": partitionKey,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":partition": &types.AttributeValueMemberS{Value: partitionValue},
+		},
+	}
+	if sortPrefix != "" {
+		input.KeyConditionExpression = aws.String("#partition = :partition AND begins_with(#sort, :prefix)")
+		input.ExpressionAttributeNames["#sort"] = sortKey
+		input.ExpressionAttributeValues[":prefix"] = &types.AttributeValueMemberS{Value: sortPrefix}
+	}
+	if limit > 0 {
+		input.Limit = aws.Int32(int32(limit))
+	}
+
+	items := []map[string]types.AttributeValue{}
+	for {
+		response, err := dc.client.Query(context.TODO(), input)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't query items of %s in table %s", partitionValue, tableName)
+			return err
+		}
+
+		items = append(items, response.Items...)
+
+		if response.LastEvaluatedKey == nil || limit > 0 {
+			break
+		}
+		input.ExclusiveStartKey = response.LastEvaluatedKey
+	}
+
+	err = attributevalue.UnmarshalListOfMaps(items, results)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't unmarshal items from table %s", tableName)
+		return err
+	}
+
+	return nil
+}
+
+// TransactWriteData writes the items in a single transaction, which applies
+// only if the conditions of every item hold. When some don't, nothing is
+// written and a database.ConditionFailedError lists those items.
+func (dc *DynamoDBClient) TransactWriteData(items []*database.TransactionItem) error {
+	transactItems := make([]types.TransactWriteItem, len(items))
+	for i, item := range items {
+		transactItem, err := dc.transactWriteItem(item)
+		if err != nil {
+			return err
+		}
+		transactItems[i] = transactItem
+	}
+
+	_, err := dc.client.TransactWriteItems(context.TODO(), &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	var tce *types.TransactionCanceledException
+	if errors.As(err, &tce) {
+		failed := []int{}
+		for i := range items {
+			if isConditionalCheckFailed(tce, i) {
+				failed = append(failed, i)
+			}
+		}
+		if len(failed) > 0 {
+			return database.NewConditionFailedError(failed)
+		}
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't write a transaction of %d items", len(items))
+		return err
+	}
+
+	return nil
+}
+
+func (dc *DynamoDBClient) transactWriteItem(item *database.TransactionItem) (types.TransactWriteItem, error) {
+	tableName := aws.String(dc.tables.Name(item.TableName))
+	expressions := newExpressionBuilder()
+	condition, err := expressions.condition(item.Conditions)
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+
+	if item.Item != nil {
+		av, err := attributevalue.MarshalMap(item.Item)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't map %v to AttributeValues", item.Item)
+			return types.TransactWriteItem{}, err
+		}
+		return types.TransactWriteItem{
+			Put: &types.Put{
+				TableName:                 tableName,
+				Item:                      av,
+				ConditionExpression:       condition,
+				ExpressionAttributeNames:  expressions.attributeNames(),
+				ExpressionAttributeValues: expressions.attributeValues(),
+			},
+		}, nil
+	}
+
+	k, err := attributevalue.MarshalMap(item.Key)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't map %v key to AttributeValues", item.Key)
+		return types.TransactWriteItem{}, err
+	}
+
+	if item.Delete {
+		return types.TransactWriteItem{
+			Delete: &types.Delete{
+				TableName:                 tableName,
+				Key:                       k,
+				ConditionExpression:       condition,
+				ExpressionAttributeNames:  expressions.attributeNames(),
+				ExpressionAttributeValues: expressions.attributeValues(),
+			},
+		}, nil
+	}
+
+	update, err := expressions.update(item)
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+	if update == "" {
+		return types.TransactWriteItem{
+			ConditionCheck: &types.ConditionCheck{
+				TableName:                 tableName,
+				Key:                       k,
+				ConditionExpression:       condition,
+				ExpressionAttributeNames:  expressions.attributeNames(),
+				ExpressionAttributeValues: expressions.attributeValues(),
+			},
+		}, nil
+	}
+
+	return types.TransactWriteItem{
+		Update: &types.Update{
+			TableName:                 tableName,
+			Key:                       k,
+			UpdateExpression:          aws.String(update),
+			ConditionExpression:       condition,
+			ExpressionAttributeNames:  expressions.attributeNames(),
+			ExpressionAttributeValues: expressions.attributeValues(),
+		},
+	}, nil
+}
+
+// expressionBuilder names the attributes and values of the expressions of a
+// transaction item, each attribute once.
+type expressionBuilder struct {
+	names  map[string]string
+	values map[string]types.AttributeValue
+}
+
+func newExpressionBuilder() *expressionBuilder {
+	return &expressionBuilder{
+		names:  map[string]string{},
+		values: map[string]types.AttributeValue{},
+	}
+}
+
+func (b *expressionBuilder) name(attribute string) string {
+	for placeholder, name := range b.names {
+		if name == attribute {
+			return placeholder
+		}
+	}
+	placeholder := fmt.Sprintf("#a%d", len(b.names))
+	b.names[placeholder] = attribute
+	return placeholder
+}
+
+// value marshals []string values as string sets.
+func (b *expressionBuilder) value(value any) (string, error) {
+	var av types.AttributeValue
+	if set, ok := value.([]string); ok {
+		av = &types.AttributeValueMemberSS{Value: set}
+	} else {
+		var err error
+		av, err = attributevalue.Marshal(value)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't map %v to AttributeValues", value)
+			return "", err
+		}
+	}
+	placeholder := fmt.Sprintf(":v%d", len(b.values))
+	b.values[placeholder] = av
+	return placeholder, nil
+}
+
+func (b *expressionBuilder) condition(conditions []database.Condition) (*string, error) {
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	expressions := make([]string, len(conditions))
+	for i, condition := range conditions {
+		name := b.name(condition.Attribute)
+		switch condition.Operator {
+		case database.Exists:
+			expressions[i] = fmt.Sprintf("attribute_exists(%s)", name)
+			continue
+		case database.NotExists:
+			expressions[i] = fmt.Sprintf("attribute_not_exists(%s)", name)
+			continue
+		}
+
+		value, err := b.value(condition.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch condition.Operator {
+		case database.Equal:
+			expressions[i] = fmt.Sprintf("%s = %s", name, value)
+		case database.Contains:
+			expressions[i] = fmt.Sprintf("contains(%s, %s)", name, value)
+		case database.SizeEqual:
+			expressions[i] = fmt.Sprintf("size(%s) = %s", name, value)
+		default:
+			return nil, fmt.Errorf("unknown condition operator %d", condition.Operator)
+		}
+	}
+
+	return aws.String(strings.Join(expressions, " AND ")), nil
+}
+
+// update returns the update expression of the item, empty when it has
+// nothing to update.
+func (b *expressionBuilder) update(item *database.TransactionItem) (string, error) {
+	clauses := []string{}
+	for _, clause := range []struct {
+		action     string
+		attributes map[string]any
+		separator  string
+	}{
+		{"SET", item.Set, " = "},
+		{"ADD", item.Add, " "},
+		{"DELETE", item.DeleteFromSet, " "},
+	} {
+		if len(clause.attributes) == 0 {
+			continue
+		}
+		actions := []string{}
+		for _, attribute := range sortedAttributeNames(clause.attributes) {
+			value, err := b.value(clause.attributes[attribute])
+			if err != nil {
+				return "", err
+			}
+			actions = append(actions, b.name(attribute)+clause.separator+value)
+		}
+		clauses = append(clauses, clause.action+" "+strings.Join(actions, ", "))
+	}
+
+	if len(item.Unset) > 0 {
+		actions := make([]string, len(item.Unset))
+		for i, attribute := range item.Unset {
+			actions[i] = b.name(attribute)
+		}
+		clauses = append(clauses, "REMOVE "+strings.Join(actions, ", "))
+	}
+
+	return strings.Join(clauses, " "), nil
+}
+
+// attributeNames is nil without names, as DynamoDB rejects empty maps.
+func (b *expressionBuilder) attributeNames() map[string]string {
+	if len(b.names) == 0 {
+		return nil
+	}
+	return b.names
+}
+
+func (b *expressionBuilder) attributeValues() map[string]types.AttributeValue {
+	if len(b.values) == 0 {
+		return nil
+	}
+	return b.values
+}
@@ -0,0 +1,105 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "readmodels/internal/db"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingDynamoDB keeps the last transaction it receives and fails it with
+// err.
+type recordingDynamoDB struct {
+	dynamoDBAPI
+	transaction *dynamodb.TransactWriteItemsInput
+	err         error
+}
+
+func (r *recordingDynamoDB) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	r.transaction = params
+	return &dynamodb.TransactWriteItemsOutput{}, r.err
+}
+
+func setUpRecording(err error) (*DynamoDBClient, *recordingDynamoDB) {
+	recording := &recordingDynamoDB{err: err}
+	return &DynamoDBClient{client: recording}, recording
+}
+
+func TestTransactWriteDataBuildsUpdateExpressions(t *testing.T) {
+	client, recording := setUpRecording(nil)
+
+	err := client.TransactWriteData([]*database.TransactionItem{
+		{
+			TableName:     "table",
+			Key:           &database.NotificationKey{Username: "user1", NotificationId: "like#post1"},
+			Set:           map[string]any{"Read": false},
+			Add:           map[string]any{"ActorsCount": 1, "Actors": []string{"user2"}},
+			DeleteFromSet: map[string]any{"Refs": []string{"ref1"}},
+			Unset:         []string{"LastActor"},
+			Conditions:    []database.Condition{database.AttributeEquals("Read", true), database.SetSizeEquals("Actors", 2)},
+		},
+	})
+
+	assert.Nil(t, err)
+	update := recording.transaction.TransactItems[0].Update
+	assert.Equal(t, "#a0 = :v0 AND size(#a1) = :v1", *update.ConditionExpression)
+	assert.Equal(t, "SET #a0 = :v2 ADD #a1 :v3, #a2 :v4 DELETE #a3 :v5 REMOVE #a4", *update.UpdateExpression)
+	assert.Equal(t, map[string]string{"#a0": "Read", "#a1": "Actors", "#a2": "ActorsCount", "#a3": "Refs", "#a4": "LastActor"}, update.ExpressionAttributeNames)
+	assert.Equal(t, &types.AttributeValueMemberBOOL{Value: true}, update.ExpressionAttributeValues[":v0"])
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "2"}, update.ExpressionAttributeValues[":v1"])
+	assert.Equal(t, &types.AttributeValueMemberSS{Value: []string{"user2"}}, update.ExpressionAttributeValues[":v3"])
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "1"}, update.ExpressionAttributeValues[":v4"])
+}
+
+func TestTransactWriteDataPutsDeletesAndChecks(t *testing.T) {
+	client, recording := setUpRecording(nil)
+	key := &database.NotificationKey{Username: "user1", NotificationId: "follow"}
+
+	err := client.TransactWriteData([]*database.TransactionItem{
+		{TableName: "table", Item: &database.NotificationRecord{Username: "user1", NotificationId: "follow"}, Conditions: []database.Condition{database.AttributeNotExists("Username")}},
+		{TableName: "table", Key: key, Delete: true},
+		{TableName: "table", Key: key, Conditions: []database.Condition{database.AttributeExists("Username")}},
+	})
+
+	assert.Nil(t, err)
+	items := recording.transaction.TransactItems
+	assert.Equal(t, "attribute_not_exists(#a0)", *items[0].Put.ConditionExpression)
+	assert.Nil(t, items[1].Delete.ConditionExpression)
+	assert.Nil(t, items[1].Delete.ExpressionAttributeNames)
+	assert.Equal(t, "attribute_exists(#a0)", *items[2].ConditionCheck.ConditionExpression)
+	assert.Nil(t, items[2].ConditionCheck.ExpressionAttributeValues)
+}
+
+func TestTransactWriteDataReportsTheFailedConditions(t *testing.T) {
+	client, _ := setUpRecording(canceled("None", "ConditionalCheckFailed", "ConditionalCheckFailed"))
+	key := &database.NotificationKey{Username: "user1", NotificationId: "follow"}
+
+	err := client.TransactWriteData([]*database.TransactionItem{
+		{TableName: "table", Key: key, Delete: true},
+		{TableName: "table", Key: key, Delete: true},
+		{TableName: "table", Key: key, Delete: true},
+	})
+
+	var conditionFailedError *database.ConditionFailedError
+	assert.True(t, errors.As(err, &conditionFailedError))
+	assert.Equal(t, []int{1, 2}, conditionFailedError.Items)
+	assert.False(t, conditionFailedError.Failed(0))
+	assert.True(t, conditionFailedError.Failed(2))
+}
+
+func TestTransactWriteDataReturnsOtherCancellations(t *testing.T) {
+	client, _ := setUpRecording(canceled("TransactionConflict"))
+
+	err := client.TransactWriteData([]*database.TransactionItem{
+		{TableName: "table", Key: &database.NotificationKey{Username: "user1"}, Delete: true},
+	})
+
+	var conditionFailedError *database.ConditionFailedError
+	assert.NotNil(t, err)
+	assert.False(t, errors.As(err, &conditionFailedError))
+}
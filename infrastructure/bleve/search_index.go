@@ -0,0 +1,423 @@
+package bleve
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"readmodels/internal/model"
+	"strings"
+	"sync"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	postsIndexDir = "posts"
+	usersIndexDir = "users"
+	builtFile     = "built"
+	// The documents are searched on their analyzed fields and returned from
+	// the JSON kept in sourceField, so the times keep their precision
+	sourceField = "source"
+	// Lowercases the whole username as a single term
+	usernameAnalyzer = "username"
+	// Lowercases every word of the name without stemming, for prefixes
+	nameAnalyzer = "name"
+	fuzziness    = 1
+	// Shorter terms would match almost any other at the fuzziness
+	minFuzzyLength = 3
+	removalBatch   = 1000
+)
+
+// The index is locked by the process that opens it, a second one gives up
+// after the timeout instead of waiting for it to be released
+var openConfig = map[string]interface{}{
+	"bolt_timeout": "1s",
+}
+
+type postDocument struct {
+	Username    string `json:"username"`
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Source      string `json:"source"`
+}
+
+type userDocument struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Source   string `json:"source"`
+}
+
+// SearchIndex keeps the posts and the users in two bleve indexes under path.
+// Being on local disk, every instance maintains its own copy.
+type SearchIndex struct {
+	path  string
+	mutex sync.RWMutex
+	posts bleve.Index
+	users bleve.Index
+}
+
+// NewSearchIndex opens the indexes under path, creating them when they don't
+// exist. It fails while another process holds them open.
+func NewSearchIndex(path string) (*SearchIndex, error) {
+	searchIndex := &SearchIndex{
+		path: path,
+	}
+
+	err := searchIndex.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return searchIndex, nil
+}
+
+// Built tells whether the copy of the index was filled by a rebuild, which a
+// new or reset copy needs before following the events.
+func (i *SearchIndex) Built() (bool, error) {
+	_, err := os.Stat(filepath.Join(i.path, builtFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error reading %s", builtFile)
+		return false, err
+	}
+	return true, nil
+}
+
+// MarkBuilt records that the copy of the index was filled, until it's reset.
+func (i *SearchIndex) MarkBuilt() error {
+	err := os.WriteFile(filepath.Join(i.path, builtFile), nil, 0o644)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error writing %s", builtFile)
+	}
+	return err
+}
+
+func (i *SearchIndex) GetPost(postId string) (*model.SearchPost, error) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	posts := []*model.SearchPost{}
+	_, err := i.search(i.posts, bleve.NewDocIDQuery([]string{postId}), 0, 1, &posts)
+	if err != nil || len(posts) == 0 {
+		return nil, err
+	}
+
+	return posts[0], nil
+}
+
+func (i *SearchIndex) IndexPosts(posts []*model.SearchPost) error {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	batch := i.posts.NewBatch()
+	for _, post := range posts {
+		source, err := json.Marshal(post)
+		if err != nil {
+			return err
+		}
+		err = batch.Index(post.PostId, &postDocument{
+			Username:    post.Username,
+			Type:        post.Type,
+			Title:       post.Title,
+			Description: post.Description,
+			Source:      string(source),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return i.posts.Batch(batch)
+}
+
+func (i *SearchIndex) RemovePosts(postIds []string) error {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return removeDocuments(i.posts, postIds)
+}
+
+func (i *SearchIndex) RemoveUserPosts(username string) error {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	userPosts := bleve.NewTermQuery(username)
+	userPosts.SetField("username")
+	for {
+		request := bleve.NewSearchRequestOptions(userPosts, removalBatch, 0, false)
+		result, err := i.posts.Search(request)
+		if err != nil {
+			return err
+		}
+		if len(result.Hits) == 0 {
+			return nil
+		}
+
+		postIds := make([]string, len(result.Hits))
+		for j, hit := range result.Hits {
+			postIds[j] = hit.ID
+		}
+		err = removeDocuments(i.posts, postIds)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (i *SearchIndex) IndexUsers(users []*model.SearchUser) error {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	batch := i.users.NewBatch()
+	for _, user := range users {
+		source, err := json.Marshal(user)
+		if err != nil {
+			return err
+		}
+		err = batch.Index(user.Username, &userDocument{
+			Username: user.Username,
+			Name:     user.Name,
+			Source:   string(source),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return i.users.Batch(batch)
+}
+
+func (i *SearchIndex) RemoveUser(username string) error {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return i.users.Delete(username)
+}
+
+// SearchPosts matches the words of text in the title and the description of
+// the posts, the title weighting more.
+func (i *SearchIndex) SearchPosts(text string, offset, limit int) ([]*model.SearchPost, int, error) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	title := bleve.NewMatchQuery(text)
+	title.SetField("title")
+	title.SetBoost(2)
+	description := bleve.NewMatchQuery(text)
+	description.SetField("description")
+
+	posts := []*model.SearchPost{}
+	total, err := i.search(i.posts, bleve.NewDisjunctionQuery(title, description), offset, limit, &posts)
+	return posts, total, err
+}
+
+// SearchUsers matches text as a prefix of the username or of the words of the
+// name, tolerating a typo in the longer terms. Usernames weight more.
+func (i *SearchIndex) SearchUsers(text string, offset, limit int) ([]*model.SearchUser, int, error) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	text = strings.ToLower(strings.TrimSpace(text))
+	username := termQuery("username", text)
+	username.SetBoost(2)
+
+	nameTerms := []query.Query{}
+	for _, term := range strings.Fields(text) {
+		nameTerms = append(nameTerms, termQuery("name", term))
+	}
+
+	users := []*model.SearchUser{}
+	total, err := i.search(i.users, bleve.NewDisjunctionQuery(username, bleve.NewConjunctionQuery(nameTerms...)), offset, limit, &users)
+	return users, total, err
+}
+
+// Reset removes every post and user from the index.
+func (i *SearchIndex) Reset() error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	err := i.close()
+	if err != nil {
+		return err
+	}
+	for _, dir := range []string{builtFile, postsIndexDir, usersIndexDir} {
+		err = os.RemoveAll(filepath.Join(i.path, dir))
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Error removing search index %s", dir)
+			return err
+		}
+	}
+
+	return i.open()
+}
+
+func (i *SearchIndex) Close() error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.close()
+}
+
+func (i *SearchIndex) open() error {
+	err := os.MkdirAll(i.path, 0o755)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error creating search index directory %s", i.path)
+		return err
+	}
+
+	i.posts, err = openIndex(filepath.Join(i.path, postsIndexDir), postsMapping())
+	if err != nil {
+		return err
+	}
+	i.users, err = openIndex(filepath.Join(i.path, usersIndexDir), usersMapping())
+	if err != nil {
+		_ = i.posts.Close()
+		return err
+	}
+
+	return nil
+}
+
+func (i *SearchIndex) close() error {
+	return errors.Join(i.posts.Close(), i.users.Close())
+}
+
+// search decodes the source of the documents in the page into results and
+// returns the total of matches. Documents with the same score keep their id
+// order so the pages don't overlap.
+func (i *SearchIndex) search(index bleve.Index, searchQuery query.Query, offset, limit int, results any) (int, error) {
+	request := bleve.NewSearchRequestOptions(searchQuery, limit, offset, false)
+	request.Fields = []string{sourceField}
+	request.SortBy([]string{"-_score", "_id"})
+
+	result, err := index.Search(request)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error searching %s", index.Name())
+		return 0, err
+	}
+
+	sources := make([]json.RawMessage, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		source, _ := hit.Fields[sourceField].(string)
+		sources = append(sources, json.RawMessage(source))
+	}
+	encoded, err := json.Marshal(sources)
+	if err != nil {
+		return 0, err
+	}
+	err = json.Unmarshal(encoded, results)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error decoding the documents of %s", index.Name())
+		return 0, err
+	}
+
+	return int(result.Total), nil
+}
+
+func openIndex(path string, indexMapping mapping.IndexMapping) (bleve.Index, error) {
+	index, err := bleve.OpenUsing(path, openConfig)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		log.Info().Msgf("Creating search index %s", path)
+		index, err = bleve.New(path, indexMapping)
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error opening search index %s", path)
+		return nil, err
+	}
+
+	return index, nil
+}
+
+func removeDocuments(index bleve.Index, ids []string) error {
+	batch := index.NewBatch()
+	for _, id := range ids {
+		batch.Delete(id)
+	}
+
+	return index.Batch(batch)
+}
+
+// termQuery matches the prefixes of term in field, and the terms one typo
+// away from it.
+func termQuery(field, term string) *query.DisjunctionQuery {
+	prefix := bleve.NewPrefixQuery(term)
+	prefix.SetField(field)
+	if len([]rune(term)) < minFuzzyLength {
+		return bleve.NewDisjunctionQuery(prefix)
+	}
+
+	fuzzy := bleve.NewFuzzyQuery(term)
+	fuzzy.SetField(field)
+	fuzzy.SetFuzziness(fuzziness)
+	return bleve.NewDisjunctionQuery(prefix, fuzzy)
+}
+
+func postsMapping() mapping.IndexMapping {
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = en.AnalyzerName
+	text.Store = false
+	exact := bleve.NewKeywordFieldMapping()
+	exact.Store = false
+
+	document := bleve.NewDocumentStaticMapping()
+	document.AddFieldMappingsAt("username", exact)
+	document.AddFieldMappingsAt("type", exact)
+	document.AddFieldMappingsAt("title", text)
+	document.AddFieldMappingsAt("description", text)
+	document.AddFieldMappingsAt(sourceField, sourceMapping())
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultMapping = document
+	return indexMapping
+}
+
+func usersMapping() mapping.IndexMapping {
+	indexMapping := bleve.NewIndexMapping()
+	// Registering the static analyzer definitions can't fail
+	_ = indexMapping.AddCustomAnalyzer(usernameAnalyzer, map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     single.Name,
+		"token_filters": []string{lowercase.Name},
+	})
+	_ = indexMapping.AddCustomAnalyzer(nameAnalyzer, map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{lowercase.Name},
+	})
+
+	username := bleve.NewTextFieldMapping()
+	username.Analyzer = usernameAnalyzer
+	username.Store = false
+	name := bleve.NewTextFieldMapping()
+	name.Analyzer = nameAnalyzer
+	name.Store = false
+
+	document := bleve.NewDocumentStaticMapping()
+	document.AddFieldMappingsAt("username", username)
+	document.AddFieldMappingsAt("name", name)
+	document.AddFieldMappingsAt(sourceField, sourceMapping())
+
+	indexMapping.DefaultMapping = document
+	return indexMapping
+}
+
+func sourceMapping() *mapping.FieldMapping {
+	source := bleve.NewTextFieldMapping()
+	source.Analyzer = keyword.Name
+	source.Index = false
+	source.IncludeInAll = false
+	return source
+}
@@ -0,0 +1,254 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	SASLMechanismPlain       = "PLAIN"
+	SASLMechanismScramSha256 = "SCRAM-SHA-256"
+	SASLMechanismScramSha512 = "SCRAM-SHA-512"
+)
+
+const (
+	defaultClientId          = "readmodels"
+	defaultVersion           = "2.0.0"
+	defaultSessionTimeout    = 10 * time.Second
+	defaultHeartbeatInterval = 3 * time.Second
+)
+
+// Config holds the connection settings shared by every Kafka client of the
+// service. Empty values fall back to the defaults of NewConfig.
+// Topics are named after the event types unless TopicMapping renames them,
+// and TopicPrefix scopes every topic to the environment.
+type Config struct {
+	Brokers           []string
+	ClientId          string
+	Version           string
+	SessionTimeout    time.Duration
+	HeartbeatInterval time.Duration
+	TopicPrefix       string
+	TopicMapping      map[string]string
+	TLS               TLSConfig
+	SASL              SASLConfig
+}
+
+// TLSConfig enables TLS towards the brokers. CAFile is only needed when the
+// brokers certificates aren't signed by a system trusted authority, and
+// CertFile and KeyFile when the brokers require client authentication.
+// InsecureSkipVerify disables the verification of the brokers certificates
+// and is meant for development only.
+type TLSConfig struct {
+	Enabled            bool
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	InsecureSkipVerify bool
+}
+
+// SASLConfig enables SASL authentication when Mechanism is set to one of
+// the SASLMechanism constants.
+type SASLConfig struct {
+	Mechanism string
+	Username  string
+	Password  string
+}
+
+func NewConfig(brokers []string) *Config {
+	return &Config{
+		Brokers:           brokers,
+		ClientId:          defaultClientId,
+		Version:           defaultVersion,
+		SessionTimeout:    defaultSessionTimeout,
+		HeartbeatInterval: defaultHeartbeatInterval,
+	}
+}
+
+// Validate checks the settings without connecting to the brokers, so that
+// a misconfiguration stops the service at startup.
+func (c *Config) Validate() error {
+	errs := []error{}
+	if len(c.Brokers) == 0 {
+		errs = append(errs, errors.New("at least one broker is required"))
+	}
+	for _, broker := range c.Brokers {
+		if strings.TrimSpace(broker) == "" {
+			errs = append(errs, errors.New("broker addresses can't be blank"))
+			break
+		}
+	}
+	if strings.TrimSpace(c.ClientId) == "" {
+		errs = append(errs, errors.New("client id is required"))
+	}
+	if _, err := sarama.ParseKafkaVersion(c.Version); err != nil {
+		errs = append(errs, fmt.Errorf("invalid version %q", c.Version))
+	}
+	if c.SessionTimeout <= 0 {
+		errs = append(errs, errors.New("session timeout must be positive"))
+	}
+	if c.HeartbeatInterval <= 0 {
+		errs = append(errs, errors.New("heartbeat interval must be positive"))
+	} else if c.HeartbeatInterval >= c.SessionTimeout {
+		errs = append(errs, fmt.Errorf("heartbeat interval %s must be lower than the session timeout %s", c.HeartbeatInterval, c.SessionTimeout))
+	}
+	for eventType, topic := range c.TopicMapping {
+		if strings.TrimSpace(eventType) == "" || strings.TrimSpace(topic) == "" {
+			errs = append(errs, fmt.Errorf("invalid topic mapping %q=%q", eventType, topic))
+		}
+	}
+	errs = append(errs, c.TLS.validate()...)
+	errs = append(errs, c.SASL.validate()...)
+
+	if len(errs) > 0 {
+		return NewInvalidConfigError(errs)
+	}
+	return nil
+}
+
+func (c *TLSConfig) validate() []error {
+	errs := []error{}
+	if !c.Enabled {
+		if c.CAFile != "" || c.CertFile != "" || c.KeyFile != "" || c.InsecureSkipVerify {
+			errs = append(errs, errors.New("TLS settings are given but TLS is disabled"))
+		}
+		return errs
+	}
+
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		errs = append(errs, errors.New("TLS client certificate and key must be given together"))
+	}
+	for _, file := range []string{c.CAFile, c.CertFile, c.KeyFile} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			errs = append(errs, fmt.Errorf("TLS file %s can't be read: %w", file, err))
+		}
+	}
+	return errs
+}
+
+func (c *SASLConfig) validate() []error {
+	errs := []error{}
+	switch c.Mechanism {
+	case "":
+		if c.Username != "" || c.Password != "" {
+			errs = append(errs, errors.New("SASL credentials are given but no SASL mechanism is set"))
+		}
+		return errs
+	case SASLMechanismPlain, SASLMechanismScramSha256, SASLMechanismScramSha512:
+	default:
+		errs = append(errs, fmt.Errorf("unsupported SASL mechanism %q, expected one of %s, %s, %s",
+			c.Mechanism, SASLMechanismPlain, SASLMechanismScramSha256, SASLMechanismScramSha512))
+	}
+
+	if c.Username == "" || c.Password == "" {
+		errs = append(errs, errors.New("SASL username and password are required"))
+	}
+	return errs
+}
+
+// saramaConfig builds the sarama configuration shared by the consumer group
+// and the producers.
+func (c *Config) saramaConfig() (*sarama.Config, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	config := sarama.NewConfig()
+	config.ClientID = c.ClientId
+	config.Version, _ = sarama.ParseKafkaVersion(c.Version)
+	config.Consumer.Group.Session.Timeout = c.SessionTimeout
+	config.Consumer.Group.Heartbeat.Interval = c.HeartbeatInterval
+
+	if c.TLS.Enabled {
+		tlsConfig, err := c.TLS.build()
+		if err != nil {
+			return nil, err
+		}
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = tlsConfig
+	}
+
+	if c.SASL.Mechanism != "" {
+		if !c.TLS.Enabled {
+			log.Warn().Msgf("SASL %s is enabled without TLS, credentials will travel unencrypted", c.SASL.Mechanism)
+		}
+		config.Net.SASL.Enable = true
+		config.Net.SASL.Handshake = true
+		config.Net.SASL.User = c.SASL.Username
+		config.Net.SASL.Password = c.SASL.Password
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(c.SASL.Mechanism)
+		switch c.SASL.Mechanism {
+		case SASLMechanismScramSha256:
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return newScramClient(scramSha256) }
+		case SASLMechanismScramSha512:
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return newScramClient(scramSha512) }
+		}
+	}
+
+	return config, nil
+}
+
+func (c *TLSConfig) build() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" {
+		caCertificate, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't read the Kafka CA file %s", c.CAFile)
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCertificate) {
+			return nil, NewInvalidConfigError([]error{fmt.Errorf("no PEM certificates found in %s", c.CAFile)})
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't load the Kafka client certificate %s", c.CertFile)
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+type InvalidConfigError struct {
+	errs []error
+}
+
+func (e *InvalidConfigError) Error() string {
+	described := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		described[i] = err.Error()
+	}
+	return "Invalid Kafka configuration: " + strings.Join(described, "; ")
+}
+
+func (e *InvalidConfigError) Unwrap() []error {
+	return e.errs
+}
+
+func NewInvalidConfigError(errs []error) *InvalidConfigError {
+	return &InvalidConfigError{
+		errs: errs,
+	}
+}
@@ -0,0 +1,178 @@
+package kafka
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCertificate writes a self-signed certificate and its key as PEM
+// files, returning their paths.
+func writeCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "readmodels-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	encodedKey, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client.key")
+	assert.Nil(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0600))
+	assert.Nil(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: encodedKey}), 0600))
+	return certFile, keyFile
+}
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	config := NewConfig([]string{"localhost:9092"})
+
+	assert.Nil(t, config.Validate())
+}
+
+func TestValidateReportsEveryInvalidSetting(t *testing.T) {
+	config := &Config{
+		Brokers:           []string{"localhost:9092", " "},
+		Version:           "not-a-version",
+		SessionTimeout:    time.Second,
+		HeartbeatInterval: 2 * time.Second,
+		TopicMapping:      map[string]string{"PostWasCreatedEvent": ""},
+		TLS:               TLSConfig{CAFile: "ca.pem"},
+		SASL:              SASLConfig{Mechanism: "GSSAPI"},
+	}
+
+	err := config.Validate()
+
+	var invalidConfigError *InvalidConfigError
+	assert.ErrorAs(t, err, &invalidConfigError)
+	assert.Equal(t, []string{
+		"broker addresses can't be blank",
+		"client id is required",
+		`invalid version "not-a-version"`,
+		"heartbeat interval 2s must be lower than the session timeout 1s",
+		`invalid topic mapping "PostWasCreatedEvent"=""`,
+		"TLS settings are given but TLS is disabled",
+		`unsupported SASL mechanism "GSSAPI", expected one of PLAIN, SCRAM-SHA-256, SCRAM-SHA-512`,
+		"SASL username and password are required",
+	}, errorMessages(invalidConfigError))
+}
+
+func errorMessages(err *InvalidConfigError) []string {
+	messages := []string{}
+	for _, e := range err.Unwrap() {
+		messages = append(messages, e.Error())
+	}
+	return messages
+}
+
+func TestValidateRequiresBrokersAndPositiveTimeouts(t *testing.T) {
+	config := NewConfig(nil)
+	config.SessionTimeout = 0
+	config.HeartbeatInterval = 0
+
+	err := config.Validate()
+
+	var invalidConfigError *InvalidConfigError
+	assert.ErrorAs(t, err, &invalidConfigError)
+	assert.Equal(t, []string{
+		"at least one broker is required",
+		"session timeout must be positive",
+		"heartbeat interval must be positive",
+	}, errorMessages(invalidConfigError))
+}
+
+func TestValidateChecksTheTLSFiles(t *testing.T) {
+	certFile, _ := writeCertificate(t)
+	config := NewConfig([]string{"localhost:9093"})
+	config.TLS = TLSConfig{Enabled: true, CAFile: filepath.Join(t.TempDir(), "missing.pem"), CertFile: certFile}
+
+	err := config.Validate()
+
+	var invalidConfigError *InvalidConfigError
+	assert.ErrorAs(t, err, &invalidConfigError)
+	messages := errorMessages(invalidConfigError)
+	assert.Equal(t, 2, len(messages))
+	assert.Equal(t, "TLS client certificate and key must be given together", messages[0])
+	assert.Contains(t, messages[1], "missing.pem can't be read")
+}
+
+func TestValidateRejectsSASLCredentialsWithoutMechanism(t *testing.T) {
+	config := NewConfig([]string{"localhost:9092"})
+	config.SASL = SASLConfig{Username: "user", Password: "pencil"}
+
+	assert.EqualError(t, config.Validate(), "Invalid Kafka configuration: SASL credentials are given but no SASL mechanism is set")
+}
+
+func TestSaramaConfigLoadsTheTLSFiles(t *testing.T) {
+	certFile, keyFile := writeCertificate(t)
+	config := NewConfig([]string{"localhost:9093"})
+	config.TLS = TLSConfig{Enabled: true, CAFile: certFile, CertFile: certFile, KeyFile: keyFile}
+
+	saramaConfig, err := config.saramaConfig()
+
+	assert.Nil(t, err)
+	assert.True(t, saramaConfig.Net.TLS.Enable)
+	assert.Equal(t, uint16(tls.VersionTLS12), saramaConfig.Net.TLS.Config.MinVersion)
+	assert.NotNil(t, saramaConfig.Net.TLS.Config.RootCAs)
+	assert.Equal(t, 1, len(saramaConfig.Net.TLS.Config.Certificates))
+	assert.False(t, saramaConfig.Net.TLS.Config.InsecureSkipVerify)
+}
+
+func TestSaramaConfigRejectsACAFileWithoutCertificates(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, os.WriteFile(caFile, []byte("not a certificate"), 0600))
+	config := NewConfig([]string{"localhost:9093"})
+	config.TLS = TLSConfig{Enabled: true, CAFile: caFile}
+
+	_, err := config.saramaConfig()
+
+	var invalidConfigError *InvalidConfigError
+	assert.ErrorAs(t, err, &invalidConfigError)
+	assert.Contains(t, err.Error(), "no PEM certificates found in")
+}
+
+func TestSaramaConfigRejectsAKeyNotMatchingTheCertificate(t *testing.T) {
+	certFile, _ := writeCertificate(t)
+	_, otherKeyFile := writeCertificate(t)
+	config := NewConfig([]string{"localhost:9093"})
+	config.TLS = TLSConfig{Enabled: true, CertFile: certFile, KeyFile: otherKeyFile}
+
+	_, err := config.saramaConfig()
+
+	assert.NotNil(t, err)
+}
+
+func TestSaramaConfigUsesTheScramClientOfTheMechanism(t *testing.T) {
+	config := NewConfig([]string{"localhost:9092"})
+	config.SASL = SASLConfig{Mechanism: SASLMechanismScramSha512, Username: "user", Password: "pencil"}
+
+	saramaConfig, err := config.saramaConfig()
+
+	assert.Nil(t, err)
+	assert.True(t, saramaConfig.Net.SASL.Enable)
+	assert.Equal(t, sarama.SASLMechanism(SASLMechanismScramSha512), saramaConfig.Net.SASL.Mechanism)
+	client, ok := saramaConfig.Net.SASL.SCRAMClientGeneratorFunc().(*scramClient)
+	assert.True(t, ok)
+	assert.Equal(t, 64, client.hashGenerator().Size())
+}
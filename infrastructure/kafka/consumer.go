@@ -1,24 +1,48 @@
 package kafka
 
 import (
+	"context"
 	"readmodels/internal/bus"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/rs/zerolog/log"
 )
 
+const handlersDrainTimeout = 20 * time.Second
+
 type Consumer struct {
 	ready    chan bool
 	eventBus *bus.EventBus
+	topics   *topics
+	status   *consumerStatus
+	paused   *pausedTopics
 }
 
-func (consumer *Consumer) Setup(sarama.ConsumerGroupSession) error {
+func (consumer *Consumer) Setup(session sarama.ConsumerGroupSession) error {
+	consumer.status.sessionStarted(session)
 	// Mark the consumer as ready
 	close(consumer.ready)
 	return nil
 }
 
-func (consumer *Consumer) Cleanup(sarama.ConsumerGroupSession) error {
+// Cleanup runs once every claim has stopped, either on a rebalance or on
+// shutdown. It waits for the handlers of the claimed messages before their
+// offsets are committed, so they aren't given to another member meanwhile.
+// Only the offsets of handled messages are marked, those abandoned after the
+// timeout are consumed again.
+func (consumer *Consumer) Cleanup(session sarama.ConsumerGroupSession) error {
+	consumer.status.sessionEnded()
+
+	ctx, cancel := context.WithTimeout(context.Background(), handlersDrainTimeout)
+	defer cancel()
+	err := consumer.eventBus.WaitInFlight(ctx)
+	if err != nil {
+		log.Error().Err(err).Msgf("Handlers didn't finish within %s, committing the offsets of the handled messages", handlersDrainTimeout)
+	}
+
+	session.Commit()
+	log.Info().Msg("Kafka offsets committed")
 	return nil
 }
 
@@ -27,6 +51,7 @@ func (consumer *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, clai
 	// Do not move the code below to a goroutine.
 	// The `ConsumeClaim` itself is called within a goroutine, see:
 	// https://github.com/IBM/sarama/blob/main/consumer_group.go#L27-L29
+	tracker := newOffsetTracker(session, claim.Topic(), claim.Partition())
 	for {
 		select {
 		case message, ok := <-claim.Messages():
@@ -34,14 +59,32 @@ func (consumer *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, clai
 				log.Error().Stack().Msg("message channel was closed")
 				return nil
 			}
-			log.Info().Msgf("Message claimed: value = %s, timestamp = %v, topic = %s", string(message.Value), message.Timestamp, message.Topic)
-			session.MarkMessage(message, "")
+			// The message is left unmarked when the session ends while the
+			// topic is paused, so it's claimed again by the next session
+			if !consumer.paused.wait(message.Topic, session.Context().Done()) {
+				return nil
+			}
+			consumer.status.messageClaimed(message, claim.HighWaterMarkOffset())
+			offset := message.Offset
+			tracker.track(offset)
 
+			eventType, ok := consumer.topics.eventType(message.Topic)
+			if !ok {
+				log.Error().Msgf("No event type is mapped to topic %s", message.Topic)
+				tracker.done(offset)
+				continue
+			}
+			// The payload holds user content, so it's only logged when
+			// debugging
+			log.Info().Msgf("Message claimed: topic = %s, partition = %d, offset = %d, event type = %s, timestamp = %v", message.Topic, message.Partition, offset, eventType, message.Timestamp)
+			log.Debug().Msgf("Message payload: offset = %d, value = %s", offset, string(message.Value))
 			event := bus.Event{
-				Type: message.Topic,
+				Type: eventType,
 				Data: message.Value,
 			}
-			consumer.eventBus.Publish(event)
+			consumer.eventBus.PublishAndNotify(event, func() {
+				tracker.done(offset)
+			})
 		case <-session.Context().Done():
 			return nil
 		}
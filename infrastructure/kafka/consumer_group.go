@@ -4,33 +4,78 @@ import (
 	"context"
 	"errors"
 	"readmodels/internal/bus"
+	internalConsumer "readmodels/internal/consumer"
 	"sync"
 
 	"github.com/IBM/sarama"
 	"github.com/rs/zerolog/log"
 )
 
+const groupId = "readmodels-group"
+
 type KafkaConsumer struct {
 	ConsumerGroup sarama.ConsumerGroup
+	groupId       string
+	client        sarama.Client
+	admin         sarama.ClusterAdmin
 	eventBus      *bus.EventBus
+	topics        *topics
+	status        *consumerStatus
+	paused        *pausedTopics
+}
+
+// NewKafkaConsumer subscribes to the topics of the event types subscribed on
+// the bus, so every subscription must be made before.
+func NewKafkaConsumer(kafkaConfig *Config, eventBus *bus.EventBus) (*KafkaConsumer, error) {
+	return NewKafkaConsumerInGroup(kafkaConfig, eventBus, groupId)
 }
 
-func NewKafkaConsumer(brokers []string, eventBus *bus.EventBus) (*KafkaConsumer, error) {
-	config := sarama.NewConfig()
-	config.Version = sarama.V2_0_0_0
+// NewKafkaConsumerInGroup is NewKafkaConsumer joining another consumer group,
+// for projections that need every event on every instance instead of
+// sharing the partitions with the other instances.
+func NewKafkaConsumerInGroup(kafkaConfig *Config, eventBus *bus.EventBus, groupId string) (*KafkaConsumer, error) {
+	topics, err := newTopics(kafkaConfig, eventBus.EventTypes())
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error resolving Kafka topics")
+		return nil, err
+	}
+
+	config, err := kafkaConfig.saramaConfig()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error configuring consumer group client")
+		return nil, err
+	}
 	config.Consumer.Group.Rebalance.GroupStrategies = []sarama.BalanceStrategy{sarama.NewBalanceStrategySticky()}
 	config.Consumer.Offsets.Initial = sarama.OffsetOldest
-	groupId := "readmodels-group"
 
-	consumerGroup, err := sarama.NewConsumerGroup(brokers, groupId, config)
+	// The consumer group and the admin share the client, which the admin closes
+	client, err := sarama.NewClient(kafkaConfig.Brokers, config)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error creating Kafka client")
+		return nil, err
+	}
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error creating Kafka cluster admin")
+		_ = client.Close()
+		return nil, err
+	}
+	consumerGroup, err := sarama.NewConsumerGroupFromClient(groupId, client)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("Error creating consumer group client")
+		_ = admin.Close()
 		return nil, err
 	}
 
 	return &KafkaConsumer{
 		ConsumerGroup: consumerGroup,
+		groupId:       groupId,
+		client:        client,
+		admin:         admin,
 		eventBus:      eventBus,
+		topics:        topics,
+		status:        newConsumerStatus(),
+		paused:        newPausedTopics(),
 	}, nil
 }
 
@@ -38,25 +83,39 @@ func (k *KafkaConsumer) InitConsumption(ctx context.Context) error {
 	consumer := Consumer{
 		ready:    make(chan bool),
 		eventBus: k.eventBus,
+		topics:   k.topics,
+		status:   k.status,
+		paused:   k.paused,
 	}
 
-	log.Info().Msg("Initiating Kafka Consumer Group...")
+	log.Info().Msgf("Initiating Kafka Consumer Group on topics %v...", k.topics.names)
 
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go k.runConsumerGroup(ctx, &wg, &consumer)
 
-	<-consumer.ready // Await till the consumer has been set up
-	log.Info().Msg("Kafka Consumer up and running...")
+	// Await till the consumer has been set up, unless it's stopped before
+	select {
+	case <-consumer.ready:
+		log.Info().Msg("Kafka Consumer up and running...")
+	case <-ctx.Done():
+	}
 
 	<-ctx.Done()
 	log.Info().Msg("Terminating Kafka Consumer: context cancelled")
 
+	// Consume returns once the session is released, after the handlers were
+	// drained and the offsets committed in Consumer.Cleanup
 	wg.Wait()
+	k.status.setState(internalConsumer.StateStopped)
 	if err := k.ConsumerGroup.Close(); err != nil {
 		log.Error().Stack().Err(err).Msg("Error closing Kafka Consumer Group")
 		return err
 	}
+	if err := k.admin.Close(); err != nil {
+		log.Error().Stack().Err(err).Msg("Error closing Kafka client")
+		return err
+	}
 
 	return nil
 }
@@ -67,7 +126,7 @@ func (k *KafkaConsumer) runConsumerGroup(ctx context.Context, wg *sync.WaitGroup
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := k.ConsumerGroup.Consume(ctx, getTopics(), consumer); err != nil {
+		if err := k.ConsumerGroup.Consume(ctx, k.topics.names, consumer); err != nil {
 			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
 				log.Error().Stack().Err(err).Msg("Consumer Group was closed")
 				return
@@ -0,0 +1,81 @@
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"readmodels/internal/bus"
+	"readmodels/internal/events"
+
+	"github.com/IBM/sarama"
+	"github.com/rs/zerolog/log"
+)
+
+const deadLetterTopic = "readmodels.deadLetters"
+
+// DeadLetterProducer publishes rejected events to the dead letter topic,
+// keeping the original message and the rejection reason as headers.
+type DeadLetterProducer struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func NewDeadLetterProducer(kafkaConfig *Config) (*DeadLetterProducer, error) {
+	config, err := kafkaConfig.saramaConfig()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error configuring dead letter producer")
+		return nil, err
+	}
+	config.Producer.Return.Successes = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+
+	producer, err := sarama.NewSyncProducer(kafkaConfig.Brokers, config)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error creating dead letter producer")
+		return nil, err
+	}
+
+	return &DeadLetterProducer{
+		producer: producer,
+		topic:    kafkaConfig.topicName(deadLetterTopic),
+	}, nil
+}
+
+func (p *DeadLetterProducer) Send(event bus.Event, reason error) {
+	code := "handler_error"
+	headers := []sarama.RecordHeader{
+		{Key: []byte("event-type"), Value: []byte(event.Type)},
+	}
+	var decodeError *events.DecodeError
+	var handlerPanicError *bus.HandlerPanicError
+	if errors.As(reason, &handlerPanicError) {
+		code = "handler_panic"
+		headers = append(headers, sarama.RecordHeader{Key: []byte("subscription"), Value: []byte(handlerPanicError.Subscription())})
+	} else if errors.As(reason, &decodeError) {
+		code = decodeError.Reason
+		if len(decodeError.Violations) > 0 {
+			violations, _ := json.Marshal(decodeError.Violations)
+			headers = append(headers, sarama.RecordHeader{Key: []byte("violations"), Value: violations})
+		}
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte("reason"), Value: []byte(code)},
+		sarama.RecordHeader{Key: []byte("error"), Value: []byte(reason.Error())},
+	)
+
+	_, _, err := p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   p.topic,
+		Key:     sarama.StringEncoder(event.Type),
+		Value:   sarama.ByteEncoder(event.Data),
+		Headers: headers,
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't send %s to the dead letter topic, reason: %s", event.Type, reason)
+		return
+	}
+
+	log.Warn().Msgf("%s sent to the dead letter topic, reason: %s", event.Type, code)
+}
+
+func (p *DeadLetterProducer) Close() error {
+	return p.producer.Close()
+}
@@ -0,0 +1,120 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"readmodels/internal/events"
+	"readmodels/internal/live"
+	"readmodels/internal/outbox"
+	"sync"
+
+	"github.com/IBM/sarama"
+	"github.com/rs/zerolog/log"
+)
+
+// LiveFeed reads the post counter changes published by the outbox relay
+// from every partition, outside the consumer group, so that each instance
+// streams every change to its live clients. Reading starts at the newest
+// offsets, the changes made while the instance was down are not replayed.
+type LiveFeed struct {
+	client sarama.Client
+	topic  string
+	hub    *live.Hub
+}
+
+func NewLiveFeed(kafkaConfig *Config, hub *live.Hub) (*LiveFeed, error) {
+	config, err := kafkaConfig.saramaConfig()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error configuring live feed")
+		return nil, err
+	}
+
+	client, err := sarama.NewClient(kafkaConfig.Brokers, config)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error creating live feed client")
+		return nil, err
+	}
+
+	return &LiveFeed{
+		client: client,
+		topic:  kafkaConfig.topicName(outbox.PostCounterChangedEventType),
+		hub:    hub,
+	}, nil
+}
+
+// Run feeds the hub until ctx is done.
+func (f *LiveFeed) Run(ctx context.Context) error {
+	defer f.client.Close()
+
+	consumer, err := sarama.NewConsumerFromClient(f.client)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error creating live feed consumer")
+		return err
+	}
+	defer consumer.Close()
+
+	partitions, err := consumer.Partitions(f.topic)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't get the partitions of %s", f.topic)
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, partition := range partitions {
+		partitionConsumer, err := consumer.ConsumePartition(f.topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't consume partition %d of %s", partition, f.topic)
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer partitionConsumer.Close()
+			f.feed(ctx, partitionConsumer)
+		}()
+	}
+
+	log.Info().Msgf("Live feed reading %d partitions of %s", len(partitions), f.topic)
+	wg.Wait()
+	return nil
+}
+
+func (f *LiveFeed) feed(ctx context.Context, partitionConsumer sarama.PartitionConsumer) {
+	for {
+		select {
+		case message, ok := <-partitionConsumer.Messages():
+			if !ok {
+				return
+			}
+			change, err := decodeCounterChange(message.Value)
+			if err != nil {
+				log.Error().Err(err).Msgf("Couldn't decode counter change at offset %d of %s", message.Offset, f.topic)
+				continue
+			}
+			f.hub.Publish(change)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func decodeCounterChange(data []byte) (*live.CounterChange, error) {
+	var envelope events.Envelope
+	err := json.Unmarshal(data, &envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	var event outbox.PostCounterChangedEvent
+	err = json.Unmarshal(envelope.Payload, &event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &live.CounterChange{
+		Id:      envelope.Id,
+		PostId:  event.PostId,
+		Counter: event.Counter,
+		Delta:   event.Delta,
+	}, nil
+}
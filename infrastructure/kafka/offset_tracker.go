@@ -0,0 +1,54 @@
+package kafka
+
+import (
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// offsetTracker marks the offsets of a claimed partition once the handlers of
+// its messages finish. Handlers finish out of order, so the mark only moves
+// past the messages whose predecessors are all handled, otherwise the commit
+// could skip a message still being handled.
+type offsetTracker struct {
+	mutex     sync.Mutex
+	session   sarama.ConsumerGroupSession
+	topic     string
+	partition int32
+	pending   []int64
+	handled   map[int64]bool
+}
+
+func newOffsetTracker(session sarama.ConsumerGroupSession, topic string, partition int32) *offsetTracker {
+	return &offsetTracker{
+		session:   session,
+		topic:     topic,
+		partition: partition,
+		handled:   map[int64]bool{},
+	}
+}
+
+// track must be called in the order the messages are claimed.
+func (t *offsetTracker) track(offset int64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.pending = append(t.pending, offset)
+}
+
+func (t *offsetTracker) done(offset int64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.handled[offset] = true
+	marked := int64(-1)
+	for len(t.pending) > 0 && t.handled[t.pending[0]] {
+		marked = t.pending[0]
+		delete(t.handled, marked)
+		t.pending = t.pending[1:]
+	}
+	if marked >= 0 {
+		// The committed offset is the next message to consume
+		t.session.MarkOffset(t.topic, t.partition, marked+1, "")
+	}
+}
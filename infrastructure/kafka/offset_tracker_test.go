@@ -0,0 +1,35 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+type markingSession struct {
+	sarama.ConsumerGroupSession
+	marked []int64
+}
+
+func (s *markingSession) MarkOffset(topic string, partition int32, offset int64, metadata string) {
+	s.marked = append(s.marked, offset)
+}
+
+func TestOffsetTrackerOnlyMarksContiguousHandledMessages(t *testing.T) {
+	session := &markingSession{}
+	tracker := newOffsetTracker(session, "topic", 0)
+	for offset := int64(10); offset < 14; offset++ {
+		tracker.track(offset)
+	}
+
+	tracker.done(12)
+	tracker.done(11)
+	assert.Empty(t, session.marked)
+
+	tracker.done(10)
+	assert.Equal(t, []int64{13}, session.marked)
+
+	tracker.done(13)
+	assert.Equal(t, []int64{13, 14}, session.marked)
+}
@@ -0,0 +1,271 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/rs/zerolog/log"
+)
+
+// OffsetTarget is where ResetOffsets moves the group. Exactly one of its
+// fields must be set.
+type OffsetTarget struct {
+	Earliest  bool
+	Latest    bool
+	Offset    *int64
+	Timestamp *time.Time
+}
+
+// OffsetReset describes the reset of a partition. Current is -1 when the
+// group hasn't committed an offset yet.
+type OffsetReset struct {
+	Topic     string
+	Partition int32
+	Current   int64
+	Target    int64
+}
+
+// Rewinds reports whether the reset makes the group consume again messages
+// it already handled.
+func (r *OffsetReset) Rewinds() bool {
+	return r.Current >= 0 && r.Target < r.Current
+}
+
+// OffsetResetter moves the committed offsets of a consumer group. Kafka
+// only accepts offsets committed from outside the group while it has no
+// members, so every consumer must be stopped before applying a reset.
+//
+// Rewinds are refused unless AllowReplays is called, since the projections
+// of the main group aren't idempotent: replayed likes, superlikes, comments
+// and reviews increase their counters again.
+type OffsetResetter struct {
+	client         sarama.Client
+	admin          sarama.ClusterAdmin
+	groupId        string
+	replaysAllowed bool
+}
+
+func NewOffsetResetter(kafkaConfig *Config) (*OffsetResetter, error) {
+	return NewOffsetResetterInGroup(kafkaConfig, groupId)
+}
+
+// NewOffsetResetterInGroup is NewOffsetResetter for another consumer group.
+func NewOffsetResetterInGroup(kafkaConfig *Config, groupId string) (*OffsetResetter, error) {
+	config, err := kafkaConfig.saramaConfig()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error configuring Kafka client")
+		return nil, err
+	}
+
+	client, err := sarama.NewClient(kafkaConfig.Brokers, config)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error creating Kafka client")
+		return nil, err
+	}
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error creating Kafka cluster admin")
+		_ = client.Close()
+		return nil, err
+	}
+
+	return &OffsetResetter{
+		client:  client,
+		admin:   admin,
+		groupId: groupId,
+	}, nil
+}
+
+// AllowReplays lets Apply rewind the group, for groups whose handlers are
+// idempotent or when consuming the messages again is accepted.
+func (r *OffsetResetter) AllowReplays() {
+	r.replaysAllowed = true
+}
+
+func (t OffsetTarget) validate() error {
+	selected := 0
+	for _, isSet := range []bool{t.Earliest, t.Latest, t.Offset != nil, t.Timestamp != nil} {
+		if isSet {
+			selected++
+		}
+	}
+	if selected != 1 {
+		return errors.New("exactly one offset target must be given")
+	}
+	return nil
+}
+
+// Plan computes the offsets every partition of the topic would be reset to.
+// Offsets out of the partition range are clamped to it, and a timestamp
+// after the last message resets to the end of the partition.
+func (r *OffsetResetter) Plan(topic string, target OffsetTarget) ([]*OffsetReset, error) {
+	err := target.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	partitions, err := r.client.Partitions(topic)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't get the partitions of topic %s", topic)
+		return nil, err
+	}
+	committed, err := r.admin.ListConsumerGroupOffsets(r.groupId, map[string][]int32{topic: partitions})
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't fetch the committed offsets of %s", r.groupId)
+		return nil, err
+	}
+
+	resets := make([]*OffsetReset, 0, len(partitions))
+	for _, partition := range partitions {
+		reset := &OffsetReset{
+			Topic:     topic,
+			Partition: partition,
+			Current:   -1,
+		}
+		if block := committed.GetBlock(topic, partition); block != nil && block.Err == sarama.ErrNoError {
+			reset.Current = block.Offset
+		}
+		reset.Target, err = r.targetOffset(topic, partition, target)
+		if err != nil {
+			return nil, err
+		}
+		resets = append(resets, reset)
+	}
+
+	sort.Slice(resets, func(i, j int) bool {
+		return resets[i].Partition < resets[j].Partition
+	})
+	return resets, nil
+}
+
+func (r *OffsetResetter) targetOffset(topic string, partition int32, target OffsetTarget) (int64, error) {
+	earliest, err := r.client.GetOffset(topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't get the earliest offset of %s/%d", topic, partition)
+		return 0, err
+	}
+	latest, err := r.client.GetOffset(topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't get the latest offset of %s/%d", topic, partition)
+		return 0, err
+	}
+
+	switch {
+	case target.Earliest:
+		return earliest, nil
+	case target.Latest:
+		return latest, nil
+	case target.Offset != nil:
+		return min(max(*target.Offset, earliest), latest), nil
+	default:
+		offset, err := r.client.GetOffset(topic, partition, target.Timestamp.UnixMilli())
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't get the offset of %s/%d at %s", topic, partition, target.Timestamp)
+			return 0, err
+		}
+		if offset < 0 {
+			return latest, nil
+		}
+		return offset, nil
+	}
+}
+
+// Apply commits the planned offsets for the consumer group, which must have
+// no active members. Resets rewinding a partition are refused unless replays
+// are allowed.
+func (r *OffsetResetter) Apply(resets []*OffsetReset) error {
+	if !r.replaysAllowed {
+		rewound := []string{}
+		for _, reset := range resets {
+			if reset.Rewinds() {
+				rewound = append(rewound, fmt.Sprintf("%s/%d", reset.Topic, reset.Partition))
+			}
+		}
+		if len(rewound) > 0 {
+			return NewReplayRefusedError(r.groupId, rewound)
+		}
+	}
+
+	groups, err := r.admin.DescribeConsumerGroups([]string{r.groupId})
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't describe consumer group %s", r.groupId)
+		return err
+	}
+	for _, group := range groups {
+		if len(group.Members) > 0 {
+			return NewActiveConsumerGroupError(r.groupId, group.State, len(group.Members))
+		}
+	}
+
+	coordinator, err := r.client.Coordinator(r.groupId)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't find the coordinator of %s", r.groupId)
+		return err
+	}
+
+	request := &sarama.OffsetCommitRequest{
+		Version:                 2,
+		ConsumerGroup:           r.groupId,
+		ConsumerGroupGeneration: sarama.GroupGenerationUndefined,
+		RetentionTime:           -1,
+	}
+	for _, reset := range resets {
+		request.AddBlock(reset.Topic, reset.Partition, reset.Target, sarama.ReceiveTime, "")
+	}
+	response, err := coordinator.CommitOffset(request)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't commit the offsets of %s", r.groupId)
+		return err
+	}
+
+	errs := []error{}
+	for topic, partitions := range response.Errors {
+		for partition, kafkaError := range partitions {
+			if kafkaError != sarama.ErrNoError {
+				errs = append(errs, fmt.Errorf("%s/%d: %w", topic, partition, kafkaError))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *OffsetResetter) Close() error {
+	return r.admin.Close()
+}
+
+type ActiveConsumerGroupError struct {
+	groupId string
+	state   string
+	members int
+}
+
+func (e *ActiveConsumerGroupError) Error() string {
+	return fmt.Sprintf("Consumer group %s is %s with %d members, stop every consumer before resetting its offsets", e.groupId, e.state, e.members)
+}
+
+func NewActiveConsumerGroupError(groupId, state string, members int) *ActiveConsumerGroupError {
+	return &ActiveConsumerGroupError{
+		groupId: groupId,
+		state:   state,
+		members: members,
+	}
+}
+
+type ReplayRefusedError struct {
+	groupId    string
+	partitions []string
+}
+
+func (e *ReplayRefusedError) Error() string {
+	return fmt.Sprintf("Resetting consumer group %s rewinds partitions %v, whose messages would be projected again", e.groupId, e.partitions)
+}
+
+func NewReplayRefusedError(groupId string, partitions []string) *ReplayRefusedError {
+	return &ReplayRefusedError{
+		groupId:    groupId,
+		partitions: partitions,
+	}
+}
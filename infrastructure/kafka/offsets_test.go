@@ -0,0 +1,29 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyRefusesToRewindWithoutReplays(t *testing.T) {
+	resetter := &OffsetResetter{groupId: "readmodels"}
+	resets := []*OffsetReset{
+		{Topic: "topic", Partition: 0, Current: 10, Target: 10},
+		{Topic: "topic", Partition: 1, Current: 10, Target: 4},
+		{Topic: "topic", Partition: 2, Current: -1, Target: 0},
+	}
+
+	err := resetter.Apply(resets)
+
+	var replayRefusedError *ReplayRefusedError
+	assert.True(t, errors.As(err, &replayRefusedError))
+	assert.Equal(t, []string{"topic/1"}, replayRefusedError.partitions)
+}
+
+func TestRewinds(t *testing.T) {
+	assert.True(t, (&OffsetReset{Current: 10, Target: 9}).Rewinds())
+	assert.False(t, (&OffsetReset{Current: 10, Target: 12}).Rewinds())
+	assert.False(t, (&OffsetReset{Current: -1, Target: 0}).Rewinds())
+}
@@ -0,0 +1,59 @@
+package kafka
+
+import (
+	database "readmodels/internal/db"
+	"strconv"
+
+	"github.com/IBM/sarama"
+	"github.com/rs/zerolog/log"
+)
+
+// OutboxProducer publishes the outbox messages to the topic of their event
+// type, keyed so that the events of an entity land on the same partition.
+// The sequence header lets consumers drop the messages of an entity they
+// already handled.
+type OutboxProducer struct {
+	producer    sarama.SyncProducer
+	kafkaConfig *Config
+}
+
+func NewOutboxProducer(kafkaConfig *Config) (*OutboxProducer, error) {
+	config, err := kafkaConfig.saramaConfig()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error configuring outbox producer")
+		return nil, err
+	}
+	config.Producer.Return.Successes = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	// A single request in flight keeps retries from reordering the messages
+	config.Net.MaxOpenRequests = 1
+
+	producer, err := sarama.NewSyncProducer(kafkaConfig.Brokers, config)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error creating outbox producer")
+		return nil, err
+	}
+
+	return &OutboxProducer{
+		producer:    producer,
+		kafkaConfig: kafkaConfig,
+	}, nil
+}
+
+func (p *OutboxProducer) Publish(message *database.OutboxMessage) error {
+	_, _, err := p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: p.kafkaConfig.topicName(message.EventType),
+		Key:   sarama.StringEncoder(message.Key),
+		Value: sarama.ByteEncoder(message.Payload),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("event-type"), Value: []byte(message.EventType)},
+			{Key: []byte("message-id"), Value: []byte(message.MessageId)},
+			{Key: []byte("sequence"), Value: []byte(strconv.FormatInt(message.Sequence, 10))},
+		},
+	})
+	return err
+}
+
+func (p *OutboxProducer) Close() error {
+	return p.producer.Close()
+}
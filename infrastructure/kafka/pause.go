@@ -0,0 +1,122 @@
+package kafka
+
+import (
+	"readmodels/internal/consumer"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// pausedTopics holds the topics whose messages mustn't be projected. The
+// claims of a paused topic wait for it to be resumed, so the messages are
+// neither lost nor committed meanwhile.
+type pausedTopics struct {
+	mutex   sync.RWMutex
+	topics  map[string]bool
+	resumed chan struct{}
+}
+
+func newPausedTopics() *pausedTopics {
+	return &pausedTopics{
+		topics:  map[string]bool{},
+		resumed: make(chan struct{}),
+	}
+}
+
+func (p *pausedTopics) pause(topics []string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, topic := range topics {
+		p.topics[topic] = true
+	}
+}
+
+func (p *pausedTopics) resume(topics []string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, topic := range topics {
+		delete(p.topics, topic)
+	}
+	close(p.resumed)
+	p.resumed = make(chan struct{})
+}
+
+func (p *pausedTopics) isPaused(topic string) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.topics[topic]
+}
+
+func (p *pausedTopics) areAllPaused(topics []string) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	for _, topic := range topics {
+		if !p.topics[topic] {
+			return false
+		}
+	}
+	return true
+}
+
+// wait blocks while the topic is paused. It returns false when done is
+// closed before the topic is resumed.
+func (p *pausedTopics) wait(topic string, done <-chan struct{}) bool {
+	for {
+		p.mutex.RLock()
+		paused, resumed := p.topics[topic], p.resumed
+		p.mutex.RUnlock()
+		if !paused {
+			return true
+		}
+
+		select {
+		case <-resumed:
+		case <-done:
+			return false
+		}
+	}
+}
+
+// Pause implements consumer.Consumer. The paused topics are kept in memory,
+// so other members of the group and restarted instances aren't paused.
+func (k *KafkaConsumer) Pause(topics []string) error {
+	selected, err := k.selectTopics(topics)
+	if err != nil {
+		return err
+	}
+
+	k.paused.pause(selected)
+	log.Warn().Msgf("Kafka Consumer paused on topics %v", selected)
+	return nil
+}
+
+// Resume implements consumer.Consumer
+func (k *KafkaConsumer) Resume(topics []string) error {
+	selected, err := k.selectTopics(topics)
+	if err != nil {
+		return err
+	}
+
+	k.paused.resume(selected)
+	log.Info().Msgf("Kafka Consumer resumed on topics %v", selected)
+	return nil
+}
+
+func (k *KafkaConsumer) selectTopics(topics []string) ([]string, error) {
+	if len(topics) == 0 {
+		return k.topics.names, nil
+	}
+
+	unknown := []string{}
+	for _, topic := range topics {
+		if _, ok := k.topics.eventType(topic); !ok {
+			unknown = append(unknown, topic)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, consumer.NewUnknownTopicError(unknown)
+	}
+	return topics, nil
+}
@@ -0,0 +1,170 @@
+package kafka
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	scramSha256 = sha256.New
+	scramSha512 = sha512.New
+)
+
+const scramNonceLength = 24
+
+// scramClient implements the client side of the SCRAM exchange (RFC 5802)
+// that sarama delegates to a sarama.SCRAMClient.
+type scramClient struct {
+	hashGenerator func() hash.Hash
+	nonce         func() (string, error)
+	username      string
+	password      string
+	authzId       string
+	clientNonce   string
+	gs2Header     string
+	clientFirst   string
+	serverSign    []byte
+	step          int
+	done          bool
+}
+
+func newScramClient(hashGenerator func() hash.Hash) *scramClient {
+	return &scramClient{
+		hashGenerator: hashGenerator,
+		nonce:         randomScramNonce,
+	}
+}
+
+func (c *scramClient) Begin(username, password, authzId string) error {
+	nonce, err := c.nonce()
+	if err != nil {
+		return err
+	}
+
+	c.username = username
+	c.password = password
+	c.authzId = authzId
+	c.clientNonce = nonce
+	c.step = 0
+	c.done = false
+	return nil
+}
+
+func (c *scramClient) Step(challenge string) (string, error) {
+	defer func() { c.step++ }()
+
+	switch c.step {
+	case 0:
+		return c.clientFirstMessage(), nil
+	case 1:
+		return c.clientFinalMessage(challenge)
+	case 2:
+		c.done = true
+		return "", c.verifyServerFinal(challenge)
+	default:
+		return "", errors.New("SCRAM exchange already finished")
+	}
+}
+
+func (c *scramClient) Done() bool {
+	return c.done
+}
+
+func (c *scramClient) clientFirstMessage() string {
+	c.gs2Header = "n,,"
+	if c.authzId != "" {
+		c.gs2Header = "n,a=" + escapeScramName(c.authzId) + ","
+	}
+	c.clientFirst = "n=" + escapeScramName(c.username) + ",r=" + c.clientNonce
+	return c.gs2Header + c.clientFirst
+}
+
+func (c *scramClient) clientFinalMessage(serverFirst string) (string, error) {
+	attributes := parseScramAttributes(serverFirst)
+	nonce, salt, iterations := attributes["r"], attributes["s"], attributes["i"]
+	if !strings.HasPrefix(nonce, c.clientNonce) || len(nonce) == len(c.clientNonce) {
+		return "", errors.New("SCRAM server nonce doesn't extend the client nonce")
+	}
+	decodedSalt, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil || len(decodedSalt) == 0 {
+		return "", errors.New("SCRAM server sent an invalid salt")
+	}
+	iterationCount, err := strconv.Atoi(iterations)
+	if err != nil || iterationCount <= 0 {
+		return "", fmt.Errorf("SCRAM server sent an invalid iteration count %q", iterations)
+	}
+
+	saltedPassword := pbkdf2.Key([]byte(c.password), decodedSalt, iterationCount, c.hashGenerator().Size(), c.hashGenerator)
+	clientKey := c.hmac(saltedPassword, "Client Key")
+	storedKey := c.hash(clientKey)
+
+	clientFinalWithoutProof := "c=" + base64.StdEncoding.EncodeToString([]byte(c.gs2Header)) + ",r=" + nonce
+	authMessage := c.clientFirst + "," + serverFirst + "," + clientFinalWithoutProof
+
+	clientSignature := c.hmac(storedKey, authMessage)
+	proof := make([]byte, len(clientKey))
+	for i := range clientKey {
+		proof[i] = clientKey[i] ^ clientSignature[i]
+	}
+	c.serverSign = c.hmac(c.hmac(saltedPassword, "Server Key"), authMessage)
+
+	return clientFinalWithoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof), nil
+}
+
+func (c *scramClient) verifyServerFinal(serverFinal string) error {
+	attributes := parseScramAttributes(serverFinal)
+	if serverError, ok := attributes["e"]; ok {
+		return fmt.Errorf("SCRAM authentication failed: %s", serverError)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(attributes["v"])
+	if err != nil || !hmac.Equal(signature, c.serverSign) {
+		return errors.New("SCRAM server signature doesn't match")
+	}
+	return nil
+}
+
+func (c *scramClient) hmac(key []byte, message string) []byte {
+	mac := hmac.New(c.hashGenerator, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+func (c *scramClient) hash(data []byte) []byte {
+	h := c.hashGenerator()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func randomScramNonce() (string, error) {
+	nonce := make([]byte, scramNonceLength)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawStdEncoding.EncodeToString(nonce), nil
+}
+
+func parseScramAttributes(message string) map[string]string {
+	attributes := map[string]string{}
+	for _, attribute := range strings.Split(message, ",") {
+		key, value, found := strings.Cut(attribute, "=")
+		if found {
+			attributes[key] = value
+		}
+	}
+	return attributes
+}
+
+func escapeScramName(name string) string {
+	return strings.NewReplacer("=", "=3D", ",", "=2C").Replace(name)
+}
@@ -0,0 +1,129 @@
+package kafka
+
+import (
+	"crypto/sha1"
+	"hash"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type scramExchange struct {
+	hashGenerator func() hash.Hash
+	clientNonce   string
+	clientFirst   string
+	serverFirst   string
+	clientFinal   string
+	serverFinal   string
+}
+
+// The examples of RFC 5802 section 5 and RFC 7677 section 3, for the user
+// "user" with the password "pencil"
+var rfcExchanges = map[string]scramExchange{
+	"SCRAM-SHA-1": {
+		hashGenerator: sha1.New,
+		clientNonce:   "fyko+d2lbbFgONRv9qkxdawL",
+		clientFirst:   "n,,n=user,r=fyko+d2lbbFgONRv9qkxdawL",
+		serverFirst:   "r=fyko+d2lbbFgONRv9qkxdawL3rfcNHYJY1ZVvWVs7j,s=QSXCR+Q6sek8bf92,i=4096",
+		clientFinal:   "c=biws,r=fyko+d2lbbFgONRv9qkxdawL3rfcNHYJY1ZVvWVs7j,p=v0X8v3Bz2T0CJGbJQyF0X+HI4Ts=",
+		serverFinal:   "v=rmF9pqV8S7suAoZWja4dJRkFsKQ=",
+	},
+	"SCRAM-SHA-256": {
+		hashGenerator: scramSha256,
+		clientNonce:   "rOprNGfwEbeRWgbNEkqO",
+		clientFirst:   "n,,n=user,r=rOprNGfwEbeRWgbNEkqO",
+		serverFirst:   "r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096",
+		clientFinal:   "c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ=",
+		serverFinal:   "v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=",
+	},
+}
+
+func beginScram(t *testing.T, exchange scramExchange, username, authzId string) *scramClient {
+	client := newScramClient(exchange.hashGenerator)
+	client.nonce = func() (string, error) { return exchange.clientNonce, nil }
+	err := client.Begin(username, "pencil", authzId)
+	assert.Nil(t, err)
+	return client
+}
+
+func TestScramClientFollowsTheRFCExamples(t *testing.T) {
+	for mechanism, exchange := range rfcExchanges {
+		t.Run(mechanism, func(t *testing.T) {
+			client := beginScram(t, exchange, "user", "")
+
+			clientFirst, err := client.Step("")
+			assert.Nil(t, err)
+			assert.Equal(t, exchange.clientFirst, clientFirst)
+
+			clientFinal, err := client.Step(exchange.serverFirst)
+			assert.Nil(t, err)
+			assert.Equal(t, exchange.clientFinal, clientFinal)
+			assert.False(t, client.Done())
+
+			_, err = client.Step(exchange.serverFinal)
+			assert.Nil(t, err)
+			assert.True(t, client.Done())
+		})
+	}
+}
+
+func TestScramClientRejectsAWrongServerSignature(t *testing.T) {
+	exchange := rfcExchanges["SCRAM-SHA-256"]
+	client := beginScram(t, exchange, "user", "")
+	_, _ = client.Step("")
+	_, _ = client.Step(exchange.serverFirst)
+
+	_, err := client.Step(rfcExchanges["SCRAM-SHA-1"].serverFinal)
+
+	assert.EqualError(t, err, "SCRAM server signature doesn't match")
+}
+
+func TestScramClientReportsTheServerError(t *testing.T) {
+	exchange := rfcExchanges["SCRAM-SHA-256"]
+	client := beginScram(t, exchange, "user", "")
+	_, _ = client.Step("")
+	_, _ = client.Step(exchange.serverFirst)
+
+	_, err := client.Step("e=invalid-proof")
+
+	assert.EqualError(t, err, "SCRAM authentication failed: invalid-proof")
+}
+
+func TestScramClientRejectsAnInvalidServerFirstMessage(t *testing.T) {
+	exchange := rfcExchanges["SCRAM-SHA-256"]
+	serverFirsts := map[string]string{
+		"nonce not extended":      "r=rOprNGfwEbeRWgbNEkqO,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096",
+		"nonce of another client": "r=fyko+d2lbbFgONRv9qkxdawL3rfcNHYJY1ZVvWVs7j,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096",
+		"missing salt":            "r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,i=4096",
+		"invalid iterations":      "r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=0",
+	}
+	for name, serverFirst := range serverFirsts {
+		t.Run(name, func(t *testing.T) {
+			client := beginScram(t, exchange, "user", "")
+			_, _ = client.Step("")
+
+			_, err := client.Step(serverFirst)
+
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestScramClientEscapesTheUsernameAndAuthorizationId(t *testing.T) {
+	client := beginScram(t, rfcExchanges["SCRAM-SHA-256"], "us=er,1", "admin=1")
+
+	clientFirst, err := client.Step("")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "n,a=admin=3D1,n=us=3Der=2C1,r=rOprNGfwEbeRWgbNEkqO", clientFirst)
+}
+
+func TestScramClientGeneratesANewNonceForEachExchange(t *testing.T) {
+	client := newScramClient(scramSha512)
+	_ = client.Begin("user", "pencil", "")
+	first := client.clientNonce
+	_ = client.Begin("user", "pencil", "")
+
+	assert.NotEqual(t, first, client.clientNonce)
+	assert.Len(t, client.clientNonce, 32)
+}
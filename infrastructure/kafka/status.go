@@ -0,0 +1,186 @@
+package kafka
+
+import (
+	"readmodels/internal/consumer"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/rs/zerolog/log"
+)
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+type partitionState struct {
+	currentOffset int64
+	highWaterMark int64
+	lastMessageAt *time.Time
+}
+
+// consumerStatus keeps what the consumer group sessions report, to be
+// combined with the committed and high water offsets on request.
+type consumerStatus struct {
+	mutex        sync.RWMutex
+	state        string
+	memberId     string
+	generationId int32
+	sessions     uint64
+	partitions   map[topicPartition]*partitionState
+}
+
+func newConsumerStatus() *consumerStatus {
+	return &consumerStatus{
+		state:      consumer.StateStarting,
+		partitions: map[topicPartition]*partitionState{},
+	}
+}
+
+func (s *consumerStatus) setState(state string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.state = state
+}
+
+func (s *consumerStatus) sessionStarted(session sarama.ConsumerGroupSession) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.state = consumer.StateRunning
+	s.memberId = session.MemberID()
+	s.generationId = session.GenerationID()
+	s.sessions++
+	s.partitions = map[topicPartition]*partitionState{}
+	for topic, partitions := range session.Claims() {
+		for _, partition := range partitions {
+			s.partitions[topicPartition{topic: topic, partition: partition}] = &partitionState{
+				currentOffset: -1,
+				highWaterMark: -1,
+			}
+		}
+	}
+}
+
+func (s *consumerStatus) sessionEnded() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.state = consumer.StateRebalancing
+	s.partitions = map[topicPartition]*partitionState{}
+}
+
+func (s *consumerStatus) messageClaimed(message *sarama.ConsumerMessage, highWaterMark int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	state, ok := s.partitions[topicPartition{topic: message.Topic, partition: message.Partition}]
+	if !ok {
+		return
+	}
+	timestamp := message.Timestamp
+	state.currentOffset = message.Offset + 1
+	state.highWaterMark = highWaterMark
+	state.lastMessageAt = &timestamp
+}
+
+// snapshot copies the status so the offsets can be fetched without the lock.
+func (s *consumerStatus) snapshot(groupId string) (*consumer.Status, map[topicPartition]partitionState) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	status := &consumer.Status{
+		GroupId:      groupId,
+		State:        s.state,
+		MemberId:     s.memberId,
+		GenerationId: s.generationId,
+		Partitions:   []*consumer.PartitionStatus{},
+	}
+	// The first session is the initial join, not a rebalance
+	if s.sessions > 1 {
+		status.Rebalances = s.sessions - 1
+	}
+
+	partitions := make(map[topicPartition]partitionState, len(s.partitions))
+	for key, state := range s.partitions {
+		partitions[key] = *state
+	}
+	return status, partitions
+}
+
+// Status implements consumer.Monitor
+func (k *KafkaConsumer) Status() (*consumer.Status, error) {
+	status, partitions := k.status.snapshot(k.groupId)
+	if status.State == consumer.StateRunning && k.paused.areAllPaused(k.topics.names) {
+		status.State = consumer.StatePaused
+	}
+	if len(partitions) == 0 {
+		return status, nil
+	}
+
+	topicPartitions := map[string][]int32{}
+	for key := range partitions {
+		topicPartitions[key.topic] = append(topicPartitions[key.topic], key.partition)
+	}
+	committed, err := k.admin.ListConsumerGroupOffsets(k.groupId, topicPartitions)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't fetch the committed offsets of %s", k.groupId)
+		return nil, err
+	}
+
+	for key, state := range partitions {
+		partitionStatus := &consumer.PartitionStatus{
+			Topic:           key.topic,
+			Partition:       key.partition,
+			CurrentOffset:   state.currentOffset,
+			CommittedOffset: -1,
+			HighWaterMark:   state.highWaterMark,
+			Paused:          k.paused.isPaused(key.topic),
+			LastMessageAt:   state.lastMessageAt,
+		}
+		if block := committed.GetBlock(key.topic, key.partition); block != nil && block.Err == sarama.ErrNoError {
+			partitionStatus.CommittedOffset = block.Offset
+		}
+		highWaterMark, err := k.client.GetOffset(key.topic, key.partition, sarama.OffsetNewest)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Couldn't fetch the high water mark of %s/%d, using the last claimed one", key.topic, key.partition)
+		} else {
+			partitionStatus.HighWaterMark = highWaterMark
+		}
+		partitionStatus.Lag = lag(partitionStatus)
+
+		status.TotalLag += partitionStatus.Lag
+		status.Partitions = append(status.Partitions, partitionStatus)
+	}
+
+	sort.Slice(status.Partitions, func(i, j int) bool {
+		if status.Partitions[i].Topic != status.Partitions[j].Topic {
+			return status.Partitions[i].Topic < status.Partitions[j].Topic
+		}
+		return status.Partitions[i].Partition < status.Partitions[j].Partition
+	})
+
+	return status, nil
+}
+
+// lag counts from the committed offset, falling back to the current one and
+// to the whole partition when the group hasn't consumed it yet.
+func lag(partition *consumer.PartitionStatus) int64 {
+	if partition.HighWaterMark < 0 {
+		return 0
+	}
+
+	offset := partition.CommittedOffset
+	if offset < 0 {
+		offset = partition.CurrentOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > partition.HighWaterMark {
+		return 0
+	}
+	return partition.HighWaterMark - offset
+}
@@ -0,0 +1,108 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/IBM/sarama"
+	"github.com/rs/zerolog/log"
+)
+
+// TopicReader reads the events of a type still retained in their topic, from
+// every partition and outside the consumer group, for the commands that
+// backfill data from the event history.
+type TopicReader struct {
+	client sarama.Client
+	config *Config
+}
+
+func NewTopicReader(kafkaConfig *Config) (*TopicReader, error) {
+	config, err := kafkaConfig.saramaConfig()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error configuring topic reader")
+		return nil, err
+	}
+
+	client, err := sarama.NewClient(kafkaConfig.Brokers, config)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error creating topic reader client")
+		return nil, err
+	}
+
+	return &TopicReader{
+		client: client,
+		config: kafkaConfig,
+	}, nil
+}
+
+// Read calls read with the value of every event of the type, partition by
+// partition from the oldest retained one up to the last one published when
+// Read was called. It returns how many events were read.
+func (r *TopicReader) Read(ctx context.Context, eventType string, read func(data []byte)) (int, error) {
+	topic := r.config.topicName(eventType)
+	consumer, err := sarama.NewConsumerFromClient(r.client)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error creating topic reader consumer")
+		return 0, err
+	}
+	defer consumer.Close()
+
+	partitions, err := r.client.Partitions(topic)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't get the partitions of %s", topic)
+		return 0, err
+	}
+
+	total := 0
+	for _, partition := range partitions {
+		count, err := r.readPartition(ctx, consumer, topic, partition, read)
+		total += count
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+func (r *TopicReader) readPartition(ctx context.Context, consumer sarama.Consumer, topic string, partition int32, read func(data []byte)) (int, error) {
+	oldest, err := r.client.GetOffset(topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't get the earliest offset of %s/%d", topic, partition)
+		return 0, err
+	}
+	newest, err := r.client.GetOffset(topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't get the latest offset of %s/%d", topic, partition)
+		return 0, err
+	}
+	if oldest >= newest {
+		return 0, nil
+	}
+
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, oldest)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't consume partition %d of %s", partition, topic)
+		return 0, err
+	}
+	defer partitionConsumer.Close()
+
+	count := 0
+	for {
+		select {
+		case message := <-partitionConsumer.Messages():
+			read(message.Value)
+			count++
+			// Compacted topics can skip offsets, so the end is the last one
+			// at or past newest
+			if message.Offset >= newest-1 {
+				return count, nil
+			}
+		case <-ctx.Done():
+			return count, ctx.Err()
+		}
+	}
+}
+
+func (r *TopicReader) Close() error {
+	return r.client.Close()
+}
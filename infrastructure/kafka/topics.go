@@ -1,20 +1,92 @@
 package kafka
 
-func getTopics() []string {
-	return []string{
-		"UserWasRegisteredEvent",
-		"UserProfileUpdatedEvent",
-		"PostWasCreatedEvent",
-		"PostsWereDeletedEvent",
-		"UserAFollowedUserBEvent",
-		"UserAUnfollowedUserBEvent",
-		"CommentWasCreatedEvent",
-		"CommentWasUpdatedEvent",
-		"CommentWasDeletedEvent",
-		"UserLikedPostEvent",
-		"UserUnlikedPostEvent",
-		"UserSuperlikedPostEvent",
-		"UserUnsuperlikedPostEvent",
-		"ReviewWasCreatedEvent",
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// topics maps the event types subscribed on the bus to the Kafka topics they
+// are published to, applying the Config topic mapping and prefix.
+type topics struct {
+	names      []string
+	eventTypes map[string]string
+}
+
+func newTopics(config *Config, eventTypes []string) (*topics, error) {
+	if len(eventTypes) == 0 {
+		return nil, NewTopicMismatchError("no event types are subscribed on the bus")
+	}
+
+	subscribed := make(map[string]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		subscribed[eventType] = true
+	}
+	unknown := []string{}
+	for eventType := range config.TopicMapping {
+		if !subscribed[eventType] {
+			unknown = append(unknown, eventType)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, NewTopicMismatchError(fmt.Sprintf("topic mapping given for event types without subscriptions: %s", strings.Join(unknown, ", ")))
+	}
+
+	t := &topics{
+		names:      make([]string, 0, len(eventTypes)),
+		eventTypes: make(map[string]string, len(eventTypes)),
+	}
+	for _, eventType := range eventTypes {
+		name := config.topicName(eventType)
+		if other, ok := t.eventTypes[name]; ok {
+			return nil, NewTopicMismatchError(fmt.Sprintf("%s and %s are both mapped to topic %s", other, eventType, name))
+		}
+		t.names = append(t.names, name)
+		t.eventTypes[name] = eventType
+	}
+	sort.Strings(t.names)
+
+	return t, nil
+}
+
+// Topics returns the topics the event types are consumed from.
+func (c *Config) Topics(eventTypes []string) ([]string, error) {
+	t, err := newTopics(c, eventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return t.names, nil
+}
+
+func (t *topics) eventType(topic string) (string, bool) {
+	eventType, ok := t.eventTypes[topic]
+	return eventType, ok
+}
+
+// topicName returns the topic of a logical name, e.g. with the prefix
+// "staging" UserWasRegisteredEvent turns into staging.UserWasRegisteredEvent.
+func (c *Config) topicName(name string) string {
+	if mapped, ok := c.TopicMapping[name]; ok {
+		name = mapped
+	}
+	prefix := strings.Trim(c.TopicPrefix, ".")
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+type TopicMismatchError struct {
+	detail string
+}
+
+func (e *TopicMismatchError) Error() string {
+	return "Kafka topics don't match the bus subscriptions: " + e.detail
+}
+
+func NewTopicMismatchError(detail string) *TopicMismatchError {
+	return &TopicMismatchError{
+		detail: detail,
 	}
 }
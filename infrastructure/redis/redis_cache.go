@@ -0,0 +1,148 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"readmodels/internal/cache"
+	"sync/atomic"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+const maxConnections = 16
+
+// deleteGroup removes the members of a group set and the set itself in one
+// step, so a key added to the group meanwhile is either removed or kept in it.
+var deleteGroup = goredis.NewScript(`
+local removed = 0
+local keys = redis.call('SMEMBERS', KEYS[1])
+for i = 1, #keys, 500 do
+	removed = removed + redis.call('DEL', unpack(keys, i, math.min(i + 499, #keys)))
+end
+redis.call('DEL', KEYS[1])
+return removed
+`)
+
+// RedisCache is a cache.Cache shared by every instance through a Redis
+// server. A group is a Redis set holding the keys set in it, which expires
+// with the last of them, so deleting a group doesn't scan the keyspace.
+type RedisCache struct {
+	client  *goredis.Client
+	ttl     time.Duration
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+	removed atomic.Uint64
+}
+
+func NewRedisCache(address string, ttl time.Duration) *RedisCache {
+	return &RedisCache{
+		client: goredis.NewClient(&goredis.Options{
+			Addr:         address,
+			PoolSize:     maxConnections,
+			DialTimeout:  2 * time.Second,
+			ReadTimeout:  2 * time.Second,
+			WriteTimeout: 2 * time.Second,
+		}),
+		ttl: ttl,
+	}
+}
+
+func (c *RedisCache) Get(key string, result any) bool {
+	value, err := c.client.Get(context.Background(), key).Bytes()
+	if err != nil {
+		if !errors.Is(err, goredis.Nil) {
+			log.Error().Stack().Err(err).Msgf("Couldn't get key %s from redis", key)
+		}
+		c.misses.Add(1)
+		return false
+	}
+
+	err = json.Unmarshal(value, result)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't unmarshal cached value for key %s", key)
+		c.misses.Add(1)
+		return false
+	}
+
+	c.hits.Add(1)
+	return true
+}
+
+func (c *RedisCache) Set(key string, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't marshal value for key %s", key)
+		return
+	}
+
+	err = c.client.Set(context.Background(), key, data, c.ttl).Err()
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't set key %s in redis", key)
+	}
+}
+
+func (c *RedisCache) SetInGroup(group string, key string, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't marshal value for key %s", key)
+		return
+	}
+
+	ctx := context.Background()
+	_, err = c.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Set(ctx, key, data, c.ttl)
+		pipe.SAdd(ctx, groupKey(group), key)
+		pipe.PExpire(ctx, groupKey(group), c.ttl)
+		return nil
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't set key %s of group %s in redis", key, group)
+	}
+}
+
+func (c *RedisCache) Delete(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	removed, err := c.client.Del(context.Background(), keys...).Result()
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't delete keys %v from redis", keys)
+		return
+	}
+	c.removed.Add(uint64(removed))
+}
+
+func (c *RedisCache) DeleteGroup(group string) {
+	removed, err := deleteGroup.Run(context.Background(), c.client, []string{groupKey(group)}).Int64()
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't delete group %s from redis", group)
+		return
+	}
+	c.removed.Add(uint64(removed))
+}
+
+func (c *RedisCache) Stats() *cache.Stats {
+	entries, err := c.client.DBSize(context.Background()).Result()
+	if err != nil {
+		entries = 0
+	}
+
+	return &cache.Stats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Invalidations: c.removed.Load(),
+		Entries:       int(entries),
+	}
+}
+
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
+
+func groupKey(group string) string {
+	return "group:" + group
+}
@@ -0,0 +1,87 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func setUpRedis(t *testing.T) (*miniredis.Miniredis, *RedisCache) {
+	server := miniredis.RunT(t)
+	redisCache := NewRedisCache(server.Addr(), time.Minute)
+	t.Cleanup(func() { redisCache.Close() })
+	return server, redisCache
+}
+
+func TestSetGetAndDeleteRoundTrip(t *testing.T) {
+	_, redisCache := setUpRedis(t)
+
+	redisCache.Set("key", map[string]string{"name": "user name"})
+	var result map[string]string
+	found := redisCache.Get("key", &result)
+	redisCache.Delete("key")
+	foundAfterDelete := redisCache.Get("key", &result)
+
+	assert.True(t, found)
+	assert.Equal(t, map[string]string{"name": "user name"}, result)
+	assert.False(t, foundAfterDelete)
+	stats := redisCache.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Invalidations)
+	assert.Equal(t, 0, stats.Entries)
+}
+
+func TestSetExpiresAfterTheTTL(t *testing.T) {
+	server, redisCache := setUpRedis(t)
+	redisCache.Set("key", "value")
+
+	server.FastForward(time.Minute)
+
+	var result string
+	assert.False(t, redisCache.Get("key", &result))
+}
+
+func TestDeleteGroupRemovesItsKeysOnly(t *testing.T) {
+	server, redisCache := setUpRedis(t)
+	redisCache.SetInGroup("posts:user1", "posts:user1:a", "value1")
+	redisCache.SetInGroup("posts:user1", "posts:user1:b", "value2")
+	redisCache.SetInGroup("posts:user10", "posts:user10:a", "value3")
+
+	redisCache.DeleteGroup("posts:user1")
+
+	var result string
+	assert.False(t, redisCache.Get("posts:user1:a", &result))
+	assert.False(t, redisCache.Get("posts:user1:b", &result))
+	assert.True(t, redisCache.Get("posts:user10:a", &result))
+	assert.False(t, server.Exists(groupKey("posts:user1")))
+	assert.Equal(t, uint64(2), redisCache.Stats().Invalidations)
+}
+
+func TestGroupExpiresWithItsLastKey(t *testing.T) {
+	server, redisCache := setUpRedis(t)
+	redisCache.SetInGroup("group", "a", "value1")
+	server.FastForward(30 * time.Second)
+	redisCache.SetInGroup("group", "b", "value2")
+
+	server.FastForward(30 * time.Second)
+
+	assert.True(t, server.Exists(groupKey("group")))
+	assert.False(t, server.Exists("a"))
+	server.FastForward(30 * time.Second)
+	assert.False(t, server.Exists(groupKey("group")))
+}
+
+func TestErrorsCountAsMisses(t *testing.T) {
+	server, redisCache := setUpRedis(t)
+	redisCache.Set("key", "value")
+	server.Close()
+
+	var result string
+	found := redisCache.Get("key", &result)
+
+	assert.False(t, found)
+	assert.Equal(t, uint64(1), redisCache.Stats().Misses)
+}
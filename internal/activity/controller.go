@@ -0,0 +1,83 @@
+package activity
+
+import (
+	"readmodels/internal/api"
+	"readmodels/internal/model"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=controller.go -destination=test/mock/controller.go
+
+type ControllerService interface {
+	GetActivities(username string, activityTypes []string, lastActivityId, lastOccurredAt string, limit int) ([]*model.Activity, string, string, error)
+}
+
+type ActivityController struct {
+	service ControllerService
+}
+
+type GetActivitiesResponse struct {
+	Activities     []*model.Activity `json:"activities"`
+	Limit          int               `json:"limit"`
+	LastActivityId string            `json:"lastActivityId"`
+	LastOccurredAt string            `json:"lastOccurredAt"`
+}
+
+func NewActivityController(service ControllerService) *ActivityController {
+	return &ActivityController{
+		service: service,
+	}
+}
+
+func (controller *ActivityController) Routes(routerGroup *gin.RouterGroup) {
+	routerGroup.GET("/users/:username/activity", controller.GetActivities)
+}
+
+// GetActivities accepts a comma separated list of activity types in the
+// types query parameter, every type is returned when it is empty.
+func (controller *ActivityController) GetActivities(c *gin.Context) {
+	log.Info().Msg("Handling Request GET Activities")
+	username := c.Param("username")
+	lastActivityId := c.DefaultQuery("lastActivityId", "")
+	lastOccurredAt := c.DefaultQuery("lastOccurredAt", "")
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "12"))
+
+	if err != nil || limit <= 0 {
+		api.SendBadRequest(c, "Invalid pagination parameters, limit has to be greater than 0")
+		return
+	}
+
+	if (lastActivityId != "" && lastOccurredAt == "") || (lastActivityId == "" && lastOccurredAt != "") {
+		api.SendBadRequest(c, "Invalid pagination parameters, lastActivityId and lastOccurredAt both have to have value or both have to be empty")
+		return
+	}
+
+	activityTypes := []string{}
+	if types := c.DefaultQuery("types", ""); types != "" {
+		activityTypes = strings.Split(types, ",")
+	}
+	for _, activityType := range activityTypes {
+		if !slices.Contains(model.ActivityTypes, activityType) {
+			api.SendBadRequest(c, "Invalid activity type "+activityType+", it has to be one of "+strings.Join(model.ActivityTypes, ", "))
+			return
+		}
+	}
+
+	activities, lastActivityId, lastOccurredAt, err := controller.service.GetActivities(username, activityTypes, lastActivityId, lastOccurredAt, limit)
+	if err != nil {
+		api.SendInternalServerError(c, err.Error())
+		return
+	}
+
+	api.SendOKWithResult(c, &GetActivitiesResponse{
+		Activities:     activities,
+		Limit:          limit,
+		LastActivityId: lastActivityId,
+		LastOccurredAt: lastOccurredAt,
+	})
+}
@@ -0,0 +1,51 @@
+package activity_handler
+
+import (
+	comment_handler "readmodels/internal/comment/handler"
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=comment_was_created_event_handler.go -destination=test/mock/comment_was_created_event_handler.go
+
+type CommentWasCreatedEventService interface {
+	RecordActivity(activity *model.Activity)
+}
+
+type CommentWasCreatedEventHandler struct {
+	service CommentWasCreatedEventService
+}
+
+func NewCommentWasCreatedEventHandler(service CommentWasCreatedEventService) *CommentWasCreatedEventHandler {
+	return &CommentWasCreatedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *CommentWasCreatedEventHandler) Handle(event []byte) {
+	var commentWasCreatedEvent comment_handler.CommentWasCreatedEvent
+	log.Info().Msg("Handling CommentWasCreatedEvent for the activity log")
+
+	err := common_data.DeserializeData(event, &commentWasCreatedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	occurredAt, err := time.Parse(model.TimeLayout, commentWasCreatedEvent.CreatedAt)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error parsing time CreatedAt")
+		return
+	}
+
+	handler.service.RecordActivity(&model.Activity{
+		Username:   commentWasCreatedEvent.Username,
+		Type:       model.ActivityTypeCommented,
+		PostId:     commentWasCreatedEvent.PostId,
+		CommentId:  commentWasCreatedEvent.CommentId,
+		OccurredAt: occurredAt,
+	})
+}
@@ -0,0 +1,37 @@
+package activity_handler
+
+import (
+	comment_handler "readmodels/internal/comment/handler"
+	common_data "readmodels/internal/common/data"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=comment_was_deleted_event_handler.go -destination=test/mock/comment_was_deleted_event_handler.go
+
+type CommentWasDeletedEventService interface {
+	RemoveCommentActivity(postId string, commentId uint64)
+}
+
+type CommentWasDeletedEventHandler struct {
+	service CommentWasDeletedEventService
+}
+
+func NewCommentWasDeletedEventHandler(service CommentWasDeletedEventService) *CommentWasDeletedEventHandler {
+	return &CommentWasDeletedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *CommentWasDeletedEventHandler) Handle(event []byte) {
+	var commentWasDeletedEvent comment_handler.CommentWasDeletedEvent
+	log.Info().Msg("Handling CommentWasDeletedEvent for the activity log")
+
+	err := common_data.DeserializeData(event, &commentWasDeletedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.RemoveCommentActivity(commentWasDeletedEvent.PostId, commentWasDeletedEvent.CommentId)
+}
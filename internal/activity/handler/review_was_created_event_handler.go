@@ -0,0 +1,51 @@
+package activity_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	reaction_handler "readmodels/internal/reaction/handler"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=review_was_created_event_handler.go -destination=test/mock/review_was_created_event_handler.go
+
+type ReviewWasCreatedEventService interface {
+	RecordActivity(activity *model.Activity)
+}
+
+type ReviewWasCreatedEventHandler struct {
+	service ReviewWasCreatedEventService
+}
+
+func NewReviewWasCreatedEventHandler(service ReviewWasCreatedEventService) *ReviewWasCreatedEventHandler {
+	return &ReviewWasCreatedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *ReviewWasCreatedEventHandler) Handle(event []byte) {
+	var reviewWasCreatedEvent reaction_handler.ReviewWasCreatedEvent
+	log.Info().Msg("Handling ReviewWasCreatedEvent for the activity log")
+
+	err := common_data.DeserializeData(event, &reviewWasCreatedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	occurredAt, err := time.Parse(model.TimeLayout, reviewWasCreatedEvent.CreatedAt)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error parsing time CreatedAt")
+		return
+	}
+
+	handler.service.RecordActivity(&model.Activity{
+		Username:   reviewWasCreatedEvent.Username,
+		Type:       model.ActivityTypeReviewed,
+		PostId:     reviewWasCreatedEvent.PostId,
+		ReviewId:   reviewWasCreatedEvent.ReviewId,
+		OccurredAt: occurredAt,
+	})
+}
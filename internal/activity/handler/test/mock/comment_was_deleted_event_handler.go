@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment_was_deleted_event_handler.go
+
+// Package mock_activity_handler is a generated GoMock package.
+package mock_activity_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCommentWasDeletedEventService is a mock of CommentWasDeletedEventService interface.
+type MockCommentWasDeletedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentWasDeletedEventServiceMockRecorder
+}
+
+// MockCommentWasDeletedEventServiceMockRecorder is the mock recorder for MockCommentWasDeletedEventService.
+type MockCommentWasDeletedEventServiceMockRecorder struct {
+	mock *MockCommentWasDeletedEventService
+}
+
+// NewMockCommentWasDeletedEventService creates a new mock instance.
+func NewMockCommentWasDeletedEventService(ctrl *gomock.Controller) *MockCommentWasDeletedEventService {
+	mock := &MockCommentWasDeletedEventService{ctrl: ctrl}
+	mock.recorder = &MockCommentWasDeletedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentWasDeletedEventService) EXPECT() *MockCommentWasDeletedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// RemoveCommentActivity mocks base method.
+func (m *MockCommentWasDeletedEventService) RemoveCommentActivity(postId string, commentId uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveCommentActivity", postId, commentId)
+}
+
+// RemoveCommentActivity indicates an expected call of RemoveCommentActivity.
+func (mr *MockCommentWasDeletedEventServiceMockRecorder) RemoveCommentActivity(postId, commentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCommentActivity", reflect.TypeOf((*MockCommentWasDeletedEventService)(nil).RemoveCommentActivity), postId, commentId)
+}
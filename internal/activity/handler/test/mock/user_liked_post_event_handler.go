@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_liked_post_event_handler.go
+
+// Package mock_activity_handler is a generated GoMock package.
+package mock_activity_handler
+
+import (
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserLikedPostEventService is a mock of UserLikedPostEventService interface.
+type MockUserLikedPostEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserLikedPostEventServiceMockRecorder
+}
+
+// MockUserLikedPostEventServiceMockRecorder is the mock recorder for MockUserLikedPostEventService.
+type MockUserLikedPostEventServiceMockRecorder struct {
+	mock *MockUserLikedPostEventService
+}
+
+// NewMockUserLikedPostEventService creates a new mock instance.
+func NewMockUserLikedPostEventService(ctrl *gomock.Controller) *MockUserLikedPostEventService {
+	mock := &MockUserLikedPostEventService{ctrl: ctrl}
+	mock.recorder = &MockUserLikedPostEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserLikedPostEventService) EXPECT() *MockUserLikedPostEventServiceMockRecorder {
+	return m.recorder
+}
+
+// RecordActivity mocks base method.
+func (m *MockUserLikedPostEventService) RecordActivity(activity *model.Activity) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordActivity", activity)
+}
+
+// RecordActivity indicates an expected call of RecordActivity.
+func (mr *MockUserLikedPostEventServiceMockRecorder) RecordActivity(activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordActivity", reflect.TypeOf((*MockUserLikedPostEventService)(nil).RecordActivity), activity)
+}
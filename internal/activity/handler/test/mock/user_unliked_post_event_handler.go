@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_unliked_post_event_handler.go
+
+// Package mock_activity_handler is a generated GoMock package.
+package mock_activity_handler
+
+import (
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserUnlikedPostEventService is a mock of UserUnlikedPostEventService interface.
+type MockUserUnlikedPostEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserUnlikedPostEventServiceMockRecorder
+}
+
+// MockUserUnlikedPostEventServiceMockRecorder is the mock recorder for MockUserUnlikedPostEventService.
+type MockUserUnlikedPostEventServiceMockRecorder struct {
+	mock *MockUserUnlikedPostEventService
+}
+
+// NewMockUserUnlikedPostEventService creates a new mock instance.
+func NewMockUserUnlikedPostEventService(ctrl *gomock.Controller) *MockUserUnlikedPostEventService {
+	mock := &MockUserUnlikedPostEventService{ctrl: ctrl}
+	mock.recorder = &MockUserUnlikedPostEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserUnlikedPostEventService) EXPECT() *MockUserUnlikedPostEventServiceMockRecorder {
+	return m.recorder
+}
+
+// RemoveActivity mocks base method.
+func (m *MockUserUnlikedPostEventService) RemoveActivity(activity *model.Activity) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveActivity", activity)
+}
+
+// RemoveActivity indicates an expected call of RemoveActivity.
+func (mr *MockUserUnlikedPostEventServiceMockRecorder) RemoveActivity(activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveActivity", reflect.TypeOf((*MockUserUnlikedPostEventService)(nil).RemoveActivity), activity)
+}
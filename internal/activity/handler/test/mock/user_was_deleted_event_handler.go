@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_was_deleted_event_handler.go
+
+// Package mock_activity_handler is a generated GoMock package.
+package mock_activity_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserWasDeletedEventService is a mock of UserWasDeletedEventService interface.
+type MockUserWasDeletedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserWasDeletedEventServiceMockRecorder
+}
+
+// MockUserWasDeletedEventServiceMockRecorder is the mock recorder for MockUserWasDeletedEventService.
+type MockUserWasDeletedEventServiceMockRecorder struct {
+	mock *MockUserWasDeletedEventService
+}
+
+// NewMockUserWasDeletedEventService creates a new mock instance.
+func NewMockUserWasDeletedEventService(ctrl *gomock.Controller) *MockUserWasDeletedEventService {
+	mock := &MockUserWasDeletedEventService{ctrl: ctrl}
+	mock.recorder = &MockUserWasDeletedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserWasDeletedEventService) EXPECT() *MockUserWasDeletedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// RemoveUserActivity mocks base method.
+func (m *MockUserWasDeletedEventService) RemoveUserActivity(username string, followerIds []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveUserActivity", username, followerIds)
+}
+
+// RemoveUserActivity indicates an expected call of RemoveUserActivity.
+func (mr *MockUserWasDeletedEventServiceMockRecorder) RemoveUserActivity(username, followerIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserActivity", reflect.TypeOf((*MockUserWasDeletedEventService)(nil).RemoveUserActivity), username, followerIds)
+}
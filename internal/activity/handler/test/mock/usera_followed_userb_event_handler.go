@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usera_followed_userb_event_handler.go
+
+// Package mock_activity_handler is a generated GoMock package.
+package mock_activity_handler
+
+import (
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserAFollowedUserBEventService is a mock of UserAFollowedUserBEventService interface.
+type MockUserAFollowedUserBEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserAFollowedUserBEventServiceMockRecorder
+}
+
+// MockUserAFollowedUserBEventServiceMockRecorder is the mock recorder for MockUserAFollowedUserBEventService.
+type MockUserAFollowedUserBEventServiceMockRecorder struct {
+	mock *MockUserAFollowedUserBEventService
+}
+
+// NewMockUserAFollowedUserBEventService creates a new mock instance.
+func NewMockUserAFollowedUserBEventService(ctrl *gomock.Controller) *MockUserAFollowedUserBEventService {
+	mock := &MockUserAFollowedUserBEventService{ctrl: ctrl}
+	mock.recorder = &MockUserAFollowedUserBEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserAFollowedUserBEventService) EXPECT() *MockUserAFollowedUserBEventServiceMockRecorder {
+	return m.recorder
+}
+
+// RecordActivity mocks base method.
+func (m *MockUserAFollowedUserBEventService) RecordActivity(activity *model.Activity) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordActivity", activity)
+}
+
+// RecordActivity indicates an expected call of RecordActivity.
+func (mr *MockUserAFollowedUserBEventServiceMockRecorder) RecordActivity(activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordActivity", reflect.TypeOf((*MockUserAFollowedUserBEventService)(nil).RecordActivity), activity)
+}
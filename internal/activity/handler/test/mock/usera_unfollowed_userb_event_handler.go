@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usera_unfollowed_userb_event_handler.go
+
+// Package mock_activity_handler is a generated GoMock package.
+package mock_activity_handler
+
+import (
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserAUnfollowedUserBEventService is a mock of UserAUnfollowedUserBEventService interface.
+type MockUserAUnfollowedUserBEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserAUnfollowedUserBEventServiceMockRecorder
+}
+
+// MockUserAUnfollowedUserBEventServiceMockRecorder is the mock recorder for MockUserAUnfollowedUserBEventService.
+type MockUserAUnfollowedUserBEventServiceMockRecorder struct {
+	mock *MockUserAUnfollowedUserBEventService
+}
+
+// NewMockUserAUnfollowedUserBEventService creates a new mock instance.
+func NewMockUserAUnfollowedUserBEventService(ctrl *gomock.Controller) *MockUserAUnfollowedUserBEventService {
+	mock := &MockUserAUnfollowedUserBEventService{ctrl: ctrl}
+	mock.recorder = &MockUserAUnfollowedUserBEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserAUnfollowedUserBEventService) EXPECT() *MockUserAUnfollowedUserBEventServiceMockRecorder {
+	return m.recorder
+}
+
+// RemoveActivity mocks base method.
+func (m *MockUserAUnfollowedUserBEventService) RemoveActivity(activity *model.Activity) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveActivity", activity)
+}
+
+// RemoveActivity indicates an expected call of RemoveActivity.
+func (mr *MockUserAUnfollowedUserBEventServiceMockRecorder) RemoveActivity(activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveActivity", reflect.TypeOf((*MockUserAUnfollowedUserBEventService)(nil).RemoveActivity), activity)
+}
@@ -0,0 +1,42 @@
+package activity_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	reaction_handler "readmodels/internal/reaction/handler"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=user_superliked_post_event_handler.go -destination=test/mock/user_superliked_post_event_handler.go
+
+type UserSuperlikedPostEventService interface {
+	RecordActivity(activity *model.Activity)
+}
+
+type UserSuperlikedPostEventHandler struct {
+	service UserSuperlikedPostEventService
+}
+
+func NewUserSuperlikedPostEventHandler(service UserSuperlikedPostEventService) *UserSuperlikedPostEventHandler {
+	return &UserSuperlikedPostEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserSuperlikedPostEventHandler) Handle(event []byte) {
+	var userSuperlikedPostEvent reaction_handler.UserSuperlikedPostEvent
+	log.Info().Msg("Handling UserSuperlikedPostEvent for the activity log")
+
+	err := common_data.DeserializeData(event, &userSuperlikedPostEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.RecordActivity(&model.Activity{
+		Username: userSuperlikedPostEvent.Username,
+		Type:     model.ActivityTypeSuperliked,
+		PostId:   userSuperlikedPostEvent.PostId,
+	})
+}
@@ -0,0 +1,42 @@
+package activity_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	reaction_handler "readmodels/internal/reaction/handler"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=user_unliked_post_event_handler.go -destination=test/mock/user_unliked_post_event_handler.go
+
+type UserUnlikedPostEventService interface {
+	RemoveActivity(activity *model.Activity)
+}
+
+type UserUnlikedPostEventHandler struct {
+	service UserUnlikedPostEventService
+}
+
+func NewUserUnlikedPostEventHandler(service UserUnlikedPostEventService) *UserUnlikedPostEventHandler {
+	return &UserUnlikedPostEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserUnlikedPostEventHandler) Handle(event []byte) {
+	var userUnlikedPostEvent reaction_handler.UserUnlikedPostEvent
+	log.Info().Msg("Handling UserUnlikedPostEvent for the activity log")
+
+	err := common_data.DeserializeData(event, &userUnlikedPostEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.RemoveActivity(&model.Activity{
+		Username: userUnlikedPostEvent.Username,
+		Type:     model.ActivityTypeLiked,
+		PostId:   userUnlikedPostEvent.PostId,
+	})
+}
@@ -0,0 +1,42 @@
+package activity_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	userprofile_handler "readmodels/internal/userprofile/handlers"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=usera_followed_userb_event_handler.go -destination=test/mock/usera_followed_userb_event_handler.go
+
+type UserAFollowedUserBEventService interface {
+	RecordActivity(activity *model.Activity)
+}
+
+type UserAFollowedUserBEventHandler struct {
+	service UserAFollowedUserBEventService
+}
+
+func NewUserAFollowedUserBEventHandler(service UserAFollowedUserBEventService) *UserAFollowedUserBEventHandler {
+	return &UserAFollowedUserBEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserAFollowedUserBEventHandler) Handle(event []byte) {
+	var userAFollowedUserBEvent userprofile_handler.UserAFollowedUserBEvent
+	log.Info().Msg("Handling UserAFollowedUserBEvent for the activity log")
+
+	err := common_data.DeserializeData(event, &userAFollowedUserBEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.RecordActivity(&model.Activity{
+		Username: userAFollowedUserBEvent.FollowerID,
+		Type:     model.ActivityTypeFollowed,
+		Followee: userAFollowedUserBEvent.FolloweeID,
+	})
+}
@@ -0,0 +1,42 @@
+package activity_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	userprofile_handler "readmodels/internal/userprofile/handlers"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=usera_unfollowed_userb_event_handler.go -destination=test/mock/usera_unfollowed_userb_event_handler.go
+
+type UserAUnfollowedUserBEventService interface {
+	RemoveActivity(activity *model.Activity)
+}
+
+type UserAUnfollowedUserBEventHandler struct {
+	service UserAUnfollowedUserBEventService
+}
+
+func NewUserAUnfollowedUserBEventHandler(service UserAUnfollowedUserBEventService) *UserAUnfollowedUserBEventHandler {
+	return &UserAUnfollowedUserBEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserAUnfollowedUserBEventHandler) Handle(event []byte) {
+	var userAUnfollowedUserBEvent userprofile_handler.UserAUnfollowedUserBEvent
+	log.Info().Msg("Handling UserAUnfollowedUserBEvent for the activity log")
+
+	err := common_data.DeserializeData(event, &userAUnfollowedUserBEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.RemoveActivity(&model.Activity{
+		Username: userAUnfollowedUserBEvent.FollowerID,
+		Type:     model.ActivityTypeFollowed,
+		Followee: userAUnfollowedUserBEvent.FolloweeID,
+	})
+}
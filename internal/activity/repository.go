@@ -0,0 +1,103 @@
+package activity
+
+import (
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"time"
+)
+
+// Every activity of a user is read by pages when the user is deleted.
+const activitiesPageSize = 100
+
+type ActivityRepository struct {
+	database *database.Database
+}
+
+func NewActivityRepository(database *database.Database) *ActivityRepository {
+	return &ActivityRepository{
+		database: database,
+	}
+}
+
+// AddActivity stores the activity, replacing the one with the same id when
+// the event is consumed again.
+func (r *ActivityRepository) AddActivity(activity *model.Activity) error {
+	return r.database.Client.InsertData(database.ActivityTable, &database.ActivityRecord{
+		Username:   activity.Username,
+		ActivityId: activity.ActivityId,
+		Type:       activity.Type,
+		PostId:     activity.PostId,
+		CommentId:  activity.CommentId,
+		ReviewId:   activity.ReviewId,
+		Followee:   activity.Followee,
+		OccurredAt: activity.OccurredAt.UTC().Format(model.TimeLayout),
+	})
+}
+
+func (r *ActivityRepository) RemoveActivities(activities []*model.Activity) error {
+	keys := make([]any, len(activities))
+	for i, activity := range activities {
+		keys[i] = &database.ActivityKey{
+			Username:   activity.Username,
+			ActivityId: activity.ActivityId,
+		}
+	}
+
+	return r.database.Client.RemoveMultipleData(database.ActivityTable, keys)
+}
+
+// GetPostActivities returns the activities of every user about the post.
+func (r *ActivityRepository) GetPostActivities(postId string) ([]*model.Activity, error) {
+	records := []*database.ActivityRecord{}
+	err := r.database.Client.GetAllDataByIndex(database.ActivityTable, database.ActivityPostIndex, "PostId", postId, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapActivityRecords(records), nil
+}
+
+// GetUserActivities returns every activity of the user.
+func (r *ActivityRepository) GetUserActivities(username string) ([]*model.Activity, error) {
+	activities := []*model.Activity{}
+	lastActivityId, lastOccurredAt := "", ""
+	for {
+		page, nextActivityId, nextOccurredAt, err := r.GetActivities(username, nil, lastActivityId, lastOccurredAt, activitiesPageSize)
+		if err != nil {
+			return nil, err
+		}
+		activities = append(activities, page...)
+
+		if nextActivityId == "" {
+			return activities, nil
+		}
+		lastActivityId, lastOccurredAt = nextActivityId, nextOccurredAt
+	}
+}
+
+func (r *ActivityRepository) GetActivities(username string, activityTypes []string, lastActivityId, lastOccurredAt string, limit int) ([]*model.Activity, string, string, error) {
+	records, lastActivityId, lastOccurredAt, err := r.database.Client.GetActivitiesByIndexUsername(username, activityTypes, lastActivityId, lastOccurredAt, limit)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return mapActivityRecords(records), lastActivityId, lastOccurredAt, nil
+}
+
+func mapActivityRecords(records []*database.ActivityRecord) []*model.Activity {
+	activities := make([]*model.Activity, len(records))
+	for i, record := range records {
+		occurredAt, _ := time.Parse(model.TimeLayout, record.OccurredAt)
+		activities[i] = &model.Activity{
+			ActivityId: record.ActivityId,
+			Username:   record.Username,
+			Type:       record.Type,
+			PostId:     record.PostId,
+			CommentId:  record.CommentId,
+			ReviewId:   record.ReviewId,
+			Followee:   record.Followee,
+			OccurredAt: occurredAt,
+		}
+	}
+	return activities
+}
@@ -0,0 +1,164 @@
+package activity
+
+import (
+	"fmt"
+	"readmodels/internal/model"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=service.go -destination=test/mock/service.go
+
+type Repository interface {
+	AddActivity(activity *model.Activity) error
+	RemoveActivities(activities []*model.Activity) error
+	GetPostActivities(postId string) ([]*model.Activity, error)
+	GetUserActivities(username string) ([]*model.Activity, error)
+	GetActivities(username string, activityTypes []string, lastActivityId, lastOccurredAt string, limit int) ([]*model.Activity, string, string, error)
+}
+
+type ActivityService struct {
+	repository Repository
+	now        func() time.Time
+}
+
+func NewActivityService(repository Repository) *ActivityService {
+	return &ActivityService{
+		repository: repository,
+		now:        time.Now,
+	}
+}
+
+// RecordActivity adds the activity to the log of its user. Events without a
+// timestamp, such as likes and follows, are recorded at the time they are
+// handled.
+func (s *ActivityService) RecordActivity(activity *model.Activity) {
+	activity.ActivityId = activityId(activity)
+	if activity.OccurredAt.IsZero() {
+		activity.OccurredAt = s.now()
+	}
+
+	err := s.repository.AddActivity(activity)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error recording activity %s of %s", activity.ActivityId, activity.Username)
+		return
+	}
+
+	log.Info().Msgf("Activity %s of %s was recorded", activity.ActivityId, activity.Username)
+}
+
+// RemoveActivity removes an activity that was undone, e.g. an unlike.
+func (s *ActivityService) RemoveActivity(activity *model.Activity) {
+	activity.ActivityId = activityId(activity)
+	s.removeActivities(activity.Username, []*model.Activity{activity})
+}
+
+// RemoveCommentActivity removes the deleted comment, whose event doesn't
+// carry its author, from the activity log.
+func (s *ActivityService) RemoveCommentActivity(postId string, commentId uint64) {
+	activities, err := s.repository.GetPostActivities(postId)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting the activities of post %s", postId)
+		return
+	}
+
+	commentActivityId := activityId(&model.Activity{Type: model.ActivityTypeCommented, CommentId: commentId})
+	for _, activity := range activities {
+		if activity.ActivityId == commentActivityId {
+			s.removeActivities(activity.Username, []*model.Activity{activity})
+		}
+	}
+}
+
+// RemovePostsActivity removes every activity about the deleted posts, from
+// the activity logs of all users.
+func (s *ActivityService) RemovePostsActivity(username string, postIds []string) {
+	activities := []*model.Activity{}
+	for _, postId := range postIds {
+		postActivities, err := s.repository.GetPostActivities(postId)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Error getting the activities of post %s", postId)
+			return
+		}
+		activities = append(activities, postActivities...)
+	}
+
+	s.removeActivities(username, activities)
+}
+
+// RemoveUserActivity removes the activity log of the deleted user, the
+// activities of others about the posts of the user, and the follows of the
+// user from the logs of the followers.
+func (s *ActivityService) RemoveUserActivity(username string, followerIds []string) {
+	activities, err := s.repository.GetUserActivities(username)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting the activities of %s", username)
+		return
+	}
+
+	removed := activities
+	for _, activity := range activities {
+		if activity.Type != model.ActivityTypePosted {
+			continue
+		}
+		postActivities, err := s.repository.GetPostActivities(activity.PostId)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Error getting the activities of post %s", activity.PostId)
+			return
+		}
+		for _, postActivity := range postActivities {
+			if postActivity.Username != username {
+				removed = append(removed, postActivity)
+			}
+		}
+	}
+
+	for _, followerId := range followerIds {
+		follow := &model.Activity{
+			Username: followerId,
+			Type:     model.ActivityTypeFollowed,
+			Followee: username,
+		}
+		follow.ActivityId = activityId(follow)
+		removed = append(removed, follow)
+	}
+
+	s.removeActivities(username, removed)
+}
+
+func (s *ActivityService) GetActivities(username string, activityTypes []string, lastActivityId, lastOccurredAt string, limit int) ([]*model.Activity, string, string, error) {
+	activities, lastActivityId, lastOccurredAt, err := s.repository.GetActivities(username, activityTypes, lastActivityId, lastOccurredAt, limit)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting the activities of %s", username)
+		return nil, "", "", err
+	}
+
+	return activities, lastActivityId, lastOccurredAt, nil
+}
+
+func (s *ActivityService) removeActivities(username string, activities []*model.Activity) {
+	err := s.repository.RemoveActivities(activities)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error removing %d activities related to %s", len(activities), username)
+		return
+	}
+
+	log.Info().Msgf("%d activities related to %s were removed", len(activities), username)
+}
+
+// activityId identifies the activity by its subject, so that the activity
+// can be found again when it is undone.
+func activityId(activity *model.Activity) string {
+	subject := activity.PostId
+	switch activity.Type {
+	case model.ActivityTypeCommented:
+		subject = strconv.FormatUint(activity.CommentId, 10)
+	case model.ActivityTypeReviewed:
+		subject = strconv.FormatUint(activity.ReviewId, 10)
+	case model.ActivityTypeFollowed:
+		subject = activity.Followee
+	}
+	return fmt.Sprintf("%s#%s", activity.Type, subject)
+}
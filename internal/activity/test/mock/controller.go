@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller.go
+
+// Package mock_activity is a generated GoMock package.
+package mock_activity
+
+import (
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockControllerService is a mock of ControllerService interface.
+type MockControllerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockControllerServiceMockRecorder
+}
+
+// MockControllerServiceMockRecorder is the mock recorder for MockControllerService.
+type MockControllerServiceMockRecorder struct {
+	mock *MockControllerService
+}
+
+// NewMockControllerService creates a new mock instance.
+func NewMockControllerService(ctrl *gomock.Controller) *MockControllerService {
+	mock := &MockControllerService{ctrl: ctrl}
+	mock.recorder = &MockControllerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockControllerService) EXPECT() *MockControllerServiceMockRecorder {
+	return m.recorder
+}
+
+// GetActivities mocks base method.
+func (m *MockControllerService) GetActivities(username string, activityTypes []string, lastActivityId, lastOccurredAt string, limit int) ([]*model.Activity, string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivities", username, activityTypes, lastActivityId, lastOccurredAt, limit)
+	ret0, _ := ret[0].([]*model.Activity)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetActivities indicates an expected call of GetActivities.
+func (mr *MockControllerServiceMockRecorder) GetActivities(username, activityTypes, lastActivityId, lastOccurredAt, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivities", reflect.TypeOf((*MockControllerService)(nil).GetActivities), username, activityTypes, lastActivityId, lastOccurredAt, limit)
+}
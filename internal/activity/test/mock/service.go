@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_activity is a generated GoMock package.
+package mock_activity
+
+import (
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddActivity mocks base method.
+func (m *MockRepository) AddActivity(activity *model.Activity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddActivity", activity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddActivity indicates an expected call of AddActivity.
+func (mr *MockRepositoryMockRecorder) AddActivity(activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActivity", reflect.TypeOf((*MockRepository)(nil).AddActivity), activity)
+}
+
+// GetActivities mocks base method.
+func (m *MockRepository) GetActivities(username string, activityTypes []string, lastActivityId, lastOccurredAt string, limit int) ([]*model.Activity, string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivities", username, activityTypes, lastActivityId, lastOccurredAt, limit)
+	ret0, _ := ret[0].([]*model.Activity)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetActivities indicates an expected call of GetActivities.
+func (mr *MockRepositoryMockRecorder) GetActivities(username, activityTypes, lastActivityId, lastOccurredAt, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivities", reflect.TypeOf((*MockRepository)(nil).GetActivities), username, activityTypes, lastActivityId, lastOccurredAt, limit)
+}
+
+// GetPostActivities mocks base method.
+func (m *MockRepository) GetPostActivities(postId string) ([]*model.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostActivities", postId)
+	ret0, _ := ret[0].([]*model.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostActivities indicates an expected call of GetPostActivities.
+func (mr *MockRepositoryMockRecorder) GetPostActivities(postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostActivities", reflect.TypeOf((*MockRepository)(nil).GetPostActivities), postId)
+}
+
+// GetUserActivities mocks base method.
+func (m *MockRepository) GetUserActivities(username string) ([]*model.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserActivities", username)
+	ret0, _ := ret[0].([]*model.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserActivities indicates an expected call of GetUserActivities.
+func (mr *MockRepositoryMockRecorder) GetUserActivities(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserActivities", reflect.TypeOf((*MockRepository)(nil).GetUserActivities), username)
+}
+
+// RemoveActivities mocks base method.
+func (m *MockRepository) RemoveActivities(activities []*model.Activity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveActivities", activities)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveActivities indicates an expected call of RemoveActivities.
+func (mr *MockRepositoryMockRecorder) RemoveActivities(activities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveActivities", reflect.TypeOf((*MockRepository)(nil).RemoveActivities), activities)
+}
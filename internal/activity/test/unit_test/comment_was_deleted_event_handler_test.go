@@ -0,0 +1,30 @@
+package activity_test
+
+import (
+	"encoding/json"
+	activity_handler "readmodels/internal/activity/handler"
+	mock_activity_handler "readmodels/internal/activity/handler/test/mock"
+	comment_handler "readmodels/internal/comment/handler"
+	"testing"
+)
+
+var commentWasDeletedEventHandler *activity_handler.CommentWasDeletedEventHandler
+var commentWasDeletedEventService *mock_activity_handler.MockCommentWasDeletedEventService
+
+func setUpCommentWasDeletedEventHandler(t *testing.T) {
+	SetUp(t)
+	commentWasDeletedEventService = mock_activity_handler.NewMockCommentWasDeletedEventService(ctrl)
+	commentWasDeletedEventHandler = activity_handler.NewCommentWasDeletedEventHandler(commentWasDeletedEventService)
+}
+
+func TestHandleCommentWasDeletedEventForActivity(t *testing.T) {
+	setUpCommentWasDeletedEventHandler(t)
+	data := &comment_handler.CommentWasDeletedEvent{
+		PostId:    "post1",
+		CommentId: uint64(42),
+	}
+	event, _ := json.Marshal(data)
+	commentWasDeletedEventService.EXPECT().RemoveCommentActivity("post1", uint64(42))
+
+	commentWasDeletedEventHandler.Handle(event)
+}
@@ -0,0 +1,108 @@
+package activity_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"readmodels/internal/activity"
+	mock_activity "readmodels/internal/activity/test/mock"
+	"readmodels/internal/model"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+)
+
+var controller *activity.ActivityController
+var controllerService *mock_activity.MockControllerService
+
+func setUpController(t *testing.T) {
+	SetUp(t)
+	controllerService = mock_activity.NewMockControllerService(ctrl)
+	controller = activity.NewActivityController(controllerService)
+}
+
+func TestGetActivitiesWithController_WhenSuccess(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/users/user1/activity", nil)
+	ginContext.Params = []gin.Param{{Key: "username", Value: "user1"}}
+	u := url.Values{}
+	u.Add("types", "liked,commented")
+	u.Add("lastActivityId", "liked#post9")
+	u.Add("lastOccurredAt", "2024-05-01T10:00:00.000000Z")
+	u.Add("limit", "4")
+	ginContext.Request.URL.RawQuery = u.Encode()
+	expectedActivities := []*model.Activity{
+		{
+			ActivityId: "commented#42",
+			Username:   "user1",
+			Type:       model.ActivityTypeCommented,
+			PostId:     "post1",
+			CommentId:  uint64(42),
+			OccurredAt: time.Date(2024, 4, 30, 9, 0, 0, 123456000, time.UTC),
+		},
+	}
+	controllerService.EXPECT().GetActivities("user1", []string{"liked", "commented"}, "liked#post9", "2024-05-01T10:00:00.000000Z", 4).Return(expectedActivities, "", "", nil)
+	expectedBodyResponse := `{
+		"error": false,
+		"message": "200 OK",
+		"content": {
+			"activities": [
+				{
+					"activityId": "commented#42",
+					"username": "user1",
+					"type": "commented",
+					"postId": "post1",
+					"commentId": 42,
+					"occurredAt": "2024-04-30T09:00:00.123456Z"
+				}
+			],
+			"limit": 4,
+			"lastActivityId": "",
+			"lastOccurredAt": ""
+		}
+	}`
+
+	controller.GetActivities(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 200)
+	assert.Equal(t, removeSpace(apiResponse.Body.String()), removeSpace(expectedBodyResponse))
+}
+
+func TestBadRequestOnGetActivitiesWithController_WhenTypeIsUnknown(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/users/user1/activity", nil)
+	ginContext.Params = []gin.Param{{Key: "username", Value: "user1"}}
+	u := url.Values{}
+	u.Add("types", "liked,shared")
+	ginContext.Request.URL.RawQuery = u.Encode()
+
+	controller.GetActivities(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 400)
+}
+
+func TestBadRequestOnGetActivitiesWithController_WhenOnlyOneCursorIsSet(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/users/user1/activity", nil)
+	ginContext.Params = []gin.Param{{Key: "username", Value: "user1"}}
+	u := url.Values{}
+	u.Add("lastOccurredAt", "2024-05-01T10:00:00.000000Z")
+	ginContext.Request.URL.RawQuery = u.Encode()
+
+	controller.GetActivities(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 400)
+}
+
+func TestInternalServerErrorOnGetActivitiesWithController(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/users/user1/activity", nil)
+	ginContext.Params = []gin.Param{{Key: "username", Value: "user1"}}
+	controllerService.EXPECT().GetActivities("user1", []string{}, "", "", 12).Return(nil, "", "", errors.New("some error"))
+
+	controller.GetActivities(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 500)
+}
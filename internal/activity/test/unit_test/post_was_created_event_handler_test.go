@@ -0,0 +1,63 @@
+package activity_test
+
+import (
+	"encoding/json"
+	activity_handler "readmodels/internal/activity/handler"
+	mock_activity_handler "readmodels/internal/activity/handler/test/mock"
+	"readmodels/internal/model"
+	post_handler "readmodels/internal/post/handler"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var postWasCreatedEventHandler *activity_handler.PostWasCreatedEventHandler
+var postWasCreatedEventService *mock_activity_handler.MockPostWasCreatedEventService
+
+func setUpPostWasCreatedEventHandler(t *testing.T) {
+	SetUp(t)
+	postWasCreatedEventService = mock_activity_handler.NewMockPostWasCreatedEventService(ctrl)
+	postWasCreatedEventHandler = activity_handler.NewPostWasCreatedEventHandler(postWasCreatedEventService)
+}
+
+func TestHandlePostWasCreatedEventForActivity(t *testing.T) {
+	setUpPostWasCreatedEventHandler(t)
+	data := &post_handler.PostWasCreatedEvent{
+		PostId: "post1",
+		Metadata: post_handler.Metadata{
+			Username:    "user1",
+			Type:        "TEXT",
+			Title:       "Exemplo de título",
+			Description: "Exemplo de descripción",
+			CreatedAt:   "2024-05-01T10:00:00.000000Z",
+			LastUpdated: "2024-05-01T10:00:00.000000Z",
+		},
+	}
+	event, _ := json.Marshal(data)
+	expectedActivity := &model.Activity{
+		Username:   "user1",
+		Type:       model.ActivityTypePosted,
+		PostId:     "post1",
+		OccurredAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+	postWasCreatedEventService.EXPECT().RecordActivity(expectedActivity)
+
+	postWasCreatedEventHandler.Handle(event)
+}
+
+func TestInvalidCreatedAtInPostWasCreatedEventHandlerForActivity(t *testing.T) {
+	setUpPostWasCreatedEventHandler(t)
+	data := &post_handler.PostWasCreatedEvent{
+		PostId: "post1",
+		Metadata: post_handler.Metadata{
+			Username:  "user1",
+			CreatedAt: "yesterday",
+		},
+	}
+	event, _ := json.Marshal(data)
+
+	postWasCreatedEventHandler.Handle(event)
+
+	assert.Contains(t, loggerOutput.String(), "Error parsing time CreatedAt")
+}
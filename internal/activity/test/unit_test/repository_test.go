@@ -0,0 +1,115 @@
+package activity_test
+
+import (
+	"readmodels/internal/activity"
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var activityRepository *activity.ActivityRepository
+
+func setUpRepository(t *testing.T) {
+	SetUp(t)
+	activityRepository = activity.NewActivityRepository(database.NewDatabase(client))
+}
+
+func TestAddActivityInRepository(t *testing.T) {
+	setUpRepository(t)
+	data := &model.Activity{
+		ActivityId: "reviewed#7",
+		Username:   "user1",
+		Type:       model.ActivityTypeReviewed,
+		PostId:     "post1",
+		ReviewId:   uint64(7),
+		OccurredAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+	expectedRecord := &database.ActivityRecord{
+		Username:   "user1",
+		ActivityId: "reviewed#7",
+		Type:       model.ActivityTypeReviewed,
+		PostId:     "post1",
+		ReviewId:   uint64(7),
+		OccurredAt: "2024-05-01T10:00:00.000000Z",
+	}
+	client.EXPECT().InsertData("readmodels.activity", expectedRecord).Return(nil)
+
+	err := activityRepository.AddActivity(data)
+
+	assert.Nil(t, err)
+}
+
+func TestRemoveActivitiesInRepository(t *testing.T) {
+	setUpRepository(t)
+	data := []*model.Activity{
+		{ActivityId: "posted#post1", Username: "user1"},
+		{ActivityId: "liked#post1", Username: "user2"},
+	}
+	expectedKeys := []any{
+		&database.ActivityKey{Username: "user1", ActivityId: "posted#post1"},
+		&database.ActivityKey{Username: "user2", ActivityId: "liked#post1"},
+	}
+	client.EXPECT().RemoveMultipleData("readmodels.activity", expectedKeys).Return(nil)
+
+	err := activityRepository.RemoveActivities(data)
+
+	assert.Nil(t, err)
+}
+
+func TestGetPostActivitiesInRepository(t *testing.T) {
+	setUpRepository(t)
+	records := []*database.ActivityRecord{
+		{Username: "user2", ActivityId: "commented#42", Type: model.ActivityTypeCommented, PostId: "post1", CommentId: uint64(42), OccurredAt: "2024-05-01T10:00:00.000000Z"},
+	}
+	expectedActivities := []*model.Activity{
+		{ActivityId: "commented#42", Username: "user2", Type: model.ActivityTypeCommented, PostId: "post1", CommentId: uint64(42), OccurredAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	client.EXPECT().GetAllDataByIndex("readmodels.activity", "PostIndex", "PostId", "post1", &[]*database.ActivityRecord{}).SetArg(4, records).Return(nil)
+
+	activities, err := activityRepository.GetPostActivities("post1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedActivities, activities)
+}
+
+func TestGetActivitiesInRepository(t *testing.T) {
+	setUpRepository(t)
+	records := []*database.ActivityRecord{
+		{Username: "user1", ActivityId: "followed#user2", Type: model.ActivityTypeFollowed, Followee: "user2", OccurredAt: "2024-05-01T10:00:00.000000Z"},
+	}
+	expectedActivities := []*model.Activity{
+		{ActivityId: "followed#user2", Username: "user1", Type: model.ActivityTypeFollowed, Followee: "user2", OccurredAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	client.EXPECT().GetActivitiesByIndexUsername("user1", []string{}, "", "", 5).Return(records, "followed#user2", "2024-05-01T10:00:00.000000Z", nil)
+
+	activities, lastActivityId, lastOccurredAt, err := activityRepository.GetActivities("user1", []string{}, "", "", 5)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedActivities, activities)
+	assert.Equal(t, "followed#user2", lastActivityId)
+	assert.Equal(t, "2024-05-01T10:00:00.000000Z", lastOccurredAt)
+}
+
+func TestGetUserActivitiesInRepository(t *testing.T) {
+	setUpRepository(t)
+	firstPage := []*database.ActivityRecord{
+		{Username: "user1", ActivityId: "followed#user2", Type: model.ActivityTypeFollowed, Followee: "user2", OccurredAt: "2024-05-02T10:00:00.000000Z"},
+	}
+	secondPage := []*database.ActivityRecord{
+		{Username: "user1", ActivityId: "posted#post1", Type: model.ActivityTypePosted, PostId: "post1", OccurredAt: "2024-05-01T10:00:00.000000Z"},
+	}
+	expectedActivities := []*model.Activity{
+		{ActivityId: "followed#user2", Username: "user1", Type: model.ActivityTypeFollowed, Followee: "user2", OccurredAt: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)},
+		{ActivityId: "posted#post1", Username: "user1", Type: model.ActivityTypePosted, PostId: "post1", OccurredAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	client.EXPECT().GetActivitiesByIndexUsername("user1", nil, "", "", 100).Return(firstPage, "followed#user2", "2024-05-02T10:00:00.000000Z", nil)
+	client.EXPECT().GetActivitiesByIndexUsername("user1", nil, "followed#user2", "2024-05-02T10:00:00.000000Z", 100).Return(secondPage, "", "", nil)
+
+	activities, err := activityRepository.GetUserActivities("user1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedActivities, activities)
+}
@@ -0,0 +1,185 @@
+package activity_test
+
+import (
+	"errors"
+	"readmodels/internal/activity"
+	"readmodels/internal/model"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var activityService *activity.ActivityService
+
+func setUpService(t *testing.T) {
+	SetUp(t)
+	activityService = activity.NewActivityService(repository)
+}
+
+func TestRecordCommentWithService(t *testing.T) {
+	setUpService(t)
+	occurredAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	data := &model.Activity{
+		Username:   "user1",
+		Type:       model.ActivityTypeCommented,
+		PostId:     "post1",
+		CommentId:  uint64(42),
+		OccurredAt: occurredAt,
+	}
+	expectedActivity := &model.Activity{
+		ActivityId: "commented#42",
+		Username:   "user1",
+		Type:       model.ActivityTypeCommented,
+		PostId:     "post1",
+		CommentId:  uint64(42),
+		OccurredAt: occurredAt,
+	}
+	repository.EXPECT().AddActivity(expectedActivity).Return(nil)
+
+	activityService.RecordActivity(data)
+
+	assert.Contains(t, loggerOutput.String(), "Activity commented#42 of user1 was recorded")
+}
+
+func TestRecordLikeAtHandlingTimeWithService(t *testing.T) {
+	setUpService(t)
+	data := &model.Activity{
+		Username: "user1",
+		Type:     model.ActivityTypeLiked,
+		PostId:   "post1",
+	}
+	repository.EXPECT().AddActivity(gomock.Any()).DoAndReturn(func(data *model.Activity) error {
+		assert.Equal(t, "liked#post1", data.ActivityId)
+		assert.WithinDuration(t, time.Now(), data.OccurredAt, time.Minute)
+		return nil
+	})
+
+	activityService.RecordActivity(data)
+}
+
+func TestErrorOnRecordActivityWithService(t *testing.T) {
+	setUpService(t)
+	data := &model.Activity{
+		Username: "user1",
+		Type:     model.ActivityTypeFollowed,
+		Followee: "user2",
+	}
+	repository.EXPECT().AddActivity(gomock.Any()).Return(errors.New("some error"))
+
+	activityService.RecordActivity(data)
+
+	assert.Contains(t, loggerOutput.String(), "Error recording activity followed#user2 of user1")
+}
+
+func TestRemoveUnfollowWithService(t *testing.T) {
+	setUpService(t)
+	data := &model.Activity{
+		Username: "user1",
+		Type:     model.ActivityTypeFollowed,
+		Followee: "user2",
+	}
+	expectedActivities := []*model.Activity{
+		{
+			ActivityId: "followed#user2",
+			Username:   "user1",
+			Type:       model.ActivityTypeFollowed,
+			Followee:   "user2",
+		},
+	}
+	repository.EXPECT().RemoveActivities(expectedActivities).Return(nil)
+
+	activityService.RemoveActivity(data)
+
+	assert.Contains(t, loggerOutput.String(), "1 activities related to user1 were removed")
+}
+
+func TestRemoveCommentActivityWithService(t *testing.T) {
+	setUpService(t)
+	postActivities := []*model.Activity{
+		{ActivityId: "liked#post1", Username: "user2", Type: model.ActivityTypeLiked, PostId: "post1"},
+		{ActivityId: "commented#41", Username: "user2", Type: model.ActivityTypeCommented, PostId: "post1", CommentId: uint64(41)},
+		{ActivityId: "commented#42", Username: "user3", Type: model.ActivityTypeCommented, PostId: "post1", CommentId: uint64(42)},
+	}
+	repository.EXPECT().GetPostActivities("post1").Return(postActivities, nil)
+	repository.EXPECT().RemoveActivities([]*model.Activity{postActivities[2]}).Return(nil)
+
+	activityService.RemoveCommentActivity("post1", uint64(42))
+}
+
+func TestRemovePostsActivityWithService(t *testing.T) {
+	setUpService(t)
+	post1Activities := []*model.Activity{
+		{ActivityId: "posted#post1", Username: "user1", Type: model.ActivityTypePosted, PostId: "post1"},
+		{ActivityId: "liked#post1", Username: "user2", Type: model.ActivityTypeLiked, PostId: "post1"},
+	}
+	post2Activities := []*model.Activity{
+		{ActivityId: "posted#post2", Username: "user1", Type: model.ActivityTypePosted, PostId: "post2"},
+	}
+	repository.EXPECT().GetPostActivities("post1").Return(post1Activities, nil)
+	repository.EXPECT().GetPostActivities("post2").Return(post2Activities, nil)
+	repository.EXPECT().RemoveActivities(append(post1Activities, post2Activities...)).Return(nil)
+
+	activityService.RemovePostsActivity("user1", []string{"post1", "post2"})
+
+	assert.Contains(t, loggerOutput.String(), "3 activities related to user1 were removed")
+}
+
+func TestErrorOnRemovePostsActivityWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPostActivities("post1").Return(nil, errors.New("some error"))
+
+	activityService.RemovePostsActivity("user1", []string{"post1", "post2"})
+
+	assert.Contains(t, loggerOutput.String(), "Error getting the activities of post post1")
+}
+
+func TestRemoveUserActivityWithService(t *testing.T) {
+	setUpService(t)
+	userActivities := []*model.Activity{
+		{ActivityId: "posted#post1", Username: "user1", Type: model.ActivityTypePosted, PostId: "post1"},
+		{ActivityId: "liked#post2", Username: "user1", Type: model.ActivityTypeLiked, PostId: "post2"},
+	}
+	post1Activities := []*model.Activity{
+		{ActivityId: "posted#post1", Username: "user1", Type: model.ActivityTypePosted, PostId: "post1"},
+		{ActivityId: "commented#42", Username: "user2", Type: model.ActivityTypeCommented, PostId: "post1", CommentId: uint64(42)},
+	}
+	expectedActivities := []*model.Activity{
+		userActivities[0],
+		userActivities[1],
+		post1Activities[1],
+		{ActivityId: "followed#user1", Username: "follower1", Type: model.ActivityTypeFollowed, Followee: "user1"},
+	}
+	repository.EXPECT().GetUserActivities("user1").Return(userActivities, nil)
+	repository.EXPECT().GetPostActivities("post1").Return(post1Activities, nil)
+	repository.EXPECT().RemoveActivities(expectedActivities).Return(nil)
+
+	activityService.RemoveUserActivity("user1", []string{"follower1"})
+
+	assert.Contains(t, loggerOutput.String(), "4 activities related to user1 were removed")
+}
+
+func TestErrorOnRemoveUserActivityWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetUserActivities("user1").Return(nil, errors.New("some error"))
+
+	activityService.RemoveUserActivity("user1", []string{"follower1"})
+
+	assert.Contains(t, loggerOutput.String(), "Error getting the activities of user1")
+}
+
+func TestGetActivitiesWithService(t *testing.T) {
+	setUpService(t)
+	expectedActivities := []*model.Activity{
+		{ActivityId: "liked#post1", Username: "user1", Type: model.ActivityTypeLiked, PostId: "post1"},
+	}
+	repository.EXPECT().GetActivities("user1", []string{model.ActivityTypeLiked}, "", "", 10).Return(expectedActivities, "liked#post1", "2024-05-01T10:00:00.000000Z", nil)
+
+	activities, lastActivityId, lastOccurredAt, err := activityService.GetActivities("user1", []string{model.ActivityTypeLiked}, "", "", 10)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedActivities, activities)
+	assert.Equal(t, "liked#post1", lastActivityId)
+	assert.Equal(t, "2024-05-01T10:00:00.000000Z", lastOccurredAt)
+}
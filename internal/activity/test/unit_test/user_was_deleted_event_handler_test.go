@@ -0,0 +1,31 @@
+package activity_test
+
+import (
+	"encoding/json"
+	activity_handler "readmodels/internal/activity/handler"
+	mock_activity_handler "readmodels/internal/activity/handler/test/mock"
+	erasure_handler "readmodels/internal/erasure/handler"
+	"testing"
+)
+
+var userWasDeletedEventHandler *activity_handler.UserWasDeletedEventHandler
+var userWasDeletedEventService *mock_activity_handler.MockUserWasDeletedEventService
+
+func setUpUserWasDeletedEventHandler(t *testing.T) {
+	SetUp(t)
+	userWasDeletedEventService = mock_activity_handler.NewMockUserWasDeletedEventService(ctrl)
+	userWasDeletedEventHandler = activity_handler.NewUserWasDeletedEventHandler(userWasDeletedEventService)
+}
+
+func TestHandleUserWasDeletedEventForActivity(t *testing.T) {
+	setUpUserWasDeletedEventHandler(t)
+	data := &erasure_handler.UserWasDeletedEvent{
+		Username:    "user1",
+		FollowerIds: []string{"follower1"},
+		FolloweeIds: []string{"followee1"},
+	}
+	event, _ := json.Marshal(data)
+	userWasDeletedEventService.EXPECT().RemoveUserActivity("user1", []string{"follower1"})
+
+	userWasDeletedEventHandler.Handle(event)
+}
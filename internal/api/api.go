@@ -3,22 +3,45 @@ package api
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/rs/zerolog/log"
 )
 
+const shutdownGracePeriod = 5 * time.Second
+
+const defaultAdminAddress = "127.0.0.1:5556"
+
 type Api struct {
-	port        int
+	name        string
+	address     string
 	env         string
+	public      bool
 	controllers []Controller
 }
 
 func NewApiEndpoint(env string, controllers []Controller) *Api {
 	return &Api{
-		port:        5555,
+		name:        "Readmodels Api Server",
+		address:     ":5555",
+		env:         env,
+		public:      true,
+		controllers: controllers,
+	}
+}
+
+// NewAdminEndpoint serves the operational controllers on their own listener,
+// which is bound to the loopback interface unless another address is given
+// and must never be exposed to clients.
+func NewAdminEndpoint(env string, address string, controllers []Controller) *Api {
+	if address == "" {
+		address = defaultAdminAddress
+	}
+
+	return &Api{
+		name:        "Readmodels Admin Server",
+		address:     address,
 		env:         env,
 		controllers: controllers,
 	}
@@ -28,7 +51,7 @@ func (api *Api) Run(ctx context.Context) error {
 	routes := api.routes()
 
 	server := &http.Server{
-		Addr:              fmt.Sprintf(":%d", api.port),
+		Addr:              api.address,
 		Handler:           routes,
 		IdleTimeout:       30 * time.Second,
 		ReadTimeout:       10 * time.Second,
@@ -36,14 +59,29 @@ func (api *Api) Run(ctx context.Context) error {
 		WriteTimeout:      5 * time.Second,
 	}
 
-	log.Info().Msgf("Starting Readmodels Api Server on port %d", api.port)
+	for _, controller := range api.controllers {
+		if streamingController, ok := controller.(StreamingController); ok {
+			server.RegisterOnShutdown(streamingController.Close)
+		}
+	}
+
+	log.Info().Msgf("Starting %s on %s", api.name, api.address)
 
 	go func() {
 		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Error().Err(err).Msg("Readmodels Api Server failed")
+			log.Error().Err(err).Msgf("%s failed", api.name)
 		}
 	}()
 
 	<-ctx.Done()
-	return server.Shutdown(ctx)
+
+	// ctx is already cancelled, the in-flight requests get their own grace period
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+	defer cancel()
+	err := server.Shutdown(shutdownCtx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		log.Warn().Msgf("Requests still in flight after %s, closing their connections", shutdownGracePeriod)
+		return server.Close()
+	}
+	return err
 }
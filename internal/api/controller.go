@@ -10,6 +10,13 @@ type Controller interface {
 	Routes(routerGroup *gin.RouterGroup)
 }
 
+// StreamingController is a controller holding long lived responses, which
+// Close ends when the Api shuts down.
+type StreamingController interface {
+	Controller
+	Close()
+}
+
 type response struct {
 	Error   bool   `json:"error"`
 	Message string `json:"message"`
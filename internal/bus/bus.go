@@ -2,53 +2,279 @@ package bus
 
 import (
 	"context"
+	"fmt"
+	"path"
+	"readmodels/internal/events"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
 )
 
+//go:generate mockgen -source=bus.go -destination=test/mock/bus.go
+
 type Event struct {
 	Type string
 	Data []byte
+	// Original is the payload as it was published, before being upcast, so
+	// the dead letter queue receives an event that can be published again
+	Original []byte
 }
 
+// published returns the event as it was published.
+func (e Event) published() Event {
+	return Event{
+		Type: e.Type,
+		Data: e.Original,
+	}
+}
+
+// EventBus is safe for concurrent use, subscriptions can be added and
+// removed while events are published.
 type EventBus struct {
-	subscribers map[string][]chan<- Event
+	mutex       sync.RWMutex
+	subscribers map[string]*subscriber
+	registry    *events.Registry
+	deadLetters DeadLetterQueue
+	inFlight    *inFlightHandlers
+	// The workers read the middlewares on every event, so they have their own
+	// lock
+	middlewaresMutex sync.RWMutex
+	middlewares      []Middleware
 }
 
+// EventSubscription hands the events of EventType to Handler. EventType is
+// either an event type or a pattern such as *PostEvent matching several of
+// them (see path.Match). Name identifies the subscription and defaults to
+// the event type and the handler type.
+// Workers handlers run at once and up to QueueSize events wait for a free
+// worker, after which Publish blocks. Zero values take DefaultWorkers and
+// DefaultQueueSize.
 type EventSubscription struct {
+	Name      string
 	EventType string
 	Handler   EventHandler
+	Workers   int
+	QueueSize int
 }
 
 type EventHandler interface {
 	Handle(event []byte)
 }
 
-func NewEventBus() *EventBus {
+// DeadLetterQueue keeps the events the bus rejects, or whose handler panics,
+// together with the reason.
+type DeadLetterQueue interface {
+	Send(event Event, reason error)
+}
+
+func NewEventBus(registry *events.Registry, deadLetters DeadLetterQueue) *EventBus {
 	return &EventBus{
-		subscribers: make(map[string][]chan<- Event),
+		subscribers: make(map[string]*subscriber),
+		registry:    registry,
+		deadLetters: deadLetters,
+		inFlight:    newInFlightHandlers(),
 	}
 }
 
+// Use adds middlewares around the handlers of every subscription, the first
+// one being the outermost.
+func (eb *EventBus) Use(middlewares ...Middleware) {
+	eb.middlewaresMutex.Lock()
+	defer eb.middlewaresMutex.Unlock()
+	eb.middlewares = append(eb.middlewares, middlewares...)
+}
+
+// Publish decodes the event against its schema and queues the payload, upcast
+// to the current version, for the subscribers. Events that don't match their
+// schema go to the dead letter queue instead. Publish blocks while the queue
+// of a subscriber is full, which slows down the Kafka consumption, until the
+// subscription is removed or its context is done.
 func (eb *EventBus) Publish(event Event) {
-	subscriberChannels := eb.subscribers[event.Type]
+	eb.PublishAndNotify(event, nil)
+}
+
+// PublishAndNotify is Publish calling handled once the handlers of every
+// subscriber finished with the event, or right away when the event is
+// rejected or has no subscriber. handled may be nil.
+func (eb *EventBus) PublishAndNotify(event Event, handled func()) {
+	envelope, err := eb.registry.Decode(event.Type, event.Data)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("%s was rejected", event.Type)
+		eb.deadLetters.Send(event, err)
+		notify(handled)
+		return
+	}
+	event.Original = event.Data
+	event.Data = envelope.Payload
+
+	// The sends are counted under the read lock, so Unsubscribe waits for the
+	// events already on their way to the subscriber it removes. The lock is
+	// released before queueing, which may block on a full queue
+	eb.mutex.RLock()
+	matching := []*subscriber{}
+	for _, subscriber := range eb.sortedSubscribers() {
+		if subscriber.matches(event.Type) {
+			subscriber.sending.Add(1)
+			matching = append(matching, subscriber)
+		}
+	}
+	eb.mutex.RUnlock()
+	if len(matching) == 0 {
+		notify(handled)
+		return
+	}
 
-	for _, subscriberChannel := range subscriberChannels {
-		subscriberChannel <- event
+	pending := newPendingHandlers(len(matching), handled)
+	for _, subscriber := range matching {
+		// Counted before queueing it so WaitInFlight can't miss it
+		eb.inFlight.add(event.Type)
+		subscriber.enqueue(queuedEvent{event: event, pending: pending})
 	}
 }
 
-func (eb *EventBus) Subscribe(subscription *EventSubscription, ctx context.Context) {
-	subscriptionChan := make(chan Event)
-	eb.subscribers[subscription.EventType] = append(eb.subscribers[subscription.EventType], subscriptionChan)
-	go subscription.handle(subscriptionChan, ctx)
+// WaitInFlight blocks until the handlers of every published event finish or
+// ctx is done, in which case the error lists the abandoned handlers.
+func (eb *EventBus) WaitInFlight(ctx context.Context) error {
+	return eb.inFlight.wait(ctx)
+}
+
+// EventTypes returns the sorted event types with at least one subscriber,
+// including the registered event types matched by pattern subscriptions.
+func (eb *EventBus) EventTypes() []string {
+	eb.mutex.RLock()
+	defer eb.mutex.RUnlock()
+
+	subscribed := map[string]bool{}
+	for _, subscriber := range eb.subscribers {
+		if !subscriber.isPattern() {
+			subscribed[subscriber.subscription.EventType] = true
+			continue
+		}
+		for _, eventType := range eb.registry.Types() {
+			if subscriber.matches(eventType) {
+				subscribed[eventType] = true
+			}
+		}
+	}
+
+	eventTypes := make([]string, 0, len(subscribed))
+	for eventType := range subscribed {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+	return eventTypes
 }
 
-func (es EventSubscription) handle(busChannel <-chan Event, ctx context.Context) {
-	for {
-		select {
-		case event := <-busChannel:
-			go es.Handler.Handle(event.Data)
-		case <-ctx.Done():
-			return
+// Verify checks that the subscriptions and the registered schemas cover the
+// same event types. Otherwise the events of a subscription without schema
+// would all be rejected, and those of a schema without subscription would be
+// consumed and discarded.
+func (eb *EventBus) Verify() error {
+	eb.mutex.RLock()
+	defer eb.mutex.RUnlock()
+
+	withoutSchema := []string{}
+	for _, subscriber := range eb.sortedSubscribers() {
+		matched := false
+		for _, eventType := range eb.registry.Types() {
+			matched = matched || subscriber.matches(eventType)
 		}
+		if !matched {
+			withoutSchema = append(withoutSchema, subscriber.subscription.EventType)
+		}
+	}
+
+	withoutSubscription := []string{}
+	for _, eventType := range eb.registry.Types() {
+		subscribed := false
+		for _, subscriber := range eb.subscribers {
+			subscribed = subscribed || subscriber.matches(eventType)
+		}
+		if !subscribed {
+			withoutSubscription = append(withoutSubscription, eventType)
+		}
+	}
+
+	if len(withoutSchema) > 0 || len(withoutSubscription) > 0 {
+		sort.Strings(withoutSchema)
+		sort.Strings(withoutSubscription)
+		return NewSubscriptionMismatchError(withoutSchema, withoutSubscription)
+	}
+	return nil
+}
+
+// Stats returns the queue and worker metrics of every subscription.
+func (eb *EventBus) Stats() []*SubscriptionStats {
+	eb.mutex.RLock()
+	defer eb.mutex.RUnlock()
+
+	stats := []*SubscriptionStats{}
+	for _, subscriber := range eb.sortedSubscribers() {
+		stats = append(stats, subscriber.stats())
+	}
+	return stats
+}
+
+// Subscribe starts the workers of the subscription, which stop when ctx is
+// done or the subscription is removed with Unsubscribe.
+func (eb *EventBus) Subscribe(subscription *EventSubscription, ctx context.Context) error {
+	named := *subscription
+	if named.Name == "" {
+		named.Name = fmt.Sprintf("%s:%s", named.EventType, strings.TrimPrefix(fmt.Sprintf("%T", named.Handler), "*"))
+	}
+	if _, err := path.Match(named.EventType, ""); err != nil {
+		return NewInvalidSubscriptionError(named.Name, fmt.Sprintf("malformed event type pattern %s", named.EventType))
+	}
+
+	eb.mutex.Lock()
+	defer eb.mutex.Unlock()
+	if _, ok := eb.subscribers[named.Name]; ok {
+		return NewInvalidSubscriptionError(named.Name, "a subscription with the same name already exists")
+	}
+
+	subscriber := newSubscriber(&named, eb.inFlight, eb.dispatch, eb.deadLetters)
+	eb.subscribers[named.Name] = subscriber
+	subscriber.start(ctx)
+	return nil
+}
+
+// Unsubscribe removes the subscription. The events already queued for it are
+// still handled before its workers stop.
+func (eb *EventBus) Unsubscribe(name string) error {
+	eb.mutex.Lock()
+	subscriber, ok := eb.subscribers[name]
+	delete(eb.subscribers, name)
+	eb.mutex.Unlock()
+
+	if !ok {
+		return NewUnknownSubscriptionError(name)
+	}
+
+	subscriber.stop()
+	return nil
+}
+
+// dispatch runs the handler of a subscription through the middlewares.
+func (eb *EventBus) dispatch(delivery *Delivery, handle HandleFunc) {
+	eb.middlewaresMutex.RLock()
+	middlewares := eb.middlewares
+	eb.middlewaresMutex.RUnlock()
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handle = middlewares[i](handle)
+	}
+	handle(delivery)
+}
+
+func (eb *EventBus) sortedSubscribers() []*subscriber {
+	subscribers := make([]*subscriber, 0, len(eb.subscribers))
+	for _, subscriber := range eb.subscribers {
+		subscribers = append(subscribers, subscriber)
 	}
+	sort.Slice(subscribers, func(i, j int) bool {
+		return subscribers[i].subscription.Name < subscribers[j].subscription.Name
+	})
+	return subscribers
 }
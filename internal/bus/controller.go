@@ -0,0 +1,28 @@
+package bus
+
+import (
+	"readmodels/internal/api"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type BusController struct {
+	eventBus *EventBus
+}
+
+func NewBusController(eventBus *EventBus) *BusController {
+	return &BusController{
+		eventBus: eventBus,
+	}
+}
+
+func (controller *BusController) Routes(routerGroup *gin.RouterGroup) {
+	routerGroup.GET("/admin/bus", controller.GetStats)
+}
+
+func (controller *BusController) GetStats(c *gin.Context) {
+	log.Info().Msg("Handling Request GET BusStats")
+
+	api.SendOKWithResult(c, controller.eventBus.Stats())
+}
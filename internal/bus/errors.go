@@ -0,0 +1,97 @@
+package bus
+
+import (
+	"fmt"
+	"strings"
+)
+
+type SubscriptionMismatchError struct {
+	withoutSchema       []string
+	withoutSubscription []string
+}
+
+func (e *SubscriptionMismatchError) Error() string {
+	mismatches := []string{}
+	if len(e.withoutSchema) > 0 {
+		mismatches = append(mismatches, "subscriptions without schema: "+strings.Join(e.withoutSchema, ", "))
+	}
+	if len(e.withoutSubscription) > 0 {
+		mismatches = append(mismatches, "schemas without subscription: "+strings.Join(e.withoutSubscription, ", "))
+	}
+	return fmt.Sprintf("Event bus subscriptions don't match the event schemas, %s", strings.Join(mismatches, "; "))
+}
+
+func NewSubscriptionMismatchError(withoutSchema, withoutSubscription []string) *SubscriptionMismatchError {
+	return &SubscriptionMismatchError{
+		withoutSchema:       withoutSchema,
+		withoutSubscription: withoutSubscription,
+	}
+}
+
+type AbandonedHandlersError struct {
+	counts map[string]int
+}
+
+func (e *AbandonedHandlersError) Error() string {
+	abandoned := []string{}
+	for _, eventType := range sortedEventTypes(e.counts) {
+		abandoned = append(abandoned, fmt.Sprintf("%s (%d)", eventType, e.counts[eventType]))
+	}
+	return "Event handlers still running: " + strings.Join(abandoned, ", ")
+}
+
+func NewAbandonedHandlersError(counts map[string]int) *AbandonedHandlersError {
+	return &AbandonedHandlersError{
+		counts: counts,
+	}
+}
+
+type InvalidSubscriptionError struct {
+	name   string
+	reason string
+}
+
+func (e *InvalidSubscriptionError) Error() string {
+	return fmt.Sprintf("Invalid subscription %s, %s", e.name, e.reason)
+}
+
+func NewInvalidSubscriptionError(name, reason string) *InvalidSubscriptionError {
+	return &InvalidSubscriptionError{
+		name:   name,
+		reason: reason,
+	}
+}
+
+type UnknownSubscriptionError struct {
+	name string
+}
+
+func (e *UnknownSubscriptionError) Error() string {
+	return fmt.Sprintf("Subscription %s doesn't exist", e.name)
+}
+
+func NewUnknownSubscriptionError(name string) *UnknownSubscriptionError {
+	return &UnknownSubscriptionError{
+		name: name,
+	}
+}
+
+type HandlerPanicError struct {
+	subscription string
+	value        string
+}
+
+func (e *HandlerPanicError) Error() string {
+	return fmt.Sprintf("Handler of subscription %s panicked: %s", e.subscription, e.value)
+}
+
+func (e *HandlerPanicError) Subscription() string {
+	return e.subscription
+}
+
+func NewHandlerPanicError(subscription, value string) *HandlerPanicError {
+	return &HandlerPanicError{
+		subscription: subscription,
+		value:        value,
+	}
+}
@@ -0,0 +1,111 @@
+package bus
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// inFlightHandlers counts the published events whose handlers haven't
+// finished yet, per event type.
+type inFlightHandlers struct {
+	mutex  sync.Mutex
+	counts map[string]int
+	total  int
+	idle   chan struct{}
+}
+
+func newInFlightHandlers() *inFlightHandlers {
+	idle := make(chan struct{})
+	close(idle)
+	return &inFlightHandlers{
+		counts: map[string]int{},
+		idle:   idle,
+	}
+}
+
+func (h *inFlightHandlers) add(eventType string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.total == 0 {
+		h.idle = make(chan struct{})
+	}
+	h.counts[eventType]++
+	h.total++
+}
+
+func (h *inFlightHandlers) done(eventType string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.counts[eventType]--
+	if h.counts[eventType] == 0 {
+		delete(h.counts, eventType)
+	}
+	h.total--
+	if h.total == 0 {
+		close(h.idle)
+	}
+}
+
+func (h *inFlightHandlers) wait(ctx context.Context) error {
+	h.mutex.Lock()
+	idle := h.idle
+	h.mutex.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return NewAbandonedHandlersError(h.snapshot())
+	}
+}
+
+func (h *inFlightHandlers) snapshot() map[string]int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	counts := make(map[string]int, len(h.counts))
+	for eventType, count := range h.counts {
+		counts[eventType] = count
+	}
+	return counts
+}
+
+func sortedEventTypes(counts map[string]int) []string {
+	eventTypes := make([]string, 0, len(counts))
+	for eventType := range counts {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+	return eventTypes
+}
+
+// pendingHandlers calls handled once the last of the subscribers an event was
+// queued for is done with it.
+type pendingHandlers struct {
+	remaining atomic.Int32
+	handled   func()
+}
+
+func newPendingHandlers(subscribers int, handled func()) *pendingHandlers {
+	pending := &pendingHandlers{
+		handled: handled,
+	}
+	pending.remaining.Store(int32(subscribers))
+	return pending
+}
+
+func (p *pendingHandlers) done() {
+	if p.remaining.Add(-1) == 0 {
+		notify(p.handled)
+	}
+}
+
+func notify(handled func()) {
+	if handled != nil {
+		handled()
+	}
+}
@@ -0,0 +1,29 @@
+package bus
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Delivery is an event handed to the handler of a subscription.
+type Delivery struct {
+	Subscription string
+	Event        Event
+}
+
+type HandleFunc func(delivery *Delivery)
+
+// Middleware wraps the handling of every delivery, e.g. to log or measure it.
+type Middleware func(next HandleFunc) HandleFunc
+
+// LoggingMiddleware logs every delivery with the time its handler took.
+func LoggingMiddleware() Middleware {
+	return func(next HandleFunc) HandleFunc {
+		return func(delivery *Delivery) {
+			start := time.Now()
+			next(delivery)
+			log.Debug().Msgf("%s handled by %s in %s", delivery.Event.Type, delivery.Subscription, time.Since(start))
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DefaultWorkers   = 4
+	DefaultQueueSize = 100
+)
+
+type SubscriptionStats struct {
+	Name             string `json:"name"`
+	EventType        string `json:"eventType"`
+	Workers          int    `json:"workers"`
+	BusyWorkers      int32  `json:"busyWorkers"`
+	QueueSize        int    `json:"queueSize"`
+	QueueDepth       int    `json:"queueDepth"`
+	Handled          uint64 `json:"handled"`
+	Panics           uint64 `json:"panics"`
+	BlockedPublishes uint64 `json:"blockedPublishes"`
+	BlockedMillis    int64  `json:"blockedMillis"`
+}
+
+// subscriber runs the handler of a subscription on a fixed pool of workers
+// fed by a bounded queue.
+type subscriber struct {
+	subscription EventSubscription
+	queue        chan queuedEvent
+	workers      int
+	inFlight     *inFlightHandlers
+	dispatch     dispatcher
+	deadLetters  DeadLetterQueue
+	ctx          context.Context
+	stopping     chan struct{}
+	running      sync.WaitGroup
+	// sending counts the publishers about to queue an event, which the
+	// workers wait for before draining the queue and leaving
+	sending          sync.WaitGroup
+	busyWorkers      atomic.Int32
+	handled          atomic.Uint64
+	panics           atomic.Uint64
+	blockedPublishes atomic.Uint64
+	blockedTime      atomic.Int64
+}
+
+type queuedEvent struct {
+	event   Event
+	pending *pendingHandlers
+}
+
+// dispatcher runs handle for the delivery, through the bus middlewares.
+type dispatcher func(delivery *Delivery, handle HandleFunc)
+
+func newSubscriber(subscription *EventSubscription, inFlight *inFlightHandlers, dispatch dispatcher, deadLetters DeadLetterQueue) *subscriber {
+	workers := subscription.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	queueSize := subscription.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+
+	return &subscriber{
+		subscription: *subscription,
+		queue:        make(chan queuedEvent, queueSize),
+		workers:      workers,
+		inFlight:     inFlight,
+		dispatch:     dispatch,
+		deadLetters:  deadLetters,
+		stopping:     make(chan struct{}),
+	}
+}
+
+func (s *subscriber) isPattern() bool {
+	return strings.ContainsAny(s.subscription.EventType, "*?[\\")
+}
+
+func (s *subscriber) matches(eventType string) bool {
+	if !s.isPattern() {
+		return s.subscription.EventType == eventType
+	}
+	matched, _ := path.Match(s.subscription.EventType, eventType)
+	return matched
+}
+
+func (s *subscriber) start(ctx context.Context) {
+	s.ctx = ctx
+	s.running.Add(s.workers)
+	for i := 0; i < s.workers; i++ {
+		go s.work(ctx)
+	}
+}
+
+// stop waits for the workers to handle the queued events and leave. No
+// sending may be added once stop is called.
+func (s *subscriber) stop() {
+	close(s.stopping)
+	s.running.Wait()
+}
+
+// enqueue queues the event, after sending.Add(1) was called. When the queue
+// is full it waits for the workers, unless the subscription stops or its
+// context is done meanwhile. The event is then given up: for a stopped
+// subscription it counts as handled, while after the context is done it's
+// never reported handled, so its offset isn't committed.
+func (s *subscriber) enqueue(event queuedEvent) {
+	defer s.sending.Done()
+
+	select {
+	case s.queue <- event:
+		return
+	default:
+	}
+
+	// The queue is full, wait for the workers to catch up
+	s.blockedPublishes.Add(1)
+	start := time.Now()
+	defer func() {
+		s.blockedTime.Add(int64(time.Since(start)))
+	}()
+	select {
+	case s.queue <- event:
+	case <-s.stopping:
+		log.Warn().Msgf("%s was not queued for %s, which was removed", event.event.Type, s.subscription.Name)
+		s.inFlight.done(event.event.Type)
+		event.pending.done()
+	case <-s.ctx.Done():
+		log.Warn().Msgf("%s was not queued for %s, which stopped", event.event.Type, s.subscription.Name)
+		s.inFlight.done(event.event.Type)
+	}
+}
+
+func (s *subscriber) work(ctx context.Context) {
+	defer s.running.Done()
+	for {
+		select {
+		case event := <-s.queue:
+			s.handle(event)
+		case <-ctx.Done():
+			return
+		case <-s.stopping:
+			s.sending.Wait()
+			s.drain()
+			return
+		}
+	}
+}
+
+func (s *subscriber) drain() {
+	for {
+		select {
+		case event := <-s.queue:
+			s.handle(event)
+		default:
+			return
+		}
+	}
+}
+
+func (s *subscriber) handle(queued queuedEvent) {
+	event := queued.event
+	s.busyWorkers.Add(1)
+	defer func() {
+		s.busyWorkers.Add(-1)
+		s.handled.Add(1)
+		s.inFlight.done(event.Type)
+		queued.pending.done()
+	}()
+	defer s.recover(event)
+
+	delivery := &Delivery{
+		Subscription: s.subscription.Name,
+		Event:        event,
+	}
+	s.dispatch(delivery, func(delivery *Delivery) {
+		s.subscription.Handler.Handle(delivery.Event.Data)
+	})
+}
+
+// recover keeps a panicking handler, or middleware, from taking down the
+// service. The event is given up and sent to the dead letter queue as it was
+// published.
+func (s *subscriber) recover(event Event) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	s.panics.Add(1)
+	err := NewHandlerPanicError(s.subscription.Name, fmt.Sprint(r))
+	log.Error().Str("stack", string(debug.Stack())).Err(err).Msgf("%s handler panicked", event.Type)
+	s.deadLetters.Send(event.published(), err)
+}
+
+func (s *subscriber) stats() *SubscriptionStats {
+	return &SubscriptionStats{
+		Name:             s.subscription.Name,
+		EventType:        s.subscription.EventType,
+		Workers:          s.workers,
+		BusyWorkers:      s.busyWorkers.Load(),
+		QueueSize:        cap(s.queue),
+		QueueDepth:       len(s.queue),
+		Handled:          s.handled.Load(),
+		Panics:           s.panics.Load(),
+		BlockedPublishes: s.blockedPublishes.Load(),
+		BlockedMillis:    time.Duration(s.blockedTime.Load()).Milliseconds(),
+	}
+}
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: bus.go
+
+// Package mock_bus is a generated GoMock package.
+package mock_bus
+
+import (
+	bus "readmodels/internal/bus"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEventHandler is a mock of EventHandler interface.
+type MockEventHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventHandlerMockRecorder
+}
+
+// MockEventHandlerMockRecorder is the mock recorder for MockEventHandler.
+type MockEventHandlerMockRecorder struct {
+	mock *MockEventHandler
+}
+
+// NewMockEventHandler creates a new mock instance.
+func NewMockEventHandler(ctrl *gomock.Controller) *MockEventHandler {
+	mock := &MockEventHandler{ctrl: ctrl}
+	mock.recorder = &MockEventHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventHandler) EXPECT() *MockEventHandlerMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockEventHandler) Handle(event []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Handle", event)
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockEventHandlerMockRecorder) Handle(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockEventHandler)(nil).Handle), event)
+}
+
+// MockDeadLetterQueue is a mock of DeadLetterQueue interface.
+type MockDeadLetterQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterQueueMockRecorder
+}
+
+// MockDeadLetterQueueMockRecorder is the mock recorder for MockDeadLetterQueue.
+type MockDeadLetterQueueMockRecorder struct {
+	mock *MockDeadLetterQueue
+}
+
+// NewMockDeadLetterQueue creates a new mock instance.
+func NewMockDeadLetterQueue(ctrl *gomock.Controller) *MockDeadLetterQueue {
+	mock := &MockDeadLetterQueue{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterQueue) EXPECT() *MockDeadLetterQueueMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockDeadLetterQueue) Send(event bus.Event, reason error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Send", event, reason)
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockDeadLetterQueueMockRecorder) Send(event, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockDeadLetterQueue)(nil).Send), event, reason)
+}
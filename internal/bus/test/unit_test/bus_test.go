@@ -0,0 +1,560 @@
+package bus_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"readmodels/internal/bus"
+	mock_bus "readmodels/internal/bus/test/mock"
+	"readmodels/internal/events"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestEvent struct {
+	PostId string `json:"postId"`
+}
+
+var ctrl *gomock.Controller
+var loggerOutput bytes.Buffer
+var handler *mock_bus.MockEventHandler
+var deadLetters *mock_bus.MockDeadLetterQueue
+var eventBus *bus.EventBus
+
+func setUp(t *testing.T) {
+	ctrl = gomock.NewController(t)
+	loggerOutput.Reset()
+	log.Logger = log.Output(&loggerOutput)
+	handler = mock_bus.NewMockEventHandler(ctrl)
+	deadLetters = mock_bus.NewMockDeadLetterQueue(ctrl)
+	registry, _ := events.NewRegistry(events.Schema{
+		Type:    "TestEvent",
+		Version: 2,
+		Payload: TestEvent{},
+		Upcasters: map[int]events.Upcaster{
+			1: events.RenameField("post_id", "postId"),
+		},
+	})
+	eventBus = bus.NewEventBus(registry, deadLetters)
+}
+
+func TestPublishHandsUpcastPayloadToSubscribers(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler}, ctx)
+	handled := make(chan []byte, 1)
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) {
+		handled <- event
+	})
+
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"post_id":"post1"}`)})
+
+	select {
+	case event := <-handled:
+		assert.JSONEq(t, `{"postId":"post1"}`, string(event))
+	case <-time.After(time.Second):
+		t.Fatal("event was not handled")
+	}
+}
+
+func TestPublishSendsInvalidEventsToDeadLetterQueue(t *testing.T) {
+	setUp(t)
+	event := bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1","likes":3}`)}
+	deadLetters.EXPECT().Send(event, gomock.Any()).Do(func(event bus.Event, reason error) {
+		var decodeError *events.DecodeError
+		assert.True(t, errors.As(reason, &decodeError))
+		assert.Equal(t, events.ReasonUnknownField, decodeError.Reason)
+	})
+
+	eventBus.Publish(event)
+
+	assert.Contains(t, loggerOutput.String(), "TestEvent was rejected")
+}
+
+func TestPublishAndNotifyWaitsForEverySubscription(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	otherHandler := mock_bus.NewMockEventHandler(ctrl)
+	eventBus.Subscribe(&bus.EventSubscription{Name: "first", EventType: "TestEvent", Handler: handler}, ctx)
+	eventBus.Subscribe(&bus.EventSubscription{Name: "second", EventType: "TestEvent", Handler: otherHandler}, ctx)
+	release := make(chan struct{})
+	handler.EXPECT().Handle(gomock.Any())
+	otherHandler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) {
+		<-release
+	})
+	handled := make(chan struct{})
+
+	eventBus.PublishAndNotify(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1"}`)}, func() {
+		close(handled)
+	})
+
+	select {
+	case <-handled:
+		t.Fatal("notified while a handler was running")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Fatal("not notified after every handler finished")
+	}
+}
+
+func TestPublishAndNotifyNotifiesRejectedEventsRightAway(t *testing.T) {
+	setUp(t)
+	deadLetters.EXPECT().Send(gomock.Any(), gomock.Any())
+	handled := false
+
+	eventBus.PublishAndNotify(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1","likes":3}`)}, func() {
+		handled = true
+	})
+
+	assert.True(t, handled)
+}
+
+func TestEventTypesReturnsSubscribedEventTypes(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler}, ctx)
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "OtherEvent", Handler: handler}, ctx)
+	eventBus.Subscribe(&bus.EventSubscription{Name: "another", EventType: "TestEvent", Handler: handler}, ctx)
+
+	assert.Equal(t, []string{"OtherEvent", "TestEvent"}, eventBus.EventTypes())
+}
+
+func TestVerifySucceedsWhenSubscriptionsMatchSchemas(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler}, ctx)
+
+	assert.Nil(t, eventBus.Verify())
+}
+
+func TestVerifyFailsOnSubscriptionsWithoutSchema(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler}, ctx)
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "OtherEvent", Handler: handler}, ctx)
+
+	err := eventBus.Verify()
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "subscriptions without schema: OtherEvent")
+}
+
+func TestVerifyFailsOnSchemasWithoutSubscription(t *testing.T) {
+	setUp(t)
+
+	err := eventBus.Verify()
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "schemas without subscription: TestEvent")
+}
+
+func TestWaitInFlightWaitsForRunningHandlers(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler}, ctx)
+	release := make(chan struct{})
+	finished := false
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) {
+		<-release
+		finished = true
+	})
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1"}`)})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	err := eventBus.WaitInFlight(waitCtx)
+
+	assert.Nil(t, err)
+	assert.True(t, finished)
+}
+
+func TestWaitInFlightReturnsImmediatelyWithoutRunningHandlers(t *testing.T) {
+	setUp(t)
+
+	err := eventBus.WaitInFlight(context.Background())
+
+	assert.Nil(t, err)
+}
+
+func TestWaitInFlightReportsAbandonedHandlers(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler}, ctx)
+	release := make(chan struct{})
+	defer close(release)
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) {
+		<-release
+	}).Times(2)
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1"}`)})
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post2"}`)})
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer waitCancel()
+	err := eventBus.WaitInFlight(waitCtx)
+
+	var abandonedHandlersError *bus.AbandonedHandlersError
+	assert.True(t, errors.As(err, &abandonedHandlersError))
+	assert.Equal(t, "Event handlers still running: TestEvent (2)", err.Error())
+}
+
+func TestSubscriptionRunsAtMostItsWorkersAtOnce(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler, Workers: 2, QueueSize: 10}, ctx)
+	started := make(chan struct{}, 5)
+	release := make(chan struct{})
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) {
+		started <- struct{}{}
+		<-release
+	}).Times(5)
+
+	for i := 0; i < 5; i++ {
+		eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1"}`)})
+	}
+	<-started
+	<-started
+
+	stats := eventBus.Stats()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, int32(2), stats[0].BusyWorkers)
+	assert.Equal(t, 3, stats[0].QueueDepth)
+	assert.Len(t, started, 0)
+
+	close(release)
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	assert.Nil(t, eventBus.WaitInFlight(waitCtx))
+	assert.Equal(t, uint64(5), eventBus.Stats()[0].Handled)
+}
+
+func TestPublishBlocksWhileTheQueueIsFull(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler, Workers: 1, QueueSize: 1}, ctx)
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) {
+		started <- struct{}{}
+		<-release
+	}).Times(3)
+	event := bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1"}`)}
+	eventBus.Publish(event)
+	<-started
+	eventBus.Publish(event)
+
+	published := make(chan struct{})
+	go func() {
+		eventBus.Publish(event)
+		close(published)
+	}()
+
+	select {
+	case <-published:
+		t.Fatal("publish didn't wait for a free slot in the queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("publish didn't resume")
+	}
+	assert.Equal(t, uint64(1), eventBus.Stats()[0].BlockedPublishes)
+}
+
+// blockPublish publishes until the queue of the subscription, whose handler
+// waits for release, is full and a publish is blocked. handled is closed
+// once the blocked event is reported handled.
+func blockPublish(t *testing.T, release chan struct{}) (published chan struct{}, handled chan struct{}) {
+	started := make(chan struct{}, 3)
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) {
+		started <- struct{}{}
+		<-release
+	}).AnyTimes()
+	event := bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1"}`)}
+	eventBus.Publish(event)
+	<-started
+	eventBus.Publish(event)
+
+	published = make(chan struct{})
+	handled = make(chan struct{})
+	go func() {
+		eventBus.PublishAndNotify(event, func() { close(handled) })
+		close(published)
+	}()
+	assert.Eventually(t, func() bool { return eventBus.Stats()[0].BlockedPublishes == 1 }, time.Second, time.Millisecond)
+	return published, handled
+}
+
+func TestBlockedPublishDoesNotHoldOtherSubscriptions(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{Name: "test", EventType: "TestEvent", Handler: handler, Workers: 1, QueueSize: 1}, ctx)
+	release := make(chan struct{})
+	published, _ := blockPublish(t, release)
+
+	err := eventBus.Subscribe(&bus.EventSubscription{Name: "other", EventType: "TestEvent", Handler: handler}, ctx)
+
+	assert.Nil(t, err)
+	assert.Len(t, eventBus.Stats(), 2)
+	close(release)
+	<-published
+}
+
+func TestBlockedPublishGivesUpWhenTheSubscriptionContextIsDone(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler, Workers: 1, QueueSize: 1}, ctx)
+	release := make(chan struct{})
+	defer close(release)
+	published, handled := blockPublish(t, release)
+
+	cancel()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("publish didn't give up")
+	}
+	select {
+	case <-handled:
+		t.Fatal("an event that wasn't queued was reported handled")
+	default:
+	}
+}
+
+func TestUnsubscribeReleasesBlockedPublish(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{Name: "test", EventType: "TestEvent", Handler: handler, Workers: 1, QueueSize: 1}, ctx)
+	release := make(chan struct{})
+	published, handled := blockPublish(t, release)
+
+	unsubscribed := make(chan error)
+	go func() {
+		unsubscribed <- eventBus.Unsubscribe("test")
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("publish didn't give up")
+	}
+	<-handled
+	close(release)
+	assert.Nil(t, <-unsubscribed)
+	assert.Nil(t, eventBus.WaitInFlight(context.Background()))
+}
+
+func TestStatsUseDefaultsForUnsetPoolSizes(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler}, ctx)
+
+	assert.Equal(t, []*bus.SubscriptionStats{
+		{
+			Name:      "TestEvent:mock_bus.MockEventHandler",
+			EventType: "TestEvent",
+			Workers:   bus.DefaultWorkers,
+			QueueSize: bus.DefaultQueueSize,
+		},
+	}, eventBus.Stats())
+}
+
+func TestSubscribeRejectsDuplicatedNames(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.Nil(t, eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler}, ctx))
+
+	err := eventBus.Subscribe(&bus.EventSubscription{EventType: "TestEvent", Handler: handler}, ctx)
+
+	var invalidSubscriptionError *bus.InvalidSubscriptionError
+	assert.True(t, errors.As(err, &invalidSubscriptionError))
+	assert.Len(t, eventBus.Stats(), 1)
+}
+
+func TestSubscribeRejectsMalformedPatterns(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := eventBus.Subscribe(&bus.EventSubscription{EventType: "[Test", Handler: handler}, ctx)
+
+	var invalidSubscriptionError *bus.InvalidSubscriptionError
+	assert.True(t, errors.As(err, &invalidSubscriptionError))
+}
+
+func TestPublishHandsEventToEverySubscription(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	otherHandler := mock_bus.NewMockEventHandler(ctrl)
+	eventBus.Subscribe(&bus.EventSubscription{Name: "first", EventType: "TestEvent", Handler: handler}, ctx)
+	eventBus.Subscribe(&bus.EventSubscription{Name: "second", EventType: "TestEvent", Handler: otherHandler}, ctx)
+	handled := make(chan struct{}, 2)
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) { handled <- struct{}{} })
+	otherHandler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) { handled <- struct{}{} })
+
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1"}`)})
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	assert.Nil(t, eventBus.WaitInFlight(waitCtx))
+	assert.Len(t, handled, 2)
+}
+
+func TestPatternSubscriptionReceivesMatchingEvents(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	registry, _ := events.NewRegistry(
+		events.Schema{Type: "PostWasCreatedEvent", Version: 1, Payload: TestEvent{}},
+		events.Schema{Type: "PostWasDeletedEvent", Version: 1, Payload: TestEvent{}},
+		events.Schema{Type: "UserWasRegisteredEvent", Version: 1, Payload: TestEvent{}},
+	)
+	eventBus = bus.NewEventBus(registry, deadLetters)
+	eventBus.Subscribe(&bus.EventSubscription{EventType: "Post*Event", Handler: handler}, ctx)
+	handled := make(chan []byte, 3)
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) { handled <- event }).Times(2)
+
+	eventBus.Publish(bus.Event{Type: "PostWasCreatedEvent", Data: []byte(`{"postId":"post1"}`)})
+	eventBus.Publish(bus.Event{Type: "PostWasDeletedEvent", Data: []byte(`{"postId":"post1"}`)})
+	eventBus.Publish(bus.Event{Type: "UserWasRegisteredEvent", Data: []byte(`{"postId":"post1"}`)})
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	assert.Nil(t, eventBus.WaitInFlight(waitCtx))
+	assert.Len(t, handled, 2)
+	assert.Equal(t, []string{"PostWasCreatedEvent", "PostWasDeletedEvent"}, eventBus.EventTypes())
+	assert.Contains(t, eventBus.Verify().Error(), "schemas without subscription: UserWasRegisteredEvent")
+}
+
+func TestUnsubscribeHandlesQueuedEventsAndStopsDelivering(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{Name: "test", EventType: "TestEvent", Handler: handler, Workers: 1}, ctx)
+	release := make(chan struct{})
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) {
+		<-release
+	}).Times(2)
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1"}`)})
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post2"}`)})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+
+	err := eventBus.Unsubscribe("test")
+
+	assert.Nil(t, err)
+	assert.Nil(t, eventBus.WaitInFlight(context.Background()))
+	assert.Empty(t, eventBus.Stats())
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post3"}`)})
+}
+
+func TestUnsubscribeFailsOnUnknownSubscription(t *testing.T) {
+	setUp(t)
+
+	err := eventBus.Unsubscribe("test")
+
+	var unknownSubscriptionError *bus.UnknownSubscriptionError
+	assert.True(t, errors.As(err, &unknownSubscriptionError))
+}
+
+func TestMiddlewaresWrapHandlersInOrder(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := make(chan string, 5)
+	tracing := func(name string) bus.Middleware {
+		return func(next bus.HandleFunc) bus.HandleFunc {
+			return func(delivery *bus.Delivery) {
+				calls <- name + " before " + delivery.Subscription
+				next(delivery)
+				calls <- name + " after"
+			}
+		}
+	}
+	eventBus.Use(tracing("outer"), tracing("inner"))
+	eventBus.Subscribe(&bus.EventSubscription{Name: "test", EventType: "TestEvent", Handler: handler}, ctx)
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) { calls <- "handler" })
+
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1"}`)})
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	assert.Nil(t, eventBus.WaitInFlight(waitCtx))
+	close(calls)
+	trace := []string{}
+	for call := range calls {
+		trace = append(trace, call)
+	}
+	assert.Equal(t, []string{"outer before test", "inner before test", "handler", "inner after", "outer after"}, trace)
+}
+
+func TestPanickingHandlersAreIsolated(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{Name: "test", EventType: "TestEvent", Handler: handler, Workers: 1}, ctx)
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) { panic("boom") })
+	handler.EXPECT().Handle(gomock.Any())
+	deadLetters.EXPECT().Send(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1"}`)}, gomock.Any()).Do(func(event bus.Event, reason error) {
+		var handlerPanicError *bus.HandlerPanicError
+		assert.True(t, errors.As(reason, &handlerPanicError))
+		assert.Equal(t, "test", handlerPanicError.Subscription())
+	})
+
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post1"}`)})
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"postId":"post2"}`)})
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	assert.Nil(t, eventBus.WaitInFlight(waitCtx))
+	stats := eventBus.Stats()[0]
+	assert.Equal(t, uint64(2), stats.Handled)
+	assert.Equal(t, uint64(1), stats.Panics)
+	assert.Contains(t, loggerOutput.String(), "Handler of subscription test panicked: boom")
+	assert.Contains(t, loggerOutput.String(), "runtime/debug.Stack")
+}
+
+func TestPanickingHandlersSendThePublishedPayload(t *testing.T) {
+	setUp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventBus.Subscribe(&bus.EventSubscription{Name: "test", EventType: "TestEvent", Handler: handler, Workers: 1}, ctx)
+	handler.EXPECT().Handle(gomock.Any()).Do(func(event []byte) { panic("boom") })
+	deadLetters.EXPECT().Send(bus.Event{Type: "TestEvent", Data: []byte(`{"post_id":"post1"}`)}, gomock.Any())
+
+	eventBus.Publish(bus.Event{Type: "TestEvent", Data: []byte(`{"post_id":"post1"}`)})
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	assert.Nil(t, eventBus.WaitInFlight(waitCtx))
+}
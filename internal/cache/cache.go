@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"sync/atomic"
+)
+
+// Cache stores JSON encoded values for a TTL. The keys set in a group are
+// tracked with it, so DeleteGroup drops them without going through the cache.
+type Cache interface {
+	Get(key string, result any) bool
+	Set(key string, value any)
+	SetInGroup(group string, key string, value any)
+	Delete(keys ...string)
+	DeleteGroup(group string)
+	Stats() *Stats
+}
+
+type Stats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Invalidations uint64 `json:"invalidations"`
+	Entries       int    `json:"entries"`
+}
+
+type metrics struct {
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+func (m *metrics) hit() {
+	m.hits.Add(1)
+}
+
+func (m *metrics) miss() {
+	m.misses.Add(1)
+}
+
+func (m *metrics) invalidated(amount int) {
+	m.invalidations.Add(uint64(amount))
+}
+
+func (m *metrics) stats(entries int) *Stats {
+	return &Stats{
+		Hits:          m.hits.Load(),
+		Misses:        m.misses.Load(),
+		Invalidations: m.invalidations.Load(),
+		Entries:       entries,
+	}
+}
+
+func UserProfileKey(username string) string {
+	return "userprofile:" + username
+}
+
+func PostsByUserGroup(username string) string {
+	return "posts:" + username
+}
+
+func PostOwnerKey(postId string) string {
+	return "post-owner:" + postId
+}
+
+func InvalidateUserProfile(c Cache, username string) {
+	c.Delete(UserProfileKey(username))
+}
+
+func InvalidatePostsByUser(c Cache, username string) {
+	c.DeleteGroup(PostsByUserGroup(username))
+}
+
+// InvalidatePost drops every cached post listing containing the post. Listings
+// record the owner of each post they hold after caching themselves and on every
+// hit, so the owner entry is never evicted before a listing holding the post
+// and a missing owner entry means no such listing is cached.
+func InvalidatePost(c Cache, postId string) {
+	var owner string
+	if c.Get(PostOwnerKey(postId), &owner) {
+		InvalidatePostsByUser(c, owner)
+	}
+}
@@ -0,0 +1,28 @@
+package cache
+
+import (
+	"readmodels/internal/api"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type CacheController struct {
+	cache Cache
+}
+
+func NewCacheController(cache Cache) *CacheController {
+	return &CacheController{
+		cache: cache,
+	}
+}
+
+func (controller *CacheController) Routes(routerGroup *gin.RouterGroup) {
+	routerGroup.GET("/cache/stats", controller.GetStats)
+}
+
+func (controller *CacheController) GetStats(c *gin.Context) {
+	log.Info().Msg("Handling Request GET CacheStats")
+
+	api.SendOKWithResult(c, controller.cache.Stats())
+}
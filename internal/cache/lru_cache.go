@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type LRUCache struct {
+	capacity int
+	ttl      time.Duration
+	mutex    sync.Mutex
+	entries  map[string]*list.Element
+	groups   map[string]map[string]bool
+	order    *list.List
+	metrics  metrics
+}
+
+type lruEntry struct {
+	key       string
+	group     string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewLRUCache(capacity int, ttl time.Duration) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		groups:   make(map[string]map[string]bool),
+		order:    list.New(),
+	}
+}
+
+func (c *LRUCache) Get(key string, result any) bool {
+	c.mutex.Lock()
+	element, ok := c.entries[key]
+	if !ok {
+		c.mutex.Unlock()
+		c.metrics.miss()
+		return false
+	}
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(element)
+		c.mutex.Unlock()
+		c.metrics.miss()
+		return false
+	}
+	c.order.MoveToFront(element)
+	value := entry.value
+	c.mutex.Unlock()
+
+	err := json.Unmarshal(value, result)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't unmarshal cached value for key %s", key)
+		c.metrics.miss()
+		return false
+	}
+
+	c.metrics.hit()
+	return true
+}
+
+func (c *LRUCache) Set(key string, value any) {
+	c.SetInGroup("", key, value)
+}
+
+func (c *LRUCache) SetInGroup(group string, key string, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't marshal value for key %s", key)
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		c.leaveGroup(entry)
+		entry.group = group
+		entry.value = data
+		entry.expiresAt = expiresAt
+		c.joinGroup(entry)
+		c.order.MoveToFront(element)
+		return
+	}
+
+	entry := &lruEntry{
+		key:       key,
+		group:     group,
+		value:     data,
+		expiresAt: expiresAt,
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	c.joinGroup(entry)
+
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *LRUCache) Delete(keys ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	removed := 0
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.removeElement(element)
+			removed++
+		}
+	}
+	c.metrics.invalidated(removed)
+}
+
+func (c *LRUCache) DeleteGroup(group string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	removed := 0
+	for key := range c.groups[group] {
+		c.removeElement(c.entries[key])
+		removed++
+	}
+	c.metrics.invalidated(removed)
+}
+
+func (c *LRUCache) Stats() *Stats {
+	c.mutex.Lock()
+	entries := c.order.Len()
+	c.mutex.Unlock()
+
+	return c.metrics.stats(entries)
+}
+
+func (c *LRUCache) removeElement(element *list.Element) {
+	entry := element.Value.(*lruEntry)
+	delete(c.entries, entry.key)
+	c.leaveGroup(entry)
+	c.order.Remove(element)
+}
+
+func (c *LRUCache) joinGroup(entry *lruEntry) {
+	if entry.group == "" {
+		return
+	}
+	if c.groups[entry.group] == nil {
+		c.groups[entry.group] = map[string]bool{}
+	}
+	c.groups[entry.group][entry.key] = true
+}
+
+func (c *LRUCache) leaveGroup(entry *lruEntry) {
+	if entry.group == "" {
+		return
+	}
+	delete(c.groups[entry.group], entry.key)
+	if len(c.groups[entry.group]) == 0 {
+		delete(c.groups, entry.group)
+	}
+}
@@ -0,0 +1,100 @@
+package unit_test_cache
+
+import (
+	"readmodels/internal/cache"
+	"readmodels/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReturnsCachedValue(t *testing.T) {
+	lruCache := cache.NewLRUCache(10, time.Minute)
+	expected := &model.UserProfile{
+		Username: "username1",
+		Name:     "user name",
+	}
+	lruCache.Set("key", expected)
+
+	var result model.UserProfile
+	found := lruCache.Get("key", &result)
+
+	assert.True(t, found)
+	assert.Equal(t, *expected, result)
+	assert.Equal(t, uint64(1), lruCache.Stats().Hits)
+}
+
+func TestGetMissesWhenKeyDoesNotExist(t *testing.T) {
+	lruCache := cache.NewLRUCache(10, time.Minute)
+
+	var result model.UserProfile
+	found := lruCache.Get("key", &result)
+
+	assert.False(t, found)
+	assert.Equal(t, uint64(1), lruCache.Stats().Misses)
+}
+
+func TestGetMissesWhenEntryExpired(t *testing.T) {
+	lruCache := cache.NewLRUCache(10, 10*time.Millisecond)
+	lruCache.Set("key", "value")
+	time.Sleep(20 * time.Millisecond)
+
+	var result string
+	found := lruCache.Get("key", &result)
+
+	assert.False(t, found)
+	assert.Equal(t, 0, lruCache.Stats().Entries)
+}
+
+func TestSetEvictsLeastRecentlyUsedEntry(t *testing.T) {
+	lruCache := cache.NewLRUCache(2, time.Minute)
+	lruCache.Set("key1", "value1")
+	lruCache.Set("key2", "value2")
+	var result string
+	lruCache.Get("key1", &result)
+
+	lruCache.Set("key3", "value3")
+
+	assert.True(t, lruCache.Get("key1", &result))
+	assert.False(t, lruCache.Get("key2", &result))
+	assert.True(t, lruCache.Get("key3", &result))
+}
+
+func TestDeleteGroupRemovesItsEntries(t *testing.T) {
+	lruCache := cache.NewLRUCache(10, time.Minute)
+	lruCache.SetInGroup(cache.PostsByUserGroup("username1"), "a", "value1")
+	lruCache.SetInGroup(cache.PostsByUserGroup("username1"), "b", "value2")
+	lruCache.SetInGroup(cache.PostsByUserGroup("username2"), "c", "value3")
+
+	lruCache.DeleteGroup(cache.PostsByUserGroup("username1"))
+
+	assert.Equal(t, 1, lruCache.Stats().Entries)
+	assert.Equal(t, uint64(2), lruCache.Stats().Invalidations)
+}
+
+func TestDeleteGroupSkipsEntriesEvictedOrMovedToAnotherGroup(t *testing.T) {
+	lruCache := cache.NewLRUCache(2, time.Minute)
+	lruCache.SetInGroup("group1", "a", "value1")
+	lruCache.SetInGroup("group1", "b", "value2")
+	lruCache.SetInGroup("group2", "b", "value3")
+	lruCache.Set("c", "value4")
+
+	lruCache.DeleteGroup("group1")
+
+	var result string
+	assert.True(t, lruCache.Get("b", &result))
+	assert.True(t, lruCache.Get("c", &result))
+	assert.Equal(t, uint64(0), lruCache.Stats().Invalidations)
+}
+
+func TestInvalidatePostRemovesOwnerListings(t *testing.T) {
+	lruCache := cache.NewLRUCache(10, time.Minute)
+	lruCache.Set(cache.PostOwnerKey("post1"), "username1")
+	lruCache.SetInGroup(cache.PostsByUserGroup("username1"), "a", "value1")
+
+	cache.InvalidatePost(lruCache, "post1")
+
+	var result string
+	assert.False(t, lruCache.Get("a", &result))
+}
@@ -0,0 +1,36 @@
+package comment
+
+import (
+	"readmodels/internal/cache"
+	"readmodels/internal/model"
+)
+
+type CachedRepository struct {
+	repository Repository
+	cache      cache.Cache
+}
+
+func NewCachedRepository(repository Repository, cache cache.Cache) *CachedRepository {
+	return &CachedRepository{
+		repository: repository,
+		cache:      cache,
+	}
+}
+
+func (r *CachedRepository) CreateComment(data *model.Comment) error {
+	defer cache.InvalidatePost(r.cache, data.PostId)
+	return r.repository.CreateComment(data)
+}
+
+func (r *CachedRepository) GetCommentsByPostId(postId string, lastCommentId uint64, limit int) ([]*model.Comment, uint64, error) {
+	return r.repository.GetCommentsByPostId(postId, lastCommentId, limit)
+}
+
+func (r *CachedRepository) UpdateComment(data *model.Comment) error {
+	return r.repository.UpdateComment(data)
+}
+
+func (r *CachedRepository) DeleteComment(postId string, commentId uint64) error {
+	defer cache.InvalidatePost(r.cache, postId)
+	return r.repository.DeleteComment(postId, commentId)
+}
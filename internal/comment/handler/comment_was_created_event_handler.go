@@ -11,11 +11,11 @@ import (
 //go:generate mockgen -source=comment_was_created_event_handler.go -destination=test/mock/comment_was_created_event_handler.go
 
 type CommentWasCreatedEvent struct {
-	CommentId uint64 `json:"commentId"`
-	Username  string `json:"username"`
-	PostId    string `json:"postId"`
-	Content   string `json:"content"`
-	CreatedAt string `json:"createdAt"`
+	CommentId uint64 `json:"commentId" validate:"required"`
+	Username  string `json:"username" validate:"id"`
+	PostId    string `json:"postId" validate:"id"`
+	Content   string `json:"content" validate:"required,max=5000"`
+	CreatedAt string `json:"createdAt" validate:"timestamp"`
 }
 
 type CommentWasCreatedEventService interface {
@@ -9,8 +9,8 @@ import (
 //go:generate mockgen -source=comment_was_deleted_event_handler.go -destination=test/mock/comment_was_deleted_event_handler.go
 
 type CommentWasDeletedEvent struct {
-	PostId    string `json:"postId"`
-	CommentId uint64 `json:"commentId"`
+	PostId    string `json:"postId" validate:"id"`
+	CommentId uint64 `json:"commentId" validate:"required"`
 }
 
 type CommentWasDeletedEventService interface {
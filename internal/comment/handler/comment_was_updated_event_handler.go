@@ -10,9 +10,9 @@ import (
 )
 
 type CommentWasUpdatedEvent struct {
-	CommentId uint64 `json:"commentId"`
-	Content   string `json:"content"`
-	UpdatedAt string `json:"updatedAt"`
+	CommentId uint64 `json:"commentId" validate:"required"`
+	Content   string `json:"content" validate:"required,max=5000"`
+	UpdatedAt string `json:"updatedAt" validate:"timestamp"`
 }
 
 type CommentWasUpdatedEventService interface {
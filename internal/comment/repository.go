@@ -19,7 +19,7 @@ func (r CommentRepository) CreateComment(data *model.Comment) error {
 	postKey := &database.PostMetadataKey{
 		PostId: data.PostId,
 	}
-	return r.database.Client.InsertDataAndIncreaseCounter("readmodels.comments", data, "PostMetadata", postKey, "Comments")
+	return r.database.Client.InsertDataAndIncreaseCounter(database.CommentsTable, data, database.PostMetadataTable, postKey, "Comments")
 }
 
 func (r CommentRepository) GetCommentsByPostId(postId string, lastCommentId uint64, limit int) ([]*model.Comment, uint64, error) {
@@ -39,7 +39,7 @@ func (r CommentRepository) GetPostIdFromComment(commentId uint64) (string, error
 	data := &struct {
 		PostId string `json:"postId"`
 	}{}
-	err := r.database.Client.GetData("readmodels.comments", commentKey, data)
+	err := r.database.Client.GetData(database.CommentsTable, commentKey, data)
 	if err != nil {
 		return "", err
 	}
@@ -57,7 +57,7 @@ func (r CommentRepository) UpdateComment(data *model.Comment) error {
 		"UpdatedAt": data.UpdatedAt,
 	}
 
-	return r.database.Client.UpdateData("readmodels.comments", commentKey, updateAttributes)
+	return r.database.Client.UpdateData(database.CommentsTable, commentKey, updateAttributes)
 }
 
 func (r CommentRepository) DeleteComment(postId string, commentId uint64) error {
@@ -69,5 +69,5 @@ func (r CommentRepository) DeleteComment(postId string, commentId uint64) error
 		PostId: postId,
 	}
 
-	return r.database.Client.RemoveDataAndDecreaseCounter("readmodels.comments", commentKey, "PostMetadata", postKey, "Comments")
+	return r.database.Client.RemoveDataAndDecreaseCounter(database.CommentsTable, commentKey, database.PostMetadataTable, postKey, "Comments")
 }
@@ -0,0 +1,48 @@
+package consumer
+
+import "time"
+
+//go:generate mockgen -source=consumer.go -destination=test/mock/consumer.go
+
+const (
+	StateStarting    = "starting"
+	StateRunning     = "running"
+	StatePaused      = "paused"
+	StateRebalancing = "rebalancing"
+	StateStopped     = "stopped"
+)
+
+// Consumer is the Kafka consumer group member of the service. Pause and
+// Resume act on the given topics, or on every topic when none is given, and
+// only on this instance: the partitions claimed by other replicas keep being
+// consumed, so each replica must be paused on its own.
+type Consumer interface {
+	Status() (*Status, error)
+	Pause(topics []string) error
+	Resume(topics []string) error
+}
+
+type Status struct {
+	GroupId      string             `json:"groupId"`
+	State        string             `json:"state"`
+	MemberId     string             `json:"memberId"`
+	GenerationId int32              `json:"generationId"`
+	Rebalances   uint64             `json:"rebalances"`
+	TotalLag     int64              `json:"totalLag"`
+	Partitions   []*PartitionStatus `json:"partitions"`
+}
+
+// PartitionStatus describes an assigned partition. CurrentOffset is the next
+// offset the consumer will process, CommittedOffset the one the group will
+// resume from (-1 when nothing was committed yet) and Lag the messages between
+// the committed offset and the high water mark.
+type PartitionStatus struct {
+	Topic           string     `json:"topic"`
+	Partition       int32      `json:"partition"`
+	CurrentOffset   int64      `json:"currentOffset"`
+	CommittedOffset int64      `json:"committedOffset"`
+	HighWaterMark   int64      `json:"highWaterMark"`
+	Lag             int64      `json:"lag"`
+	Paused          bool       `json:"paused"`
+	LastMessageAt   *time.Time `json:"lastMessageAt"`
+}
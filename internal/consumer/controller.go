@@ -0,0 +1,78 @@
+package consumer
+
+import (
+	"errors"
+	"readmodels/internal/api"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type ConsumerController struct {
+	consumer Consumer
+}
+
+func NewConsumerController(consumer Consumer) *ConsumerController {
+	return &ConsumerController{
+		consumer: consumer,
+	}
+}
+
+func (controller *ConsumerController) Routes(routerGroup *gin.RouterGroup) {
+	routerGroup.GET("/admin/consumer", controller.GetStatus)
+	routerGroup.POST("/admin/consumer/pause", controller.Pause)
+	routerGroup.POST("/admin/consumer/resume", controller.Resume)
+}
+
+func (controller *ConsumerController) GetStatus(c *gin.Context) {
+	log.Info().Msg("Handling Request GET ConsumerStatus")
+
+	controller.sendStatus(c)
+}
+
+// Pause stops projecting the topics given with the topic query parameter,
+// or every topic when there is none. Only the instance serving the request
+// is paused, the other replicas keep consuming their partitions.
+func (controller *ConsumerController) Pause(c *gin.Context) {
+	log.Info().Msg("Handling Request POST PauseConsumer")
+
+	err := controller.consumer.Pause(c.QueryArray("topic"))
+	if err != nil {
+		controller.sendError(c, err, "Couldn't pause the consumer")
+		return
+	}
+
+	controller.sendStatus(c)
+}
+
+func (controller *ConsumerController) Resume(c *gin.Context) {
+	log.Info().Msg("Handling Request POST ResumeConsumer")
+
+	err := controller.consumer.Resume(c.QueryArray("topic"))
+	if err != nil {
+		controller.sendError(c, err, "Couldn't resume the consumer")
+		return
+	}
+
+	controller.sendStatus(c)
+}
+
+func (controller *ConsumerController) sendStatus(c *gin.Context) {
+	status, err := controller.consumer.Status()
+	if err != nil {
+		api.SendInternalServerError(c, "Couldn't get the consumer status")
+		return
+	}
+
+	api.SendOKWithResult(c, status)
+}
+
+func (controller *ConsumerController) sendError(c *gin.Context, err error, message string) {
+	var unknownTopicError *UnknownTopicError
+	if errors.As(err, &unknownTopicError) {
+		api.SendBadRequest(c, err.Error())
+		return
+	}
+
+	api.SendInternalServerError(c, message)
+}
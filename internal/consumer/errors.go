@@ -0,0 +1,20 @@
+package consumer
+
+import (
+	"fmt"
+	"strings"
+)
+
+type UnknownTopicError struct {
+	topics []string
+}
+
+func (e *UnknownTopicError) Error() string {
+	return fmt.Sprintf("The consumer isn't subscribed to %s", strings.Join(e.topics, ", "))
+}
+
+func NewUnknownTopicError(topics []string) *UnknownTopicError {
+	return &UnknownTopicError{
+		topics: topics,
+	}
+}
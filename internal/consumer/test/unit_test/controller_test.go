@@ -0,0 +1,204 @@
+package consumer_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"readmodels/internal/consumer"
+	mock_consumer "readmodels/internal/consumer/test/mock"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog/log"
+)
+
+var loggerOutput bytes.Buffer
+var kafkaConsumer *mock_consumer.MockConsumer
+var controller *consumer.ConsumerController
+var apiResponse *httptest.ResponseRecorder
+var ginContext *gin.Context
+
+func setUpController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	kafkaConsumer = mock_consumer.NewMockConsumer(ctrl)
+	log.Logger = log.Output(&loggerOutput)
+	controller = consumer.NewConsumerController(kafkaConsumer)
+	gin.SetMode(gin.TestMode)
+	apiResponse = httptest.NewRecorder()
+	ginContext, _ = gin.CreateTestContext(apiResponse)
+}
+
+func removeSpace(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(s, " ", ""), "\t", ""), "\n", "")
+}
+
+func TestGetStatusWithController_WhenSuccess(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/admin/consumer", nil)
+	lastMessageAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	kafkaConsumer.EXPECT().Status().Return(&consumer.Status{
+		GroupId:      "readmodels-group",
+		State:        consumer.StateRunning,
+		MemberId:     "member1",
+		GenerationId: 3,
+		Rebalances:   2,
+		TotalLag:     5,
+		Partitions: []*consumer.PartitionStatus{
+			{
+				Topic:           "PostWasCreatedEvent",
+				Partition:       0,
+				CurrentOffset:   10,
+				CommittedOffset: 9,
+				HighWaterMark:   14,
+				Lag:             5,
+				Paused:          true,
+				LastMessageAt:   &lastMessageAt,
+			},
+			{
+				Topic:           "UserLikedPostEvent",
+				Partition:       1,
+				CurrentOffset:   -1,
+				CommittedOffset: -1,
+				HighWaterMark:   0,
+				Lag:             0,
+			},
+		},
+	}, nil)
+	expectedBodyResponse := `{
+		"error": false,
+		"message": "200 OK",
+		"content": {
+			"groupId": "readmodels-group",
+			"state": "running",
+			"memberId": "member1",
+			"generationId": 3,
+			"rebalances": 2,
+			"totalLag": 5,
+			"partitions": [
+				{
+					"topic": "PostWasCreatedEvent",
+					"partition": 0,
+					"currentOffset": 10,
+					"committedOffset": 9,
+					"highWaterMark": 14,
+					"lag": 5,
+					"paused": true,
+					"lastMessageAt": "2024-01-02T03:04:05Z"
+				},
+				{
+					"topic": "UserLikedPostEvent",
+					"partition": 1,
+					"currentOffset": -1,
+					"committedOffset": -1,
+					"highWaterMark": 0,
+					"lag": 0,
+					"paused": false,
+					"lastMessageAt": null
+				}
+			]
+		}
+	}`
+
+	controller.GetStatus(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 200)
+	assert.Equal(t, removeSpace(apiResponse.Body.String()), removeSpace(expectedBodyResponse))
+}
+
+func TestInternalServerErrorOnGetStatusWithController_WhenConsumerFails(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/admin/consumer", nil)
+	kafkaConsumer.EXPECT().Status().Return(nil, errors.New("some error"))
+	expectedBodyResponse := `{
+		"error": true,
+		"message": "Couldn't get the consumer status",
+		"content": null
+	}`
+
+	controller.GetStatus(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 500)
+	assert.Equal(t, removeSpace(apiResponse.Body.String()), removeSpace(expectedBodyResponse))
+}
+
+func TestPauseWithController_WhenSuccess(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("POST", "/admin/consumer/pause?topic=PostWasCreatedEvent&topic=UserLikedPostEvent", nil)
+	kafkaConsumer.EXPECT().Pause([]string{"PostWasCreatedEvent", "UserLikedPostEvent"}).Return(nil)
+	kafkaConsumer.EXPECT().Status().Return(&consumer.Status{
+		GroupId:    "readmodels-group",
+		State:      consumer.StateRunning,
+		Partitions: []*consumer.PartitionStatus{},
+	}, nil)
+
+	controller.Pause(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 200)
+}
+
+func TestPauseWithController_WhenNoTopicIsGiven(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("POST", "/admin/consumer/pause", nil)
+	kafkaConsumer.EXPECT().Pause(gomock.Len(0)).Return(nil)
+	kafkaConsumer.EXPECT().Status().Return(&consumer.Status{
+		GroupId:    "readmodels-group",
+		State:      consumer.StatePaused,
+		Partitions: []*consumer.PartitionStatus{},
+	}, nil)
+
+	controller.Pause(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 200)
+}
+
+func TestBadRequestErrorOnPauseWithController_WhenTopicIsUnknown(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("POST", "/admin/consumer/pause?topic=UnknownEvent", nil)
+	kafkaConsumer.EXPECT().Pause([]string{"UnknownEvent"}).Return(consumer.NewUnknownTopicError([]string{"UnknownEvent"}))
+	expectedBodyResponse := `{
+		"error": true,
+		"message": "The consumer isn't subscribed to UnknownEvent",
+		"content": null
+	}`
+
+	controller.Pause(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 400)
+	assert.Equal(t, removeSpace(apiResponse.Body.String()), removeSpace(expectedBodyResponse))
+}
+
+func TestResumeWithController_WhenSuccess(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("POST", "/admin/consumer/resume?topic=PostWasCreatedEvent", nil)
+	kafkaConsumer.EXPECT().Resume([]string{"PostWasCreatedEvent"}).Return(nil)
+	kafkaConsumer.EXPECT().Status().Return(&consumer.Status{
+		GroupId:    "readmodels-group",
+		State:      consumer.StateRunning,
+		Partitions: []*consumer.PartitionStatus{},
+	}, nil)
+
+	controller.Resume(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 200)
+}
+
+func TestInternalServerErrorOnResumeWithController_WhenConsumerFails(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("POST", "/admin/consumer/resume", nil)
+	kafkaConsumer.EXPECT().Resume(gomock.Len(0)).Return(errors.New("some error"))
+	expectedBodyResponse := `{
+		"error": true,
+		"message": "Couldn't resume the consumer",
+		"content": null
+	}`
+
+	controller.Resume(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 500)
+	assert.Equal(t, removeSpace(apiResponse.Body.String()), removeSpace(expectedBodyResponse))
+}
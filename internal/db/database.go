@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"readmodels/internal/model"
+	"time"
 )
 
 //go:generate mockgen -source=database.go -destination=test/mock/database.go
@@ -23,10 +24,17 @@ type DatabaseClient interface {
 	CreateTable(tableName string, keys *[]TableAttributes, ctx context.Context) error
 	IndexExists(tableName string, indexName string) bool
 	CreateIndexesOnTable(tableName, indexName string, inndexes *[]TableAttributes, ctx context.Context) error
+	EnableTimeToLive(tableName string, attributeName string, ctx context.Context) error
 	InsertData(tableName string, attributes any) error
 	InsertDataAndIncreaseCounter(tableName string, attributes any, counterTableName string, counterKey any, counterFieldName string) error
 	GetData(tableName string, key any, result any) error
+	GetDataIfExists(tableName string, key any, result any) (bool, error)
 	GetMultipleData(tableName string, keys []any, results any) error
+	GetAllData(tableName string, results any) error
+	ScanPage(tableName string, cursor string, limit int, results any) (string, error)
+	GetAllDataByIndex(tableName string, indexName string, attributeName string, value string, results any) error
+	GetDataByKeyPrefix(tableName string, partitionKey, partitionValue string, sortKey, sortPrefix string, limit int, results any) error
+	GetUserProfilesByIndexRegion(region, userType string, lastUsername, lastUserType string, limit int) ([]*model.UserProfile, string, string, error)
 	GetPostsByIndexUser(username string, currentUsername string, lastPostId, lastPostCreatedAt string, limit int) ([]*PostMetadata, string, string, error)
 	GetCommentsByIndexPostId(postID string, lastCommentId uint64, limit int) ([]*model.Comment, uint64, error)
 	GetPostLikesByIndexPostId(postID string, lastUsername string, limit int) ([]*model.UserMetadata, string, error)
@@ -34,9 +42,19 @@ type DatabaseClient interface {
 	GetReviewsByIndexPostId(postID string, lastReviewId uint64, limit int) ([]*model.Review, uint64, error)
 	UpdateData(tableName string, key any, updateAttributes map[string]any) error
 	IncrementCounter(tableName string, key any, counterFieldName string, incrementValue int) error
+	IncrementCounterOnce(markerTableName string, markerKey any, tableName string, key any, counterFieldName string, incrementValue int) error
+	IncrementAndGet(tableName string, key any, fieldName string, incrementValue float64, updateAttributes map[string]any) (float64, error)
+	UpdateDataIfEqual(tableName string, key any, updateAttributes map[string]any, removeAttributes []string, conditionFieldName string, conditionValue any) (bool, error)
+	UpdateDataIfLower(tableName string, key any, updateAttributes map[string]any, conditionFieldName string, conditionValue any) (bool, error)
 	RemoveDataAndDecreaseCounter(tableName string, key any, counterTableName string, counterKey any, counterFieldName string) error
 	RemoveMultipleDataAndDecreaseCounter(tableName string, keys []any, counterTableName string, counterKey any, counterFieldName string) error
 	RemoveMultipleData(tableName string, keys []any) error
+	TransactWriteData(items []*TransactionItem) error
+	GetNotificationsByIndexUsername(username string, lastNotificationId, lastUpdatedAt string, limit int) ([]*NotificationRecord, string, string, error)
+	GetTrendingPostsByIndex(postType string, lastPostId, lastScore string, limit int) ([]*TrendingRecord, string, string, error)
+	GetActivitiesByIndexUsername(username string, activityTypes []string, lastActivityId, lastOccurredAt string, limit int) ([]*ActivityRecord, string, string, error)
+	AcquireLock(tableName string, key any, owner string, ttl time.Duration) (bool, error)
+	ReleaseLock(tableName string, key any, owner string) error
 }
 
 func NewDatabase(client DatabaseClient) *Database {
@@ -38,3 +38,123 @@ func NewInvalidResultsError(expected, got string) *InvalidResultsError {
 func NewInvalidSlicePointerError(gotType string) *InvalidResultsError {
 	return NewInvalidResultsError("pointer to slice", gotType)
 }
+
+type UnknownMigrationError struct {
+	version int
+}
+
+func (e *UnknownMigrationError) Error() string {
+	return fmt.Sprintf("Migration version %d doesn't exist", e.version)
+}
+
+func NewUnknownMigrationError(version int) *UnknownMigrationError {
+	return &UnknownMigrationError{
+		version: version,
+	}
+}
+
+type MigrationLockedError struct {
+	owner string
+}
+
+func (e *MigrationLockedError) Error() string {
+	return fmt.Sprintf("Migrations are locked by another instance, %s couldn't acquire the lock", e.owner)
+}
+
+func NewMigrationLockedError(owner string) *MigrationLockedError {
+	return &MigrationLockedError{
+		owner: owner,
+	}
+}
+
+type MigrationDowngradeError struct {
+	appliedVersion int
+	targetVersion  int
+}
+
+func (e *MigrationDowngradeError) Error() string {
+	return fmt.Sprintf("Migration %d is already applied, it can't be migrated down to %d", e.appliedVersion, e.targetVersion)
+}
+
+func NewMigrationDowngradeError(appliedVersion, targetVersion int) *MigrationDowngradeError {
+	return &MigrationDowngradeError{
+		appliedVersion: appliedVersion,
+		targetVersion:  targetVersion,
+	}
+}
+
+type ChecksumMismatchError struct {
+	version  int
+	applied  string
+	expected string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("Migration %d was modified after being applied: applied checksum %s, current checksum %s", e.version, e.applied, e.expected)
+}
+
+func NewChecksumMismatchError(version int, applied, expected string) *ChecksumMismatchError {
+	return &ChecksumMismatchError{
+		version:  version,
+		applied:  applied,
+		expected: expected,
+	}
+}
+
+type UnprocessedItemsError struct {
+	table string
+	count int
+}
+
+func (e *UnprocessedItemsError) Error() string {
+	return fmt.Sprintf("%d items in table %s were left unprocessed after retrying", e.count, e.table)
+}
+
+func NewUnprocessedItemsError(table string, count int) *UnprocessedItemsError {
+	return &UnprocessedItemsError{
+		table: table,
+		count: count,
+	}
+}
+
+type ConcurrentUpdateError struct {
+	table string
+	key   any
+}
+
+func (e *ConcurrentUpdateError) Error() string {
+	return fmt.Sprintf("item %v in table %s kept changing while it was updated", e.key, e.table)
+}
+
+func NewConcurrentUpdateError(table string, key any) *ConcurrentUpdateError {
+	return &ConcurrentUpdateError{
+		table: table,
+		key:   key,
+	}
+}
+
+// ConditionFailedError reports the items of a transaction whose conditions
+// didn't hold. Nothing of the transaction was written.
+type ConditionFailedError struct {
+	Items []int
+}
+
+func (e *ConditionFailedError) Error() string {
+	return fmt.Sprintf("conditions of transaction items %v failed", e.Items)
+}
+
+// Failed reports whether the conditions of the item at index failed.
+func (e *ConditionFailedError) Failed(index int) bool {
+	for _, item := range e.Items {
+		if item == index {
+			return true
+		}
+	}
+	return false
+}
+
+func NewConditionFailedError(items []int) *ConditionFailedError {
+	return &ConditionFailedError{
+		Items: items,
+	}
+}
@@ -0,0 +1,378 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const migrationLockVersion = 0
+const migrationLockTtl = 10 * time.Minute
+const migrationLockPollInterval = time.Second
+const migrationPageSize = 100
+
+type Migration struct {
+	Version     int
+	Description string
+	Steps       []MigrationStep
+}
+
+type MigrationStep interface {
+	Describe() string
+	Apply(ctx context.Context, client DatabaseClient) error
+}
+
+type MigrationRecordKey struct {
+	Version int
+}
+
+type MigrationRecord struct {
+	Version     int
+	Description string
+	Checksum    string
+	AppliedAt   time.Time
+}
+
+type MigrationStatus struct {
+	Version     int
+	Description string
+	Checksum    string
+	Applied     bool
+	AppliedAt   time.Time
+}
+
+type CreateTableStep struct {
+	TableName string
+	Keys      []TableAttributes
+}
+
+type CreateIndexStep struct {
+	TableName string
+	IndexName string
+	Keys      []TableAttributes
+}
+
+// EnableTimeToLiveStep makes DynamoDB delete the items of the table once the
+// Unix time in AttributeName is past.
+type EnableTimeToLiveStep struct {
+	TableName     string
+	AttributeName string
+}
+
+// SetPostRevisionsStep sets the Revision of the posts stored before it was
+// written on creation, so their updates are ordered against LastUpdated.
+type SetPostRevisionsStep struct{}
+
+type Migrator struct {
+	client     DatabaseClient
+	migrations []Migration
+	owner      string
+}
+
+func NewMigrator(client DatabaseClient, migrations []Migration) *Migrator {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	hostname, _ := os.Hostname()
+
+	return &Migrator{
+		client:     client,
+		migrations: sorted,
+		owner:      fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+func (m Migration) Checksum() string {
+	hash := sha256.New()
+	hash.Write([]byte(fmt.Sprintf("%d:%s", m.Version, m.Description)))
+	for _, step := range m.Steps {
+		hash.Write([]byte("\n" + step.Describe()))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (s CreateTableStep) Describe() string {
+	return fmt.Sprintf("create table %s with keys %s", s.TableName, describeAttributes(s.Keys))
+}
+
+func (s CreateTableStep) Apply(ctx context.Context, client DatabaseClient) error {
+	if client.TableExists(s.TableName) {
+		log.Info().Msgf("Table %s already exists", s.TableName)
+		return nil
+	}
+
+	return client.CreateTable(s.TableName, &s.Keys, ctx)
+}
+
+func (s CreateIndexStep) Describe() string {
+	return fmt.Sprintf("create index %s on table %s with keys %s", s.IndexName, s.TableName, describeAttributes(s.Keys))
+}
+
+func (s CreateIndexStep) Apply(ctx context.Context, client DatabaseClient) error {
+	if client.IndexExists(s.TableName, s.IndexName) {
+		log.Info().Msgf("Index %s already exists on table %s", s.IndexName, s.TableName)
+		return nil
+	}
+
+	return client.CreateIndexesOnTable(s.TableName, s.IndexName, &s.Keys, ctx)
+}
+
+func (s EnableTimeToLiveStep) Describe() string {
+	return fmt.Sprintf("expire items of table %s at attribute %s", s.TableName, s.AttributeName)
+}
+
+func (s EnableTimeToLiveStep) Apply(ctx context.Context, client DatabaseClient) error {
+	return client.EnableTimeToLive(s.TableName, s.AttributeName, ctx)
+}
+
+func (s SetPostRevisionsStep) Describe() string {
+	return fmt.Sprintf("set Revision of posts in table %s from LastUpdated", PostMetadataTable)
+}
+
+// Apply only sets missing revisions, or lower ones, so it can be applied again
+// after a failure.
+func (s SetPostRevisionsStep) Apply(ctx context.Context, client DatabaseClient) error {
+	cursor := ""
+	for {
+		posts := []*PostMetadata{}
+		next, err := client.ScanPage(PostMetadataTable, cursor, migrationPageSize, &posts)
+		if err != nil {
+			return err
+		}
+
+		for _, post := range posts {
+			if post.Revision != 0 {
+				continue
+			}
+			revision := PostRevision(post.LastUpdated)
+			_, err = client.UpdateDataIfLower(PostMetadataTable, &PostMetadataKey{PostId: post.PostId}, map[string]any{"Revision": revision}, "Revision", revision)
+			if err != nil {
+				return err
+			}
+		}
+
+		if next == "" || ctx.Err() != nil {
+			return ctx.Err()
+		}
+		cursor = next
+	}
+}
+
+func (m *Migrator) Status() ([]*MigrationStatus, error) {
+	applied, err := m.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := &MigrationStatus{
+			Version:     migration.Version,
+			Description: migration.Description,
+			Checksum:    migration.Checksum(),
+		}
+		if record, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Up applies every pending migration. With dryRun the pending steps are only
+// logged and returned.
+func (m *Migrator) Up(ctx context.Context, dryRun bool) ([]Migration, error) {
+	if len(m.migrations) == 0 {
+		return []Migration{}, nil
+	}
+
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version, dryRun)
+}
+
+// To applies pending migrations up to and including targetVersion. Migrations
+// are forward only, so a target below an already applied version fails.
+func (m *Migrator) To(ctx context.Context, targetVersion int, dryRun bool) ([]Migration, error) {
+	if !m.isKnownVersion(targetVersion) {
+		return nil, NewUnknownMigrationError(targetVersion)
+	}
+
+	if dryRun {
+		pending, err := m.pendingMigrations(targetVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, migration := range pending {
+			logMigration("Would apply", migration)
+		}
+		return pending, nil
+	}
+
+	err := m.ensureLedger(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.acquireLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := m.client.ReleaseLock(MigrationsTable, &MigrationRecordKey{Version: migrationLockVersion}, m.owner)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("Couldn't release migrations lock")
+		}
+	}()
+
+	// Read once the lock is held, as the instance that held it may have just
+	// applied them
+	pending, err := m.pendingMigrations(targetVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, migration := range pending {
+		logMigration("Applying", migration)
+		for _, step := range migration.Steps {
+			err = step.Apply(ctx, m.client)
+			if err != nil {
+				log.Error().Stack().Err(err).Msgf("Migration %d failed on step: %s", migration.Version, step.Describe())
+				return nil, err
+			}
+		}
+
+		err = m.client.InsertData(MigrationsTable, &MigrationRecord{
+			Version:     migration.Version,
+			Description: migration.Description,
+			Checksum:    migration.Checksum(),
+			AppliedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		log.Info().Msgf("Migration %d applied", migration.Version)
+	}
+
+	return pending, nil
+}
+
+// acquireLock waits for the instance applying the migrations to release the
+// lock, so that every instance starts with the migrations applied. It gives up
+// when ctx is done.
+func (m *Migrator) acquireLock(ctx context.Context) error {
+	for {
+		acquired, err := m.client.AcquireLock(MigrationsTable, &MigrationRecordKey{Version: migrationLockVersion}, m.owner, migrationLockTtl)
+		if err != nil {
+			return err
+		}
+		if acquired {
+			return nil
+		}
+
+		log.Info().Msg("Migrations are locked by another instance, waiting for the lock")
+		select {
+		case <-ctx.Done():
+			return NewMigrationLockedError(m.owner)
+		case <-time.After(migrationLockPollInterval):
+		}
+	}
+}
+
+func (m *Migrator) pendingMigrations(targetVersion int) ([]Migration, error) {
+	applied, err := m.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	for version := range applied {
+		if version > targetVersion {
+			return nil, NewMigrationDowngradeError(version, targetVersion)
+		}
+	}
+
+	pending := []Migration{}
+	for _, migration := range m.migrations {
+		if migration.Version > targetVersion {
+			break
+		}
+		record, ok := applied[migration.Version]
+		if !ok {
+			pending = append(pending, migration)
+			continue
+		}
+		if record.Checksum != migration.Checksum() {
+			return nil, NewChecksumMismatchError(migration.Version, record.Checksum, migration.Checksum())
+		}
+	}
+
+	return pending, nil
+}
+
+func (m *Migrator) appliedMigrations() (map[int]*MigrationRecord, error) {
+	applied := map[int]*MigrationRecord{}
+	if !m.client.TableExists(MigrationsTable) {
+		return applied, nil
+	}
+
+	records := []*MigrationRecord{}
+	err := m.client.GetAllData(MigrationsTable, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if record.Version == migrationLockVersion {
+			continue
+		}
+		applied[record.Version] = record
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) ensureLedger(ctx context.Context) error {
+	return CreateTableStep{
+		TableName: MigrationsTable,
+		Keys: []TableAttributes{
+			{
+				Name:          "Version",
+				AttributeType: "number",
+			},
+		},
+	}.Apply(ctx, m.client)
+}
+
+func (m *Migrator) isKnownVersion(version int) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func logMigration(action string, migration Migration) {
+	log.Info().Msgf("%s migration %d: %s", action, migration.Version, migration.Description)
+	for _, step := range migration.Steps {
+		log.Info().Msgf("  - %s", step.Describe())
+	}
+}
+
+func describeAttributes(attributes []TableAttributes) string {
+	described := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		described[i] = attribute.Name + ":" + attribute.AttributeType
+	}
+	return "[" + strings.Join(described, ", ") + "]"
+}
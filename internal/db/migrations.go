@@ -6,176 +6,357 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func (db *Database) ApplyMigrations(ctx context.Context) error {
-	log.Info().Msg("Applying migrations...")
-
-	if !db.Client.TableExists("UserProfile") {
-		keys := []TableAttributes{
-			{
-				Name:          "Username",
-				AttributeType: "string",
-			},
-		}
-		err := db.Client.CreateTable("UserProfile", &keys, ctx)
-		if err != nil {
-			return err
-		}
-	}
-
-	if !db.Client.TableExists("PostMetadata") {
-		keys := []TableAttributes{
-			{
-				Name:          "PostId",
-				AttributeType: "string",
-			},
-		}
-		err := db.Client.CreateTable("PostMetadata", &keys, ctx)
-		if err != nil {
-			return err
-		}
-		indexes := []TableAttributes{
-			{
-				Name:          "Username",
-				AttributeType: "string",
-			},
-			{
-				Name:          "CreatedAt",
-				AttributeType: "string",
-			},
-		}
-		err = db.Client.CreateIndexesOnTable("PostMetadata", "UserIndex", &indexes, ctx)
-		if err != nil {
-			return err
-		}
-		indexes = []TableAttributes{
-			{
-				Name:          "Type",
-				AttributeType: "string",
-			},
-			{
-				Name:          "CreatedAt",
-				AttributeType: "string",
-			},
-		}
-		err = db.Client.CreateIndexesOnTable("PostMetadata", "TypeIndex", &indexes, ctx)
-		if err != nil {
-			return err
-		}
-	}
-
-	if !db.Client.TableExists("readmodels.comments") {
-		keys := []TableAttributes{
-			{
-				Name:          "CommentId",
-				AttributeType: "number",
-			},
-		}
-		err := db.Client.CreateTable("readmodels.comments", &keys, ctx)
-		if err != nil {
-			return err
-		}
-
-		indexes := []TableAttributes{
-			{
-				Name:          "PostId",
-				AttributeType: "string",
-			},
-			{
-				Name:          "CommentId",
-				AttributeType: "number",
-			},
-		}
-		err = db.Client.CreateIndexesOnTable("readmodels.comments", "PostIdIndex", &indexes, ctx)
-		if err != nil {
-			return err
-		}
-	}
-
-	if !db.Client.TableExists("readmodels.reviews") {
-		keys := []TableAttributes{
-			{
-				Name:          "ReviewId",
-				AttributeType: "number",
-			},
-		}
-		err := db.Client.CreateTable("readmodels.reviews", &keys, ctx)
-		if err != nil {
-			return err
-		}
-
-		indexes := []TableAttributes{
-			{
-				Name:          "PostId",
-				AttributeType: "string",
-			},
-			{
-				Name:          "ReviewId",
-				AttributeType: "number",
-			},
-		}
-		err = db.Client.CreateIndexesOnTable("readmodels.reviews", "PostIdIndex", &indexes, ctx)
-		if err != nil {
-			return err
-		}
-	}
-
-	if !db.Client.TableExists("readmodels.postLikes") {
-		keys := []TableAttributes{
-			{
-				Name:          "PostId",
-				AttributeType: "string",
-			},
-			{
-				Name:          "Username",
-				AttributeType: "string",
-			},
-		}
-		err := db.Client.CreateTable("readmodels.postLikes", &keys, ctx)
-		if err != nil {
-			return err
-		}
-	}
-
-	if !db.Client.TableExists("readmodels.postSuperlikes") {
-		keys := []TableAttributes{
-			{
-				Name:          "PostId",
-				AttributeType: "string",
-			},
-			{
-				Name:          "Username",
-				AttributeType: "string",
-			},
-		}
-		err := db.Client.CreateTable("readmodels.postSuperlikes", &keys, ctx)
-		if err != nil {
-			return err
-		}
+// Migrations returns every schema migration known by the service. Applied
+// migrations are recorded with their checksum, so never edit or renumber an
+// existing entry: append a new version instead.
+func Migrations() []Migration {
+	return []Migration{
+		{
+			Version:     1,
+			Description: "Create UserProfile table",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: UserProfileTable,
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     2,
+			Description: "Create PostMetadata table with user and type indexes",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: PostMetadataTable,
+					Keys: []TableAttributes{
+						{Name: "PostId", AttributeType: "string"},
+					},
+				},
+				CreateIndexStep{
+					TableName: PostMetadataTable,
+					IndexName: "UserIndex",
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+						{Name: "CreatedAt", AttributeType: "string"},
+					},
+				},
+				CreateIndexStep{
+					TableName: PostMetadataTable,
+					IndexName: "TypeIndex",
+					Keys: []TableAttributes{
+						{Name: "Type", AttributeType: "string"},
+						{Name: "CreatedAt", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     3,
+			Description: "Create comments table with post index",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: CommentsTable,
+					Keys: []TableAttributes{
+						{Name: "CommentId", AttributeType: "number"},
+					},
+				},
+				CreateIndexStep{
+					TableName: CommentsTable,
+					IndexName: "PostIdIndex",
+					Keys: []TableAttributes{
+						{Name: "PostId", AttributeType: "string"},
+						{Name: "CommentId", AttributeType: "number"},
+					},
+				},
+			},
+		},
+		{
+			Version:     4,
+			Description: "Create reviews table with post index",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: ReviewsTable,
+					Keys: []TableAttributes{
+						{Name: "ReviewId", AttributeType: "number"},
+					},
+				},
+				CreateIndexStep{
+					TableName: ReviewsTable,
+					IndexName: "PostIdIndex",
+					Keys: []TableAttributes{
+						{Name: "PostId", AttributeType: "string"},
+						{Name: "ReviewId", AttributeType: "number"},
+					},
+				},
+			},
+		},
+		{
+			Version:     5,
+			Description: "Create postLikes table",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: PostLikesTable,
+					Keys: []TableAttributes{
+						{Name: "PostId", AttributeType: "string"},
+						{Name: "Username", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     6,
+			Description: "Create postSuperlikes table",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: PostSuperlikesTable,
+					Keys: []TableAttributes{
+						{Name: "PostId", AttributeType: "string"},
+						{Name: "Username", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     7,
+			Description: "Add username index to reviews table",
+			Steps: []MigrationStep{
+				CreateIndexStep{
+					TableName: ReviewsTable,
+					IndexName: "UsernamePostIndex",
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+						{Name: "PostId", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     8,
+			Description: "Add username index to comments, likes and superlikes tables",
+			Steps: []MigrationStep{
+				CreateIndexStep{
+					TableName: CommentsTable,
+					IndexName: "UsernameIndex",
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+						{Name: "CommentId", AttributeType: "number"},
+					},
+				},
+				CreateIndexStep{
+					TableName: PostLikesTable,
+					IndexName: "UsernameIndex",
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+						{Name: "PostId", AttributeType: "string"},
+					},
+				},
+				CreateIndexStep{
+					TableName: PostSuperlikesTable,
+					IndexName: "UsernameIndex",
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+						{Name: "PostId", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     9,
+			Description: "Create erasures audit table",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: ErasuresTable,
+					Keys: []TableAttributes{
+						{Name: "SubjectHash", AttributeType: "string"},
+						{Name: "ErasedAt", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     10,
+			Description: "Create outbox table with status index",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: OutboxTable,
+					Keys: []TableAttributes{
+						{Name: "MessageId", AttributeType: "string"},
+					},
+				},
+				CreateIndexStep{
+					TableName: OutboxTable,
+					IndexName: OutboxStatusIndex,
+					Keys: []TableAttributes{
+						{Name: "Status", AttributeType: "string"},
+						{Name: "MessageId", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     11,
+			Description: "Create notifications table with recency index",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: NotificationsTable,
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+						{Name: "NotificationId", AttributeType: "string"},
+					},
+				},
+				CreateIndexStep{
+					TableName: NotificationsTable,
+					IndexName: NotificationsUpdatedAtIndex,
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+						{Name: "UpdatedAt", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     12,
+			Description: "Create activity table with recency and post indexes",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: ActivityTable,
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+						{Name: "ActivityId", AttributeType: "string"},
+					},
+				},
+				CreateIndexStep{
+					TableName: ActivityTable,
+					IndexName: ActivityOccurredAtIndex,
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+						{Name: "OccurredAt", AttributeType: "string"},
+					},
+				},
+				CreateIndexStep{
+					TableName: ActivityTable,
+					IndexName: ActivityPostIndex,
+					Keys: []TableAttributes{
+						{Name: "PostId", AttributeType: "string"},
+						{Name: "ActivityId", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     13,
+			Description: "Create trending table with global and per type score indexes",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: TrendingTable,
+					Keys: []TableAttributes{
+						{Name: "PostId", AttributeType: "string"},
+					},
+				},
+				CreateIndexStep{
+					TableName: TrendingTable,
+					IndexName: TrendingScoreIndex,
+					Keys: []TableAttributes{
+						{Name: "Ranking", AttributeType: "string"},
+						{Name: "Score", AttributeType: "number"},
+					},
+				},
+				CreateIndexStep{
+					TableName: TrendingTable,
+					IndexName: TrendingTypeScoreIndex,
+					Keys: []TableAttributes{
+						{Name: "PostType", AttributeType: "string"},
+						{Name: "Score", AttributeType: "number"},
+					},
+				},
+			},
+		},
+		{
+			Version:     14,
+			Description: "Create region index on UserProfile table",
+			Steps: []MigrationStep{
+				CreateIndexStep{
+					TableName: UserProfileTable,
+					IndexName: UserProfileRegionIndex,
+					Keys: []TableAttributes{
+						{Name: "Region", AttributeType: "string"},
+						{Name: "UserType", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     15,
+			Description: "Create erasure progress table",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: ErasureProgressTable,
+					Keys: []TableAttributes{
+						{Name: "SubjectHash", AttributeType: "string"},
+						{Name: "Step", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     16,
+			Description: "Create notification actors table with actor index",
+			Steps: []MigrationStep{
+				CreateTableStep{
+					TableName: NotificationActorsTable,
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+						{Name: "ActorId", AttributeType: "string"},
+					},
+				},
+				CreateIndexStep{
+					TableName: NotificationActorsTable,
+					IndexName: NotificationActorIndex,
+					Keys: []TableAttributes{
+						{Name: "Actor", AttributeType: "string"},
+						{Name: "Username", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     17,
+			Description: "Create user index on trending table",
+			Steps: []MigrationStep{
+				CreateIndexStep{
+					TableName: TrendingTable,
+					IndexName: TrendingUserIndex,
+					Keys: []TableAttributes{
+						{Name: "Username", AttributeType: "string"},
+						{Name: "PostId", AttributeType: "string"},
+					},
+				},
+			},
+		},
+		{
+			Version:     18,
+			Description: "Set the revision of existing posts",
+			Steps: []MigrationStep{
+				SetPostRevisionsStep{},
+			},
+		},
+		{
+			Version:     19,
+			Description: "Expire sent outbox messages",
+			Steps: []MigrationStep{
+				EnableTimeToLiveStep{
+					TableName:     OutboxTable,
+					AttributeName: "ExpiresAt",
+				},
+			},
+		},
 	}
+}
 
-	if db.Client.TableExists("readmodels.reviews") {
-		// Comprobar se o índice xa existe antes de crealo
-		if !db.Client.IndexExists("readmodels.reviews", "UsernamePostIndex") {
-			usernamePostIndexes := []TableAttributes{
-				{
-					Name:          "Username",
-					AttributeType: "string",
-				},
-				{
-					Name:          "PostId",
-					AttributeType: "string",
-				},
-			}
-			err := db.Client.CreateIndexesOnTable("readmodels.reviews", "UsernamePostIndex", &usernamePostIndexes, ctx)
-			if err != nil {
-				log.Error().Err(err).Msg("Error creating UsernamePostIndex on readmodels.reviews")
-				return err
-			}
-			log.Info().Msg("Created UsernamePostIndex on readmodels.reviews table")
-		} else {
-			log.Info().Msg("UsernamePostIndex already exists on readmodels.reviews table")
-		}
-	}
+func (db *Database) ApplyMigrations(ctx context.Context) error {
+	log.Info().Msg("Applying migrations...")
 
-	return nil
+	_, err := NewMigrator(db.Client, Migrations()).Up(ctx, false)
+	return err
 }
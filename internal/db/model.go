@@ -4,6 +4,11 @@ import (
 	"time"
 )
 
+// UserProfileRegionIndex lists the users of a region by type. Users without
+// region are left out of it, including the profiles created before it, until
+// the `users backfill-regions` command fills them from the retained events.
+const UserProfileRegionIndex = "RegionIndex"
+
 type UserProfileKey struct {
 	Username string
 }
@@ -27,6 +32,13 @@ type PostMetadata struct {
 	IsSuperlikedByCurrentUser bool      `json:"isSuperlikedByCurrentUser"`
 	CreatedAt                 time.Time `json:"created_at"`
 	LastUpdated               time.Time `json:"last_updated"`
+	Revision                  int64     `json:"-"`
+}
+
+// PostRevision orders the versions of a post, as the stored LastUpdated
+// strings don't sort in time order.
+func PostRevision(lastUpdated time.Time) int64 {
+	return lastUpdated.UnixMicro()
 }
 
 type CommentKey struct {
@@ -52,6 +64,120 @@ type PostSuperlikeKey struct {
 	Username string
 }
 
+const NotificationsUpdatedAtIndex = "UpdatedAtIndex"
+
+type NotificationKey struct {
+	Username       string
+	NotificationId string
+}
+
+// NotificationRecord groups the actors of the same activity on the same
+// subject, e.g. every like of a post. Actors only keeps a few of them, the
+// ActorsCount actors are stored in the notification actors table. UpdatedAt
+// is formatted with model.TimeLayout so that it sorts in the recency index.
+type NotificationRecord struct {
+	Username       string
+	NotificationId string
+	Type           string
+	PostId         string
+	Actors         []string `dynamodbav:",stringset,omitempty"`
+	LastActor      string
+	ActorsCount    int
+	Read           bool
+	UpdatedAt      string
+}
+
+// NotificationsUnreadId is the NotificationId of the item that counts the
+// unread notifications of the user. It has no UpdatedAt, so it isn't listed
+// with the notifications.
+const NotificationsUnreadId = "unread"
+
+type UnreadNotificationsRecord struct {
+	Username       string
+	NotificationId string
+	Unread         int
+}
+
+const NotificationActorIndex = "ActorIndex"
+
+type NotificationActorKey struct {
+	Username string
+	ActorId  string
+}
+
+// NotificationActorRecord stores one actor of the notification of Username,
+// with the ids of the comments or reviews of the actor. Only a few actors are
+// kept in the notification itself, see NotificationRecord.
+type NotificationActorRecord struct {
+	Username       string
+	ActorId        string
+	NotificationId string
+	Actor          string
+	References     []string `dynamodbav:",stringset,omitempty"`
+}
+
+// NotificationActorId returns the ActorId of the actor of the notification,
+// which sorts the actors of the same notification together.
+func NotificationActorId(notificationId, actor string) string {
+	return notificationId + "/" + actor
+}
+
+const (
+	ActivityOccurredAtIndex = "OccurredAtIndex"
+	ActivityPostIndex       = "PostIndex"
+)
+
+type ActivityKey struct {
+	Username   string
+	ActivityId string
+}
+
+// ActivityRecord is an entry of the activity log of Username. PostId is left
+// out of follows so that they stay out of the post index. OccurredAt is
+// formatted with model.TimeLayout so that it sorts in the recency index.
+type ActivityRecord struct {
+	Username   string
+	ActivityId string
+	Type       string
+	PostId     string `dynamodbav:",omitempty"`
+	CommentId  uint64 `dynamodbav:",omitempty"`
+	ReviewId   uint64 `dynamodbav:",omitempty"`
+	Followee   string `dynamodbav:",omitempty"`
+	OccurredAt string
+}
+
+const (
+	TrendingScoreIndex     = "ScoreIndex"
+	TrendingTypeScoreIndex = "TypeScoreIndex"
+	TrendingUserIndex      = "UserIndex"
+	// TrendingRanking is the partition of every post in the score index
+	TrendingRanking = "global"
+)
+
+type TrendingKey struct {
+	PostId string
+}
+
+// TrendingRecord holds the weighted engagement of a post. Score ranks the
+// posts and is left out, removing the post from the indexes, while the post
+// has no engagement.
+type TrendingRecord struct {
+	PostId     string
+	Username   string
+	PostType   string
+	Ranking    string
+	CreatedAt  time.Time
+	Engagement float64
+	Score      *float64 `dynamodbav:",omitempty"`
+}
+
+// ErasureProgressKey marks a step of a user erasure as applied, so it isn't
+// applied again when the erasure is retried. Step is a hash as well.
+type ErasureProgressKey struct {
+	SubjectHash string
+	Step        string
+}
+
 type PostSuperlikeMetadata struct {
 	PostId   string
 	Username string
@@ -0,0 +1,55 @@
+package database
+
+import "time"
+
+const (
+	OutboxStatusIndex   = "StatusIndex"
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+)
+
+type OutboxMessageKey struct {
+	MessageId string
+}
+
+// OutboxMessage is a derived event written to the outbox table to be
+// published. Key is the Kafka message key that keeps the events of an entity
+// in order, and Sequence numbers the messages of the entity of EventType and
+// Key in the order they were written. Published messages are kept with the
+// sent status until ExpiresAt, the Unix time DynamoDB deletes them at.
+type OutboxMessage struct {
+	MessageId string
+	Status    string
+	EventType string
+	Key       string
+	Sequence  int64
+	Payload   []byte
+	CreatedAt time.Time
+	SentAt    *time.Time `dynamodbav:",omitempty"`
+	ExpiresAt int64      `dynamodbav:",omitempty"`
+}
+
+// OutboxSequence is the last Sequence given to the messages of an entity. It
+// has no Status, so it never shows up in the status index.
+type OutboxSequence struct {
+	MessageId string
+	Sequence  int64
+}
+
+func OutboxSequenceId(eventType, key string) string {
+	return "sequence/" + eventType + "/" + key
+}
+
+// CounterChange describes the update of a counter by a DatabaseClient method.
+type CounterChange struct {
+	CounterTable string
+	CounterKey   any
+	CounterField string
+	Delta        int
+}
+
+// DerivedEvents builds the outbox message written in the same transaction as
+// a counter update. A nil message means the change isn't published.
+type DerivedEvents interface {
+	CounterChanged(change *CounterChange) (*OutboxMessage, error)
+}
@@ -0,0 +1,82 @@
+package database
+
+import "strings"
+
+const (
+	UserProfileTable        = "UserProfile"
+	PostMetadataTable       = "PostMetadata"
+	CommentsTable           = "readmodels.comments"
+	ReviewsTable            = "readmodels.reviews"
+	PostLikesTable          = "readmodels.postLikes"
+	PostSuperlikesTable     = "readmodels.postSuperlikes"
+	MigrationsTable         = "readmodels.migrations"
+	ErasuresTable           = "readmodels.erasures"
+	ErasureProgressTable    = "readmodels.erasureProgress"
+	OutboxTable             = "readmodels.outbox"
+	NotificationsTable      = "readmodels.notifications"
+	NotificationActorsTable = "readmodels.notificationActors"
+	ActivityTable           = "readmodels.activity"
+	TrendingTable           = "readmodels.trending"
+)
+
+// Tables returns the logical name of every table of the service.
+func Tables() []string {
+	return []string{
+		UserProfileTable,
+		PostMetadataTable,
+		CommentsTable,
+		ReviewsTable,
+		PostLikesTable,
+		PostSuperlikesTable,
+		MigrationsTable,
+		ErasuresTable,
+		ErasureProgressTable,
+		OutboxTable,
+		NotificationsTable,
+		NotificationActorsTable,
+		ActivityTable,
+		TrendingTable,
+	}
+}
+
+// TableRegistry maps the logical table names used across the service to the
+// physical DynamoDB table names of the current environment, so several
+// environments or test runs can share one DynamoDB account.
+type TableRegistry struct {
+	prefix string
+	suffix string
+}
+
+func NewTableRegistry(prefix, suffix string) *TableRegistry {
+	return &TableRegistry{
+		prefix: strings.Trim(prefix, "."),
+		suffix: strings.Trim(suffix, "."),
+	}
+}
+
+func (r *TableRegistry) Name(tableName string) string {
+	if r == nil {
+		return tableName
+	}
+
+	name := tableName
+	if r.prefix != "" {
+		name = r.prefix + "." + name
+	}
+	if r.suffix != "" {
+		name = name + "." + r.suffix
+	}
+	return name
+}
+
+// Owns reports whether a physical table is one of the service tables in the
+// registry environment. Other tables of the account are never owned, even
+// without prefix or suffix.
+func (r *TableRegistry) Owns(physicalTableName string) bool {
+	for _, tableName := range Tables() {
+		if r.Name(tableName) == physicalTableName {
+			return true
+		}
+	}
+	return false
+}
@@ -9,6 +9,7 @@ import (
 	database "readmodels/internal/db"
 	model "readmodels/internal/model"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -36,6 +37,21 @@ func (m *MockDatabaseClient) EXPECT() *MockDatabaseClientMockRecorder {
 	return m.recorder
 }
 
+// AcquireLock mocks base method.
+func (m *MockDatabaseClient) AcquireLock(tableName string, key any, owner string, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireLock", tableName, key, owner, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireLock indicates an expected call of AcquireLock.
+func (mr *MockDatabaseClientMockRecorder) AcquireLock(tableName, key, owner, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLock", reflect.TypeOf((*MockDatabaseClient)(nil).AcquireLock), tableName, key, owner, ttl)
+}
+
 // Clean mocks base method.
 func (m *MockDatabaseClient) Clean() {
 	m.ctrl.T.Helper()
@@ -76,6 +92,65 @@ func (mr *MockDatabaseClientMockRecorder) CreateTable(tableName, keys, ctx inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTable", reflect.TypeOf((*MockDatabaseClient)(nil).CreateTable), tableName, keys, ctx)
 }
 
+// EnableTimeToLive mocks base method.
+func (m *MockDatabaseClient) EnableTimeToLive(tableName, attributeName string, ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTimeToLive", tableName, attributeName, ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTimeToLive indicates an expected call of EnableTimeToLive.
+func (mr *MockDatabaseClientMockRecorder) EnableTimeToLive(tableName, attributeName, ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTimeToLive", reflect.TypeOf((*MockDatabaseClient)(nil).EnableTimeToLive), tableName, attributeName, ctx)
+}
+
+// GetActivitiesByIndexUsername mocks base method.
+func (m *MockDatabaseClient) GetActivitiesByIndexUsername(username string, activityTypes []string, lastActivityId, lastOccurredAt string, limit int) ([]*database.ActivityRecord, string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivitiesByIndexUsername", username, activityTypes, lastActivityId, lastOccurredAt, limit)
+	ret0, _ := ret[0].([]*database.ActivityRecord)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetActivitiesByIndexUsername indicates an expected call of GetActivitiesByIndexUsername.
+func (mr *MockDatabaseClientMockRecorder) GetActivitiesByIndexUsername(username, activityTypes, lastActivityId, lastOccurredAt, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivitiesByIndexUsername", reflect.TypeOf((*MockDatabaseClient)(nil).GetActivitiesByIndexUsername), username, activityTypes, lastActivityId, lastOccurredAt, limit)
+}
+
+// GetAllData mocks base method.
+func (m *MockDatabaseClient) GetAllData(tableName string, results any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllData", tableName, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetAllData indicates an expected call of GetAllData.
+func (mr *MockDatabaseClientMockRecorder) GetAllData(tableName, results interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllData", reflect.TypeOf((*MockDatabaseClient)(nil).GetAllData), tableName, results)
+}
+
+// GetAllDataByIndex mocks base method.
+func (m *MockDatabaseClient) GetAllDataByIndex(tableName, indexName, attributeName, value string, results any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllDataByIndex", tableName, indexName, attributeName, value, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetAllDataByIndex indicates an expected call of GetAllDataByIndex.
+func (mr *MockDatabaseClientMockRecorder) GetAllDataByIndex(tableName, indexName, attributeName, value, results interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllDataByIndex", reflect.TypeOf((*MockDatabaseClient)(nil).GetAllDataByIndex), tableName, indexName, attributeName, value, results)
+}
+
 // GetCommentsByIndexPostId mocks base method.
 func (m *MockDatabaseClient) GetCommentsByIndexPostId(postID string, lastCommentId uint64, limit int) ([]*model.Comment, uint64, error) {
 	m.ctrl.T.Helper()
@@ -106,6 +181,35 @@ func (mr *MockDatabaseClientMockRecorder) GetData(tableName, key, result interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetData", reflect.TypeOf((*MockDatabaseClient)(nil).GetData), tableName, key, result)
 }
 
+// GetDataByKeyPrefix mocks base method.
+func (m *MockDatabaseClient) GetDataByKeyPrefix(tableName, partitionKey, partitionValue, sortKey, sortPrefix string, limit int, results any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataByKeyPrefix", tableName, partitionKey, partitionValue, sortKey, sortPrefix, limit, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetDataByKeyPrefix indicates an expected call of GetDataByKeyPrefix.
+func (mr *MockDatabaseClientMockRecorder) GetDataByKeyPrefix(tableName, partitionKey, partitionValue, sortKey, sortPrefix, limit, results interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataByKeyPrefix", reflect.TypeOf((*MockDatabaseClient)(nil).GetDataByKeyPrefix), tableName, partitionKey, partitionValue, sortKey, sortPrefix, limit, results)
+}
+
+// GetDataIfExists mocks base method.
+func (m *MockDatabaseClient) GetDataIfExists(tableName string, key, result any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataIfExists", tableName, key, result)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataIfExists indicates an expected call of GetDataIfExists.
+func (mr *MockDatabaseClientMockRecorder) GetDataIfExists(tableName, key, result interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataIfExists", reflect.TypeOf((*MockDatabaseClient)(nil).GetDataIfExists), tableName, key, result)
+}
+
 // GetMultipleData mocks base method.
 func (m *MockDatabaseClient) GetMultipleData(tableName string, keys []any, results any) error {
 	m.ctrl.T.Helper()
@@ -120,6 +224,23 @@ func (mr *MockDatabaseClientMockRecorder) GetMultipleData(tableName, keys, resul
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultipleData", reflect.TypeOf((*MockDatabaseClient)(nil).GetMultipleData), tableName, keys, results)
 }
 
+// GetNotificationsByIndexUsername mocks base method.
+func (m *MockDatabaseClient) GetNotificationsByIndexUsername(username, lastNotificationId, lastUpdatedAt string, limit int) ([]*database.NotificationRecord, string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationsByIndexUsername", username, lastNotificationId, lastUpdatedAt, limit)
+	ret0, _ := ret[0].([]*database.NotificationRecord)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetNotificationsByIndexUsername indicates an expected call of GetNotificationsByIndexUsername.
+func (mr *MockDatabaseClientMockRecorder) GetNotificationsByIndexUsername(username, lastNotificationId, lastUpdatedAt, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationsByIndexUsername", reflect.TypeOf((*MockDatabaseClient)(nil).GetNotificationsByIndexUsername), username, lastNotificationId, lastUpdatedAt, limit)
+}
+
 // GetPostLikesByIndexPostId mocks base method.
 func (m *MockDatabaseClient) GetPostLikesByIndexPostId(postID, lastUsername string, limit int) ([]*model.UserMetadata, string, error) {
 	m.ctrl.T.Helper()
@@ -185,6 +306,55 @@ func (mr *MockDatabaseClientMockRecorder) GetReviewsByIndexPostId(postID, lastRe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByIndexPostId", reflect.TypeOf((*MockDatabaseClient)(nil).GetReviewsByIndexPostId), postID, lastReviewId, limit)
 }
 
+// GetTrendingPostsByIndex mocks base method.
+func (m *MockDatabaseClient) GetTrendingPostsByIndex(postType, lastPostId, lastScore string, limit int) ([]*database.TrendingRecord, string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrendingPostsByIndex", postType, lastPostId, lastScore, limit)
+	ret0, _ := ret[0].([]*database.TrendingRecord)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetTrendingPostsByIndex indicates an expected call of GetTrendingPostsByIndex.
+func (mr *MockDatabaseClientMockRecorder) GetTrendingPostsByIndex(postType, lastPostId, lastScore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrendingPostsByIndex", reflect.TypeOf((*MockDatabaseClient)(nil).GetTrendingPostsByIndex), postType, lastPostId, lastScore, limit)
+}
+
+// GetUserProfilesByIndexRegion mocks base method.
+func (m *MockDatabaseClient) GetUserProfilesByIndexRegion(region, userType, lastUsername, lastUserType string, limit int) ([]*model.UserProfile, string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfilesByIndexRegion", region, userType, lastUsername, lastUserType, limit)
+	ret0, _ := ret[0].([]*model.UserProfile)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetUserProfilesByIndexRegion indicates an expected call of GetUserProfilesByIndexRegion.
+func (mr *MockDatabaseClientMockRecorder) GetUserProfilesByIndexRegion(region, userType, lastUsername, lastUserType, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfilesByIndexRegion", reflect.TypeOf((*MockDatabaseClient)(nil).GetUserProfilesByIndexRegion), region, userType, lastUsername, lastUserType, limit)
+}
+
+// IncrementAndGet mocks base method.
+func (m *MockDatabaseClient) IncrementAndGet(tableName string, key any, fieldName string, incrementValue float64, updateAttributes map[string]any) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementAndGet", tableName, key, fieldName, incrementValue, updateAttributes)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementAndGet indicates an expected call of IncrementAndGet.
+func (mr *MockDatabaseClientMockRecorder) IncrementAndGet(tableName, key, fieldName, incrementValue, updateAttributes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementAndGet", reflect.TypeOf((*MockDatabaseClient)(nil).IncrementAndGet), tableName, key, fieldName, incrementValue, updateAttributes)
+}
+
 // IncrementCounter mocks base method.
 func (m *MockDatabaseClient) IncrementCounter(tableName string, key any, counterFieldName string, incrementValue int) error {
 	m.ctrl.T.Helper()
@@ -199,6 +369,20 @@ func (mr *MockDatabaseClientMockRecorder) IncrementCounter(tableName, key, count
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementCounter", reflect.TypeOf((*MockDatabaseClient)(nil).IncrementCounter), tableName, key, counterFieldName, incrementValue)
 }
 
+// IncrementCounterOnce mocks base method.
+func (m *MockDatabaseClient) IncrementCounterOnce(markerTableName string, markerKey any, tableName string, key any, counterFieldName string, incrementValue int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementCounterOnce", markerTableName, markerKey, tableName, key, counterFieldName, incrementValue)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementCounterOnce indicates an expected call of IncrementCounterOnce.
+func (mr *MockDatabaseClientMockRecorder) IncrementCounterOnce(markerTableName, markerKey, tableName, key, counterFieldName, incrementValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementCounterOnce", reflect.TypeOf((*MockDatabaseClient)(nil).IncrementCounterOnce), markerTableName, markerKey, tableName, key, counterFieldName, incrementValue)
+}
+
 // IndexExists mocks base method.
 func (m *MockDatabaseClient) IndexExists(tableName, indexName string) bool {
 	m.ctrl.T.Helper()
@@ -241,6 +425,20 @@ func (mr *MockDatabaseClientMockRecorder) InsertDataAndIncreaseCounter(tableName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDataAndIncreaseCounter", reflect.TypeOf((*MockDatabaseClient)(nil).InsertDataAndIncreaseCounter), tableName, attributes, counterTableName, counterKey, counterFieldName)
 }
 
+// ReleaseLock mocks base method.
+func (m *MockDatabaseClient) ReleaseLock(tableName string, key any, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLock", tableName, key, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseLock indicates an expected call of ReleaseLock.
+func (mr *MockDatabaseClientMockRecorder) ReleaseLock(tableName, key, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLock", reflect.TypeOf((*MockDatabaseClient)(nil).ReleaseLock), tableName, key, owner)
+}
+
 // RemoveDataAndDecreaseCounter mocks base method.
 func (m *MockDatabaseClient) RemoveDataAndDecreaseCounter(tableName string, key any, counterTableName string, counterKey any, counterFieldName string) error {
 	m.ctrl.T.Helper()
@@ -283,6 +481,21 @@ func (mr *MockDatabaseClientMockRecorder) RemoveMultipleDataAndDecreaseCounter(t
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMultipleDataAndDecreaseCounter", reflect.TypeOf((*MockDatabaseClient)(nil).RemoveMultipleDataAndDecreaseCounter), tableName, keys, counterTableName, counterKey, counterFieldName)
 }
 
+// ScanPage mocks base method.
+func (m *MockDatabaseClient) ScanPage(tableName, cursor string, limit int, results any) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanPage", tableName, cursor, limit, results)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanPage indicates an expected call of ScanPage.
+func (mr *MockDatabaseClientMockRecorder) ScanPage(tableName, cursor, limit, results interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanPage", reflect.TypeOf((*MockDatabaseClient)(nil).ScanPage), tableName, cursor, limit, results)
+}
+
 // TableExists mocks base method.
 func (m *MockDatabaseClient) TableExists(tableName string) bool {
 	m.ctrl.T.Helper()
@@ -297,6 +510,20 @@ func (mr *MockDatabaseClientMockRecorder) TableExists(tableName interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TableExists", reflect.TypeOf((*MockDatabaseClient)(nil).TableExists), tableName)
 }
 
+// TransactWriteData mocks base method.
+func (m *MockDatabaseClient) TransactWriteData(items []*database.TransactionItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransactWriteData", items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransactWriteData indicates an expected call of TransactWriteData.
+func (mr *MockDatabaseClientMockRecorder) TransactWriteData(items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactWriteData", reflect.TypeOf((*MockDatabaseClient)(nil).TransactWriteData), items)
+}
+
 // Truncate mocks base method.
 func (m *MockDatabaseClient) Truncate() {
 	m.ctrl.T.Helper()
@@ -322,3 +549,33 @@ func (mr *MockDatabaseClientMockRecorder) UpdateData(tableName, key, updateAttri
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateData", reflect.TypeOf((*MockDatabaseClient)(nil).UpdateData), tableName, key, updateAttributes)
 }
+
+// UpdateDataIfEqual mocks base method.
+func (m *MockDatabaseClient) UpdateDataIfEqual(tableName string, key any, updateAttributes map[string]any, removeAttributes []string, conditionFieldName string, conditionValue any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDataIfEqual", tableName, key, updateAttributes, removeAttributes, conditionFieldName, conditionValue)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDataIfEqual indicates an expected call of UpdateDataIfEqual.
+func (mr *MockDatabaseClientMockRecorder) UpdateDataIfEqual(tableName, key, updateAttributes, removeAttributes, conditionFieldName, conditionValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDataIfEqual", reflect.TypeOf((*MockDatabaseClient)(nil).UpdateDataIfEqual), tableName, key, updateAttributes, removeAttributes, conditionFieldName, conditionValue)
+}
+
+// UpdateDataIfLower mocks base method.
+func (m *MockDatabaseClient) UpdateDataIfLower(tableName string, key any, updateAttributes map[string]any, conditionFieldName string, conditionValue any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDataIfLower", tableName, key, updateAttributes, conditionFieldName, conditionValue)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDataIfLower indicates an expected call of UpdateDataIfLower.
+func (mr *MockDatabaseClientMockRecorder) UpdateDataIfLower(tableName, key, updateAttributes, conditionFieldName, conditionValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDataIfLower", reflect.TypeOf((*MockDatabaseClient)(nil).UpdateDataIfLower), tableName, key, updateAttributes, conditionFieldName, conditionValue)
+}
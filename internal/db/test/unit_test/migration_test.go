@@ -0,0 +1,200 @@
+package unit_test_database
+
+import (
+	"context"
+	"errors"
+	database "readmodels/internal/db"
+	mock_database "readmodels/internal/db/test/mock"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var client *mock_database.MockDatabaseClient
+var migrator *database.Migrator
+var migrations []database.Migration
+
+func setUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client = mock_database.NewMockDatabaseClient(ctrl)
+	migrations = []database.Migration{
+		{
+			Version:     2,
+			Description: "Create table B",
+			Steps: []database.MigrationStep{
+				database.CreateTableStep{
+					TableName: "B",
+					Keys:      []database.TableAttributes{{Name: "Id", AttributeType: "string"}},
+				},
+			},
+		},
+		{
+			Version:     1,
+			Description: "Create table A",
+			Steps: []database.MigrationStep{
+				database.CreateTableStep{
+					TableName: "A",
+					Keys:      []database.TableAttributes{{Name: "Id", AttributeType: "string"}},
+				},
+			},
+		},
+	}
+	migrator = database.NewMigrator(client, migrations)
+}
+
+func expectAppliedMigrations(records []*database.MigrationRecord) {
+	client.EXPECT().TableExists(database.MigrationsTable).Return(true).AnyTimes()
+	client.EXPECT().GetAllData(database.MigrationsTable, gomock.Any()).DoAndReturn(func(tableName string, results any) error {
+		*results.(*[]*database.MigrationRecord) = records
+		return nil
+	})
+}
+
+func expectLock() {
+	lockKey := &database.MigrationRecordKey{Version: 0}
+	client.EXPECT().AcquireLock(database.MigrationsTable, lockKey, gomock.Any(), gomock.Any()).Return(true, nil)
+	client.EXPECT().ReleaseLock(database.MigrationsTable, lockKey, gomock.Any()).Return(nil)
+}
+
+func TestUpAppliesPendingMigrationsInOrder(t *testing.T) {
+	setUp(t)
+	expectAppliedMigrations([]*database.MigrationRecord{})
+	expectLock()
+	gomock.InOrder(
+		client.EXPECT().TableExists("A").Return(false),
+		client.EXPECT().CreateTable("A", gomock.Any(), gomock.Any()).Return(nil),
+		client.EXPECT().InsertData(database.MigrationsTable, gomock.Any()).Return(nil),
+		client.EXPECT().TableExists("B").Return(false),
+		client.EXPECT().CreateTable("B", gomock.Any(), gomock.Any()).Return(nil),
+		client.EXPECT().InsertData(database.MigrationsTable, gomock.Any()).Return(nil),
+	)
+
+	applied, err := migrator.Up(context.Background(), false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, applied[0].Version)
+	assert.Equal(t, 2, applied[1].Version)
+}
+
+func TestUpSkipsAlreadyAppliedMigrations(t *testing.T) {
+	setUp(t)
+	expectAppliedMigrations([]*database.MigrationRecord{
+		{Version: 0},
+		{Version: 1, Checksum: migrations[1].Checksum()},
+	})
+	expectLock()
+	client.EXPECT().TableExists("B").Return(false)
+	client.EXPECT().CreateTable("B", gomock.Any(), gomock.Any()).Return(nil)
+	client.EXPECT().InsertData(database.MigrationsTable, gomock.Any()).Return(nil)
+
+	applied, err := migrator.Up(context.Background(), false)
+
+	assert.Nil(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, 2, applied[0].Version)
+}
+
+func TestUpWithDryRunDoesNotApplyMigrations(t *testing.T) {
+	setUp(t)
+	expectAppliedMigrations([]*database.MigrationRecord{})
+
+	applied, err := migrator.Up(context.Background(), true)
+
+	assert.Nil(t, err)
+	assert.Len(t, applied, 2)
+}
+
+func TestUpFailsWhenAppliedMigrationWasModified(t *testing.T) {
+	setUp(t)
+	expectAppliedMigrations([]*database.MigrationRecord{
+		{Version: 1, Checksum: "modified"},
+	})
+	expectLock()
+
+	_, err := migrator.Up(context.Background(), false)
+
+	var checksumMismatchError *database.ChecksumMismatchError
+	assert.True(t, errors.As(err, &checksumMismatchError))
+}
+
+func TestUpFailsWhenLockIsStillHeldOnceContextIsDone(t *testing.T) {
+	setUp(t)
+	client.EXPECT().TableExists(database.MigrationsTable).Return(true)
+	client.EXPECT().AcquireLock(database.MigrationsTable, gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := migrator.Up(ctx, false)
+
+	var migrationLockedError *database.MigrationLockedError
+	assert.True(t, errors.As(err, &migrationLockedError))
+}
+
+func TestUpWaitsForTheLockAndSkipsMigrationsAppliedMeanwhile(t *testing.T) {
+	setUp(t)
+	expectAppliedMigrations([]*database.MigrationRecord{
+		{Version: 1, Checksum: migrations[1].Checksum()},
+		{Version: 2, Checksum: migrations[0].Checksum()},
+	})
+	lockKey := &database.MigrationRecordKey{Version: 0}
+	gomock.InOrder(
+		client.EXPECT().AcquireLock(database.MigrationsTable, lockKey, gomock.Any(), gomock.Any()).Return(false, nil),
+		client.EXPECT().AcquireLock(database.MigrationsTable, lockKey, gomock.Any(), gomock.Any()).Return(true, nil),
+	)
+	client.EXPECT().ReleaseLock(database.MigrationsTable, lockKey, gomock.Any()).Return(nil)
+
+	applied, err := migrator.Up(context.Background(), false)
+
+	assert.Nil(t, err)
+	assert.Empty(t, applied)
+}
+
+func TestToFailsWhenTargetIsBelowAppliedVersion(t *testing.T) {
+	setUp(t)
+	expectAppliedMigrations([]*database.MigrationRecord{
+		{Version: 1, Checksum: migrations[1].Checksum()},
+		{Version: 2, Checksum: migrations[0].Checksum()},
+	})
+
+	_, err := migrator.To(context.Background(), 1, true)
+
+	var migrationDowngradeError *database.MigrationDowngradeError
+	assert.True(t, errors.As(err, &migrationDowngradeError))
+}
+
+func TestStatusReportsAppliedAndPendingMigrations(t *testing.T) {
+	setUp(t)
+	expectAppliedMigrations([]*database.MigrationRecord{
+		{Version: 1, Checksum: migrations[1].Checksum()},
+	})
+
+	statuses, err := migrator.Status()
+
+	assert.Nil(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+}
+
+func TestSetPostRevisionsStepOnlySetsMissingRevisions(t *testing.T) {
+	setUp(t)
+	lastUpdated := time.Date(2024, 5, 1, 10, 0, 0, 500, time.UTC)
+	client.EXPECT().ScanPage(database.PostMetadataTable, "", gomock.Any(), gomock.Any()).DoAndReturn(func(tableName string, cursor string, limit int, results any) (string, error) {
+		*results.(*[]*database.PostMetadata) = []*database.PostMetadata{
+			{PostId: "post1", LastUpdated: lastUpdated},
+			{PostId: "post2", LastUpdated: lastUpdated, Revision: 5},
+		}
+		return "next", nil
+	})
+	client.EXPECT().ScanPage(database.PostMetadataTable, "next", gomock.Any(), gomock.Any()).DoAndReturn(func(tableName string, cursor string, limit int, results any) (string, error) {
+		*results.(*[]*database.PostMetadata) = []*database.PostMetadata{{PostId: "post3", LastUpdated: lastUpdated.Add(time.Second)}}
+		return "", nil
+	})
+	client.EXPECT().UpdateDataIfLower(database.PostMetadataTable, &database.PostMetadataKey{PostId: "post1"}, map[string]any{"Revision": lastUpdated.UnixMicro()}, "Revision", lastUpdated.UnixMicro()).Return(true, nil)
+	client.EXPECT().UpdateDataIfLower(database.PostMetadataTable, &database.PostMetadataKey{PostId: "post3"}, map[string]any{"Revision": lastUpdated.Add(time.Second).UnixMicro()}, "Revision", lastUpdated.Add(time.Second).UnixMicro()).Return(false, nil)
+
+	err := database.SetPostRevisionsStep{}.Apply(context.Background(), client)
+
+	assert.Nil(t, err)
+}
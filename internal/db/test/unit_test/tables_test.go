@@ -0,0 +1,40 @@
+package unit_test_database
+
+import (
+	database "readmodels/internal/db"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableRegistryWithoutPrefixOrSuffixKeepsNames(t *testing.T) {
+	tables := database.NewTableRegistry("", "")
+
+	assert.Equal(t, "readmodels.comments", tables.Name(database.CommentsTable))
+	assert.True(t, tables.Owns("UserProfile"))
+	assert.False(t, tables.Owns("billing.invoices"))
+	assert.False(t, tables.Owns("staging.UserProfile"))
+}
+
+func TestTableRegistryAppliesPrefixAndSuffix(t *testing.T) {
+	tables := database.NewTableRegistry("staging", "run42")
+
+	assert.Equal(t, "staging.readmodels.comments.run42", tables.Name(database.CommentsTable))
+	assert.Equal(t, "staging.UserProfile.run42", tables.Name(database.UserProfileTable))
+}
+
+func TestTableRegistryOwnsOnlyTablesOfItsEnvironment(t *testing.T) {
+	tables := database.NewTableRegistry("staging.", "")
+
+	assert.True(t, tables.Owns("staging.readmodels.comments"))
+	assert.False(t, tables.Owns("readmodels.comments"))
+	assert.False(t, tables.Owns("production.readmodels.comments"))
+	assert.False(t, tables.Owns("staging.billing.invoices"))
+}
+
+func TestNilTableRegistryOwnsOnlyServiceTables(t *testing.T) {
+	var tables *database.TableRegistry
+
+	assert.True(t, tables.Owns("readmodels.trending"))
+	assert.False(t, tables.Owns("billing.invoices"))
+}
@@ -0,0 +1,60 @@
+package database
+
+// TransactionItem is one write of a transaction run by TransactWriteData. It
+// puts Item when set, deletes the item of Key when Delete is true, and
+// otherwise updates the item of Key, or only checks its Conditions when there
+// is nothing to update.
+//
+// Set assigns the attributes and Unset removes them. Add increases number
+// attributes, or adds the strings of a []string to a string set, and
+// DeleteFromSet removes the strings of a []string from a string set.
+type TransactionItem struct {
+	TableName     string
+	Key           any
+	Item          any
+	Delete        bool
+	Set           map[string]any
+	Add           map[string]any
+	DeleteFromSet map[string]any
+	Unset         []string
+	Conditions    []Condition
+}
+
+// Condition must hold on the stored item for its TransactionItem to apply.
+// Value is compared with Attribute by Equal, searched in the set by Contains
+// and compared with the size of the set by SizeEqual.
+type Condition struct {
+	Attribute string
+	Operator  ConditionOperator
+	Value     any
+}
+
+type ConditionOperator int
+
+const (
+	Exists ConditionOperator = iota
+	NotExists
+	Equal
+	Contains
+	SizeEqual
+)
+
+func AttributeExists(attribute string) Condition {
+	return Condition{Attribute: attribute, Operator: Exists}
+}
+
+func AttributeNotExists(attribute string) Condition {
+	return Condition{Attribute: attribute, Operator: NotExists}
+}
+
+func AttributeEquals(attribute string, value any) Condition {
+	return Condition{Attribute: attribute, Operator: Equal, Value: value}
+}
+
+func SetContains(attribute string, value string) Condition {
+	return Condition{Attribute: attribute, Operator: Contains, Value: value}
+}
+
+func SetSizeEquals(attribute string, size int) Condition {
+	return Condition{Attribute: attribute, Operator: SizeEqual, Value: size}
+}
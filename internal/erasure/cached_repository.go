@@ -0,0 +1,95 @@
+package erasure
+
+import (
+	"readmodels/internal/cache"
+	"readmodels/internal/model"
+	"readmodels/internal/post"
+)
+
+type CachedRepository struct {
+	repository Repository
+	cache      cache.Cache
+}
+
+func NewCachedRepository(repository Repository, cache cache.Cache) *CachedRepository {
+	return &CachedRepository{
+		repository: repository,
+		cache:      cache,
+	}
+}
+
+func (r *CachedRepository) GetUserProfile(username string) (*model.UserProfile, error) {
+	return r.repository.GetUserProfile(username)
+}
+
+func (r *CachedRepository) GetPostIdsByUser(username string) ([]string, error) {
+	return r.repository.GetPostIdsByUser(username)
+}
+
+func (r *CachedRepository) RemovePostDependencies(postId string) (*post.RemovedDependencies, error) {
+	return r.repository.RemovePostDependencies(postId)
+}
+
+func (r *CachedRepository) RemovePostMetadata(username string, postIds []string) error {
+	defer cache.InvalidatePostsByUser(r.cache, username)
+	return r.repository.RemovePostMetadata(username, postIds)
+}
+
+func (r *CachedRepository) GetCommentsByUser(username string) ([]*model.Comment, error) {
+	return r.repository.GetCommentsByUser(username)
+}
+
+func (r *CachedRepository) RemoveComment(comment *model.Comment) error {
+	defer cache.InvalidatePost(r.cache, comment.PostId)
+	return r.repository.RemoveComment(comment)
+}
+
+func (r *CachedRepository) GetReviewsByUser(username string) ([]*model.Review, error) {
+	return r.repository.GetReviewsByUser(username)
+}
+
+func (r *CachedRepository) RemoveReview(review *model.Review) error {
+	defer cache.InvalidatePost(r.cache, review.PostId)
+	return r.repository.RemoveReview(review)
+}
+
+func (r *CachedRepository) GetLikedPostIdsByUser(username string) ([]string, error) {
+	return r.repository.GetLikedPostIdsByUser(username)
+}
+
+func (r *CachedRepository) RemoveLike(username, postId string) error {
+	defer cache.InvalidatePost(r.cache, postId)
+	return r.repository.RemoveLike(username, postId)
+}
+
+func (r *CachedRepository) GetSuperlikedPostIdsByUser(username string) ([]string, error) {
+	return r.repository.GetSuperlikedPostIdsByUser(username)
+}
+
+func (r *CachedRepository) RemoveSuperlike(username, postId string) error {
+	defer cache.InvalidatePost(r.cache, postId)
+	return r.repository.RemoveSuperlike(username, postId)
+}
+
+func (r *CachedRepository) DecreaseFollowers(username, subjectHash, step string) error {
+	defer cache.InvalidateUserProfile(r.cache, username)
+	return r.repository.DecreaseFollowers(username, subjectHash, step)
+}
+
+func (r *CachedRepository) DecreaseFollowees(username, subjectHash, step string) error {
+	defer cache.InvalidateUserProfile(r.cache, username)
+	return r.repository.DecreaseFollowees(username, subjectHash, step)
+}
+
+func (r *CachedRepository) RemoveUserProfile(username string) error {
+	defer cache.InvalidateUserProfile(r.cache, username)
+	return r.repository.RemoveUserProfile(username)
+}
+
+func (r *CachedRepository) RemoveErasureProgress(subjectHash string, steps []string) error {
+	return r.repository.RemoveErasureProgress(subjectHash, steps)
+}
+
+func (r *CachedRepository) AddErasureRecord(record *ErasureRecord) error {
+	return r.repository.AddErasureRecord(record)
+}
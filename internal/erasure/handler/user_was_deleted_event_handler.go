@@ -0,0 +1,42 @@
+package erasure_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=user_was_deleted_event_handler.go -destination=test/mock/user_was_deleted_event_handler.go
+
+type UserWasDeletedEvent struct {
+	Username    string   `json:"username" validate:"id"`
+	FollowerIds []string `json:"followerIds,omitempty" validate:"dive,id"`
+	FolloweeIds []string `json:"followeeIds,omitempty" validate:"dive,id"`
+}
+
+type UserWasDeletedEventService interface {
+	EraseUser(username string, followerIds, followeeIds []string)
+}
+
+type UserWasDeletedEventHandler struct {
+	service UserWasDeletedEventService
+}
+
+func NewUserWasDeletedEventHandler(service UserWasDeletedEventService) *UserWasDeletedEventHandler {
+	return &UserWasDeletedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserWasDeletedEventHandler) Handle(event []byte) {
+	var userWasDeletedEvent UserWasDeletedEvent
+	log.Info().Msg("Handling UserWasDeletedEvent")
+
+	err := common_data.DeserializeData(event, &userWasDeletedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.EraseUser(userWasDeletedEvent.Username, userWasDeletedEvent.FollowerIds, userWasDeletedEvent.FolloweeIds)
+}
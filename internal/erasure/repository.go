@@ -0,0 +1,178 @@
+package erasure
+
+import (
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"readmodels/internal/post"
+)
+
+type ErasureRepository struct {
+	database *database.Database
+}
+
+func NewErasureRepository(database *database.Database) *ErasureRepository {
+	return &ErasureRepository{
+		database: database,
+	}
+}
+
+func (r *ErasureRepository) GetUserProfile(username string) (*model.UserProfile, error) {
+	userProfileKey := &database.UserProfileKey{
+		Username: username,
+	}
+	var userProfile model.UserProfile
+	err := r.database.Client.GetData(database.UserProfileTable, userProfileKey, &userProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userProfile, nil
+}
+
+func (r *ErasureRepository) GetPostIdsByUser(username string) ([]string, error) {
+	posts := []*database.PostMetadata{}
+	err := r.database.Client.GetAllDataByIndex(database.PostMetadataTable, "UserIndex", "Username", username, &posts)
+	if err != nil {
+		return nil, err
+	}
+
+	postIds := make([]string, len(posts))
+	for i, post := range posts {
+		postIds[i] = post.PostId
+	}
+	return postIds, nil
+}
+
+func (r *ErasureRepository) RemovePostDependencies(postId string) (*post.RemovedDependencies, error) {
+	return post.PostRepository(*r.database).RemovePostDependencies(postId)
+}
+
+func (r *ErasureRepository) RemovePostMetadata(username string, postIds []string) error {
+	return post.PostRepository(*r.database).RemovePostMetadata(username, postIds)
+}
+
+func (r *ErasureRepository) GetCommentsByUser(username string) ([]*model.Comment, error) {
+	comments := []*model.Comment{}
+	err := r.database.Client.GetAllDataByIndex(database.CommentsTable, "UsernameIndex", "Username", username, &comments)
+	return comments, err
+}
+
+func (r *ErasureRepository) RemoveComment(comment *model.Comment) error {
+	commentKey := &database.CommentKey{
+		CommentId: comment.CommentId,
+	}
+	postKey := &database.PostMetadataKey{
+		PostId: comment.PostId,
+	}
+	return r.database.Client.RemoveDataAndDecreaseCounter(database.CommentsTable, commentKey, database.PostMetadataTable, postKey, "Comments")
+}
+
+func (r *ErasureRepository) GetReviewsByUser(username string) ([]*model.Review, error) {
+	reviews := []*model.Review{}
+	err := r.database.Client.GetAllDataByIndex(database.ReviewsTable, "UsernamePostIndex", "Username", username, &reviews)
+	return reviews, err
+}
+
+func (r *ErasureRepository) RemoveReview(review *model.Review) error {
+	reviewKey := &database.ReviewKey{
+		ReviewId: review.ReviewId,
+	}
+	postKey := &database.PostMetadataKey{
+		PostId: review.PostId,
+	}
+	return r.database.Client.RemoveDataAndDecreaseCounter(database.ReviewsTable, reviewKey, database.PostMetadataTable, postKey, "Reviews")
+}
+
+func (r *ErasureRepository) GetLikedPostIdsByUser(username string) ([]string, error) {
+	likes := []*database.PostLikeMetadata{}
+	err := r.database.Client.GetAllDataByIndex(database.PostLikesTable, "UsernameIndex", "Username", username, &likes)
+	if err != nil {
+		return nil, err
+	}
+
+	postIds := make([]string, len(likes))
+	for i, like := range likes {
+		postIds[i] = like.PostId
+	}
+	return postIds, nil
+}
+
+func (r *ErasureRepository) RemoveLike(username, postId string) error {
+	postLikeKey := &database.PostLikeKey{
+		PostId:   postId,
+		Username: username,
+	}
+	postKey := &database.PostMetadataKey{
+		PostId: postId,
+	}
+	return r.database.Client.RemoveDataAndDecreaseCounter(database.PostLikesTable, postLikeKey, database.PostMetadataTable, postKey, "Likes")
+}
+
+func (r *ErasureRepository) GetSuperlikedPostIdsByUser(username string) ([]string, error) {
+	superlikes := []*database.PostSuperlikeMetadata{}
+	err := r.database.Client.GetAllDataByIndex(database.PostSuperlikesTable, "UsernameIndex", "Username", username, &superlikes)
+	if err != nil {
+		return nil, err
+	}
+
+	postIds := make([]string, len(superlikes))
+	for i, superlike := range superlikes {
+		postIds[i] = superlike.PostId
+	}
+	return postIds, nil
+}
+
+func (r *ErasureRepository) RemoveSuperlike(username, postId string) error {
+	postSuperlikeKey := &database.PostSuperlikeKey{
+		PostId:   postId,
+		Username: username,
+	}
+	postKey := &database.PostMetadataKey{
+		PostId: postId,
+	}
+	return r.database.Client.RemoveDataAndDecreaseCounter(database.PostSuperlikesTable, postSuperlikeKey, database.PostMetadataTable, postKey, "Superlikes")
+}
+
+func (r *ErasureRepository) DecreaseFollowers(username, subjectHash, step string) error {
+	progressKey := &database.ErasureProgressKey{
+		SubjectHash: subjectHash,
+		Step:        step,
+	}
+	userProfileKey := &database.UserProfileKey{
+		Username: username,
+	}
+	return r.database.Client.IncrementCounterOnce(database.ErasureProgressTable, progressKey, database.UserProfileTable, userProfileKey, "FollowersAmount", -1)
+}
+
+func (r *ErasureRepository) DecreaseFollowees(username, subjectHash, step string) error {
+	progressKey := &database.ErasureProgressKey{
+		SubjectHash: subjectHash,
+		Step:        step,
+	}
+	userProfileKey := &database.UserProfileKey{
+		Username: username,
+	}
+	return r.database.Client.IncrementCounterOnce(database.ErasureProgressTable, progressKey, database.UserProfileTable, userProfileKey, "FolloweesAmount", -1)
+}
+
+func (r *ErasureRepository) RemoveUserProfile(username string) error {
+	userProfileKey := &database.UserProfileKey{
+		Username: username,
+	}
+	return r.database.Client.RemoveMultipleData(database.UserProfileTable, []any{userProfileKey})
+}
+
+func (r *ErasureRepository) RemoveErasureProgress(subjectHash string, steps []string) error {
+	progressKeys := make([]any, len(steps))
+	for i, step := range steps {
+		progressKeys[i] = &database.ErasureProgressKey{
+			SubjectHash: subjectHash,
+			Step:        step,
+		}
+	}
+	return r.database.Client.RemoveMultipleData(database.ErasureProgressTable, progressKeys)
+}
+
+func (r *ErasureRepository) AddErasureRecord(record *ErasureRecord) error {
+	return r.database.Client.InsertData(database.ErasuresTable, record)
+}
@@ -0,0 +1,282 @@
+package erasure
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"readmodels/internal/post"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=service.go -destination=test/mock/service.go
+
+type Repository interface {
+	GetUserProfile(username string) (*model.UserProfile, error)
+	GetPostIdsByUser(username string) ([]string, error)
+	RemovePostDependencies(postId string) (*post.RemovedDependencies, error)
+	RemovePostMetadata(username string, postIds []string) error
+	GetCommentsByUser(username string) ([]*model.Comment, error)
+	RemoveComment(comment *model.Comment) error
+	GetReviewsByUser(username string) ([]*model.Review, error)
+	RemoveReview(review *model.Review) error
+	GetLikedPostIdsByUser(username string) ([]string, error)
+	RemoveLike(username, postId string) error
+	GetSuperlikedPostIdsByUser(username string) ([]string, error)
+	RemoveSuperlike(username, postId string) error
+	DecreaseFollowers(username, subjectHash, step string) error
+	DecreaseFollowees(username, subjectHash, step string) error
+	RemoveUserProfile(username string) error
+	RemoveErasureProgress(subjectHash string, steps []string) error
+	AddErasureRecord(record *ErasureRecord) error
+}
+
+// The read models derived from posts are cleaned up as the
+// PostsWereDeletedEvent subscriptions do, since erased posts don't come with
+// that event.
+type ActivityService interface {
+	RemovePostsActivity(username string, postIds []string)
+}
+
+type NotificationService interface {
+	RemovePostNotifications(owner string, postIds []string)
+}
+
+type TrendingService interface {
+	RemovePosts(postIds []string)
+}
+
+// ErasureRecord is the audit trail of a user erasure. The user is identified
+// by a keyed hash of the username, which can't be matched against a list of
+// usernames without the erasure key.
+type ErasureRecord struct {
+	SubjectHash string
+	ErasedAt    time.Time
+	Posts       int
+	Comments    int
+	Reviews     int
+	Likes       int
+	Superlikes  int
+	Followers   int
+	Followees   int
+	Completed   bool
+}
+
+type ErasureService struct {
+	repository    Repository
+	activity      ActivityService
+	notifications NotificationService
+	trending      TrendingService
+	key           []byte
+}
+
+func NewErasureService(repository Repository, activity ActivityService, notifications NotificationService, trending TrendingService, key []byte) *ErasureService {
+	return &ErasureService{
+		repository:    repository,
+		activity:      activity,
+		notifications: notifications,
+		trending:      trending,
+		key:           key,
+	}
+}
+
+// SubjectHash returns the HMAC-SHA256 of the username with the erasure key.
+func SubjectHash(key []byte, username string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(username))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// EraseUser removes the user profile and everything the user wrote, keeping
+// the counters of other users' posts and profiles consistent. Every removal
+// only applies to records that still exist, so handling the same deletion
+// again finishes an erasure that failed halfway. The user is only logged by
+// the subject hash.
+func (s *ErasureService) EraseUser(username string, followerIds, followeeIds []string) {
+	record := &ErasureRecord{
+		SubjectHash: SubjectHash(s.key, username),
+		ErasedAt:    time.Now().UTC(),
+	}
+
+	err := s.erase(username, followerIds, followeeIds, record)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error erasing user %s", record.SubjectHash)
+	}
+	record.Completed = err == nil
+
+	err = s.repository.AddErasureRecord(record)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error adding erasure record %s", record.SubjectHash)
+		return
+	}
+
+	if record.Completed {
+		log.Info().Msgf("User %s was erased: %d posts, %d comments, %d reviews, %d likes, %d superlikes, %d followers and %d followees",
+			record.SubjectHash, record.Posts, record.Comments, record.Reviews, record.Likes, record.Superlikes, record.Followers, record.Followees)
+	}
+}
+
+func (s *ErasureService) erase(username string, followerIds, followeeIds []string, record *ErasureRecord) error {
+	// Follow relationships are only stored as counters. They are decreased
+	// while the profile exists, and each decrease is recorded as an erasure
+	// step so that a retry doesn't decrease them twice
+	_, err := s.repository.GetUserProfile(username)
+	var notFoundError *database.NotFoundError
+	profileExists := !errors.As(err, &notFoundError)
+	if err != nil && profileExists {
+		return err
+	}
+
+	err = s.erasePosts(username, record)
+	if err != nil {
+		return err
+	}
+
+	err = s.eraseComments(username, record)
+	if err != nil {
+		return err
+	}
+
+	err = s.eraseReviews(username, record)
+	if err != nil {
+		return err
+	}
+
+	err = s.eraseReactions(username, record)
+	if err != nil {
+		return err
+	}
+
+	followerSteps := s.steps("followees", followerIds)
+	followeeSteps := s.steps("followers", followeeIds)
+	if profileExists {
+		for i, followerId := range followerIds {
+			err = s.repository.DecreaseFollowees(followerId, record.SubjectHash, followerSteps[i])
+			if err != nil {
+				return err
+			}
+			record.Followers++
+		}
+		for i, followeeId := range followeeIds {
+			err = s.repository.DecreaseFollowers(followeeId, record.SubjectHash, followeeSteps[i])
+			if err != nil {
+				return err
+			}
+			record.Followees++
+		}
+
+		err = s.repository.RemoveUserProfile(username)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The steps are only needed until the profile is removed
+	return s.repository.RemoveErasureProgress(record.SubjectHash, append(followerSteps, followeeSteps...))
+}
+
+// steps returns the erasure steps of the counter of each user, hashed like
+// the subject so that they hold no usernames either.
+func (s *ErasureService) steps(counter string, usernames []string) []string {
+	steps := make([]string, len(usernames))
+	for i, username := range usernames {
+		steps[i] = SubjectHash(s.key, counter+":"+username)
+	}
+	return steps
+}
+
+func (s *ErasureService) erasePosts(username string, record *ErasureRecord) error {
+	postIds, err := s.repository.GetPostIdsByUser(username)
+	if err != nil {
+		return err
+	}
+	if len(postIds) == 0 {
+		return nil
+	}
+
+	for _, postId := range postIds {
+		_, err = s.repository.RemovePostDependencies(postId)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Cleaned up before the metadata, which lists the posts on a retry
+	s.activity.RemovePostsActivity(username, postIds)
+	s.notifications.RemovePostNotifications(username, postIds)
+	s.trending.RemovePosts(postIds)
+
+	err = s.repository.RemovePostMetadata(username, postIds)
+	if err != nil {
+		return err
+	}
+	record.Posts = len(postIds)
+
+	return nil
+}
+
+func (s *ErasureService) eraseComments(username string, record *ErasureRecord) error {
+	comments, err := s.repository.GetCommentsByUser(username)
+	if err != nil {
+		return err
+	}
+
+	for _, comment := range comments {
+		err = s.repository.RemoveComment(comment)
+		if err != nil {
+			return err
+		}
+		record.Comments++
+	}
+
+	return nil
+}
+
+func (s *ErasureService) eraseReviews(username string, record *ErasureRecord) error {
+	reviews, err := s.repository.GetReviewsByUser(username)
+	if err != nil {
+		return err
+	}
+
+	for _, review := range reviews {
+		err = s.repository.RemoveReview(review)
+		if err != nil {
+			return err
+		}
+		record.Reviews++
+	}
+
+	return nil
+}
+
+func (s *ErasureService) eraseReactions(username string, record *ErasureRecord) error {
+	likedPostIds, err := s.repository.GetLikedPostIdsByUser(username)
+	if err != nil {
+		return err
+	}
+	for _, postId := range likedPostIds {
+		err = s.repository.RemoveLike(username, postId)
+		if err != nil {
+			return err
+		}
+		record.Likes++
+	}
+
+	superlikedPostIds, err := s.repository.GetSuperlikedPostIdsByUser(username)
+	if err != nil {
+		return err
+	}
+	for _, postId := range superlikedPostIds {
+		err = s.repository.RemoveSuperlike(username, postId)
+		if err != nil {
+			return err
+		}
+		record.Superlikes++
+	}
+
+	return nil
+}
@@ -0,0 +1,387 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_erasure is a generated GoMock package.
+package mock_erasure
+
+import (
+	erasure "readmodels/internal/erasure"
+	model "readmodels/internal/model"
+	post "readmodels/internal/post"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddErasureRecord mocks base method.
+func (m *MockRepository) AddErasureRecord(record *erasure.ErasureRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddErasureRecord", record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddErasureRecord indicates an expected call of AddErasureRecord.
+func (mr *MockRepositoryMockRecorder) AddErasureRecord(record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddErasureRecord", reflect.TypeOf((*MockRepository)(nil).AddErasureRecord), record)
+}
+
+// DecreaseFollowees mocks base method.
+func (m *MockRepository) DecreaseFollowees(username, subjectHash, step string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecreaseFollowees", username, subjectHash, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecreaseFollowees indicates an expected call of DecreaseFollowees.
+func (mr *MockRepositoryMockRecorder) DecreaseFollowees(username, subjectHash, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseFollowees", reflect.TypeOf((*MockRepository)(nil).DecreaseFollowees), username, subjectHash, step)
+}
+
+// DecreaseFollowers mocks base method.
+func (m *MockRepository) DecreaseFollowers(username, subjectHash, step string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecreaseFollowers", username, subjectHash, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecreaseFollowers indicates an expected call of DecreaseFollowers.
+func (mr *MockRepositoryMockRecorder) DecreaseFollowers(username, subjectHash, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseFollowers", reflect.TypeOf((*MockRepository)(nil).DecreaseFollowers), username, subjectHash, step)
+}
+
+// GetCommentsByUser mocks base method.
+func (m *MockRepository) GetCommentsByUser(username string) ([]*model.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentsByUser", username)
+	ret0, _ := ret[0].([]*model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentsByUser indicates an expected call of GetCommentsByUser.
+func (mr *MockRepositoryMockRecorder) GetCommentsByUser(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentsByUser", reflect.TypeOf((*MockRepository)(nil).GetCommentsByUser), username)
+}
+
+// GetLikedPostIdsByUser mocks base method.
+func (m *MockRepository) GetLikedPostIdsByUser(username string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikedPostIdsByUser", username)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikedPostIdsByUser indicates an expected call of GetLikedPostIdsByUser.
+func (mr *MockRepositoryMockRecorder) GetLikedPostIdsByUser(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikedPostIdsByUser", reflect.TypeOf((*MockRepository)(nil).GetLikedPostIdsByUser), username)
+}
+
+// GetPostIdsByUser mocks base method.
+func (m *MockRepository) GetPostIdsByUser(username string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostIdsByUser", username)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostIdsByUser indicates an expected call of GetPostIdsByUser.
+func (mr *MockRepositoryMockRecorder) GetPostIdsByUser(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostIdsByUser", reflect.TypeOf((*MockRepository)(nil).GetPostIdsByUser), username)
+}
+
+// GetReviewsByUser mocks base method.
+func (m *MockRepository) GetReviewsByUser(username string) ([]*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewsByUser", username)
+	ret0, _ := ret[0].([]*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewsByUser indicates an expected call of GetReviewsByUser.
+func (mr *MockRepositoryMockRecorder) GetReviewsByUser(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByUser", reflect.TypeOf((*MockRepository)(nil).GetReviewsByUser), username)
+}
+
+// GetSuperlikedPostIdsByUser mocks base method.
+func (m *MockRepository) GetSuperlikedPostIdsByUser(username string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuperlikedPostIdsByUser", username)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuperlikedPostIdsByUser indicates an expected call of GetSuperlikedPostIdsByUser.
+func (mr *MockRepositoryMockRecorder) GetSuperlikedPostIdsByUser(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuperlikedPostIdsByUser", reflect.TypeOf((*MockRepository)(nil).GetSuperlikedPostIdsByUser), username)
+}
+
+// GetUserProfile mocks base method.
+func (m *MockRepository) GetUserProfile(username string) (*model.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfile", username)
+	ret0, _ := ret[0].(*model.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfile indicates an expected call of GetUserProfile.
+func (mr *MockRepositoryMockRecorder) GetUserProfile(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockRepository)(nil).GetUserProfile), username)
+}
+
+// RemoveComment mocks base method.
+func (m *MockRepository) RemoveComment(comment *model.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveComment", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveComment indicates an expected call of RemoveComment.
+func (mr *MockRepositoryMockRecorder) RemoveComment(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveComment", reflect.TypeOf((*MockRepository)(nil).RemoveComment), comment)
+}
+
+// RemoveErasureProgress mocks base method.
+func (m *MockRepository) RemoveErasureProgress(subjectHash string, steps []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveErasureProgress", subjectHash, steps)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveErasureProgress indicates an expected call of RemoveErasureProgress.
+func (mr *MockRepositoryMockRecorder) RemoveErasureProgress(subjectHash, steps interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveErasureProgress", reflect.TypeOf((*MockRepository)(nil).RemoveErasureProgress), subjectHash, steps)
+}
+
+// RemoveLike mocks base method.
+func (m *MockRepository) RemoveLike(username, postId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLike", username, postId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveLike indicates an expected call of RemoveLike.
+func (mr *MockRepositoryMockRecorder) RemoveLike(username, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLike", reflect.TypeOf((*MockRepository)(nil).RemoveLike), username, postId)
+}
+
+// RemovePostDependencies mocks base method.
+func (m *MockRepository) RemovePostDependencies(postId string) (*post.RemovedDependencies, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePostDependencies", postId)
+	ret0, _ := ret[0].(*post.RemovedDependencies)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemovePostDependencies indicates an expected call of RemovePostDependencies.
+func (mr *MockRepositoryMockRecorder) RemovePostDependencies(postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePostDependencies", reflect.TypeOf((*MockRepository)(nil).RemovePostDependencies), postId)
+}
+
+// RemovePostMetadata mocks base method.
+func (m *MockRepository) RemovePostMetadata(username string, postIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePostMetadata", username, postIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePostMetadata indicates an expected call of RemovePostMetadata.
+func (mr *MockRepositoryMockRecorder) RemovePostMetadata(username, postIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePostMetadata", reflect.TypeOf((*MockRepository)(nil).RemovePostMetadata), username, postIds)
+}
+
+// RemoveReview mocks base method.
+func (m *MockRepository) RemoveReview(review *model.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReview", review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReview indicates an expected call of RemoveReview.
+func (mr *MockRepositoryMockRecorder) RemoveReview(review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReview", reflect.TypeOf((*MockRepository)(nil).RemoveReview), review)
+}
+
+// RemoveSuperlike mocks base method.
+func (m *MockRepository) RemoveSuperlike(username, postId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSuperlike", username, postId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSuperlike indicates an expected call of RemoveSuperlike.
+func (mr *MockRepositoryMockRecorder) RemoveSuperlike(username, postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSuperlike", reflect.TypeOf((*MockRepository)(nil).RemoveSuperlike), username, postId)
+}
+
+// RemoveUserProfile mocks base method.
+func (m *MockRepository) RemoveUserProfile(username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserProfile", username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUserProfile indicates an expected call of RemoveUserProfile.
+func (mr *MockRepositoryMockRecorder) RemoveUserProfile(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserProfile", reflect.TypeOf((*MockRepository)(nil).RemoveUserProfile), username)
+}
+
+// MockActivityService is a mock of ActivityService interface.
+type MockActivityService struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityServiceMockRecorder
+}
+
+// MockActivityServiceMockRecorder is the mock recorder for MockActivityService.
+type MockActivityServiceMockRecorder struct {
+	mock *MockActivityService
+}
+
+// NewMockActivityService creates a new mock instance.
+func NewMockActivityService(ctrl *gomock.Controller) *MockActivityService {
+	mock := &MockActivityService{ctrl: ctrl}
+	mock.recorder = &MockActivityServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityService) EXPECT() *MockActivityServiceMockRecorder {
+	return m.recorder
+}
+
+// RemovePostsActivity mocks base method.
+func (m *MockActivityService) RemovePostsActivity(username string, postIds []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemovePostsActivity", username, postIds)
+}
+
+// RemovePostsActivity indicates an expected call of RemovePostsActivity.
+func (mr *MockActivityServiceMockRecorder) RemovePostsActivity(username, postIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePostsActivity", reflect.TypeOf((*MockActivityService)(nil).RemovePostsActivity), username, postIds)
+}
+
+// MockNotificationService is a mock of NotificationService interface.
+type MockNotificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceMockRecorder
+}
+
+// MockNotificationServiceMockRecorder is the mock recorder for MockNotificationService.
+type MockNotificationServiceMockRecorder struct {
+	mock *MockNotificationService
+}
+
+// NewMockNotificationService creates a new mock instance.
+func NewMockNotificationService(ctrl *gomock.Controller) *MockNotificationService {
+	mock := &MockNotificationService{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationService) EXPECT() *MockNotificationServiceMockRecorder {
+	return m.recorder
+}
+
+// RemovePostNotifications mocks base method.
+func (m *MockNotificationService) RemovePostNotifications(owner string, postIds []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemovePostNotifications", owner, postIds)
+}
+
+// RemovePostNotifications indicates an expected call of RemovePostNotifications.
+func (mr *MockNotificationServiceMockRecorder) RemovePostNotifications(owner, postIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePostNotifications", reflect.TypeOf((*MockNotificationService)(nil).RemovePostNotifications), owner, postIds)
+}
+
+// MockTrendingService is a mock of TrendingService interface.
+type MockTrendingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrendingServiceMockRecorder
+}
+
+// MockTrendingServiceMockRecorder is the mock recorder for MockTrendingService.
+type MockTrendingServiceMockRecorder struct {
+	mock *MockTrendingService
+}
+
+// NewMockTrendingService creates a new mock instance.
+func NewMockTrendingService(ctrl *gomock.Controller) *MockTrendingService {
+	mock := &MockTrendingService{ctrl: ctrl}
+	mock.recorder = &MockTrendingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrendingService) EXPECT() *MockTrendingServiceMockRecorder {
+	return m.recorder
+}
+
+// RemovePosts mocks base method.
+func (m *MockTrendingService) RemovePosts(postIds []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemovePosts", postIds)
+}
+
+// RemovePosts indicates an expected call of RemovePosts.
+func (mr *MockTrendingServiceMockRecorder) RemovePosts(postIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePosts", reflect.TypeOf((*MockTrendingService)(nil).RemovePosts), postIds)
+}
@@ -0,0 +1,90 @@
+package erasure_test
+
+import (
+	database "readmodels/internal/db"
+	"readmodels/internal/erasure"
+	"readmodels/internal/model"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var erasureRepository *erasure.ErasureRepository
+
+func setUpRepository(t *testing.T) {
+	SetUp(t)
+	erasureRepository = erasure.NewErasureRepository(database.NewDatabase(client))
+}
+
+func TestGetLikedPostIdsByUserInRepository(t *testing.T) {
+	setUpRepository(t)
+	client.EXPECT().GetAllDataByIndex("readmodels.postLikes", "UsernameIndex", "Username", "user1", gomock.Any()).DoAndReturn(func(tableName, indexName, attributeName, value string, results any) error {
+		*results.(*[]*database.PostLikeMetadata) = []*database.PostLikeMetadata{
+			{PostId: "post1", Username: "user1"},
+			{PostId: "post2", Username: "user1"},
+		}
+		return nil
+	})
+
+	postIds, err := erasureRepository.GetLikedPostIdsByUser("user1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"post1", "post2"}, postIds)
+}
+
+func TestRemoveCommentInRepository(t *testing.T) {
+	setUpRepository(t)
+	comment := &model.Comment{CommentId: 1, PostId: "post1", Username: "user1"}
+	expectedCommentKey := &database.CommentKey{CommentId: 1}
+	expectedPostKey := &database.PostMetadataKey{PostId: "post1"}
+	client.EXPECT().RemoveDataAndDecreaseCounter("readmodels.comments", expectedCommentKey, "PostMetadata", expectedPostKey, "Comments")
+
+	err := erasureRepository.RemoveComment(comment)
+
+	assert.Nil(t, err)
+}
+
+func TestRemoveUserProfileInRepository(t *testing.T) {
+	setUpRepository(t)
+	expectedKeys := []any{&database.UserProfileKey{Username: "user1"}}
+	client.EXPECT().RemoveMultipleData("UserProfile", expectedKeys)
+
+	err := erasureRepository.RemoveUserProfile("user1")
+
+	assert.Nil(t, err)
+}
+
+func TestDecreaseFolloweesInRepository(t *testing.T) {
+	setUpRepository(t)
+	expectedProgressKey := &database.ErasureProgressKey{SubjectHash: "subject", Step: "step"}
+	expectedUserProfileKey := &database.UserProfileKey{Username: "user2"}
+	client.EXPECT().IncrementCounterOnce("readmodels.erasureProgress", expectedProgressKey, "UserProfile", expectedUserProfileKey, "FolloweesAmount", -1)
+
+	err := erasureRepository.DecreaseFollowees("user2", "subject", "step")
+
+	assert.Nil(t, err)
+}
+
+func TestRemoveErasureProgressInRepository(t *testing.T) {
+	setUpRepository(t)
+	expectedKeys := []any{
+		&database.ErasureProgressKey{SubjectHash: "subject", Step: "step1"},
+		&database.ErasureProgressKey{SubjectHash: "subject", Step: "step2"},
+	}
+	client.EXPECT().RemoveMultipleData("readmodels.erasureProgress", expectedKeys)
+
+	err := erasureRepository.RemoveErasureProgress("subject", []string{"step1", "step2"})
+
+	assert.Nil(t, err)
+}
+
+func TestAddErasureRecordInRepository(t *testing.T) {
+	setUpRepository(t)
+	record := &erasure.ErasureRecord{SubjectHash: erasure.SubjectHash([]byte("key"), "user1"), Completed: true}
+	client.EXPECT().InsertData("readmodels.erasures", record)
+
+	err := erasureRepository.AddErasureRecord(record)
+
+	assert.Nil(t, err)
+}
@@ -0,0 +1,140 @@
+package erasure_test
+
+import (
+	"errors"
+	database "readmodels/internal/db"
+	"readmodels/internal/erasure"
+	mock_erasure "readmodels/internal/erasure/test/mock"
+	"readmodels/internal/model"
+	"readmodels/internal/post"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var repository *mock_erasure.MockRepository
+var activityService *mock_erasure.MockActivityService
+var notificationService *mock_erasure.MockNotificationService
+var trendingService *mock_erasure.MockTrendingService
+var erasureService *erasure.ErasureService
+var erasureKey = []byte("key")
+
+func setUpService(t *testing.T) {
+	SetUp(t)
+	repository = mock_erasure.NewMockRepository(ctrl)
+	activityService = mock_erasure.NewMockActivityService(ctrl)
+	notificationService = mock_erasure.NewMockNotificationService(ctrl)
+	trendingService = mock_erasure.NewMockTrendingService(ctrl)
+	erasureService = erasure.NewErasureService(repository, activityService, notificationService, trendingService, erasureKey)
+}
+
+func TestEraseUserWithService(t *testing.T) {
+	setUpService(t)
+	username := "user1"
+	comment := &model.Comment{CommentId: 1, PostId: "post3", Username: username}
+	review := &model.Review{ReviewId: 2, PostId: "post4", Username: username}
+	repository.EXPECT().GetUserProfile(username).Return(&model.UserProfile{Username: username}, nil)
+	repository.EXPECT().GetPostIdsByUser(username).Return([]string{"post1", "post2"}, nil)
+	repository.EXPECT().RemovePostDependencies("post1").Return(&post.RemovedDependencies{}, nil)
+	repository.EXPECT().RemovePostDependencies("post2").Return(&post.RemovedDependencies{}, nil)
+	activityService.EXPECT().RemovePostsActivity(username, []string{"post1", "post2"})
+	notificationService.EXPECT().RemovePostNotifications(username, []string{"post1", "post2"})
+	trendingService.EXPECT().RemovePosts([]string{"post1", "post2"})
+	repository.EXPECT().RemovePostMetadata(username, []string{"post1", "post2"})
+	repository.EXPECT().GetCommentsByUser(username).Return([]*model.Comment{comment}, nil)
+	repository.EXPECT().RemoveComment(comment)
+	repository.EXPECT().GetReviewsByUser(username).Return([]*model.Review{review}, nil)
+	repository.EXPECT().RemoveReview(review)
+	repository.EXPECT().GetLikedPostIdsByUser(username).Return([]string{"post3", "post5"}, nil)
+	repository.EXPECT().RemoveLike(username, "post3")
+	repository.EXPECT().RemoveLike(username, "post5")
+	repository.EXPECT().GetSuperlikedPostIdsByUser(username).Return([]string{}, nil)
+	subjectHash := erasure.SubjectHash(erasureKey, username)
+	followerStep := erasure.SubjectHash(erasureKey, "followees:user2")
+	followeeStep := erasure.SubjectHash(erasureKey, "followers:user3")
+	repository.EXPECT().DecreaseFollowees("user2", subjectHash, followerStep)
+	repository.EXPECT().DecreaseFollowers("user3", subjectHash, followeeStep)
+	repository.EXPECT().RemoveUserProfile(username)
+	repository.EXPECT().RemoveErasureProgress(subjectHash, []string{followerStep, followeeStep})
+	repository.EXPECT().AddErasureRecord(gomock.Any()).DoAndReturn(func(record *erasure.ErasureRecord) error {
+		assert.Equal(t, subjectHash, record.SubjectHash)
+		assert.Equal(t, 2, record.Posts)
+		assert.Equal(t, 1, record.Comments)
+		assert.Equal(t, 1, record.Reviews)
+		assert.Equal(t, 2, record.Likes)
+		assert.Equal(t, 0, record.Superlikes)
+		assert.Equal(t, 1, record.Followers)
+		assert.Equal(t, 1, record.Followees)
+		assert.True(t, record.Completed)
+		return nil
+	})
+
+	erasureService.EraseUser(username, []string{"user2"}, []string{"user3"})
+
+	assert.Contains(t, loggerOutput.String(), "User "+subjectHash+" was erased: 2 posts, 1 comments, 1 reviews, 2 likes, 0 superlikes, 1 followers and 1 followees")
+	assert.NotContains(t, loggerOutput.String(), username)
+}
+
+func TestEraseAlreadyRemovedUserWithService(t *testing.T) {
+	setUpService(t)
+	username := "user1"
+	repository.EXPECT().GetUserProfile(username).Return(nil, database.NewNotFoundError("UserProfile", username))
+	repository.EXPECT().GetPostIdsByUser(username).Return([]string{}, nil)
+	repository.EXPECT().GetCommentsByUser(username).Return([]*model.Comment{}, nil)
+	repository.EXPECT().GetReviewsByUser(username).Return([]*model.Review{}, nil)
+	repository.EXPECT().GetLikedPostIdsByUser(username).Return([]string{}, nil)
+	repository.EXPECT().GetSuperlikedPostIdsByUser(username).Return([]string{}, nil)
+	repository.EXPECT().RemoveErasureProgress(erasure.SubjectHash(erasureKey, username), gomock.Len(2))
+	repository.EXPECT().AddErasureRecord(gomock.Any()).DoAndReturn(func(record *erasure.ErasureRecord) error {
+		assert.Equal(t, 0, record.Followers)
+		assert.True(t, record.Completed)
+		return nil
+	})
+
+	erasureService.EraseUser(username, []string{"user2"}, []string{"user3"})
+}
+
+func TestErrorOnEraseUserWithService(t *testing.T) {
+	setUpService(t)
+	username := "user1"
+	repository.EXPECT().GetUserProfile(username).Return(&model.UserProfile{Username: username}, nil)
+	repository.EXPECT().GetPostIdsByUser(username).Return(nil, errors.New("some error"))
+	repository.EXPECT().AddErasureRecord(gomock.Any()).DoAndReturn(func(record *erasure.ErasureRecord) error {
+		assert.False(t, record.Completed)
+		return nil
+	})
+
+	erasureService.EraseUser(username, []string{}, []string{})
+
+	assert.Contains(t, loggerOutput.String(), "Error erasing user "+erasure.SubjectHash(erasureKey, username))
+	assert.NotContains(t, loggerOutput.String(), username)
+}
+
+func TestEraseUserDecreasesTheFollowsOfAFailedErasureWithService(t *testing.T) {
+	setUpService(t)
+	username := "user1"
+	subjectHash := erasure.SubjectHash(erasureKey, username)
+	followerStep := erasure.SubjectHash(erasureKey, "followees:user2")
+	repository.EXPECT().GetUserProfile(username).Return(&model.UserProfile{Username: username}, nil).Times(2)
+	repository.EXPECT().GetPostIdsByUser(username).Return([]string{}, nil).Times(2)
+	repository.EXPECT().GetCommentsByUser(username).Return([]*model.Comment{}, nil).Times(2)
+	repository.EXPECT().GetReviewsByUser(username).Return([]*model.Review{}, nil).Times(2)
+	repository.EXPECT().GetLikedPostIdsByUser(username).Return([]string{}, nil).Times(2)
+	repository.EXPECT().GetSuperlikedPostIdsByUser(username).Return([]string{}, nil).Times(2)
+	repository.EXPECT().DecreaseFollowees("user2", subjectHash, followerStep).Times(2)
+	gomock.InOrder(
+		repository.EXPECT().RemoveUserProfile(username).Return(errors.New("some error")),
+		repository.EXPECT().RemoveUserProfile(username),
+	)
+	repository.EXPECT().RemoveErasureProgress(subjectHash, []string{followerStep})
+	repository.EXPECT().AddErasureRecord(gomock.Any()).Times(2)
+
+	erasureService.EraseUser(username, []string{"user2"}, []string{})
+	erasureService.EraseUser(username, []string{"user2"}, []string{})
+}
+
+func TestSubjectHashDependsOnTheKey(t *testing.T) {
+	assert.NotEqual(t, erasure.SubjectHash([]byte("key"), "user1"), erasure.SubjectHash([]byte("other key"), "user1"))
+	assert.Equal(t, erasure.SubjectHash([]byte("key"), "user1"), erasure.SubjectHash([]byte("key"), "user1"))
+}
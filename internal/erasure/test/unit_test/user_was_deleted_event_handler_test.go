@@ -0,0 +1,42 @@
+package erasure_test
+
+import (
+	"encoding/json"
+	erasure_handler "readmodels/internal/erasure/handler"
+	mock_erasure_handler "readmodels/internal/erasure/handler/test/mock"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var userWasDeletedEventHandler *erasure_handler.UserWasDeletedEventHandler
+var userWasDeletedEventService *mock_erasure_handler.MockUserWasDeletedEventService
+
+func setUpUserWasDeletedEventHandler(t *testing.T) {
+	SetUp(t)
+	userWasDeletedEventService = mock_erasure_handler.NewMockUserWasDeletedEventService(ctrl)
+	userWasDeletedEventHandler = erasure_handler.NewUserWasDeletedEventHandler(userWasDeletedEventService)
+}
+
+func TestHandleUserWasDeletedEvent(t *testing.T) {
+	setUpUserWasDeletedEventHandler(t)
+	data := &erasure_handler.UserWasDeletedEvent{
+		Username:    "user1",
+		FollowerIds: []string{"user2", "user3"},
+		FolloweeIds: []string{"user4"},
+	}
+	event, _ := json.Marshal(data)
+	userWasDeletedEventService.EXPECT().EraseUser(data.Username, data.FollowerIds, data.FolloweeIds)
+
+	userWasDeletedEventHandler.Handle(event)
+}
+
+func TestInvalidDataInUserWasDeletedEventHandler(t *testing.T) {
+	setUpUserWasDeletedEventHandler(t)
+	invalidData := "invalid data"
+	event, _ := json.Marshal(invalidData)
+
+	userWasDeletedEventHandler.Handle(event)
+
+	assert.Contains(t, loggerOutput.String(), "Invalid event data")
+}
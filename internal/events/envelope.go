@@ -0,0 +1,54 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Envelope wraps every event published to Kafka. Messages produced before the
+// envelope existed carry the bare payload and are read as version 1.
+type Envelope struct {
+	Id         string          `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// Upcaster transforms a payload of one version into the next one. Numbers in
+// the payload are json.Number values.
+type Upcaster func(payload map[string]any) (map[string]any, error)
+
+// Schema describes the payload of an event type. Payload is a value of the
+// struct the handlers decode, which always matches the current Version.
+// Upcasters holds, for every older version, the function that brings it to
+// the following one. Fields are required unless their json tag has omitempty,
+// and their values must satisfy the rules of their `validate` tag.
+type Schema struct {
+	Type      string
+	Version   int
+	Payload   any
+	Upcasters map[int]Upcaster
+}
+
+// RenameField returns an upcaster that moves the value of a payload field to
+// a new name.
+func RenameField(from, to string) Upcaster {
+	return func(payload map[string]any) (map[string]any, error) {
+		if value, ok := payload[from]; ok {
+			delete(payload, from)
+			payload[to] = value
+		}
+		return payload, nil
+	}
+}
+
+// RenameFields chains several RenameField upcasters.
+func RenameFields(renames map[string]string) Upcaster {
+	return func(payload map[string]any) (map[string]any, error) {
+		for from, to := range renames {
+			payload, _ = RenameField(from, to)(payload)
+		}
+		return payload, nil
+	}
+}
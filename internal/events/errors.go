@@ -0,0 +1,73 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ReasonMalformedEnvelope  = "malformed_envelope"
+	ReasonTypeMismatch       = "type_mismatch"
+	ReasonUnknownEventType   = "unknown_event_type"
+	ReasonUnsupportedVersion = "unsupported_version"
+	ReasonUpcastFailed       = "upcast_failed"
+	ReasonInvalidPayload     = "invalid_payload"
+	ReasonUnknownField       = "unknown_field"
+	ReasonMissingField       = "missing_field"
+	ReasonValidationFailed   = "validation_failed"
+)
+
+// DecodeError explains why an event was rejected. Reason is one of the Reason
+// constants and is what the dead letter queue records, along with the
+// Violations when the payload broke validation rules.
+type DecodeError struct {
+	Reason     string
+	EventType  string
+	Version    int
+	Detail     string
+	Violations []*Violation
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s: %s version %d: %s", e.Reason, e.EventType, e.Version, e.Detail)
+}
+
+func NewDecodeError(reason, eventType string, version int, detail string) *DecodeError {
+	return &DecodeError{
+		Reason:    reason,
+		EventType: eventType,
+		Version:   version,
+		Detail:    detail,
+	}
+}
+
+func NewValidationError(eventType string, version int, violations []*Violation) *DecodeError {
+	described := make([]string, len(violations))
+	for i, violation := range violations {
+		described[i] = violation.String()
+	}
+
+	return &DecodeError{
+		Reason:     ReasonValidationFailed,
+		EventType:  eventType,
+		Version:    version,
+		Detail:     "invalid fields " + strings.Join(described, ", "),
+		Violations: violations,
+	}
+}
+
+type InvalidSchemaError struct {
+	eventType string
+	detail    string
+}
+
+func (e *InvalidSchemaError) Error() string {
+	return fmt.Sprintf("Invalid schema for %s: %s", e.eventType, e.detail)
+}
+
+func NewInvalidSchemaError(eventType, detail string) *InvalidSchemaError {
+	return &InvalidSchemaError{
+		eventType: eventType,
+		detail:    detail,
+	}
+}
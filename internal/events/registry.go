@@ -0,0 +1,243 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type Registry struct {
+	schemas map[string]*registeredSchema
+}
+
+type registeredSchema struct {
+	Schema
+	payloadType reflect.Type
+}
+
+func NewRegistry(schemas ...Schema) (*Registry, error) {
+	registry := &Registry{
+		schemas: make(map[string]*registeredSchema, len(schemas)),
+	}
+
+	for _, schema := range schemas {
+		err := registry.Register(schema)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// Register adds the schema, checking that every older version can be upcast
+// to the current one.
+func (r *Registry) Register(schema Schema) error {
+	if _, ok := r.schemas[schema.Type]; ok {
+		return NewInvalidSchemaError(schema.Type, "already registered")
+	}
+	if schema.Version < 1 {
+		return NewInvalidSchemaError(schema.Type, "version must be 1 or greater")
+	}
+	payloadType := reflect.TypeOf(schema.Payload)
+	if payloadType == nil || payloadType.Kind() != reflect.Struct {
+		return NewInvalidSchemaError(schema.Type, "payload must be a struct")
+	}
+	for version := 1; version < schema.Version; version++ {
+		if _, ok := schema.Upcasters[version]; !ok {
+			return NewInvalidSchemaError(schema.Type, fmt.Sprintf("missing upcaster from version %d", version))
+		}
+	}
+
+	r.schemas[schema.Type] = &registeredSchema{
+		Schema:      schema,
+		payloadType: payloadType,
+	}
+	return nil
+}
+
+func (r *Registry) Has(eventType string) bool {
+	_, ok := r.schemas[eventType]
+	return ok
+}
+
+func (r *Registry) Types() []string {
+	types := make([]string, 0, len(r.schemas))
+	for eventType := range r.schemas {
+		types = append(types, eventType)
+	}
+	return types
+}
+
+// Decode reads the message of the given event type, upcasts its payload to the
+// current version and validates it strictly against the schema and the
+// `validate` tags of the payload struct. The returned
+// envelope holds the payload re-encoded in its current version.
+func (r *Registry) Decode(eventType string, data []byte) (*Envelope, error) {
+	schema, ok := r.schemas[eventType]
+	if !ok {
+		return nil, NewDecodeError(ReasonUnknownEventType, eventType, 0, "no schema registered")
+	}
+
+	envelope, err := readEnvelope(eventType, data)
+	if err != nil {
+		return nil, err
+	}
+	if envelope.Version > schema.Version {
+		return nil, NewDecodeError(ReasonUnsupportedVersion, eventType, envelope.Version, fmt.Sprintf("latest known version is %d", schema.Version))
+	}
+
+	// Numbers are kept as json.Number so that ids above 2^53 survive the
+	// round trip through the upcasters
+	var payload map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(envelope.Payload))
+	decoder.UseNumber()
+	err = decoder.Decode(&payload)
+	if err != nil || payload == nil {
+		return nil, NewDecodeError(ReasonInvalidPayload, eventType, envelope.Version, "payload is not a JSON object")
+	}
+
+	for version := envelope.Version; version < schema.Version; version++ {
+		payload, err = schema.Upcasters[version](payload)
+		if err != nil {
+			return nil, NewDecodeError(ReasonUpcastFailed, eventType, version, err.Error())
+		}
+	}
+
+	current, err := decodeStrict(schema, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope.Version = schema.Version
+	envelope.Payload = current
+	return envelope, nil
+}
+
+func readEnvelope(eventType string, data []byte) (*Envelope, error) {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, NewDecodeError(ReasonMalformedEnvelope, eventType, 0, "message is not a JSON object")
+	}
+
+	_, hasType := fields["type"]
+	_, hasPayload := fields["payload"]
+	if !hasType || !hasPayload {
+		// Message published before events were wrapped in an envelope
+		return &Envelope{
+			Type:    eventType,
+			Version: 1,
+			Payload: data,
+		}, nil
+	}
+
+	var envelope Envelope
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&envelope)
+	if err != nil {
+		return nil, NewDecodeError(ReasonMalformedEnvelope, eventType, 0, err.Error())
+	}
+
+	for _, field := range []string{"id", "version", "occurredAt"} {
+		if _, ok := fields[field]; !ok {
+			return nil, NewDecodeError(ReasonMalformedEnvelope, eventType, envelope.Version, "missing envelope field "+field)
+		}
+	}
+	if envelope.Version < 1 {
+		return nil, NewDecodeError(ReasonMalformedEnvelope, eventType, envelope.Version, "version must be 1 or greater")
+	}
+	if envelope.Type != eventType {
+		return nil, NewDecodeError(ReasonTypeMismatch, eventType, envelope.Version, fmt.Sprintf("envelope type %s was published to %s", envelope.Type, eventType))
+	}
+
+	return &envelope, nil
+}
+
+func decodeStrict(schema *registeredSchema, payload map[string]any) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, NewDecodeError(ReasonInvalidPayload, schema.Type, schema.Version, err.Error())
+	}
+
+	value := reflect.New(schema.payloadType)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(value.Interface())
+	if err != nil {
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return nil, NewDecodeError(ReasonUnknownField, schema.Type, schema.Version, "unknown field "+field)
+		}
+		return nil, NewDecodeError(ReasonInvalidPayload, schema.Type, schema.Version, err.Error())
+	}
+
+	missing := missingFields(schema.payloadType, payload, "")
+	if len(missing) > 0 {
+		return nil, NewDecodeError(ReasonMissingField, schema.Type, schema.Version, "missing required fields "+strings.Join(missing, ", "))
+	}
+
+	violations := validatePayload(value.Elem().Interface())
+	if len(violations) > 0 {
+		return nil, NewValidationError(schema.Type, schema.Version, violations)
+	}
+
+	current, err := json.Marshal(value.Elem().Interface())
+	if err != nil {
+		return nil, NewDecodeError(ReasonInvalidPayload, schema.Type, schema.Version, err.Error())
+	}
+	return current, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func missingFields(structType reflect.Type, payload map[string]any, path string) []string {
+	missing := []string{}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, optional := jsonFieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		value, ok := payload[name]
+		if !ok || value == nil {
+			if !optional {
+				missing = append(missing, path+name)
+			}
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct && field.Type != timeType {
+			if nested, ok := value.(map[string]any); ok {
+				missing = append(missing, missingFields(field.Type, nested, path+name+".")...)
+			}
+		}
+	}
+	return missing
+}
+
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			return name, true
+		}
+	}
+	return name, false
+}
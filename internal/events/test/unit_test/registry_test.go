@@ -0,0 +1,218 @@
+package events_test
+
+import (
+	"errors"
+	"readmodels/internal/events"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMetadata struct {
+	Title       string `json:"title" validate:"max=20"`
+	Description string `json:"description,omitempty"`
+}
+
+type testEvent struct {
+	PostId   string       `json:"postId" validate:"id"`
+	Rating   int          `json:"rating" validate:"min=0,max=5"`
+	Metadata testMetadata `json:"metadata"`
+}
+
+func newRegistry(t *testing.T) *events.Registry {
+	registry, err := events.NewRegistry(events.Schema{
+		Type:    "TestEvent",
+		Version: 3,
+		Payload: testEvent{},
+		Upcasters: map[int]events.Upcaster{
+			1: events.RenameField("post_id", "postId"),
+			2: func(payload map[string]any) (map[string]any, error) {
+				if _, ok := payload["rating"]; !ok {
+					payload["rating"] = 0
+				}
+				return payload, nil
+			},
+		},
+	})
+	assert.Nil(t, err)
+	return registry
+}
+
+func assertDecodeError(t *testing.T, err error, expectedReason string) {
+	var decodeError *events.DecodeError
+	assert.True(t, errors.As(err, &decodeError))
+	assert.Equal(t, expectedReason, decodeError.Reason)
+}
+
+func TestDecodeEnvelopeWithCurrentVersion(t *testing.T) {
+	registry := newRegistry(t)
+	data := []byte(`{"id":"1","type":"TestEvent","version":3,"occurredAt":"2024-01-02T03:04:05Z","payload":{"postId":"post1","rating":4,"metadata":{"title":"Título"}}}`)
+
+	envelope, err := registry.Decode("TestEvent", data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1", envelope.Id)
+	assert.Equal(t, 3, envelope.Version)
+	assert.JSONEq(t, `{"postId":"post1","rating":4,"metadata":{"title":"Título"}}`, string(envelope.Payload))
+}
+
+func TestDecodeUpcastsMessagesWithoutEnvelopeFromVersionOne(t *testing.T) {
+	registry := newRegistry(t)
+	data := []byte(`{"post_id":"post1","metadata":{"title":"Título","description":"Descrición"}}`)
+
+	envelope, err := registry.Decode("TestEvent", data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, envelope.Version)
+	assert.JSONEq(t, `{"postId":"post1","rating":0,"metadata":{"title":"Título","description":"Descrición"}}`, string(envelope.Payload))
+}
+
+func TestDecodeUpcastsEnvelopeFromOlderVersion(t *testing.T) {
+	registry := newRegistry(t)
+	data := []byte(`{"id":"1","type":"TestEvent","version":2,"occurredAt":"2024-01-02T03:04:05Z","payload":{"postId":"post1","metadata":{"title":"Título"}}}`)
+
+	envelope, err := registry.Decode("TestEvent", data)
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"postId":"post1","rating":0,"metadata":{"title":"Título"}}`, string(envelope.Payload))
+}
+
+func TestDecodeKeepsIdsAboveTheFloatPrecision(t *testing.T) {
+	registry, err := events.NewRegistry(events.Schema{
+		Type:    "CommentEvent",
+		Version: 2,
+		Payload: struct {
+			CommentId uint64 `json:"commentId"`
+		}{},
+		Upcasters: map[int]events.Upcaster{
+			1: events.RenameField("comment_id", "commentId"),
+		},
+	})
+	assert.Nil(t, err)
+	data := []byte(`{"comment_id":9007199254740993}`)
+
+	envelope, err := registry.Decode("CommentEvent", data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"commentId":9007199254740993}`, string(envelope.Payload))
+}
+
+func TestDecodeRejectsUnknownFields(t *testing.T) {
+	registry := newRegistry(t)
+	data := []byte(`{"id":"1","type":"TestEvent","version":3,"occurredAt":"2024-01-02T03:04:05Z","payload":{"postId":"post1","rating":4,"likes":3,"metadata":{"title":"Título"}}}`)
+
+	_, err := registry.Decode("TestEvent", data)
+
+	assertDecodeError(t, err, events.ReasonUnknownField)
+	assert.Contains(t, err.Error(), `"likes"`)
+}
+
+func TestDecodeRejectsMissingRequiredFields(t *testing.T) {
+	registry := newRegistry(t)
+	data := []byte(`{"id":"1","type":"TestEvent","version":3,"occurredAt":"2024-01-02T03:04:05Z","payload":{"rating":4,"metadata":{}}}`)
+
+	_, err := registry.Decode("TestEvent", data)
+
+	assertDecodeError(t, err, events.ReasonMissingField)
+	assert.Contains(t, err.Error(), "postId, metadata.title")
+}
+
+func TestDecodeRejectsWrongFieldTypes(t *testing.T) {
+	registry := newRegistry(t)
+	data := []byte(`{"id":"1","type":"TestEvent","version":3,"occurredAt":"2024-01-02T03:04:05Z","payload":{"postId":"post1","rating":"four","metadata":{"title":"Título"}}}`)
+
+	_, err := registry.Decode("TestEvent", data)
+
+	assertDecodeError(t, err, events.ReasonInvalidPayload)
+}
+
+func TestDecodeRejectsPayloadsBreakingValidationRules(t *testing.T) {
+	registry := newRegistry(t)
+	data := []byte(`{"id":"1","type":"TestEvent","version":3,"occurredAt":"2024-01-02T03:04:05Z","payload":{"postId":"post 1","rating":9,"metadata":{"title":"A title longer than twenty characters"}}}`)
+
+	_, err := registry.Decode("TestEvent", data)
+
+	assertDecodeError(t, err, events.ReasonValidationFailed)
+	var decodeError *events.DecodeError
+	errors.As(err, &decodeError)
+	assert.Equal(t, []*events.Violation{
+		{Field: "postId", Rule: "id"},
+		{Field: "rating", Rule: "max", Param: "5"},
+		{Field: "metadata.title", Rule: "max", Param: "20"},
+	}, decodeError.Violations)
+	assert.Contains(t, err.Error(), "postId (id), rating (max=5), metadata.title (max=20)")
+}
+
+func TestDecodeValidatesUpcastedPayloads(t *testing.T) {
+	registry := newRegistry(t)
+	data := []byte(`{"post_id":"","metadata":{"title":"Título"}}`)
+
+	_, err := registry.Decode("TestEvent", data)
+
+	assertDecodeError(t, err, events.ReasonValidationFailed)
+	assert.Contains(t, err.Error(), "postId (id)")
+}
+
+func TestDecodeRejectsNewerVersions(t *testing.T) {
+	registry := newRegistry(t)
+	data := []byte(`{"id":"1","type":"TestEvent","version":4,"occurredAt":"2024-01-02T03:04:05Z","payload":{}}`)
+
+	_, err := registry.Decode("TestEvent", data)
+
+	assertDecodeError(t, err, events.ReasonUnsupportedVersion)
+}
+
+func TestDecodeRejectsEnvelopePublishedToAnotherTopic(t *testing.T) {
+	registry := newRegistry(t)
+	data := []byte(`{"id":"1","type":"OtherEvent","version":3,"occurredAt":"2024-01-02T03:04:05Z","payload":{}}`)
+
+	_, err := registry.Decode("TestEvent", data)
+
+	assertDecodeError(t, err, events.ReasonTypeMismatch)
+}
+
+func TestDecodeRejectsIncompleteEnvelope(t *testing.T) {
+	registry := newRegistry(t)
+	data := []byte(`{"type":"TestEvent","version":3,"payload":{}}`)
+
+	_, err := registry.Decode("TestEvent", data)
+
+	assertDecodeError(t, err, events.ReasonMalformedEnvelope)
+	assert.Contains(t, err.Error(), "missing envelope field id")
+}
+
+func TestDecodeRejectsUnknownEventTypes(t *testing.T) {
+	registry := newRegistry(t)
+
+	_, err := registry.Decode("UnknownEvent", []byte(`{}`))
+
+	assertDecodeError(t, err, events.ReasonUnknownEventType)
+}
+
+func TestDecodeRejectsFailedUpcasts(t *testing.T) {
+	registry, _ := events.NewRegistry(events.Schema{
+		Type:    "TestEvent",
+		Version: 2,
+		Payload: testEvent{},
+		Upcasters: map[int]events.Upcaster{
+			1: func(payload map[string]any) (map[string]any, error) {
+				return nil, errors.New("rating can't be derived")
+			},
+		},
+	})
+
+	_, err := registry.Decode("TestEvent", []byte(`{"postId":"post1"}`))
+
+	assertDecodeError(t, err, events.ReasonUpcastFailed)
+}
+
+func TestRegisterFailsWithoutUpcasterForEveryOlderVersion(t *testing.T) {
+	_, err := events.NewRegistry(events.Schema{
+		Type:    "TestEvent",
+		Version: 2,
+		Payload: testEvent{},
+	})
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "missing upcaster from version 1")
+}
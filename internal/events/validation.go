@@ -0,0 +1,85 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"readmodels/internal/model"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const maxIdLength = 128
+
+// Violation is a single failed rule of an event payload.
+type Violation struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
+}
+
+func (v *Violation) String() string {
+	if v.Param == "" {
+		return fmt.Sprintf("%s (%s)", v.Field, v.Rule)
+	}
+	return fmt.Sprintf("%s (%s=%s)", v.Field, v.Rule, v.Param)
+}
+
+var payloadValidator = newPayloadValidator()
+
+// newPayloadValidator checks the `validate` struct tags of the event payloads.
+// Besides the validator built-in rules it knows:
+//   - id: a non blank identifier without whitespace of up to 128 characters
+//   - timestamp: a time formatted with model.TimeLayout
+func newPayloadValidator() *validator.Validate {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _ := jsonFieldName(field)
+		return name
+	})
+	_ = validate.RegisterValidation("id", validateId)
+	_ = validate.RegisterValidation("timestamp", validateTimestamp)
+	return validate
+}
+
+func validateId(field validator.FieldLevel) bool {
+	id := field.Field().String()
+	if id == "" || len(id) > maxIdLength {
+		return false
+	}
+	return strings.IndexFunc(id, func(r rune) bool {
+		return unicode.IsSpace(r) || !unicode.IsPrint(r)
+	}) == -1
+}
+
+func validateTimestamp(field validator.FieldLevel) bool {
+	_, err := time.Parse(model.TimeLayout, field.Field().String())
+	return err == nil
+}
+
+func validatePayload(payload any) []*Violation {
+	err := payloadValidator.Struct(payload)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []*Violation{{Field: "", Rule: err.Error()}}
+	}
+
+	violations := make([]*Violation, len(validationErrors))
+	for i, fieldError := range validationErrors {
+		// The namespace starts with the payload struct name
+		_, field, _ := strings.Cut(fieldError.Namespace(), ".")
+		violations[i] = &Violation{
+			Field: field,
+			Rule:  fieldError.Tag(),
+			Param: fieldError.Param(),
+		}
+	}
+	return violations
+}
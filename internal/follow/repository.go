@@ -15,7 +15,7 @@ func (r FollowRepository) GetFollowersMetadata(followerIds []string) (*[]Followe
 	}
 
 	followersMetadata := &[]FollowerMetadata{} // mandatory inizialiting like this otherwise it will failed
-	err := r.Client.GetMultipleData("UserProfile", followerKeys, followersMetadata)
+	err := r.Client.GetMultipleData(database.UserProfileTable, followerKeys, followersMetadata)
 	if err != nil {
 		return followersMetadata, err
 	}
@@ -32,7 +32,7 @@ func (r FollowRepository) GetFolloweesMetadata(followeeIds []string) (*[]Followe
 	}
 
 	followeesMetadata := &[]FolloweeMetadata{} // mandatory inizialiting like this otherwise it will failed
-	err := r.Client.GetMultipleData("UserProfile", followeeKeys, followeesMetadata)
+	err := r.Client.GetMultipleData(database.UserProfileTable, followeeKeys, followeesMetadata)
 	if err != nil {
 		return followeesMetadata, err
 	}
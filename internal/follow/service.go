@@ -16,11 +16,15 @@ type FollowService struct {
 type FollowerMetadata struct {
 	Username string `json:"username"`
 	Name     string `json:"fullname"`
+	UserType string `json:"userType"`
+	Region   string `json:"region"`
 }
 
 type FolloweeMetadata struct {
 	Username string `json:"username"`
 	Name     string `json:"fullname"`
+	UserType string `json:"userType"`
+	Region   string `json:"region"`
 }
 
 func NewFollowService(repository Repository) *FollowService {
@@ -39,14 +39,20 @@ func TestGetFollowersMetadata(t *testing.T) {
 		{
 			Username: followerId1,
 			Name:     "fullname1",
+			UserType: "UA",
+			Region:   "Vigo",
 		},
 		{
 			Username: followerId2,
 			Name:     "fullname2",
+			UserType: "UA",
+			Region:   "Vigo",
 		},
 		{
 			Username: followerId3,
 			Name:     "fullname3",
+			UserType: "UA",
+			Region:   "Vigo",
 		},
 	}
 	repository.EXPECT().GetFollowersMetadata([]string{followerId1, followerId2, followerId3}).Return(expectedData, nil)
@@ -56,15 +62,21 @@ func TestGetFollowersMetadata(t *testing.T) {
 		"content": {"followers":[
 		{
 			"username":      "` + followerId1 + `",
-			"fullname":   "` + (*expectedData)[0].Name + `"
+			"fullname":   "` + (*expectedData)[0].Name + `",
+			"userType":   "UA",
+			"region":   "Vigo"
 		},
 		{
 			"username":      "` + followerId2 + `",
-			"fullname":   "` + (*expectedData)[1].Name + `"
+			"fullname":   "` + (*expectedData)[1].Name + `",
+			"userType":   "UA",
+			"region":   "Vigo"
 		},
 		{
 			"username":      "` + followerId3 + `",
-			"fullname":   "` + (*expectedData)[2].Name + `"
+			"fullname":   "` + (*expectedData)[2].Name + `",
+			"userType":   "UA",
+			"region":   "Vigo"
 		}
 		]}
 	}`
@@ -116,14 +128,20 @@ func TestGetFolloweesMetadata(t *testing.T) {
 		{
 			Username: followeeId1,
 			Name:     "fullname1",
+			UserType: "UA",
+			Region:   "Vigo",
 		},
 		{
 			Username: followeeId2,
 			Name:     "fullname2",
+			UserType: "UA",
+			Region:   "Vigo",
 		},
 		{
 			Username: followeeId3,
 			Name:     "fullname3",
+			UserType: "UA",
+			Region:   "Vigo",
 		},
 	}
 	repository.EXPECT().GetFolloweesMetadata([]string{followeeId1, followeeId2, followeeId3}).Return(expectedData, nil)
@@ -133,15 +151,21 @@ func TestGetFolloweesMetadata(t *testing.T) {
 		"content": {"followees":[
 		{
 			"username":      "` + followeeId1 + `",
-			"fullname":   "` + (*expectedData)[0].Name + `"
+			"fullname":   "` + (*expectedData)[0].Name + `",
+			"userType":   "UA",
+			"region":   "Vigo"
 		},
 		{
 			"username":      "` + followeeId2 + `",
-			"fullname":   "` + (*expectedData)[1].Name + `"
+			"fullname":   "` + (*expectedData)[1].Name + `",
+			"userType":   "UA",
+			"region":   "Vigo"
 		},
 		{
 			"username":      "` + followeeId3 + `",
-			"fullname":   "` + (*expectedData)[2].Name + `"
+			"fullname":   "` + (*expectedData)[2].Name + `",
+			"userType":   "UA",
+			"region":   "Vigo"
 		}
 		]}
 	}`
@@ -0,0 +1,166 @@
+package live
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"readmodels/internal/api"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	heartbeatInterval = 15 * time.Second
+	writeTimeout      = 5 * time.Second
+	reconnectDelay    = 3 * time.Second
+)
+
+// LiveController streams the counter changes of the posts given in the ids
+// query parameter as Server-Sent Events. Every event carries the change id,
+// which the browser sends back in Last-Event-ID when it reconnects.
+type LiveController struct {
+	hub *Hub
+}
+
+func NewLiveController(hub *Hub) *LiveController {
+	return &LiveController{
+		hub: hub,
+	}
+}
+
+func (controller *LiveController) Routes(routerGroup *gin.RouterGroup) {
+	routerGroup.GET("/live/posts", controller.StreamPosts)
+}
+
+// Close ends the open streams when the Api shuts down.
+func (controller *LiveController) Close() {
+	controller.hub.Close()
+}
+
+func (controller *LiveController) StreamPosts(c *gin.Context) {
+	log.Info().Msg("Handling Request GET LivePosts")
+
+	postIds := parsePostIds(c.QueryArray("ids"))
+	if len(postIds) == 0 {
+		api.SendBadRequest(c, "At least one post id is required in the ids query parameter")
+		return
+	}
+	lastEventId := c.GetHeader("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = c.Query("lastEventId")
+	}
+
+	subscription, err := controller.hub.Subscribe(postIds, lastEventId)
+	if err != nil {
+		controller.sendError(c, err)
+		return
+	}
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// The stream outlives the server write timeout, so every write gets its own deadline
+	responseController := http.NewResponseController(c.Writer)
+	send := func(write func(w io.Writer) error) bool {
+		responseController.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := write(c.Writer); err != nil {
+			return false
+		}
+		return responseController.Flush() == nil
+	}
+
+	if !send(writeRetry) {
+		return
+	}
+	if subscription.Reset && !send(writeReset) {
+		return
+	}
+	for _, change := range subscription.Replay {
+		if !send(changeWriter(change)) {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case change := <-subscription.Changes():
+			if !send(changeWriter(change)) {
+				return
+			}
+		case <-heartbeat.C:
+			if !send(writeHeartbeat) {
+				return
+			}
+		case <-subscription.Done():
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+func (controller *LiveController) sendError(c *gin.Context, err error) {
+	var tooManyPostsError *TooManyPostsError
+	if errors.As(err, &tooManyPostsError) {
+		api.SendBadRequest(c, err.Error())
+		return
+	}
+
+	api.SendFailure(c, http.StatusServiceUnavailable, err.Error())
+}
+
+// parsePostIds accepts both repeated and comma separated ids.
+func parsePostIds(values []string) []string {
+	seen := map[string]bool{}
+	postIds := []string{}
+	for _, value := range values {
+		for _, postId := range strings.Split(value, ",") {
+			postId = strings.TrimSpace(postId)
+			if postId == "" || seen[postId] {
+				continue
+			}
+			seen[postId] = true
+			postIds = append(postIds, postId)
+		}
+	}
+	return postIds
+}
+
+func writeRetry(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "retry: %d\n\n", reconnectDelay.Milliseconds())
+	return err
+}
+
+// writeReset tells the client that changes were missed and the counters
+// have to be reloaded.
+func writeReset(w io.Writer) error {
+	_, err := io.WriteString(w, "event: reset\ndata: {}\n\n")
+	return err
+}
+
+func writeHeartbeat(w io.Writer) error {
+	_, err := io.WriteString(w, ": heartbeat\n\n")
+	return err
+}
+
+func changeWriter(change *CounterChange) func(w io.Writer) error {
+	return func(w io.Writer) error {
+		data, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "id: %s\nevent: counter\ndata: %s\n\n", change.Id, data)
+		return err
+	}
+}
@@ -0,0 +1,41 @@
+package live
+
+import "fmt"
+
+type TooManyPostsError struct {
+	count int
+}
+
+func (e *TooManyPostsError) Error() string {
+	return fmt.Sprintf("A live stream follows at most %d posts, got %d", MaxPostsPerConnection, e.count)
+}
+
+func NewTooManyPostsError(count int) *TooManyPostsError {
+	return &TooManyPostsError{
+		count: count,
+	}
+}
+
+type TooManyConnectionsError struct {
+	maxConnections int
+}
+
+func (e *TooManyConnectionsError) Error() string {
+	return fmt.Sprintf("Live streams are limited to %d connections", e.maxConnections)
+}
+
+func NewTooManyConnectionsError(maxConnections int) *TooManyConnectionsError {
+	return &TooManyConnectionsError{
+		maxConnections: maxConnections,
+	}
+}
+
+type HubClosedError struct{}
+
+func (e *HubClosedError) Error() string {
+	return "Live streams are shutting down"
+}
+
+func NewHubClosedError() *HubClosedError {
+	return &HubClosedError{}
+}
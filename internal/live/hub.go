@@ -0,0 +1,162 @@
+package live
+
+import (
+	"sync"
+)
+
+const (
+	MaxPostsPerConnection = 100
+	historySize           = 1000
+	subscriptionBuffer    = 32
+)
+
+// CounterChange is the change of a post counter pushed to the live streams.
+// Ids sort in the order the changes happened.
+type CounterChange struct {
+	Id      string `json:"id"`
+	PostId  string `json:"postId"`
+	Counter string `json:"counter"`
+	Delta   int    `json:"delta"`
+}
+
+// Hub fans the counter changes out to the subscriptions of their post, and
+// keeps the latest ones so that a reconnecting client resumes where it left.
+type Hub struct {
+	mutex          sync.Mutex
+	subscriptions  map[*Subscription]bool
+	history        []*CounterChange
+	maxConnections int
+	closed         bool
+}
+
+// Subscription receives the changes of its posts. Replay holds the changes
+// missed since the Last-Event-ID given on subscription, and Reset is set when
+// that event is no longer known, so the client has to reload the counters.
+// A subscription that doesn't keep up is closed and has to resume.
+type Subscription struct {
+	Replay  []*CounterChange
+	Reset   bool
+	postIds map[string]bool
+	changes chan *CounterChange
+	done    chan struct{}
+	hub     *Hub
+}
+
+func NewHub(maxConnections int) *Hub {
+	return &Hub{
+		subscriptions:  make(map[*Subscription]bool),
+		maxConnections: maxConnections,
+	}
+}
+
+func (h *Hub) Subscribe(postIds []string, lastEventId string) (*Subscription, error) {
+	if len(postIds) > MaxPostsPerConnection {
+		return nil, NewTooManyPostsError(len(postIds))
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.closed {
+		return nil, NewHubClosedError()
+	}
+	if len(h.subscriptions) >= h.maxConnections {
+		return nil, NewTooManyConnectionsError(h.maxConnections)
+	}
+
+	subscription := &Subscription{
+		postIds: make(map[string]bool, len(postIds)),
+		changes: make(chan *CounterChange, subscriptionBuffer),
+		done:    make(chan struct{}),
+		hub:     h,
+	}
+	for _, postId := range postIds {
+		subscription.postIds[postId] = true
+	}
+	if lastEventId != "" {
+		subscription.Replay, subscription.Reset = h.since(lastEventId, subscription.postIds)
+	}
+
+	h.subscriptions[subscription] = true
+	return subscription, nil
+}
+
+// Publish hands the change to the subscriptions of its post without waiting
+// for them.
+func (h *Hub) Publish(change *CounterChange) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.history = append(h.history, change)
+	if len(h.history) > historySize {
+		h.history = h.history[len(h.history)-historySize:]
+	}
+
+	for subscription := range h.subscriptions {
+		if !subscription.postIds[change.PostId] {
+			continue
+		}
+		select {
+		case subscription.changes <- change:
+		default:
+			h.closeLocked(subscription)
+		}
+	}
+}
+
+// Connections returns the number of open subscriptions.
+func (h *Hub) Connections() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return len(h.subscriptions)
+}
+
+// Close ends every subscription and refuses new ones.
+func (h *Hub) Close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.closed = true
+	for subscription := range h.subscriptions {
+		h.closeLocked(subscription)
+	}
+}
+
+// since returns the changes of postIds after lastEventId, or reset when
+// lastEventId is no longer in the history.
+func (h *Hub) since(lastEventId string, postIds map[string]bool) (changes []*CounterChange, reset bool) {
+	for i, change := range h.history {
+		if change.Id != lastEventId {
+			continue
+		}
+		for _, missed := range h.history[i+1:] {
+			if postIds[missed.PostId] {
+				changes = append(changes, missed)
+			}
+		}
+		return changes, false
+	}
+	return nil, true
+}
+
+func (h *Hub) closeLocked(subscription *Subscription) {
+	if !h.subscriptions[subscription] {
+		return
+	}
+	delete(h.subscriptions, subscription)
+	close(subscription.done)
+}
+
+func (s *Subscription) Changes() <-chan *CounterChange {
+	return s.changes
+}
+
+// Done is closed when the hub ends the subscription.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscription) Close() {
+	s.hub.mutex.Lock()
+	defer s.hub.mutex.Unlock()
+	s.hub.closeLocked(s)
+}
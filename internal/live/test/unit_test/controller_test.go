@@ -0,0 +1,91 @@
+package live_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"readmodels/internal/live"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+var loggerOutput bytes.Buffer
+var hub *live.Hub
+var controller *live.LiveController
+var apiResponse *httptest.ResponseRecorder
+var ginContext *gin.Context
+
+func setUpController(t *testing.T) {
+	log.Logger = log.Output(&loggerOutput)
+	hub = live.NewHub(10)
+	controller = live.NewLiveController(hub)
+	gin.SetMode(gin.TestMode)
+	apiResponse = httptest.NewRecorder()
+	ginContext, _ = gin.CreateTestContext(apiResponse)
+}
+
+func streamUntilClosed(publish func()) {
+	streaming := make(chan struct{})
+	go func() {
+		controller.StreamPosts(ginContext)
+		close(streaming)
+	}()
+	for hub.Connections() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	publish()
+	// Leaves time to the stream to write the published changes
+	time.Sleep(10 * time.Millisecond)
+	controller.Close()
+	<-streaming
+}
+
+func TestStreamPostsSendsCounterChanges(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/live/posts?ids=post1,post2", nil)
+
+	streamUntilClosed(func() {
+		hub.Publish(&live.CounterChange{Id: "1", PostId: "post2", Counter: "likes", Delta: 1})
+		hub.Publish(&live.CounterChange{Id: "2", PostId: "post3", Counter: "likes", Delta: 1})
+	})
+
+	assert.Equal(t, "text/event-stream", apiResponse.Header().Get("Content-Type"))
+	assert.Equal(t, "retry: 3000\n\n"+
+		"id: 1\nevent: counter\ndata: {\"id\":\"1\",\"postId\":\"post2\",\"counter\":\"likes\",\"delta\":1}\n\n", apiResponse.Body.String())
+}
+
+func TestStreamPostsResumesFromLastEventId(t *testing.T) {
+	setUpController(t)
+	hub.Publish(&live.CounterChange{Id: "1", PostId: "post1", Counter: "comments", Delta: 1})
+	hub.Publish(&live.CounterChange{Id: "2", PostId: "post1", Counter: "comments", Delta: -1})
+	ginContext.Request, _ = http.NewRequest("GET", "/live/posts?ids=post1", nil)
+	ginContext.Request.Header.Set("Last-Event-ID", "1")
+
+	streamUntilClosed(func() {})
+
+	assert.Contains(t, apiResponse.Body.String(), "id: 2\nevent: counter\n")
+	assert.NotContains(t, apiResponse.Body.String(), "id: 1\n")
+}
+
+func TestStreamPostsAsksToResetOnUnknownLastEventId(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/live/posts?ids=post1", nil)
+	ginContext.Request.Header.Set("Last-Event-ID", "1")
+
+	streamUntilClosed(func() {})
+
+	assert.Contains(t, apiResponse.Body.String(), "event: reset\n")
+}
+
+func TestStreamPostsWithoutIds(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/live/posts", nil)
+
+	controller.StreamPosts(ginContext)
+
+	assert.Equal(t, http.StatusBadRequest, apiResponse.Code)
+}
@@ -0,0 +1,87 @@
+package live_test
+
+import (
+	"errors"
+	"fmt"
+	"readmodels/internal/live"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishReachesSubscriptionsOfThePost(t *testing.T) {
+	hub := live.NewHub(10)
+	subscription, _ := hub.Subscribe([]string{"post1"}, "")
+	other, _ := hub.Subscribe([]string{"post2"}, "")
+	change := &live.CounterChange{Id: "1", PostId: "post1", Counter: "likes", Delta: 1}
+
+	hub.Publish(change)
+
+	assert.Equal(t, change, <-subscription.Changes())
+	assert.Len(t, other.Changes(), 0)
+}
+
+func TestSubscribeReplaysChangesAfterLastEventId(t *testing.T) {
+	hub := live.NewHub(10)
+	hub.Publish(&live.CounterChange{Id: "1", PostId: "post1"})
+	hub.Publish(&live.CounterChange{Id: "2", PostId: "post2"})
+	hub.Publish(&live.CounterChange{Id: "3", PostId: "post1"})
+
+	subscription, err := hub.Subscribe([]string{"post1"}, "1")
+
+	assert.Nil(t, err)
+	assert.False(t, subscription.Reset)
+	assert.Equal(t, []*live.CounterChange{{Id: "3", PostId: "post1"}}, subscription.Replay)
+}
+
+func TestSubscribeResetsUnknownLastEventId(t *testing.T) {
+	hub := live.NewHub(10)
+	hub.Publish(&live.CounterChange{Id: "2", PostId: "post1"})
+
+	subscription, err := hub.Subscribe([]string{"post1"}, "1")
+
+	assert.Nil(t, err)
+	assert.True(t, subscription.Reset)
+	assert.Empty(t, subscription.Replay)
+}
+
+func TestSubscribeLimitsPostsAndConnections(t *testing.T) {
+	hub := live.NewHub(1)
+	postIds := make([]string, live.MaxPostsPerConnection+1)
+	for i := range postIds {
+		postIds[i] = fmt.Sprintf("post%d", i)
+	}
+
+	_, err := hub.Subscribe(postIds, "")
+	var tooManyPostsError *live.TooManyPostsError
+	assert.True(t, errors.As(err, &tooManyPostsError))
+
+	hub.Subscribe([]string{"post1"}, "")
+	_, err = hub.Subscribe([]string{"post1"}, "")
+	var tooManyConnectionsError *live.TooManyConnectionsError
+	assert.True(t, errors.As(err, &tooManyConnectionsError))
+}
+
+func TestSlowSubscriptionsAreClosed(t *testing.T) {
+	hub := live.NewHub(10)
+	subscription, _ := hub.Subscribe([]string{"post1"}, "")
+
+	for i := 0; i < 100; i++ {
+		hub.Publish(&live.CounterChange{Id: fmt.Sprint(i), PostId: "post1"})
+	}
+
+	<-subscription.Done()
+	assert.Equal(t, 0, hub.Connections())
+}
+
+func TestCloseEndsSubscriptions(t *testing.T) {
+	hub := live.NewHub(10)
+	subscription, _ := hub.Subscribe([]string{"post1"}, "")
+
+	hub.Close()
+
+	<-subscription.Done()
+	_, err := hub.Subscribe([]string{"post1"}, "")
+	var hubClosedError *live.HubClosedError
+	assert.True(t, errors.As(err, &hubClosedError))
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+const (
+	ActivityTypePosted     = "posted"
+	ActivityTypeLiked      = "liked"
+	ActivityTypeSuperliked = "superliked"
+	ActivityTypeCommented  = "commented"
+	ActivityTypeReviewed   = "reviewed"
+	ActivityTypeFollowed   = "followed"
+)
+
+var ActivityTypes = []string{
+	ActivityTypePosted,
+	ActivityTypeLiked,
+	ActivityTypeSuperliked,
+	ActivityTypeCommented,
+	ActivityTypeReviewed,
+	ActivityTypeFollowed,
+}
+
+// Activity is an entry of the activity log of Username. Only the fields
+// identifying the subject of its Type are set.
+type Activity struct {
+	ActivityId string    `json:"activityId"`
+	Username   string    `json:"username"`
+	Type       string    `json:"type"`
+	PostId     string    `json:"postId,omitempty"`
+	CommentId  uint64    `json:"commentId,omitempty"`
+	ReviewId   uint64    `json:"reviewId,omitempty"`
+	Followee   string    `json:"followee,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+const (
+	NotificationTypeLike      = "like"
+	NotificationTypeSuperlike = "superlike"
+	NotificationTypeComment   = "comment"
+	NotificationTypeReview    = "review"
+	NotificationTypeFollow    = "follow"
+)
+
+// Notification groups the actors of the same activity on the same subject,
+// e.g. "user1, user2 and 3 others liked your post". Actors holds the most
+// recent actor first and at most a few of them, ActorsCount all of them.
+type Notification struct {
+	NotificationId string    `json:"notificationId"`
+	Username       string    `json:"username"`
+	Type           string    `json:"type"`
+	PostId         string    `json:"postId,omitempty"`
+	Actors         []string  `json:"actors"`
+	ActorsCount    int       `json:"actorsCount"`
+	Read           bool      `json:"read"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// SearchPost is a post matching a full-text search on its title and
+// description.
+type SearchPost struct {
+	PostId      string    `json:"postId"`
+	Username    string    `json:"username"`
+	Type        string    `json:"type"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// SearchUser is a user matching a search on its username or name.
+type SearchUser struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
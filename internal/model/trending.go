@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// TrendingPost is a post ranked by its engagement, whose Score halves every
+// half-life since the post was created.
+type TrendingPost struct {
+	PostId    string    `json:"postId"`
+	Username  string    `json:"username"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"createdAt"`
+	Score     float64   `json:"score"`
+}
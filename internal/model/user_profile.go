@@ -1,10 +1,14 @@
 package model
 
+// UserProfile leaves UserType and Region out of the item when empty, as the
+// region index doesn't accept empty keys.
 type UserProfile struct {
 	Username        string `json:"username"`
 	Name            string `json:"name"`
 	Bio             string `json:"bio"`
 	Link            string `json:"link"`
+	UserType        string `json:"userType" dynamodbav:",omitempty"`
+	Region          string `json:"region" dynamodbav:",omitempty"`
 	FollowersAmount int    `json:"followersAmount"`
 	FolloweesAmount int    `json:"followeesAmount"`
 	PostsAmount     int    `json:"postsAmount"`
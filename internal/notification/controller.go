@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"readmodels/internal/api"
+	"readmodels/internal/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=controller.go -destination=test/mock/controller.go
+
+type ControllerService interface {
+	GetNotifications(username string, lastNotificationId, lastUpdatedAt string, limit int) ([]*model.Notification, string, string, int, error)
+	MarkAllRead(username string) error
+}
+
+type NotificationController struct {
+	service ControllerService
+}
+
+type GetNotificationsResponse struct {
+	Notifications      []*model.Notification `json:"notifications"`
+	UnreadCount        int                   `json:"unreadCount"`
+	Limit              int                   `json:"limit"`
+	LastNotificationId string                `json:"lastNotificationId"`
+	LastUpdatedAt      string                `json:"lastUpdatedAt"`
+}
+
+func NewNotificationController(service ControllerService) *NotificationController {
+	return &NotificationController{
+		service: service,
+	}
+}
+
+func (controller *NotificationController) Routes(routerGroup *gin.RouterGroup) {
+	routerGroup.GET("/notifications/:username", controller.GetNotifications)
+	routerGroup.POST("/notifications/:username/read", controller.MarkAllRead)
+}
+
+func (controller *NotificationController) GetNotifications(c *gin.Context) {
+	log.Info().Msg("Handling Request GET Notifications")
+	username := c.Param("username")
+	lastNotificationId := c.DefaultQuery("lastNotificationId", "")
+	lastUpdatedAt := c.DefaultQuery("lastUpdatedAt", "")
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "12"))
+
+	if err != nil || limit <= 0 {
+		api.SendBadRequest(c, "Invalid pagination parameters, limit has to be greater than 0")
+		return
+	}
+
+	if (lastNotificationId != "" && lastUpdatedAt == "") || (lastNotificationId == "" && lastUpdatedAt != "") {
+		api.SendBadRequest(c, "Invalid pagination parameters, lastNotificationId and lastUpdatedAt both have to have value or both have to be empty")
+		return
+	}
+
+	notifications, lastNotificationId, lastUpdatedAt, unreadCount, err := controller.service.GetNotifications(username, lastNotificationId, lastUpdatedAt, limit)
+	if err != nil {
+		api.SendInternalServerError(c, err.Error())
+		return
+	}
+
+	api.SendOKWithResult(c, &GetNotificationsResponse{
+		Notifications:      notifications,
+		UnreadCount:        unreadCount,
+		Limit:              limit,
+		LastNotificationId: lastNotificationId,
+		LastUpdatedAt:      lastUpdatedAt,
+	})
+}
+
+func (controller *NotificationController) MarkAllRead(c *gin.Context) {
+	log.Info().Msg("Handling Request POST Notifications read")
+	username := c.Param("username")
+
+	err := controller.service.MarkAllRead(username)
+	if err != nil {
+		api.SendInternalServerError(c, err.Error())
+		return
+	}
+
+	api.SendOKWithResult(c, nil)
+}
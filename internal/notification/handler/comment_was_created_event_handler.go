@@ -0,0 +1,39 @@
+package notification_handler
+
+import (
+	comment_handler "readmodels/internal/comment/handler"
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=comment_was_created_event_handler.go -destination=test/mock/comment_was_created_event_handler.go
+
+type CommentWasCreatedEventService interface {
+	NotifyPostActivity(notificationType, postId, actor, reference string)
+}
+
+type CommentWasCreatedEventHandler struct {
+	service CommentWasCreatedEventService
+}
+
+func NewCommentWasCreatedEventHandler(service CommentWasCreatedEventService) *CommentWasCreatedEventHandler {
+	return &CommentWasCreatedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *CommentWasCreatedEventHandler) Handle(event []byte) {
+	var commentWasCreatedEvent comment_handler.CommentWasCreatedEvent
+	log.Info().Msg("Handling CommentWasCreatedEvent for notifications")
+
+	err := common_data.DeserializeData(event, &commentWasCreatedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.NotifyPostActivity(model.NotificationTypeComment, commentWasCreatedEvent.PostId, commentWasCreatedEvent.Username, strconv.FormatUint(commentWasCreatedEvent.CommentId, 10))
+}
@@ -0,0 +1,39 @@
+package notification_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	reaction_handler "readmodels/internal/reaction/handler"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=review_was_created_event_handler.go -destination=test/mock/review_was_created_event_handler.go
+
+type ReviewWasCreatedEventService interface {
+	NotifyPostActivity(notificationType, postId, actor, reference string)
+}
+
+type ReviewWasCreatedEventHandler struct {
+	service ReviewWasCreatedEventService
+}
+
+func NewReviewWasCreatedEventHandler(service ReviewWasCreatedEventService) *ReviewWasCreatedEventHandler {
+	return &ReviewWasCreatedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *ReviewWasCreatedEventHandler) Handle(event []byte) {
+	var reviewWasCreatedEvent reaction_handler.ReviewWasCreatedEvent
+	log.Info().Msg("Handling ReviewWasCreatedEvent for notifications")
+
+	err := common_data.DeserializeData(event, &reviewWasCreatedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.NotifyPostActivity(model.NotificationTypeReview, reviewWasCreatedEvent.PostId, reviewWasCreatedEvent.Username, strconv.FormatUint(reviewWasCreatedEvent.ReviewId, 10))
+}
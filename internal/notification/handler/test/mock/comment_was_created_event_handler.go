@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment_was_created_event_handler.go
+
+// Package mock_notification_handler is a generated GoMock package.
+package mock_notification_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCommentWasCreatedEventService is a mock of CommentWasCreatedEventService interface.
+type MockCommentWasCreatedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentWasCreatedEventServiceMockRecorder
+}
+
+// MockCommentWasCreatedEventServiceMockRecorder is the mock recorder for MockCommentWasCreatedEventService.
+type MockCommentWasCreatedEventServiceMockRecorder struct {
+	mock *MockCommentWasCreatedEventService
+}
+
+// NewMockCommentWasCreatedEventService creates a new mock instance.
+func NewMockCommentWasCreatedEventService(ctrl *gomock.Controller) *MockCommentWasCreatedEventService {
+	mock := &MockCommentWasCreatedEventService{ctrl: ctrl}
+	mock.recorder = &MockCommentWasCreatedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentWasCreatedEventService) EXPECT() *MockCommentWasCreatedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// NotifyPostActivity mocks base method.
+func (m *MockCommentWasCreatedEventService) NotifyPostActivity(notificationType, postId, actor, reference string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPostActivity", notificationType, postId, actor, reference)
+}
+
+// NotifyPostActivity indicates an expected call of NotifyPostActivity.
+func (mr *MockCommentWasCreatedEventServiceMockRecorder) NotifyPostActivity(notificationType, postId, actor, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPostActivity", reflect.TypeOf((*MockCommentWasCreatedEventService)(nil).NotifyPostActivity), notificationType, postId, actor, reference)
+}
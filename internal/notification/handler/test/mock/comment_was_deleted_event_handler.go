@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment_was_deleted_event_handler.go
+
+// Package mock_notification_handler is a generated GoMock package.
+package mock_notification_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCommentWasDeletedEventService is a mock of CommentWasDeletedEventService interface.
+type MockCommentWasDeletedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentWasDeletedEventServiceMockRecorder
+}
+
+// MockCommentWasDeletedEventServiceMockRecorder is the mock recorder for MockCommentWasDeletedEventService.
+type MockCommentWasDeletedEventServiceMockRecorder struct {
+	mock *MockCommentWasDeletedEventService
+}
+
+// NewMockCommentWasDeletedEventService creates a new mock instance.
+func NewMockCommentWasDeletedEventService(ctrl *gomock.Controller) *MockCommentWasDeletedEventService {
+	mock := &MockCommentWasDeletedEventService{ctrl: ctrl}
+	mock.recorder = &MockCommentWasDeletedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentWasDeletedEventService) EXPECT() *MockCommentWasDeletedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// RetractReference mocks base method.
+func (m *MockCommentWasDeletedEventService) RetractReference(notificationType, postId, reference string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RetractReference", notificationType, postId, reference)
+}
+
+// RetractReference indicates an expected call of RetractReference.
+func (mr *MockCommentWasDeletedEventServiceMockRecorder) RetractReference(notificationType, postId, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetractReference", reflect.TypeOf((*MockCommentWasDeletedEventService)(nil).RetractReference), notificationType, postId, reference)
+}
@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_superliked_post_event_handler.go
+
+// Package mock_notification_handler is a generated GoMock package.
+package mock_notification_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserSuperlikedPostEventService is a mock of UserSuperlikedPostEventService interface.
+type MockUserSuperlikedPostEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSuperlikedPostEventServiceMockRecorder
+}
+
+// MockUserSuperlikedPostEventServiceMockRecorder is the mock recorder for MockUserSuperlikedPostEventService.
+type MockUserSuperlikedPostEventServiceMockRecorder struct {
+	mock *MockUserSuperlikedPostEventService
+}
+
+// NewMockUserSuperlikedPostEventService creates a new mock instance.
+func NewMockUserSuperlikedPostEventService(ctrl *gomock.Controller) *MockUserSuperlikedPostEventService {
+	mock := &MockUserSuperlikedPostEventService{ctrl: ctrl}
+	mock.recorder = &MockUserSuperlikedPostEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSuperlikedPostEventService) EXPECT() *MockUserSuperlikedPostEventServiceMockRecorder {
+	return m.recorder
+}
+
+// NotifyPostActivity mocks base method.
+func (m *MockUserSuperlikedPostEventService) NotifyPostActivity(notificationType, postId, actor, reference string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPostActivity", notificationType, postId, actor, reference)
+}
+
+// NotifyPostActivity indicates an expected call of NotifyPostActivity.
+func (mr *MockUserSuperlikedPostEventServiceMockRecorder) NotifyPostActivity(notificationType, postId, actor, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPostActivity", reflect.TypeOf((*MockUserSuperlikedPostEventService)(nil).NotifyPostActivity), notificationType, postId, actor, reference)
+}
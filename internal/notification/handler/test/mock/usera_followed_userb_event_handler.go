@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usera_followed_userb_event_handler.go
+
+// Package mock_notification_handler is a generated GoMock package.
+package mock_notification_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserAFollowedUserBEventService is a mock of UserAFollowedUserBEventService interface.
+type MockUserAFollowedUserBEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserAFollowedUserBEventServiceMockRecorder
+}
+
+// MockUserAFollowedUserBEventServiceMockRecorder is the mock recorder for MockUserAFollowedUserBEventService.
+type MockUserAFollowedUserBEventServiceMockRecorder struct {
+	mock *MockUserAFollowedUserBEventService
+}
+
+// NewMockUserAFollowedUserBEventService creates a new mock instance.
+func NewMockUserAFollowedUserBEventService(ctrl *gomock.Controller) *MockUserAFollowedUserBEventService {
+	mock := &MockUserAFollowedUserBEventService{ctrl: ctrl}
+	mock.recorder = &MockUserAFollowedUserBEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserAFollowedUserBEventService) EXPECT() *MockUserAFollowedUserBEventServiceMockRecorder {
+	return m.recorder
+}
+
+// NotifyFollow mocks base method.
+func (m *MockUserAFollowedUserBEventService) NotifyFollow(followee, follower string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyFollow", followee, follower)
+}
+
+// NotifyFollow indicates an expected call of NotifyFollow.
+func (mr *MockUserAFollowedUserBEventServiceMockRecorder) NotifyFollow(followee, follower interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyFollow", reflect.TypeOf((*MockUserAFollowedUserBEventService)(nil).NotifyFollow), followee, follower)
+}
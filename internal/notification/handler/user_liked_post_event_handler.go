@@ -0,0 +1,38 @@
+package notification_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	reaction_handler "readmodels/internal/reaction/handler"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=user_liked_post_event_handler.go -destination=test/mock/user_liked_post_event_handler.go
+
+type UserLikedPostEventService interface {
+	NotifyPostActivity(notificationType, postId, actor, reference string)
+}
+
+type UserLikedPostEventHandler struct {
+	service UserLikedPostEventService
+}
+
+func NewUserLikedPostEventHandler(service UserLikedPostEventService) *UserLikedPostEventHandler {
+	return &UserLikedPostEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserLikedPostEventHandler) Handle(event []byte) {
+	var userLikedPostEvent reaction_handler.UserLikedPostEvent
+	log.Info().Msg("Handling UserLikedPostEvent for notifications")
+
+	err := common_data.DeserializeData(event, &userLikedPostEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.NotifyPostActivity(model.NotificationTypeLike, userLikedPostEvent.PostId, userLikedPostEvent.Username, "")
+}
@@ -0,0 +1,38 @@
+package notification_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	reaction_handler "readmodels/internal/reaction/handler"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=user_unsuperliked_post_event_handler.go -destination=test/mock/user_unsuperliked_post_event_handler.go
+
+type UserUnsuperlikedPostEventService interface {
+	RetractPostActivity(notificationType, postId, actor string)
+}
+
+type UserUnsuperlikedPostEventHandler struct {
+	service UserUnsuperlikedPostEventService
+}
+
+func NewUserUnsuperlikedPostEventHandler(service UserUnsuperlikedPostEventService) *UserUnsuperlikedPostEventHandler {
+	return &UserUnsuperlikedPostEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserUnsuperlikedPostEventHandler) Handle(event []byte) {
+	var userUnsuperlikedPostEvent reaction_handler.UserUnsuperlikedPostEvent
+	log.Info().Msg("Handling UserUnsuperlikedPostEvent for notifications")
+
+	err := common_data.DeserializeData(event, &userUnsuperlikedPostEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.RetractPostActivity(model.NotificationTypeSuperlike, userUnsuperlikedPostEvent.PostId, userUnsuperlikedPostEvent.Username)
+}
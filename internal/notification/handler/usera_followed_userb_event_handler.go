@@ -0,0 +1,37 @@
+package notification_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	userprofile_handler "readmodels/internal/userprofile/handlers"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=usera_followed_userb_event_handler.go -destination=test/mock/usera_followed_userb_event_handler.go
+
+type UserAFollowedUserBEventService interface {
+	NotifyFollow(followee, follower string)
+}
+
+type UserAFollowedUserBEventHandler struct {
+	service UserAFollowedUserBEventService
+}
+
+func NewUserAFollowedUserBEventHandler(service UserAFollowedUserBEventService) *UserAFollowedUserBEventHandler {
+	return &UserAFollowedUserBEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserAFollowedUserBEventHandler) Handle(event []byte) {
+	var userAFollowedUserBEvent userprofile_handler.UserAFollowedUserBEvent
+	log.Info().Msg("Handling UserAFollowedUserBEvent for notifications")
+
+	err := common_data.DeserializeData(event, &userAFollowedUserBEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.NotifyFollow(userAFollowedUserBEvent.FolloweeID, userAFollowedUserBEvent.FollowerID)
+}
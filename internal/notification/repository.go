@@ -0,0 +1,635 @@
+package notification
+
+import (
+	"errors"
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Only a few actors are displayed with each notification, the others are
+// only counted.
+const displayedActors = 3
+
+// Notifications are removed by pages when the user is deleted.
+const notificationsPageSize = 100
+
+// Notifications are updated optimistically: the notification and its actor
+// are read, and written in a transaction conditioned on what was read. The
+// transaction is retried, after a growing delay, when they changed meanwhile.
+const maxUpdateAttempts = 6
+const updateRetryDelay = 20 * time.Millisecond
+
+type NotificationRepository struct {
+	database *database.Database
+}
+
+func NewNotificationRepository(database *database.Database) *NotificationRepository {
+	return &NotificationRepository{
+		database: database,
+	}
+}
+
+// GetPostOwner returns the author of the post, or an empty username when the
+// post isn't projected.
+func (r *NotificationRepository) GetPostOwner(postId string) (string, error) {
+	postKey := &database.PostMetadataKey{
+		PostId: postId,
+	}
+
+	var postMetadata database.PostMetadata
+	err := r.database.Client.GetData(database.PostMetadataTable, postKey, &postMetadata)
+	var notFoundError *database.NotFoundError
+	if errors.As(err, &notFoundError) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return postMetadata.Username, nil
+}
+
+// AddActor adds the actor to the notification, creating it when missing, and
+// marks it unread. reference is the id of the comment or review of the actor,
+// if any. Every actor is counted in ActorsCount, but only the first
+// displayedActors are kept in Actors. The unread counter of the user is
+// increased when the notification becomes unread.
+func (r *NotificationRepository) AddActor(notification *model.Notification, actor, reference string) error {
+	key := &database.NotificationKey{
+		Username:       notification.Username,
+		NotificationId: notification.NotificationId,
+	}
+	actorKey := &database.NotificationActorKey{
+		Username: notification.Username,
+		ActorId:  database.NotificationActorId(notification.NotificationId, actor),
+	}
+
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		waitToRetry(attempt)
+
+		var current database.NotificationRecord
+		exists, err := r.database.Client.GetDataIfExists(database.NotificationsTable, key, &current)
+		if err != nil {
+			return err
+		}
+		var actorRecord database.NotificationActorRecord
+		actorExists, err := r.database.Client.GetDataIfExists(database.NotificationActorsTable, actorKey, &actorRecord)
+		if err != nil {
+			return err
+		}
+
+		items := []*database.TransactionItem{
+			addActorItem(actorKey, actorExists, notification.NotificationId, actor, reference),
+			addToNotificationItem(key, notification, &current, exists, actor, !actorExists),
+		}
+		if !exists || current.Read {
+			items = append(items, unreadCounterItem(notification.Username, 1))
+		}
+
+		err = r.database.Client.TransactWriteData(items)
+		if conditionFailed(err, 0, 1) {
+			continue
+		}
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't add actor %s to notification %s of %s", actor, notification.NotificationId, notification.Username)
+			return err
+		}
+
+		return nil
+	}
+
+	return database.NewConcurrentUpdateError(database.NotificationsTable, key)
+}
+
+// RemoveActor removes the reference from the actor of the notification, and
+// the actor once none of their references is left. An empty reference removes
+// the actor with every reference. The notification is deleted with its last
+// actor, and otherwise the displayed actors are refilled.
+func (r *NotificationRepository) RemoveActor(username, notificationId, actor, reference string) error {
+	key := &database.NotificationKey{
+		Username:       username,
+		NotificationId: notificationId,
+	}
+	actorKey := &database.NotificationActorKey{
+		Username: username,
+		ActorId:  database.NotificationActorId(notificationId, actor),
+	}
+
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		waitToRetry(attempt)
+
+		var actorRecord database.NotificationActorRecord
+		actorExists, err := r.database.Client.GetDataIfExists(database.NotificationActorsTable, actorKey, &actorRecord)
+		if err != nil {
+			return err
+		}
+		if !actorExists || (reference != "" && !slices.Contains(actorRecord.References, reference)) {
+			log.Warn().Msgf("Actor %s isn't in notification %s of %s, it was not removed", actor, notificationId, username)
+			return nil
+		}
+
+		// The references are only removed while the actor has others, so the
+		// conditions apply to both the removal of the reference and of the
+		// actor
+		actorConditions := []database.Condition{database.AttributeExists("Username")}
+		if reference != "" {
+			actorConditions = append(actorConditions,
+				database.SetContains("References", reference),
+				database.SetSizeEquals("References", len(actorRecord.References)))
+		}
+
+		if reference != "" && len(actorRecord.References) > 1 {
+			err = r.database.Client.TransactWriteData([]*database.TransactionItem{
+				{
+					TableName:     database.NotificationActorsTable,
+					Key:           actorKey,
+					DeleteFromSet: map[string]any{"References": []string{reference}},
+					Conditions:    actorConditions,
+				},
+			})
+			if conditionFailed(err, 0) {
+				continue
+			}
+			if err != nil {
+				log.Error().Stack().Err(err).Msgf("Couldn't remove reference %s of actor %s from notification %s of %s", reference, actor, notificationId, username)
+				return err
+			}
+			return nil
+		}
+
+		var current database.NotificationRecord
+		exists, err := r.database.Client.GetDataIfExists(database.NotificationsTable, key, &current)
+		if err != nil {
+			return err
+		}
+
+		items := []*database.TransactionItem{
+			{
+				TableName:  database.NotificationActorsTable,
+				Key:        actorKey,
+				Delete:     true,
+				Conditions: actorConditions,
+			},
+		}
+		if exists {
+			items = append(items, removeFromNotificationItems(key, &current, actor)...)
+		}
+
+		err = r.database.Client.TransactWriteData(items)
+		if conditionFailed(err, 0, 1) {
+			continue
+		}
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't remove actor %s from notification %s of %s", actor, notificationId, username)
+			return err
+		}
+
+		if exists && current.ActorsCount > 1 && slices.Contains(current.Actors, actor) {
+			return r.refillActors(key)
+		}
+		return nil
+	}
+
+	return database.NewConcurrentUpdateError(database.NotificationsTable, key)
+}
+
+// GetReferenceActor returns the actor of the comment or review of the
+// notification, or an empty username when it isn't in the notification.
+func (r *NotificationRepository) GetReferenceActor(username, notificationId, reference string) (string, error) {
+	actors, err := r.getActors(username, notificationId, 0)
+	if err != nil {
+		return "", err
+	}
+
+	for _, actor := range actors {
+		if slices.Contains(actor.References, reference) {
+			return actor.Actor, nil
+		}
+	}
+	return "", nil
+}
+
+// GetActorNotifications returns the notifications of other users where actor
+// appears, with only their owner and id.
+func (r *NotificationRepository) GetActorNotifications(actor string) ([]*model.Notification, error) {
+	var records []*database.NotificationActorRecord
+	err := r.database.Client.GetAllDataByIndex(database.NotificationActorsTable, database.NotificationActorIndex, "Actor", actor, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*model.Notification, len(records))
+	for i, record := range records {
+		notifications[i] = &model.Notification{
+			NotificationId: record.NotificationId,
+			Username:       record.Username,
+		}
+	}
+	return notifications, nil
+}
+
+// RemoveNotifications deletes the notifications of the user with their
+// actors, and decreases the unread counter by the unread ones.
+func (r *NotificationRepository) RemoveNotifications(username string, notificationIds []string) error {
+	for _, notificationId := range notificationIds {
+		actors, err := r.getActors(username, notificationId, 0)
+		if err != nil {
+			return err
+		}
+		actorKeys := make([]any, len(actors))
+		for i, actor := range actors {
+			actorKeys[i] = &database.NotificationActorKey{
+				Username: actor.Username,
+				ActorId:  actor.ActorId,
+			}
+		}
+		err = r.database.Client.RemoveMultipleData(database.NotificationActorsTable, actorKeys)
+		if err != nil {
+			return err
+		}
+
+		err = r.removeNotification(&database.NotificationKey{
+			Username:       username,
+			NotificationId: notificationId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveAllNotifications removes every notification of the user, with the
+// unread counter.
+func (r *NotificationRepository) RemoveAllNotifications(username string) error {
+	lastNotificationId, lastUpdatedAt := "", ""
+	for {
+		records, nextNotificationId, nextUpdatedAt, err := r.database.Client.GetNotificationsByIndexUsername(username, lastNotificationId, lastUpdatedAt, notificationsPageSize)
+		if err != nil {
+			return err
+		}
+
+		notificationIds := make([]string, len(records))
+		for i, record := range records {
+			notificationIds[i] = record.NotificationId
+		}
+		err = r.RemoveNotifications(username, notificationIds)
+		if err != nil {
+			return err
+		}
+
+		if nextNotificationId == "" {
+			break
+		}
+		lastNotificationId, lastUpdatedAt = nextNotificationId, nextUpdatedAt
+	}
+
+	return r.database.Client.RemoveMultipleData(database.NotificationsTable, []any{
+		&database.NotificationKey{
+			Username:       username,
+			NotificationId: database.NotificationsUnreadId,
+		},
+	})
+}
+
+func (r *NotificationRepository) GetNotifications(username string, lastNotificationId, lastUpdatedAt string, limit int) ([]*model.Notification, string, string, error) {
+	records, lastNotificationId, lastUpdatedAt, err := r.database.Client.GetNotificationsByIndexUsername(username, lastNotificationId, lastUpdatedAt, limit)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	notifications := make([]*model.Notification, len(records))
+	for i, record := range records {
+		notifications[i] = mapNotificationRecord(record)
+	}
+	return notifications, lastNotificationId, lastUpdatedAt, nil
+}
+
+func (r *NotificationRepository) GetUnreadNotificationIds(username string) ([]string, error) {
+	var records []*database.NotificationRecord
+	err := r.database.Client.GetDataByKeyPrefix(database.NotificationsTable, "Username", username, "NotificationId", "", 0, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	notificationIds := []string{}
+	for _, record := range records {
+		if !record.Read && record.NotificationId != database.NotificationsUnreadId {
+			notificationIds = append(notificationIds, record.NotificationId)
+		}
+	}
+	return notificationIds, nil
+}
+
+func (r *NotificationRepository) GetUnreadCount(username string) (int, error) {
+	key := &database.NotificationKey{
+		Username:       username,
+		NotificationId: database.NotificationsUnreadId,
+	}
+
+	var record database.UnreadNotificationsRecord
+	err := r.database.Client.GetData(database.NotificationsTable, key, &record)
+	var notFoundError *database.NotFoundError
+	if errors.As(err, &notFoundError) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return max(record.Unread, 0), nil
+}
+
+// MarkRead marks the notification as read and decreases the unread counter.
+// Read or missing notifications are left untouched.
+func (r *NotificationRepository) MarkRead(username, notificationId string) error {
+	err := r.database.Client.TransactWriteData([]*database.TransactionItem{
+		{
+			TableName: database.NotificationsTable,
+			Key: &database.NotificationKey{
+				Username:       username,
+				NotificationId: notificationId,
+			},
+			Set:        map[string]any{"Read": true},
+			Conditions: []database.Condition{database.AttributeEquals("Read", false)},
+		},
+		unreadCounterItem(username, -1),
+	})
+	if conditionFailed(err, 0) {
+		return nil
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Couldn't mark notification %s of %s as read", notificationId, username)
+		return err
+	}
+
+	return nil
+}
+
+// refillActors displays other actors of the notification while it has less
+// than displayedActors. Nothing is done when the displayed actors change
+// meanwhile, since they are refilled by whoever changed them.
+func (r *NotificationRepository) refillActors(key *database.NotificationKey) error {
+	var current database.NotificationRecord
+	exists, err := r.database.Client.GetDataIfExists(database.NotificationsTable, key, &current)
+	if err != nil || !exists {
+		return err
+	}
+	missing := min(displayedActors, current.ActorsCount) - len(current.Actors)
+	if missing <= 0 {
+		return nil
+	}
+
+	// Among 2*displayedActors actors there are at least displayedActors not
+	// displayed
+	actors, err := r.getActors(key.Username, key.NotificationId, 2*displayedActors)
+	if err != nil {
+		return err
+	}
+	added := []string{}
+	for _, actor := range actors {
+		if len(added) < missing && !slices.Contains(current.Actors, actor.Actor) {
+			added = append(added, actor.Actor)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	err = r.database.Client.TransactWriteData([]*database.TransactionItem{
+		{
+			TableName:  database.NotificationsTable,
+			Key:        key,
+			Add:        map[string]any{"Actors": added},
+			Conditions: []database.Condition{database.AttributeExists("Username"), displayedActorsCondition(current.Actors)},
+		},
+	})
+	if err != nil && !conditionFailed(err, 0) {
+		log.Error().Stack().Err(err).Msgf("Couldn't refill the actors of notification %s of %s", key.NotificationId, key.Username)
+		return err
+	}
+
+	return nil
+}
+
+// removeNotification deletes the notification, and decreases the unread
+// counter in the same transaction when it's unread.
+func (r *NotificationRepository) removeNotification(key *database.NotificationKey) error {
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		waitToRetry(attempt)
+
+		var current database.NotificationRecord
+		exists, err := r.database.Client.GetDataIfExists(database.NotificationsTable, key, &current)
+		if err != nil || !exists {
+			return err
+		}
+
+		items := []*database.TransactionItem{
+			{
+				TableName:  database.NotificationsTable,
+				Key:        key,
+				Delete:     true,
+				Conditions: []database.Condition{database.AttributeEquals("Read", current.Read)},
+			},
+		}
+		if !current.Read {
+			items = append(items, unreadCounterItem(key.Username, -1))
+		}
+
+		err = r.database.Client.TransactWriteData(items)
+		if conditionFailed(err, 0) {
+			continue
+		}
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't remove notification %s of %s", key.NotificationId, key.Username)
+			return err
+		}
+
+		return nil
+	}
+
+	return database.NewConcurrentUpdateError(database.NotificationsTable, key)
+}
+
+// getActors returns the actors of the notification, up to limit when it
+// isn't zero.
+func (r *NotificationRepository) getActors(username, notificationId string, limit int) ([]*database.NotificationActorRecord, error) {
+	var actors []*database.NotificationActorRecord
+	err := r.database.Client.GetDataByKeyPrefix(database.NotificationActorsTable, "Username", username, "ActorId", database.NotificationActorId(notificationId, ""), limit, &actors)
+	if err != nil {
+		return nil, err
+	}
+	return actors, nil
+}
+
+// addActorItem inserts the actor of the notification, or adds the reference
+// to the existing actor.
+func addActorItem(actorKey *database.NotificationActorKey, actorExists bool, notificationId, actor, reference string) *database.TransactionItem {
+	if !actorExists {
+		record := &database.NotificationActorRecord{
+			Username:       actorKey.Username,
+			ActorId:        actorKey.ActorId,
+			NotificationId: notificationId,
+			Actor:          actor,
+		}
+		if reference != "" {
+			record.References = []string{reference}
+		}
+		return &database.TransactionItem{
+			TableName:  database.NotificationActorsTable,
+			Item:       record,
+			Conditions: []database.Condition{database.AttributeNotExists("Username")},
+		}
+	}
+
+	item := &database.TransactionItem{
+		TableName:  database.NotificationActorsTable,
+		Key:        actorKey,
+		Conditions: []database.Condition{database.AttributeExists("Username")},
+	}
+	if reference != "" {
+		item.Add = map[string]any{"References": []string{reference}}
+	}
+	return item
+}
+
+// addToNotificationItem updates the notification with the new actor. It's
+// conditioned on the read state and the displayed actors of current, which
+// decide the unread counter and whether the actor is displayed.
+func addToNotificationItem(key *database.NotificationKey, notification *model.Notification, current *database.NotificationRecord, exists bool, actor string, newActor bool) *database.TransactionItem {
+	item := &database.TransactionItem{
+		TableName: database.NotificationsTable,
+		Key:       key,
+		Set: map[string]any{
+			"Type":      notification.Type,
+			"PostId":    notification.PostId,
+			"LastActor": actor,
+			"Read":      false,
+			"UpdatedAt": notification.UpdatedAt.UTC().Format(model.TimeLayout),
+		},
+		Add: map[string]any{},
+	}
+	if newActor {
+		item.Add["ActorsCount"] = 1
+	}
+	if !slices.Contains(current.Actors, actor) && len(current.Actors) < displayedActors {
+		item.Add["Actors"] = []string{actor}
+	}
+
+	if !exists {
+		item.Conditions = []database.Condition{database.AttributeNotExists("Username")}
+	} else {
+		item.Conditions = []database.Condition{database.AttributeEquals("Read", current.Read), displayedActorsCondition(current.Actors)}
+	}
+	return item
+}
+
+// removeFromNotificationItems deletes the notification when actor is the last
+// one, decreasing the unread counter if it's unread. Otherwise the actor is
+// removed from the notification.
+func removeFromNotificationItems(key *database.NotificationKey, current *database.NotificationRecord, actor string) []*database.TransactionItem {
+	if current.ActorsCount <= 1 {
+		items := []*database.TransactionItem{
+			{
+				TableName: database.NotificationsTable,
+				Key:       key,
+				Delete:    true,
+				Conditions: []database.Condition{
+					database.AttributeEquals("ActorsCount", current.ActorsCount),
+					database.AttributeEquals("Read", current.Read),
+				},
+			},
+		}
+		if !current.Read {
+			items = append(items, unreadCounterItem(key.Username, -1))
+		}
+		return items
+	}
+
+	item := &database.TransactionItem{
+		TableName:  database.NotificationsTable,
+		Key:        key,
+		Add:        map[string]any{"ActorsCount": -1},
+		Conditions: []database.Condition{database.AttributeEquals("ActorsCount", current.ActorsCount)},
+	}
+	if slices.Contains(current.Actors, actor) {
+		item.DeleteFromSet = map[string]any{"Actors": []string{actor}}
+	}
+	if current.LastActor == actor {
+		item.Unset = []string{"LastActor"}
+		item.Conditions = append(item.Conditions, database.AttributeEquals("LastActor", actor))
+	}
+	return []*database.TransactionItem{item}
+}
+
+func unreadCounterItem(username string, incrementValue int) *database.TransactionItem {
+	return &database.TransactionItem{
+		TableName: database.NotificationsTable,
+		Key: &database.NotificationKey{
+			Username:       username,
+			NotificationId: database.NotificationsUnreadId,
+		},
+		Add: map[string]any{"Unread": incrementValue},
+	}
+}
+
+// displayedActorsCondition checks that the notification still displays as
+// many actors as actors.
+func displayedActorsCondition(actors []string) database.Condition {
+	if len(actors) == 0 {
+		return database.AttributeNotExists("Actors")
+	}
+	return database.SetSizeEquals("Actors", len(actors))
+}
+
+// conditionFailed reports whether err is a failed condition of one of the
+// items of the transaction.
+func conditionFailed(err error, items ...int) bool {
+	var conditionFailedError *database.ConditionFailedError
+	if !errors.As(err, &conditionFailedError) {
+		return false
+	}
+	return slices.ContainsFunc(items, conditionFailedError.Failed)
+}
+
+func waitToRetry(attempt int) {
+	if attempt > 0 {
+		time.Sleep(time.Duration(attempt) * updateRetryDelay)
+	}
+}
+
+// mapNotificationRecord lists the last actor first, followed by the other
+// displayed actors.
+func mapNotificationRecord(record *database.NotificationRecord) *model.Notification {
+	sorted := slices.Clone(record.Actors)
+	slices.Sort(sorted)
+
+	actors := []string{}
+	for _, actor := range append([]string{record.LastActor}, sorted...) {
+		if actor == "" || slices.Contains(actors, actor) {
+			continue
+		}
+		actors = append(actors, actor)
+	}
+	if len(actors) > displayedActors {
+		actors = actors[:displayedActors]
+	}
+
+	updatedAt, _ := time.Parse(model.TimeLayout, record.UpdatedAt)
+
+	return &model.Notification{
+		NotificationId: record.NotificationId,
+		Username:       record.Username,
+		Type:           record.Type,
+		PostId:         record.PostId,
+		Actors:         actors,
+		ActorsCount:    record.ActorsCount,
+		Read:           record.Read,
+		UpdatedAt:      updatedAt,
+	}
+}
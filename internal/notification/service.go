@@ -0,0 +1,218 @@
+package notification
+
+import (
+	"fmt"
+	"readmodels/internal/model"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=service.go -destination=test/mock/service.go
+
+var postActivityTypes = []string{
+	model.NotificationTypeLike,
+	model.NotificationTypeSuperlike,
+	model.NotificationTypeComment,
+	model.NotificationTypeReview,
+}
+
+type Repository interface {
+	GetPostOwner(postId string) (string, error)
+	AddActor(notification *model.Notification, actor, reference string) error
+	RemoveActor(username, notificationId, actor, reference string) error
+	GetReferenceActor(username, notificationId, reference string) (string, error)
+	GetActorNotifications(actor string) ([]*model.Notification, error)
+	RemoveNotifications(username string, notificationIds []string) error
+	RemoveAllNotifications(username string) error
+	GetNotifications(username string, lastNotificationId, lastUpdatedAt string, limit int) ([]*model.Notification, string, string, error)
+	GetUnreadNotificationIds(username string) ([]string, error)
+	GetUnreadCount(username string) (int, error)
+	MarkRead(username, notificationId string) error
+}
+
+type NotificationService struct {
+	repository Repository
+	now        func() time.Time
+}
+
+func NewNotificationService(repository Repository) *NotificationService {
+	return &NotificationService{
+		repository: repository,
+		now:        time.Now,
+	}
+}
+
+// NotifyPostActivity notifies the author of the post about the activity of
+// actor. reference identifies the comment or review, and is empty for
+// reactions. Users aren't notified of their own activity.
+func (s *NotificationService) NotifyPostActivity(notificationType, postId, actor, reference string) {
+	owner, err := s.repository.GetPostOwner(postId)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting the author of post %s", postId)
+		return
+	}
+	if owner == "" {
+		log.Warn().Msgf("Post %s doesn't exist, %s of %s is not notified", postId, notificationType, actor)
+		return
+	}
+	if owner == actor {
+		return
+	}
+
+	s.notify(&model.Notification{
+		NotificationId: postNotificationId(notificationType, postId),
+		Username:       owner,
+		Type:           notificationType,
+		PostId:         postId,
+	}, actor, reference)
+}
+
+func (s *NotificationService) NotifyFollow(followee, follower string) {
+	s.notify(&model.Notification{
+		NotificationId: model.NotificationTypeFollow,
+		Username:       followee,
+		Type:           model.NotificationTypeFollow,
+	}, follower, "")
+}
+
+// RetractPostActivity removes the actor from the notification of the post,
+// e.g. when a like is undone.
+func (s *NotificationService) RetractPostActivity(notificationType, postId, actor string) {
+	owner, err := s.repository.GetPostOwner(postId)
+	if err != nil || owner == "" {
+		log.Error().Stack().Err(err).Msgf("Error getting the author of post %s, %s of %s is not retracted", postId, notificationType, actor)
+		return
+	}
+
+	s.retract(owner, postNotificationId(notificationType, postId), actor, "")
+}
+
+// RetractReference removes the comment or review with the given id from the
+// notification of the post.
+func (s *NotificationService) RetractReference(notificationType, postId, reference string) {
+	owner, err := s.repository.GetPostOwner(postId)
+	if err != nil || owner == "" {
+		log.Error().Stack().Err(err).Msgf("Error getting the author of post %s, %s %s is not retracted", postId, notificationType, reference)
+		return
+	}
+
+	notificationId := postNotificationId(notificationType, postId)
+	actor, err := s.repository.GetReferenceActor(owner, notificationId, reference)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting notification %s of %s", notificationId, owner)
+		return
+	}
+	if actor == "" {
+		log.Warn().Msgf("%s %s isn't in notification %s of %s, it was not retracted", notificationType, reference, notificationId, owner)
+		return
+	}
+
+	s.retract(owner, notificationId, actor, reference)
+}
+
+func (s *NotificationService) RetractFollow(followee, follower string) {
+	s.retract(followee, model.NotificationTypeFollow, follower, "")
+}
+
+// RemovePostNotifications removes every notification about the posts of the
+// author, when the posts are deleted.
+func (s *NotificationService) RemovePostNotifications(owner string, postIds []string) {
+	notificationIds := []string{}
+	for _, postId := range postIds {
+		for _, notificationType := range postActivityTypes {
+			notificationIds = append(notificationIds, postNotificationId(notificationType, postId))
+		}
+	}
+
+	err := s.repository.RemoveNotifications(owner, notificationIds)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error removing the notifications of %d posts of %s", len(postIds), owner)
+		return
+	}
+
+	log.Info().Msgf("Notifications of %d posts of %s were removed", len(postIds), owner)
+}
+
+// RemoveUserNotifications removes the notifications of the deleted user, and
+// retracts the user from the notifications of others.
+func (s *NotificationService) RemoveUserNotifications(username string) {
+	err := s.repository.RemoveAllNotifications(username)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error removing the notifications of %s", username)
+		return
+	}
+
+	appearances, err := s.repository.GetActorNotifications(username)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting the notifications where %s appears", username)
+		return
+	}
+	for _, notification := range appearances {
+		s.retract(notification.Username, notification.NotificationId, username, "")
+	}
+
+	log.Info().Msgf("Notifications of %s were removed, and %s was retracted from %d notifications", username, username, len(appearances))
+}
+
+// GetNotifications returns a page of notifications, the most recent first,
+// with the number of unread ones.
+func (s *NotificationService) GetNotifications(username string, lastNotificationId, lastUpdatedAt string, limit int) ([]*model.Notification, string, string, int, error) {
+	notifications, lastNotificationId, lastUpdatedAt, err := s.repository.GetNotifications(username, lastNotificationId, lastUpdatedAt, limit)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting the notifications of %s", username)
+		return nil, "", "", 0, err
+	}
+
+	unread, err := s.repository.GetUnreadCount(username)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error counting the unread notifications of %s", username)
+		return nil, "", "", 0, err
+	}
+
+	return notifications, lastNotificationId, lastUpdatedAt, unread, nil
+}
+
+func (s *NotificationService) MarkAllRead(username string) error {
+	unread, err := s.repository.GetUnreadNotificationIds(username)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting the unread notifications of %s", username)
+		return err
+	}
+
+	for _, notificationId := range unread {
+		err = s.repository.MarkRead(username, notificationId)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Error marking notification %s of %s as read", notificationId, username)
+			return err
+		}
+	}
+
+	log.Info().Msgf("%d notifications of %s marked as read", len(unread), username)
+	return nil
+}
+
+func (s *NotificationService) notify(notification *model.Notification, actor, reference string) {
+	notification.UpdatedAt = s.now()
+	err := s.repository.AddActor(notification, actor, reference)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error notifying %s of %s to %s", notification.Type, actor, notification.Username)
+		return
+	}
+
+	log.Info().Msgf("%s of %s was notified to %s", notification.Type, actor, notification.Username)
+}
+
+func (s *NotificationService) retract(username, notificationId, actor, reference string) {
+	err := s.repository.RemoveActor(username, notificationId, actor, reference)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error retracting %s from notification %s of %s", actor, notificationId, username)
+		return
+	}
+
+	log.Info().Msgf("%s was retracted from notification %s of %s", actor, notificationId, username)
+}
+
+func postNotificationId(notificationType, postId string) string {
+	return fmt.Sprintf("%s#%s", notificationType, postId)
+}
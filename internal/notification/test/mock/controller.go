@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller.go
+
+// Package mock_notification is a generated GoMock package.
+package mock_notification
+
+import (
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockControllerService is a mock of ControllerService interface.
+type MockControllerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockControllerServiceMockRecorder
+}
+
+// MockControllerServiceMockRecorder is the mock recorder for MockControllerService.
+type MockControllerServiceMockRecorder struct {
+	mock *MockControllerService
+}
+
+// NewMockControllerService creates a new mock instance.
+func NewMockControllerService(ctrl *gomock.Controller) *MockControllerService {
+	mock := &MockControllerService{ctrl: ctrl}
+	mock.recorder = &MockControllerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockControllerService) EXPECT() *MockControllerServiceMockRecorder {
+	return m.recorder
+}
+
+// GetNotifications mocks base method.
+func (m *MockControllerService) GetNotifications(username, lastNotificationId, lastUpdatedAt string, limit int) ([]*model.Notification, string, string, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", username, lastNotificationId, lastUpdatedAt, limit)
+	ret0, _ := ret[0].([]*model.Notification)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(int)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockControllerServiceMockRecorder) GetNotifications(username, lastNotificationId, lastUpdatedAt, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockControllerService)(nil).GetNotifications), username, lastNotificationId, lastUpdatedAt, limit)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockControllerService) MarkAllRead(username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockControllerServiceMockRecorder) MarkAllRead(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockControllerService)(nil).MarkAllRead), username)
+}
@@ -0,0 +1,197 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_notification is a generated GoMock package.
+package mock_notification
+
+import (
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddActor mocks base method.
+func (m *MockRepository) AddActor(notification *model.Notification, actor, reference string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddActor", notification, actor, reference)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddActor indicates an expected call of AddActor.
+func (mr *MockRepositoryMockRecorder) AddActor(notification, actor, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActor", reflect.TypeOf((*MockRepository)(nil).AddActor), notification, actor, reference)
+}
+
+// GetActorNotifications mocks base method.
+func (m *MockRepository) GetActorNotifications(actor string) ([]*model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorNotifications", actor)
+	ret0, _ := ret[0].([]*model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorNotifications indicates an expected call of GetActorNotifications.
+func (mr *MockRepositoryMockRecorder) GetActorNotifications(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorNotifications", reflect.TypeOf((*MockRepository)(nil).GetActorNotifications), actor)
+}
+
+// GetNotifications mocks base method.
+func (m *MockRepository) GetNotifications(username, lastNotificationId, lastUpdatedAt string, limit int) ([]*model.Notification, string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", username, lastNotificationId, lastUpdatedAt, limit)
+	ret0, _ := ret[0].([]*model.Notification)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockRepositoryMockRecorder) GetNotifications(username, lastNotificationId, lastUpdatedAt, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockRepository)(nil).GetNotifications), username, lastNotificationId, lastUpdatedAt, limit)
+}
+
+// GetPostOwner mocks base method.
+func (m *MockRepository) GetPostOwner(postId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostOwner", postId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostOwner indicates an expected call of GetPostOwner.
+func (mr *MockRepositoryMockRecorder) GetPostOwner(postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostOwner", reflect.TypeOf((*MockRepository)(nil).GetPostOwner), postId)
+}
+
+// GetReferenceActor mocks base method.
+func (m *MockRepository) GetReferenceActor(username, notificationId, reference string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferenceActor", username, notificationId, reference)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferenceActor indicates an expected call of GetReferenceActor.
+func (mr *MockRepositoryMockRecorder) GetReferenceActor(username, notificationId, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferenceActor", reflect.TypeOf((*MockRepository)(nil).GetReferenceActor), username, notificationId, reference)
+}
+
+// GetUnreadCount mocks base method.
+func (m *MockRepository) GetUnreadCount(username string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadCount", username)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadCount indicates an expected call of GetUnreadCount.
+func (mr *MockRepositoryMockRecorder) GetUnreadCount(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadCount", reflect.TypeOf((*MockRepository)(nil).GetUnreadCount), username)
+}
+
+// GetUnreadNotificationIds mocks base method.
+func (m *MockRepository) GetUnreadNotificationIds(username string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadNotificationIds", username)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadNotificationIds indicates an expected call of GetUnreadNotificationIds.
+func (mr *MockRepositoryMockRecorder) GetUnreadNotificationIds(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadNotificationIds", reflect.TypeOf((*MockRepository)(nil).GetUnreadNotificationIds), username)
+}
+
+// MarkRead mocks base method.
+func (m *MockRepository) MarkRead(username, notificationId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", username, notificationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockRepositoryMockRecorder) MarkRead(username, notificationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockRepository)(nil).MarkRead), username, notificationId)
+}
+
+// RemoveActor mocks base method.
+func (m *MockRepository) RemoveActor(username, notificationId, actor, reference string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveActor", username, notificationId, actor, reference)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveActor indicates an expected call of RemoveActor.
+func (mr *MockRepositoryMockRecorder) RemoveActor(username, notificationId, actor, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveActor", reflect.TypeOf((*MockRepository)(nil).RemoveActor), username, notificationId, actor, reference)
+}
+
+// RemoveAllNotifications mocks base method.
+func (m *MockRepository) RemoveAllNotifications(username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAllNotifications", username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAllNotifications indicates an expected call of RemoveAllNotifications.
+func (mr *MockRepositoryMockRecorder) RemoveAllNotifications(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAllNotifications", reflect.TypeOf((*MockRepository)(nil).RemoveAllNotifications), username)
+}
+
+// RemoveNotifications mocks base method.
+func (m *MockRepository) RemoveNotifications(username string, notificationIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveNotifications", username, notificationIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveNotifications indicates an expected call of RemoveNotifications.
+func (mr *MockRepositoryMockRecorder) RemoveNotifications(username, notificationIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNotifications", reflect.TypeOf((*MockRepository)(nil).RemoveNotifications), username, notificationIds)
+}
@@ -0,0 +1,45 @@
+package notification_test
+
+import (
+	"encoding/json"
+	comment_handler "readmodels/internal/comment/handler"
+	"readmodels/internal/model"
+	notification_handler "readmodels/internal/notification/handler"
+	mock_notification_handler "readmodels/internal/notification/handler/test/mock"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var commentWasCreatedEventHandler *notification_handler.CommentWasCreatedEventHandler
+var commentWasCreatedEventService *mock_notification_handler.MockCommentWasCreatedEventService
+
+func setUpCommentWasCreatedEventHandler(t *testing.T) {
+	SetUp(t)
+	commentWasCreatedEventService = mock_notification_handler.NewMockCommentWasCreatedEventService(ctrl)
+	commentWasCreatedEventHandler = notification_handler.NewCommentWasCreatedEventHandler(commentWasCreatedEventService)
+}
+
+func TestHandleCommentWasCreatedEventForNotifications(t *testing.T) {
+	setUpCommentWasCreatedEventHandler(t)
+	data := &comment_handler.CommentWasCreatedEvent{
+		CommentId: uint64(42),
+		Username:  "user1",
+		PostId:    "post1",
+		Content:   "Exemplo de content",
+		CreatedAt: "2024-05-01T10:00:00.000000Z",
+	}
+	event, _ := json.Marshal(data)
+	commentWasCreatedEventService.EXPECT().NotifyPostActivity(model.NotificationTypeComment, "post1", "user1", "42")
+
+	commentWasCreatedEventHandler.Handle(event)
+}
+
+func TestInvalidDataInCommentWasCreatedEventHandlerForNotifications(t *testing.T) {
+	setUpCommentWasCreatedEventHandler(t)
+	event, _ := json.Marshal("invalid data")
+
+	commentWasCreatedEventHandler.Handle(event)
+
+	assert.Contains(t, loggerOutput.String(), "Invalid event data")
+}
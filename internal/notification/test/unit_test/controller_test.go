@@ -0,0 +1,123 @@
+package notification_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"readmodels/internal/model"
+	"readmodels/internal/notification"
+	mock_notification "readmodels/internal/notification/test/mock"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+)
+
+var controller *notification.NotificationController
+var controllerService *mock_notification.MockControllerService
+
+func setUpController(t *testing.T) {
+	SetUp(t)
+	controllerService = mock_notification.NewMockControllerService(ctrl)
+	controller = notification.NewNotificationController(controllerService)
+}
+
+func TestGetNotificationsWithController_WhenSuccess(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/notifications", nil)
+	ginContext.Params = []gin.Param{{Key: "username", Value: "owner1"}}
+	u := url.Values{}
+	u.Add("lastNotificationId", "follow")
+	u.Add("lastUpdatedAt", "2024-05-01T10:00:00.000000Z")
+	u.Add("limit", "4")
+	ginContext.Request.URL.RawQuery = u.Encode()
+	expectedNotifications := []*model.Notification{
+		{
+			NotificationId: "like#post1",
+			Username:       "owner1",
+			Type:           model.NotificationTypeLike,
+			PostId:         "post1",
+			Actors:         []string{"user2", "user1"},
+			ActorsCount:    2,
+			Read:           false,
+			UpdatedAt:      time.Date(2024, 4, 30, 9, 0, 0, 123456000, time.UTC),
+		},
+	}
+	controllerService.EXPECT().GetNotifications("owner1", "follow", "2024-05-01T10:00:00.000000Z", 4).Return(expectedNotifications, "like#post1", "2024-04-30T09:00:00.123456Z", 3, nil)
+	expectedBodyResponse := `{
+		"error": false,
+		"message": "200 OK",
+		"content": {
+			"notifications": [
+				{
+					"notificationId": "like#post1",
+					"username": "owner1",
+					"type": "like",
+					"postId": "post1",
+					"actors": ["user2", "user1"],
+					"actorsCount": 2,
+					"read": false,
+					"updatedAt": "2024-04-30T09:00:00.123456Z"
+				}
+			],
+			"unreadCount": 3,
+			"limit": 4,
+			"lastNotificationId": "like#post1",
+			"lastUpdatedAt": "2024-04-30T09:00:00.123456Z"
+		}
+	}`
+
+	controller.GetNotifications(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 200)
+	assert.Equal(t, removeSpace(apiResponse.Body.String()), removeSpace(expectedBodyResponse))
+}
+
+func TestBadRequestOnGetNotificationsWithController_WhenOnlyOneCursorIsSet(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/notifications", nil)
+	ginContext.Params = []gin.Param{{Key: "username", Value: "owner1"}}
+	u := url.Values{}
+	u.Add("lastNotificationId", "follow")
+	ginContext.Request.URL.RawQuery = u.Encode()
+
+	controller.GetNotifications(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 400)
+}
+
+func TestBadRequestOnGetNotificationsWithController_WhenLimitIsInvalid(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/notifications", nil)
+	ginContext.Params = []gin.Param{{Key: "username", Value: "owner1"}}
+	u := url.Values{}
+	u.Add("limit", "0")
+	ginContext.Request.URL.RawQuery = u.Encode()
+
+	controller.GetNotifications(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 400)
+}
+
+func TestInternalServerErrorOnGetNotificationsWithController(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/notifications", nil)
+	ginContext.Params = []gin.Param{{Key: "username", Value: "owner1"}}
+	controllerService.EXPECT().GetNotifications("owner1", "", "", 12).Return(nil, "", "", 0, errors.New("some error"))
+
+	controller.GetNotifications(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 500)
+}
+
+func TestMarkAllReadWithController(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("POST", "/notifications/owner1/read", nil)
+	ginContext.Params = []gin.Param{{Key: "username", Value: "owner1"}}
+	controllerService.EXPECT().MarkAllRead("owner1").Return(nil)
+
+	controller.MarkAllRead(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 200)
+}
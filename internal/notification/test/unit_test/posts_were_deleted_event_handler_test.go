@@ -0,0 +1,30 @@
+package notification_test
+
+import (
+	"encoding/json"
+	notification_handler "readmodels/internal/notification/handler"
+	mock_notification_handler "readmodels/internal/notification/handler/test/mock"
+	post_handler "readmodels/internal/post/handler"
+	"testing"
+)
+
+var postsWereDeletedEventHandler *notification_handler.PostsWereDeletedEventHandler
+var postsWereDeletedEventService *mock_notification_handler.MockPostsWereDeletedEventService
+
+func setUpPostsWereDeletedEventHandler(t *testing.T) {
+	SetUp(t)
+	postsWereDeletedEventService = mock_notification_handler.NewMockPostsWereDeletedEventService(ctrl)
+	postsWereDeletedEventHandler = notification_handler.NewPostsWereDeletedEventHandler(postsWereDeletedEventService)
+}
+
+func TestHandlePostsWereDeletedEventForNotifications(t *testing.T) {
+	setUpPostsWereDeletedEventHandler(t)
+	data := &post_handler.PostsWereDeletedEvent{
+		Username: "owner1",
+		PostIds:  []string{"post1", "post2"},
+	}
+	event, _ := json.Marshal(data)
+	postsWereDeletedEventService.EXPECT().RemovePostNotifications("owner1", []string{"post1", "post2"})
+
+	postsWereDeletedEventHandler.Handle(event)
+}
@@ -0,0 +1,445 @@
+package notification_test
+
+import (
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"readmodels/internal/notification"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var notificationRepository *notification.NotificationRepository
+
+func setUpRepository(t *testing.T) {
+	SetUp(t)
+	notificationRepository = notification.NewNotificationRepository(database.NewDatabase(client))
+}
+
+func TestGetPostOwnerInRepository(t *testing.T) {
+	setUpRepository(t)
+	expectedKey := &database.PostMetadataKey{PostId: "post1"}
+	client.EXPECT().GetData("PostMetadata", expectedKey, &database.PostMetadata{}).SetArg(2, database.PostMetadata{PostId: "post1", Username: "owner1"}).Return(nil)
+
+	owner, err := notificationRepository.GetPostOwner("post1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "owner1", owner)
+}
+
+func TestGetPostOwnerOfMissingPostInRepository(t *testing.T) {
+	setUpRepository(t)
+	expectedKey := &database.PostMetadataKey{PostId: "post1"}
+	client.EXPECT().GetData("PostMetadata", expectedKey, &database.PostMetadata{}).Return(database.NewNotFoundError("PostMetadata", expectedKey))
+
+	owner, err := notificationRepository.GetPostOwner("post1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", owner)
+}
+
+func TestAddActorInRepository(t *testing.T) {
+	setUpRepository(t)
+	updatedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	data := &model.Notification{
+		NotificationId: "comment#post1",
+		Username:       "owner1",
+		Type:           model.NotificationTypeComment,
+		PostId:         "post1",
+		UpdatedAt:      updatedAt,
+	}
+	key := &database.NotificationKey{Username: "owner1", NotificationId: "comment#post1"}
+	actorKey := &database.NotificationActorKey{Username: "owner1", ActorId: "comment#post1/user1"}
+	expectedItems := []*database.TransactionItem{
+		{
+			TableName:  "readmodels.notificationActors",
+			Item:       &database.NotificationActorRecord{Username: "owner1", ActorId: "comment#post1/user1", NotificationId: "comment#post1", Actor: "user1", References: []string{"comment1"}},
+			Conditions: []database.Condition{database.AttributeNotExists("Username")},
+		},
+		{
+			TableName: "readmodels.notifications",
+			Key:       key,
+			Set: map[string]any{
+				"Type":      model.NotificationTypeComment,
+				"PostId":    "post1",
+				"LastActor": "user1",
+				"Read":      false,
+				"UpdatedAt": "2024-05-01T10:00:00.000000Z",
+			},
+			Add:        map[string]any{"ActorsCount": 1, "Actors": []string{"user1"}},
+			Conditions: []database.Condition{database.AttributeNotExists("Username")},
+		},
+		{
+			TableName: "readmodels.notifications",
+			Key:       &database.NotificationKey{Username: "owner1", NotificationId: "unread"},
+			Add:       map[string]any{"Unread": 1},
+		},
+	}
+	client.EXPECT().GetDataIfExists("readmodels.notifications", key, gomock.Any()).Return(false, nil)
+	client.EXPECT().GetDataIfExists("readmodels.notificationActors", actorKey, gomock.Any()).Return(false, nil)
+	client.EXPECT().TransactWriteData(expectedItems).Return(nil)
+
+	err := notificationRepository.AddActor(data, "user1", "comment1")
+
+	assert.Nil(t, err)
+}
+
+func TestAddActorToFullUnreadNotificationInRepository(t *testing.T) {
+	setUpRepository(t)
+	data := &model.Notification{
+		NotificationId: "like#post1",
+		Username:       "owner1",
+		Type:           model.NotificationTypeLike,
+		PostId:         "post1",
+		UpdatedAt:      time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+	key := &database.NotificationKey{Username: "owner1", NotificationId: "like#post1"}
+	current := database.NotificationRecord{Username: "owner1", NotificationId: "like#post1", Actors: []string{"user2", "user3", "user4"}, ActorsCount: 3}
+	client.EXPECT().GetDataIfExists("readmodels.notifications", key, gomock.Any()).SetArg(2, current).Return(true, nil)
+	client.EXPECT().GetDataIfExists("readmodels.notificationActors", gomock.Any(), gomock.Any()).Return(false, nil)
+	client.EXPECT().TransactWriteData(gomock.Any()).DoAndReturn(func(items []*database.TransactionItem) error {
+		assert.Len(t, items, 2)
+		assert.Equal(t, map[string]any{"ActorsCount": 1}, items[1].Add)
+		assert.Equal(t, []database.Condition{database.AttributeEquals("Read", false), database.SetSizeEquals("Actors", 3)}, items[1].Conditions)
+		return nil
+	})
+
+	err := notificationRepository.AddActor(data, "user1", "")
+
+	assert.Nil(t, err)
+}
+
+func TestAddActorRetriesWhenTheNotificationChangedInRepository(t *testing.T) {
+	setUpRepository(t)
+	data := &model.Notification{NotificationId: "follow", Username: "owner1", Type: model.NotificationTypeFollow}
+	gomock.InOrder(
+		client.EXPECT().GetDataIfExists("readmodels.notifications", gomock.Any(), gomock.Any()).Return(false, nil),
+		client.EXPECT().GetDataIfExists("readmodels.notificationActors", gomock.Any(), gomock.Any()).Return(false, nil),
+		client.EXPECT().TransactWriteData(gomock.Any()).Return(database.NewConditionFailedError([]int{1})),
+		client.EXPECT().GetDataIfExists("readmodels.notifications", gomock.Any(), gomock.Any()).SetArg(2, database.NotificationRecord{Actors: []string{"user2"}, ActorsCount: 1}).Return(true, nil),
+		client.EXPECT().GetDataIfExists("readmodels.notificationActors", gomock.Any(), gomock.Any()).Return(false, nil),
+		client.EXPECT().TransactWriteData(gomock.Len(2)).Return(nil),
+	)
+
+	err := notificationRepository.AddActor(data, "user1", "")
+
+	assert.Nil(t, err)
+}
+
+func TestRemoveReferenceOfActorInRepository(t *testing.T) {
+	setUpRepository(t)
+	actorKey := &database.NotificationActorKey{Username: "owner1", ActorId: "comment#post1/user1"}
+	actor := database.NotificationActorRecord{Username: "owner1", ActorId: "comment#post1/user1", Actor: "user1", References: []string{"comment1", "comment2"}}
+	expectedItems := []*database.TransactionItem{
+		{
+			TableName:     "readmodels.notificationActors",
+			Key:           actorKey,
+			DeleteFromSet: map[string]any{"References": []string{"comment1"}},
+			Conditions: []database.Condition{
+				database.AttributeExists("Username"),
+				database.SetContains("References", "comment1"),
+				database.SetSizeEquals("References", 2),
+			},
+		},
+	}
+	client.EXPECT().GetDataIfExists("readmodels.notificationActors", actorKey, gomock.Any()).SetArg(2, actor).Return(true, nil)
+	client.EXPECT().TransactWriteData(expectedItems).Return(nil)
+
+	err := notificationRepository.RemoveActor("owner1", "comment#post1", "user1", "comment1")
+
+	assert.Nil(t, err)
+}
+
+func TestRemoveLastActorInRepository(t *testing.T) {
+	setUpRepository(t)
+	key := &database.NotificationKey{Username: "owner1", NotificationId: "comment#post1"}
+	actorKey := &database.NotificationActorKey{Username: "owner1", ActorId: "comment#post1/user1"}
+	actor := database.NotificationActorRecord{Username: "owner1", ActorId: "comment#post1/user1", Actor: "user1", References: []string{"comment1"}}
+	current := database.NotificationRecord{Username: "owner1", NotificationId: "comment#post1", Actors: []string{"user1"}, LastActor: "user1", ActorsCount: 1}
+	expectedItems := []*database.TransactionItem{
+		{
+			TableName: "readmodels.notificationActors",
+			Key:       actorKey,
+			Delete:    true,
+			Conditions: []database.Condition{
+				database.AttributeExists("Username"),
+				database.SetContains("References", "comment1"),
+				database.SetSizeEquals("References", 1),
+			},
+		},
+		{
+			TableName:  "readmodels.notifications",
+			Key:        key,
+			Delete:     true,
+			Conditions: []database.Condition{database.AttributeEquals("ActorsCount", 1), database.AttributeEquals("Read", false)},
+		},
+		{
+			TableName: "readmodels.notifications",
+			Key:       &database.NotificationKey{Username: "owner1", NotificationId: "unread"},
+			Add:       map[string]any{"Unread": -1},
+		},
+	}
+	client.EXPECT().GetDataIfExists("readmodels.notificationActors", actorKey, gomock.Any()).SetArg(2, actor).Return(true, nil)
+	client.EXPECT().GetDataIfExists("readmodels.notifications", key, gomock.Any()).SetArg(2, current).Return(true, nil)
+	client.EXPECT().TransactWriteData(expectedItems).Return(nil)
+
+	err := notificationRepository.RemoveActor("owner1", "comment#post1", "user1", "comment1")
+
+	assert.Nil(t, err)
+}
+
+func TestRemoveDisplayedActorRefillsActorsInRepository(t *testing.T) {
+	setUpRepository(t)
+	key := &database.NotificationKey{Username: "owner1", NotificationId: "like#post1"}
+	actor := database.NotificationActorRecord{Username: "owner1", ActorId: "like#post1/user1", Actor: "user1"}
+	current := database.NotificationRecord{Actors: []string{"user1", "user2", "user3"}, LastActor: "user4", ActorsCount: 4, Read: true}
+	removed := database.NotificationRecord{Username: "owner1", Actors: []string{"user2", "user3"}, LastActor: "user4", ActorsCount: 3, Read: true}
+	actors := []*database.NotificationActorRecord{
+		{Actor: "user2"}, {Actor: "user3"}, {Actor: "user4"},
+	}
+	gomock.InOrder(
+		client.EXPECT().GetDataIfExists("readmodels.notificationActors", gomock.Any(), gomock.Any()).SetArg(2, actor).Return(true, nil),
+		client.EXPECT().GetDataIfExists("readmodels.notifications", key, gomock.Any()).SetArg(2, current).Return(true, nil),
+		client.EXPECT().TransactWriteData(gomock.Any()).DoAndReturn(func(items []*database.TransactionItem) error {
+			assert.Len(t, items, 2)
+			assert.Equal(t, map[string]any{"ActorsCount": -1}, items[1].Add)
+			assert.Equal(t, map[string]any{"Actors": []string{"user1"}}, items[1].DeleteFromSet)
+			return nil
+		}),
+		client.EXPECT().GetDataIfExists("readmodels.notifications", key, gomock.Any()).SetArg(2, removed).Return(true, nil),
+		client.EXPECT().GetDataByKeyPrefix("readmodels.notificationActors", "Username", "owner1", "ActorId", "like#post1/", 6, gomock.Any()).SetArg(6, actors).Return(nil),
+		client.EXPECT().TransactWriteData([]*database.TransactionItem{
+			{
+				TableName:  "readmodels.notifications",
+				Key:        key,
+				Add:        map[string]any{"Actors": []string{"user4"}},
+				Conditions: []database.Condition{database.AttributeExists("Username"), database.SetSizeEquals("Actors", 2)},
+			},
+		}).Return(nil),
+	)
+
+	err := notificationRepository.RemoveActor("owner1", "like#post1", "user1", "")
+
+	assert.Nil(t, err)
+}
+
+func TestRemoveMissingActorInRepository(t *testing.T) {
+	setUpRepository(t)
+	client.EXPECT().GetDataIfExists("readmodels.notificationActors", gomock.Any(), gomock.Any()).Return(false, nil)
+
+	err := notificationRepository.RemoveActor("owner1", "comment#post1", "user1", "comment1")
+
+	assert.Nil(t, err)
+}
+
+func TestGetReferenceActorInRepository(t *testing.T) {
+	setUpRepository(t)
+	actors := []*database.NotificationActorRecord{
+		{Username: "owner1", ActorId: "comment#post1/user1", NotificationId: "comment#post1", Actor: "user1", References: []string{"comment1"}},
+		{Username: "owner1", ActorId: "comment#post1/user2", NotificationId: "comment#post1", Actor: "user2", References: []string{"comment2", "comment3"}},
+	}
+	client.EXPECT().GetDataByKeyPrefix("readmodels.notificationActors", "Username", "owner1", "ActorId", "comment#post1/", 0, gomock.Any()).SetArg(6, actors).Return(nil)
+
+	actor, err := notificationRepository.GetReferenceActor("owner1", "comment#post1", "comment3")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "user2", actor)
+}
+
+func TestGetActorNotificationsInRepository(t *testing.T) {
+	setUpRepository(t)
+	actors := []*database.NotificationActorRecord{
+		{Username: "owner1", ActorId: "like#post1/user1", NotificationId: "like#post1", Actor: "user1"},
+		{Username: "owner2", ActorId: "follow/user1", NotificationId: "follow", Actor: "user1"},
+	}
+	expectedNotifications := []*model.Notification{
+		{Username: "owner1", NotificationId: "like#post1"},
+		{Username: "owner2", NotificationId: "follow"},
+	}
+	client.EXPECT().GetAllDataByIndex("readmodels.notificationActors", "ActorIndex", "Actor", "user1", gomock.Any()).SetArg(4, actors).Return(nil)
+
+	notifications, err := notificationRepository.GetActorNotifications("user1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedNotifications, notifications)
+}
+
+func TestRemoveAllNotificationsInRepository(t *testing.T) {
+	setUpRepository(t)
+	firstPage := []*database.NotificationRecord{
+		{Username: "owner1", NotificationId: "like#post1"},
+		{Username: "owner1", NotificationId: "follow"},
+	}
+	secondPage := []*database.NotificationRecord{
+		{Username: "owner1", NotificationId: "comment#post2"},
+	}
+	likeActors := []*database.NotificationActorRecord{
+		{Username: "owner1", ActorId: "like#post1/user1", Actor: "user1"},
+	}
+	unreadKey := &database.NotificationKey{Username: "owner1", NotificationId: "unread"}
+	likeKey := &database.NotificationKey{Username: "owner1", NotificationId: "like#post1"}
+	followKey := &database.NotificationKey{Username: "owner1", NotificationId: "follow"}
+	commentKey := &database.NotificationKey{Username: "owner1", NotificationId: "comment#post2"}
+	gomock.InOrder(
+		client.EXPECT().GetNotificationsByIndexUsername("owner1", "", "", 100).Return(firstPage, "follow", "2024-05-01T10:00:00.000000Z", nil),
+		client.EXPECT().GetDataByKeyPrefix("readmodels.notificationActors", "Username", "owner1", "ActorId", "like#post1/", 0, gomock.Any()).SetArg(6, likeActors).Return(nil),
+		client.EXPECT().RemoveMultipleData("readmodels.notificationActors", []any{&database.NotificationActorKey{Username: "owner1", ActorId: "like#post1/user1"}}).Return(nil),
+		client.EXPECT().GetDataIfExists("readmodels.notifications", likeKey, gomock.Any()).SetArg(2, database.NotificationRecord{Read: true}).Return(true, nil),
+		client.EXPECT().TransactWriteData(gomock.Len(1)).Return(nil),
+		client.EXPECT().GetDataByKeyPrefix("readmodels.notificationActors", "Username", "owner1", "ActorId", "follow/", 0, gomock.Any()).Return(nil),
+		client.EXPECT().RemoveMultipleData("readmodels.notificationActors", []any{}).Return(nil),
+		client.EXPECT().GetDataIfExists("readmodels.notifications", followKey, gomock.Any()).Return(false, nil),
+		client.EXPECT().GetNotificationsByIndexUsername("owner1", "follow", "2024-05-01T10:00:00.000000Z", 100).Return(secondPage, "", "", nil),
+		client.EXPECT().GetDataByKeyPrefix("readmodels.notificationActors", "Username", "owner1", "ActorId", "comment#post2/", 0, gomock.Any()).Return(nil),
+		client.EXPECT().RemoveMultipleData("readmodels.notificationActors", []any{}).Return(nil),
+		client.EXPECT().GetDataIfExists("readmodels.notifications", commentKey, gomock.Any()).SetArg(2, database.NotificationRecord{Read: false}).Return(true, nil),
+		client.EXPECT().TransactWriteData([]*database.TransactionItem{
+			{
+				TableName:  "readmodels.notifications",
+				Key:        commentKey,
+				Delete:     true,
+				Conditions: []database.Condition{database.AttributeEquals("Read", false)},
+			},
+			{
+				TableName: "readmodels.notifications",
+				Key:       unreadKey,
+				Add:       map[string]any{"Unread": -1},
+			},
+		}).Return(nil),
+		client.EXPECT().RemoveMultipleData("readmodels.notifications", []any{unreadKey}).Return(nil),
+	)
+
+	err := notificationRepository.RemoveAllNotifications("owner1")
+
+	assert.Nil(t, err)
+}
+
+func TestGetUnreadNotificationIdsInRepository(t *testing.T) {
+	setUpRepository(t)
+	records := []*database.NotificationRecord{
+		{Username: "owner1", NotificationId: "comment#post1", Read: false},
+		{Username: "owner1", NotificationId: "follow", Read: true},
+		{Username: "owner1", NotificationId: "like#post1", Read: false},
+		{Username: "owner1", NotificationId: "unread"},
+	}
+	client.EXPECT().GetDataByKeyPrefix("readmodels.notifications", "Username", "owner1", "NotificationId", "", 0, gomock.Any()).SetArg(6, records).Return(nil)
+
+	notificationIds, err := notificationRepository.GetUnreadNotificationIds("owner1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"comment#post1", "like#post1"}, notificationIds)
+}
+
+func TestGetUnreadCountInRepository(t *testing.T) {
+	setUpRepository(t)
+	expectedKey := &database.NotificationKey{Username: "owner1", NotificationId: "unread"}
+	client.EXPECT().GetData("readmodels.notifications", expectedKey, &database.UnreadNotificationsRecord{}).SetArg(2, database.UnreadNotificationsRecord{Username: "owner1", NotificationId: "unread", Unread: 4}).Return(nil)
+
+	unread, err := notificationRepository.GetUnreadCount("owner1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4, unread)
+}
+
+func TestGetUnreadCountWithoutNotificationsInRepository(t *testing.T) {
+	setUpRepository(t)
+	expectedKey := &database.NotificationKey{Username: "owner1", NotificationId: "unread"}
+	client.EXPECT().GetData("readmodels.notifications", expectedKey, &database.UnreadNotificationsRecord{}).Return(database.NewNotFoundError("readmodels.notifications", expectedKey))
+
+	unread, err := notificationRepository.GetUnreadCount("owner1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, unread)
+}
+
+func TestMarkReadInRepository(t *testing.T) {
+	setUpRepository(t)
+	expectedItems := []*database.TransactionItem{
+		{
+			TableName:  "readmodels.notifications",
+			Key:        &database.NotificationKey{Username: "owner1", NotificationId: "like#post1"},
+			Set:        map[string]any{"Read": true},
+			Conditions: []database.Condition{database.AttributeEquals("Read", false)},
+		},
+		{
+			TableName: "readmodels.notifications",
+			Key:       &database.NotificationKey{Username: "owner1", NotificationId: "unread"},
+			Add:       map[string]any{"Unread": -1},
+		},
+	}
+	client.EXPECT().TransactWriteData(expectedItems).Return(nil)
+
+	err := notificationRepository.MarkRead("owner1", "like#post1")
+
+	assert.Nil(t, err)
+}
+
+func TestMarkReadAlreadyReadNotificationInRepository(t *testing.T) {
+	setUpRepository(t)
+	client.EXPECT().TransactWriteData(gomock.Any()).Return(database.NewConditionFailedError([]int{0}))
+
+	err := notificationRepository.MarkRead("owner1", "like#post1")
+
+	assert.Nil(t, err)
+}
+
+func TestGetNotificationsInRepository(t *testing.T) {
+	setUpRepository(t)
+	records := []*database.NotificationRecord{
+		{
+			Username:       "owner1",
+			NotificationId: "comment#post1",
+			Type:           model.NotificationTypeComment,
+			PostId:         "post1",
+			Actors:         []string{"user1", "user2", "user3"},
+			LastActor:      "user4",
+			ActorsCount:    12,
+			Read:           false,
+			UpdatedAt:      "2024-05-01T10:00:00.000000Z",
+		},
+	}
+	expectedNotifications := []*model.Notification{
+		{
+			NotificationId: "comment#post1",
+			Username:       "owner1",
+			Type:           model.NotificationTypeComment,
+			PostId:         "post1",
+			Actors:         []string{"user4", "user1", "user2"},
+			ActorsCount:    12,
+			Read:           false,
+			UpdatedAt:      time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}
+	client.EXPECT().GetNotificationsByIndexUsername("owner1", "", "", 5).Return(records, "comment#post1", "2024-05-01T10:00:00.000000Z", nil)
+
+	notifications, lastNotificationId, lastUpdatedAt, err := notificationRepository.GetNotifications("owner1", "", "", 5)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedNotifications, notifications)
+	assert.Equal(t, "comment#post1", lastNotificationId)
+	assert.Equal(t, "2024-05-01T10:00:00.000000Z", lastUpdatedAt)
+}
+
+func TestLastActorRetractedIsNotListedInRepository(t *testing.T) {
+	setUpRepository(t)
+	records := []*database.NotificationRecord{
+		{
+			Username:       "owner1",
+			NotificationId: "like#post1",
+			Type:           model.NotificationTypeLike,
+			PostId:         "post1",
+			Actors:         []string{"user1", "user2"},
+			ActorsCount:    2,
+			UpdatedAt:      "2024-05-01T10:00:00.000000Z",
+		},
+	}
+	client.EXPECT().GetNotificationsByIndexUsername("owner1", "", "", 5).Return(records, "", "", nil)
+
+	notifications, _, _, err := notificationRepository.GetNotifications("owner1", "", "", 5)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"user1", "user2"}, notifications[0].Actors)
+	assert.Equal(t, 2, notifications[0].ActorsCount)
+}
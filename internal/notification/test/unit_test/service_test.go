@@ -0,0 +1,199 @@
+package notification_test
+
+import (
+	"errors"
+	"readmodels/internal/model"
+	"readmodels/internal/notification"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var notificationService *notification.NotificationService
+
+func setUpService(t *testing.T) {
+	SetUp(t)
+	notificationService = notification.NewNotificationService(repository)
+}
+
+func TestNotifyLikeWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPostOwner("post1").Return("owner1", nil)
+	repository.EXPECT().AddActor(gomock.Any(), "user1", "").DoAndReturn(func(data *model.Notification, actor, reference string) error {
+		assert.Equal(t, "like#post1", data.NotificationId)
+		assert.Equal(t, "owner1", data.Username)
+		assert.Equal(t, model.NotificationTypeLike, data.Type)
+		assert.Equal(t, "post1", data.PostId)
+		assert.WithinDuration(t, time.Now(), data.UpdatedAt, time.Minute)
+		return nil
+	})
+
+	notificationService.NotifyPostActivity(model.NotificationTypeLike, "post1", "user1", "")
+
+	assert.Contains(t, loggerOutput.String(), "like of user1 was notified to owner1")
+}
+
+func TestNotifyCommentKeepsTheCommentIdWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPostOwner("post1").Return("owner1", nil)
+	repository.EXPECT().AddActor(gomock.Any(), "user1", "42").Return(nil)
+
+	notificationService.NotifyPostActivity(model.NotificationTypeComment, "post1", "user1", "42")
+}
+
+func TestOwnActivityIsNotNotifiedWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPostOwner("post1").Return("user1", nil)
+
+	notificationService.NotifyPostActivity(model.NotificationTypeLike, "post1", "user1", "")
+}
+
+func TestActivityOnMissingPostIsNotNotifiedWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPostOwner("post1").Return("", nil)
+
+	notificationService.NotifyPostActivity(model.NotificationTypeLike, "post1", "user1", "")
+
+	assert.Contains(t, loggerOutput.String(), "Post post1 doesn't exist, like of user1 is not notified")
+}
+
+func TestErrorOnNotifyWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPostOwner("post1").Return("owner1", nil)
+	repository.EXPECT().AddActor(gomock.Any(), "user1", "").Return(errors.New("some error"))
+
+	notificationService.NotifyPostActivity(model.NotificationTypeSuperlike, "post1", "user1", "")
+
+	assert.Contains(t, loggerOutput.String(), "Error notifying superlike of user1 to owner1")
+}
+
+func TestNotifyFollowWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().AddActor(gomock.Any(), "follower1", "").DoAndReturn(func(data *model.Notification, actor, reference string) error {
+		assert.Equal(t, "follow", data.NotificationId)
+		assert.Equal(t, "followee1", data.Username)
+		assert.Equal(t, "", data.PostId)
+		return nil
+	})
+
+	notificationService.NotifyFollow("followee1", "follower1")
+}
+
+func TestRetractLikeWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPostOwner("post1").Return("owner1", nil)
+	repository.EXPECT().RemoveActor("owner1", "like#post1", "user1", "").Return(nil)
+
+	notificationService.RetractPostActivity(model.NotificationTypeLike, "post1", "user1")
+
+	assert.Contains(t, loggerOutput.String(), "user1 was retracted from notification like#post1 of owner1")
+}
+
+func TestRetractCommentWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPostOwner("post1").Return("owner1", nil)
+	repository.EXPECT().GetReferenceActor("owner1", "comment#post1", "42").Return("user1", nil)
+	repository.EXPECT().RemoveActor("owner1", "comment#post1", "user1", "42").Return(nil)
+
+	notificationService.RetractReference(model.NotificationTypeComment, "post1", "42")
+}
+
+func TestRetractMissingCommentWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPostOwner("post1").Return("owner1", nil)
+	repository.EXPECT().GetReferenceActor("owner1", "comment#post1", "42").Return("", nil)
+
+	notificationService.RetractReference(model.NotificationTypeComment, "post1", "42")
+
+	assert.Contains(t, loggerOutput.String(), "comment 42 isn't in notification comment#post1 of owner1, it was not retracted")
+}
+
+func TestRetractFollowWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().RemoveActor("followee1", "follow", "follower1", "").Return(nil)
+
+	notificationService.RetractFollow("followee1", "follower1")
+}
+
+func TestRemovePostNotificationsWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().RemoveNotifications("owner1", []string{
+		"like#post1", "superlike#post1", "comment#post1", "review#post1",
+		"like#post2", "superlike#post2", "comment#post2", "review#post2",
+	}).Return(nil)
+
+	notificationService.RemovePostNotifications("owner1", []string{"post1", "post2"})
+
+	assert.Contains(t, loggerOutput.String(), "Notifications of 2 posts of owner1 were removed")
+}
+
+func TestRemoveUserNotificationsWithService(t *testing.T) {
+	setUpService(t)
+	appearances := []*model.Notification{
+		{Username: "owner1", NotificationId: "like#post1"},
+		{Username: "owner2", NotificationId: "follow"},
+	}
+	repository.EXPECT().RemoveAllNotifications("user1").Return(nil)
+	repository.EXPECT().GetActorNotifications("user1").Return(appearances, nil)
+	repository.EXPECT().RemoveActor("owner1", "like#post1", "user1", "").Return(nil)
+	repository.EXPECT().RemoveActor("owner2", "follow", "user1", "").Return(nil)
+
+	notificationService.RemoveUserNotifications("user1")
+
+	assert.Contains(t, loggerOutput.String(), "Notifications of user1 were removed, and user1 was retracted from 2 notifications")
+}
+
+func TestErrorOnRemoveUserNotificationsWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().RemoveAllNotifications("user1").Return(errors.New("some error"))
+
+	notificationService.RemoveUserNotifications("user1")
+
+	assert.Contains(t, loggerOutput.String(), "Error removing the notifications of user1")
+}
+
+func TestGetNotificationsWithService(t *testing.T) {
+	setUpService(t)
+	expectedNotifications := []*model.Notification{
+		{
+			NotificationId: "follow",
+			Username:       "user1",
+			Type:           model.NotificationTypeFollow,
+			Actors:         []string{"user2"},
+			ActorsCount:    1,
+		},
+	}
+	repository.EXPECT().GetNotifications("user1", "", "", 10).Return(expectedNotifications, "follow", "2024-01-01T00:00:00.000000Z", nil)
+	repository.EXPECT().GetUnreadCount("user1").Return(2, nil)
+
+	notifications, lastNotificationId, lastUpdatedAt, unreadCount, err := notificationService.GetNotifications("user1", "", "", 10)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedNotifications, notifications)
+	assert.Equal(t, "follow", lastNotificationId)
+	assert.Equal(t, "2024-01-01T00:00:00.000000Z", lastUpdatedAt)
+	assert.Equal(t, 2, unreadCount)
+}
+
+func TestMarkAllReadWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetUnreadNotificationIds("user1").Return([]string{"follow", "like#post1"}, nil)
+	repository.EXPECT().MarkRead("user1", "follow").Return(nil)
+	repository.EXPECT().MarkRead("user1", "like#post1").Return(nil)
+
+	err := notificationService.MarkAllRead("user1")
+
+	assert.Nil(t, err)
+}
+
+func TestErrorOnMarkAllReadWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetUnreadNotificationIds("user1").Return([]string{"follow"}, nil)
+	repository.EXPECT().MarkRead("user1", "follow").Return(errors.New("some error"))
+
+	err := notificationService.MarkAllRead("user1")
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,31 @@
+package notification_test
+
+import (
+	"encoding/json"
+	erasure_handler "readmodels/internal/erasure/handler"
+	notification_handler "readmodels/internal/notification/handler"
+	mock_notification_handler "readmodels/internal/notification/handler/test/mock"
+	"testing"
+)
+
+var userWasDeletedEventHandler *notification_handler.UserWasDeletedEventHandler
+var userWasDeletedEventService *mock_notification_handler.MockUserWasDeletedEventService
+
+func setUpUserWasDeletedEventHandler(t *testing.T) {
+	SetUp(t)
+	userWasDeletedEventService = mock_notification_handler.NewMockUserWasDeletedEventService(ctrl)
+	userWasDeletedEventHandler = notification_handler.NewUserWasDeletedEventHandler(userWasDeletedEventService)
+}
+
+func TestHandleUserWasDeletedEventForNotifications(t *testing.T) {
+	setUpUserWasDeletedEventHandler(t)
+	data := &erasure_handler.UserWasDeletedEvent{
+		Username:    "user1",
+		FollowerIds: []string{"follower1"},
+		FolloweeIds: []string{"followee1"},
+	}
+	event, _ := json.Marshal(data)
+	userWasDeletedEventService.EXPECT().RemoveUserNotifications("user1")
+
+	userWasDeletedEventHandler.Handle(event)
+}
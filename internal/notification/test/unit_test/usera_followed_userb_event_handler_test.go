@@ -0,0 +1,30 @@
+package notification_test
+
+import (
+	"encoding/json"
+	notification_handler "readmodels/internal/notification/handler"
+	mock_notification_handler "readmodels/internal/notification/handler/test/mock"
+	userprofile_handler "readmodels/internal/userprofile/handlers"
+	"testing"
+)
+
+var userAFollowedUserBEventHandler *notification_handler.UserAFollowedUserBEventHandler
+var userAFollowedUserBEventService *mock_notification_handler.MockUserAFollowedUserBEventService
+
+func setUpUserAFollowedUserBEventHandler(t *testing.T) {
+	SetUp(t)
+	userAFollowedUserBEventService = mock_notification_handler.NewMockUserAFollowedUserBEventService(ctrl)
+	userAFollowedUserBEventHandler = notification_handler.NewUserAFollowedUserBEventHandler(userAFollowedUserBEventService)
+}
+
+func TestHandleUserAFollowedUserBEventForNotifications(t *testing.T) {
+	setUpUserAFollowedUserBEventHandler(t)
+	data := &userprofile_handler.UserAFollowedUserBEvent{
+		FollowerID: "follower1",
+		FolloweeID: "followee1",
+	}
+	event, _ := json.Marshal(data)
+	userAFollowedUserBEventService.EXPECT().NotifyFollow("followee1", "follower1")
+
+	userAFollowedUserBEventHandler.Handle(event)
+}
@@ -0,0 +1,127 @@
+package outbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	database "readmodels/internal/db"
+	"readmodels/internal/events"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	PostCounterChangedEventType = "PostCounterChangedEvent"
+	UserCounterChangedEventType = "UserCounterChangedEvent"
+)
+
+// Counter names published for the counter fields of the read models.
+var postCounters = map[string]string{
+	"Likes":      "likes",
+	"Superlikes": "superlikes",
+	"Comments":   "comments",
+	"Reviews":    "reviews",
+}
+
+var userCounters = map[string]string{
+	"PostsAmount":     "posts",
+	"FollowersAmount": "followers",
+	"FolloweesAmount": "followees",
+}
+
+type PostCounterChangedEvent struct {
+	PostId  string `json:"postId"`
+	Counter string `json:"counter"`
+	Delta   int    `json:"delta"`
+}
+
+type UserCounterChangedEvent struct {
+	Username string `json:"username"`
+	Counter  string `json:"counter"`
+	Delta    int    `json:"delta"`
+}
+
+// DerivedEvents turns the counter updates of the post and user read models
+// into PostCounterChangedEvent and UserCounterChangedEvent messages. The
+// DatabaseClient numbers the messages of each entity when writing them.
+type DerivedEvents struct {
+	now func() time.Time
+}
+
+func NewDerivedEvents() *DerivedEvents {
+	return &DerivedEvents{
+		now: time.Now,
+	}
+}
+
+func (d *DerivedEvents) CounterChanged(change *database.CounterChange) (*database.OutboxMessage, error) {
+	switch key := change.CounterKey.(type) {
+	case *database.PostMetadataKey:
+		counter, ok := postCounters[change.CounterField]
+		if !ok {
+			break
+		}
+		return d.newMessage(PostCounterChangedEventType, key.PostId, &PostCounterChangedEvent{
+			PostId:  key.PostId,
+			Counter: counter,
+			Delta:   change.Delta,
+		})
+	case *database.UserProfileKey:
+		counter, ok := userCounters[change.CounterField]
+		if !ok {
+			break
+		}
+		return d.newMessage(UserCounterChangedEventType, key.Username, &UserCounterChangedEvent{
+			Username: key.Username,
+			Counter:  counter,
+			Delta:    change.Delta,
+		})
+	}
+
+	log.Warn().Msgf("Counter %s in %s has no derived event", change.CounterField, change.CounterTable)
+	return nil, nil
+}
+
+// newMessage wraps the payload in the same envelope as the consumed events.
+func (d *DerivedEvents) newMessage(eventType, key string, payload any) (*database.OutboxMessage, error) {
+	now := d.now().UTC()
+	messageId, err := newMessageId()
+	if err != nil {
+		return nil, err
+	}
+
+	encodedPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := json.Marshal(&events.Envelope{
+		Id:         messageId,
+		Type:       eventType,
+		Version:    1,
+		OccurredAt: now,
+		Payload:    encodedPayload,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &database.OutboxMessage{
+		MessageId: messageId,
+		Status:    database.OutboxStatusPending,
+		EventType: eventType,
+		Key:       key,
+		Payload:   envelope,
+		CreatedAt: now,
+	}, nil
+}
+
+// newMessageId is random, the clocks of the instances writing messages don't
+// tell their order.
+func newMessageId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,134 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	database "readmodels/internal/db"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=relay.go -destination=test/mock/relay.go
+
+const (
+	relayInterval = time.Second
+	relayLockTtl  = 30 * time.Second
+	relayLockId   = "relay-lock"
+	// Sent messages are kept to audit what was published
+	sentRetention = 7 * 24 * time.Hour
+)
+
+type Publisher interface {
+	Publish(message *database.OutboxMessage) error
+}
+
+// Relay publishes the pending outbox messages of every entity in sequence
+// order and marks them sent, keeping them for sentRetention. A message
+// published but not marked is published again on the next pass, so consumers
+// must drop the sequences of an entity they already handled. Only the
+// instance holding the relay lock publishes.
+type Relay struct {
+	database  *database.Database
+	publisher Publisher
+	owner     string
+	now       func() time.Time
+}
+
+func NewRelay(db *database.Database, publisher Publisher) *Relay {
+	hostname, _ := os.Hostname()
+
+	return &Relay{
+		database:  db,
+		publisher: publisher,
+		owner:     fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		now:       time.Now,
+	}
+}
+
+// Run relays the pending messages every second until ctx is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(relayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.RelayPending()
+		case <-ctx.Done():
+			r.database.Client.ReleaseLock(database.OutboxTable, r.lockKey(), r.owner)
+			return
+		}
+	}
+}
+
+// RelayPending publishes the pending messages and returns how many were sent.
+// It stops at the first failure so that no message overtakes an earlier one.
+func (r *Relay) RelayPending() (int, error) {
+	acquired, err := r.database.Client.AcquireLock(database.OutboxTable, r.lockKey(), r.owner, relayLockTtl)
+	if err != nil || !acquired {
+		return 0, err
+	}
+
+	var messages []*database.OutboxMessage
+	err = r.database.Client.GetAllDataByIndex(database.OutboxTable, database.OutboxStatusIndex, "Status", database.OutboxStatusPending, &messages)
+	if err != nil {
+		return 0, err
+	}
+	sortBySequence(messages)
+
+	for i, message := range messages {
+		err = r.publisher.Publish(message)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Couldn't publish outbox message %s, %d messages left", message.MessageId, len(messages)-i)
+			return i, err
+		}
+
+		err = r.markSent(message)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Outbox message %s was published but couldn't be marked sent", message.MessageId)
+			return i + 1, err
+		}
+	}
+
+	if len(messages) > 0 {
+		log.Info().Msgf("%d outbox messages published", len(messages))
+	}
+	return len(messages), nil
+}
+
+func (r *Relay) markSent(message *database.OutboxMessage) error {
+	now := r.now().UTC()
+	_, err := r.database.Client.UpdateDataIfEqual(database.OutboxTable, &database.OutboxMessageKey{MessageId: message.MessageId}, map[string]any{
+		"Status":    database.OutboxStatusSent,
+		"SentAt":    now,
+		"ExpiresAt": now.Add(sentRetention).Unix(),
+	}, nil, "Status", database.OutboxStatusPending)
+	return err
+}
+
+// sortBySequence groups the messages by entity in sequence order. Messages
+// written before they had a sequence come first, ordered by their
+// time-prefixed ids.
+func sortBySequence(messages []*database.OutboxMessage) {
+	sort.Slice(messages, func(i, j int) bool {
+		a, b := messages[i], messages[j]
+		if a.EventType != b.EventType {
+			return a.EventType < b.EventType
+		}
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Sequence != b.Sequence {
+			return a.Sequence < b.Sequence
+		}
+		return a.MessageId < b.MessageId
+	})
+}
+
+// The lock item has no Status, so it never shows up in the status index
+func (r *Relay) lockKey() *database.OutboxMessageKey {
+	return &database.OutboxMessageKey{MessageId: relayLockId}
+}
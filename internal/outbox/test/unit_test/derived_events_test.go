@@ -0,0 +1,80 @@
+package outbox_test
+
+import (
+	"encoding/json"
+	database "readmodels/internal/db"
+	"readmodels/internal/events"
+	"readmodels/internal/outbox"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterChangedDerivesPostCounterChangedEvent(t *testing.T) {
+	derivedEvents := outbox.NewDerivedEvents()
+
+	message, err := derivedEvents.CounterChanged(&database.CounterChange{
+		CounterTable: database.PostMetadataTable,
+		CounterKey:   &database.PostMetadataKey{PostId: "post1"},
+		CounterField: "Likes",
+		Delta:        -2,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, outbox.PostCounterChangedEventType, message.EventType)
+	assert.Equal(t, "post1", message.Key)
+	assert.Equal(t, database.OutboxStatusPending, message.Status)
+	var envelope events.Envelope
+	assert.Nil(t, json.Unmarshal(message.Payload, &envelope))
+	assert.Equal(t, message.MessageId, envelope.Id)
+	assert.Equal(t, 1, envelope.Version)
+	assert.JSONEq(t, `{"postId":"post1","counter":"likes","delta":-2}`, string(envelope.Payload))
+}
+
+func TestCounterChangedDerivesUserCounterChangedEvent(t *testing.T) {
+	derivedEvents := outbox.NewDerivedEvents()
+
+	message, err := derivedEvents.CounterChanged(&database.CounterChange{
+		CounterTable: database.UserProfileTable,
+		CounterKey:   &database.UserProfileKey{Username: "user1"},
+		CounterField: "FollowersAmount",
+		Delta:        1,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, outbox.UserCounterChangedEventType, message.EventType)
+	assert.Equal(t, "user1", message.Key)
+	var envelope events.Envelope
+	assert.Nil(t, json.Unmarshal(message.Payload, &envelope))
+	assert.JSONEq(t, `{"username":"user1","counter":"followers","delta":1}`, string(envelope.Payload))
+}
+
+func TestCounterChangedIgnoresUnknownCounters(t *testing.T) {
+	derivedEvents := outbox.NewDerivedEvents()
+
+	message, err := derivedEvents.CounterChanged(&database.CounterChange{
+		CounterTable: database.PostMetadataTable,
+		CounterKey:   &database.PostMetadataKey{PostId: "post1"},
+		CounterField: "Views",
+		Delta:        1,
+	})
+
+	assert.Nil(t, err)
+	assert.Nil(t, message)
+}
+
+func TestMessageIdsAreUnique(t *testing.T) {
+	derivedEvents := outbox.NewDerivedEvents()
+	change := &database.CounterChange{
+		CounterTable: database.PostMetadataTable,
+		CounterKey:   &database.PostMetadataKey{PostId: "post1"},
+		CounterField: "Comments",
+		Delta:        1,
+	}
+
+	first, _ := derivedEvents.CounterChanged(change)
+	second, _ := derivedEvents.CounterChanged(change)
+
+	assert.NotEqual(t, first.MessageId, second.MessageId)
+	assert.Zero(t, first.Sequence)
+}
@@ -0,0 +1,128 @@
+package outbox_test
+
+import (
+	"errors"
+	database "readmodels/internal/db"
+	mock_database "readmodels/internal/db/test/mock"
+	"readmodels/internal/outbox"
+	mock_outbox "readmodels/internal/outbox/test/mock"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var client *mock_database.MockDatabaseClient
+var publisher *mock_outbox.MockPublisher
+var relay *outbox.Relay
+
+var lockKey = &database.OutboxMessageKey{MessageId: "relay-lock"}
+
+func setUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client = mock_database.NewMockDatabaseClient(ctrl)
+	publisher = mock_outbox.NewMockPublisher(ctrl)
+	relay = outbox.NewRelay(database.NewDatabase(client), publisher)
+}
+
+func expectPendingMessages(messages []*database.OutboxMessage) {
+	client.EXPECT().AcquireLock(database.OutboxTable, lockKey, gomock.Any(), gomock.Any()).Return(true, nil)
+	client.EXPECT().GetAllDataByIndex(database.OutboxTable, database.OutboxStatusIndex, "Status", database.OutboxStatusPending, gomock.Any()).
+		DoAndReturn(func(tableName, indexName, attributeName, value string, results any) error {
+			*results.(*[]*database.OutboxMessage) = messages
+			return nil
+		})
+}
+
+func expectMarkedSent(messageId string) *gomock.Call {
+	return client.EXPECT().UpdateDataIfEqual(database.OutboxTable, &database.OutboxMessageKey{MessageId: messageId}, gomock.Any(), nil, "Status", database.OutboxStatusPending).
+		DoAndReturn(func(tableName string, key any, updateAttributes map[string]any, removeAttributes []string, conditionFieldName string, conditionValue any) (bool, error) {
+			sentAt := updateAttributes["SentAt"].(time.Time)
+			expiresAt := time.Unix(updateAttributes["ExpiresAt"].(int64), 0)
+			if updateAttributes["Status"] != database.OutboxStatusSent || expiresAt.Sub(sentAt) < 24*time.Hour {
+				return false, errors.New("unexpected sent attributes")
+			}
+			return true, nil
+		})
+}
+
+func TestRelayPendingPublishesMessagesInSequenceOrder(t *testing.T) {
+	setUp(t)
+	first := &database.OutboxMessage{MessageId: "ff", EventType: "PostCounterChangedEvent", Key: "post1", Sequence: 1}
+	second := &database.OutboxMessage{MessageId: "aa", EventType: "PostCounterChangedEvent", Key: "post1", Sequence: 2}
+	other := &database.OutboxMessage{MessageId: "bb", EventType: "PostCounterChangedEvent", Key: "post2", Sequence: 1}
+	expectPendingMessages([]*database.OutboxMessage{other, second, first})
+	gomock.InOrder(
+		publisher.EXPECT().Publish(first),
+		expectMarkedSent(first.MessageId),
+		publisher.EXPECT().Publish(second),
+		expectMarkedSent(second.MessageId),
+		publisher.EXPECT().Publish(other),
+		expectMarkedSent(other.MessageId),
+	)
+
+	sent, err := relay.RelayPending()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, sent)
+}
+
+func TestRelayPendingPublishesMessagesWithoutSequenceFirst(t *testing.T) {
+	setUp(t)
+	legacy := &database.OutboxMessage{MessageId: "20240101T000000.000000001Z-aa", Key: "post1"}
+	sequenced := &database.OutboxMessage{MessageId: "00", Key: "post1", Sequence: 1}
+	expectPendingMessages([]*database.OutboxMessage{sequenced, legacy})
+	gomock.InOrder(
+		publisher.EXPECT().Publish(legacy),
+		expectMarkedSent(legacy.MessageId),
+		publisher.EXPECT().Publish(sequenced),
+		expectMarkedSent(sequenced.MessageId),
+	)
+
+	sent, err := relay.RelayPending()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, sent)
+}
+
+func TestRelayPendingStopsAtTheFirstFailure(t *testing.T) {
+	setUp(t)
+	first := &database.OutboxMessage{MessageId: "aa", Key: "post1", Sequence: 1}
+	second := &database.OutboxMessage{MessageId: "bb", Key: "post1", Sequence: 2}
+	expectPendingMessages([]*database.OutboxMessage{first, second})
+	expectedErr := errors.New("broker unavailable")
+	publisher.EXPECT().Publish(first).Return(expectedErr)
+
+	sent, err := relay.RelayPending()
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 0, sent)
+}
+
+func TestRelayPendingCountsAMessagePublishedButNotMarkedSent(t *testing.T) {
+	setUp(t)
+	first := &database.OutboxMessage{MessageId: "aa", Key: "post1", Sequence: 1}
+	second := &database.OutboxMessage{MessageId: "bb", Key: "post1", Sequence: 2}
+	expectPendingMessages([]*database.OutboxMessage{first, second})
+	expectedErr := errors.New("throttled")
+	gomock.InOrder(
+		publisher.EXPECT().Publish(first),
+		client.EXPECT().UpdateDataIfEqual(database.OutboxTable, &database.OutboxMessageKey{MessageId: first.MessageId}, gomock.Any(), nil, "Status", database.OutboxStatusPending).Return(false, expectedErr),
+	)
+
+	sent, err := relay.RelayPending()
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 1, sent)
+}
+
+func TestRelayPendingDoesNothingWithoutTheLock(t *testing.T) {
+	setUp(t)
+	client.EXPECT().AcquireLock(database.OutboxTable, lockKey, gomock.Any(), gomock.Any()).Return(false, nil)
+
+	sent, err := relay.RelayPending()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, sent)
+}
@@ -0,0 +1,86 @@
+package post
+
+import (
+	"fmt"
+	"readmodels/internal/cache"
+)
+
+type CachedRepository struct {
+	repository Repository
+	cache      cache.Cache
+}
+
+type cachedPostMetadatas struct {
+	Posts             []*PostMetadata `json:"posts"`
+	LastPostId        string          `json:"lastPostId"`
+	LastPostCreatedAt string          `json:"lastPostCreatedAt"`
+}
+
+func NewCachedRepository(repository Repository, cache cache.Cache) *CachedRepository {
+	return &CachedRepository{
+		repository: repository,
+		cache:      cache,
+	}
+}
+
+func (r *CachedRepository) AddNewPostMetadata(data *PostMetadata) error {
+	defer r.invalidate(data.Username)
+	return r.repository.AddNewPostMetadata(data)
+}
+
+func (r *CachedRepository) GetPostMetadatasByUser(username string, currentUsername string, lastPostId, lastPostCreatedAt string, limit int) ([]*PostMetadata, string, string, error) {
+	group := cache.PostsByUserGroup(username)
+	key := fmt.Sprintf("%s:%s:%s:%s:%d", group, currentUsername, lastPostId, lastPostCreatedAt, limit)
+
+	var cached cachedPostMetadatas
+	if r.cache.Get(key, &cached) {
+		r.setOwners(cached.Posts)
+		return cached.Posts, cached.LastPostId, cached.LastPostCreatedAt, nil
+	}
+
+	posts, nextPostId, nextPostCreatedAt, err := r.repository.GetPostMetadatasByUser(username, currentUsername, lastPostId, lastPostCreatedAt, limit)
+	if err != nil {
+		return posts, nextPostId, nextPostCreatedAt, err
+	}
+
+	r.cache.SetInGroup(group, key, &cachedPostMetadatas{
+		Posts:             posts,
+		LastPostId:        nextPostId,
+		LastPostCreatedAt: nextPostCreatedAt,
+	})
+	r.setOwners(posts)
+
+	return posts, nextPostId, nextPostCreatedAt, nil
+}
+
+func (r *CachedRepository) GetPostMetadata(postId string) (*PostMetadata, error) {
+	return r.repository.GetPostMetadata(postId)
+}
+
+func (r *CachedRepository) UpdatePostMetadata(data *PostMetadata) (bool, error) {
+	defer cache.InvalidatePostsByUser(r.cache, data.Username)
+	return r.repository.UpdatePostMetadata(data)
+}
+
+func (r *CachedRepository) RemovePostMetadata(username string, postIds []string) error {
+	defer r.invalidate(username)
+	return r.repository.RemovePostMetadata(username, postIds)
+}
+
+func (r *CachedRepository) RemovePostDependencies(postId string) (*RemovedDependencies, error) {
+	return r.repository.RemovePostDependencies(postId)
+}
+
+// setOwners records the owner of each listed post after the listing itself, and
+// again on every listing hit, so the owner entries outlive the listing both by
+// TTL and by recency and InvalidatePost can always find it.
+func (r *CachedRepository) setOwners(posts []*PostMetadata) {
+	for _, post := range posts {
+		r.cache.Set(cache.PostOwnerKey(post.PostId), post.Username)
+	}
+}
+
+func (r *CachedRepository) invalidate(username string) {
+	cache.InvalidatePostsByUser(r.cache, username)
+	cache.InvalidateUserProfile(r.cache, username)
+}
@@ -0,0 +1,52 @@
+package post_test
+
+import (
+	"readmodels/internal/cache"
+	"readmodels/internal/post"
+	mock_post "readmodels/internal/post/mock"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var cachedRepositoryRepository *mock_post.MockRepository
+var cachedRepositoryCache *cache.LRUCache
+var cachedRepository *post.CachedRepository
+
+func setUpCachedRepository(t *testing.T, capacity int) {
+	ctrl := gomock.NewController(t)
+	cachedRepositoryRepository = mock_post.NewMockRepository(ctrl)
+	cachedRepositoryCache = cache.NewLRUCache(capacity, time.Minute)
+	cachedRepository = post.NewCachedRepository(cachedRepositoryRepository, cachedRepositoryCache)
+}
+
+func TestGetPostMetadatasByUserIsReadThroughCache(t *testing.T) {
+	setUpCachedRepository(t, 10)
+	posts := []*post.PostMetadata{{PostId: "post1", Username: "username1"}}
+	cachedRepositoryRepository.EXPECT().GetPostMetadatasByUser("username1", "username2", "", "", 4).Return(posts, "post1", "", nil).Times(1)
+
+	cachedRepository.GetPostMetadatasByUser("username1", "username2", "", "", 4)
+	result, lastPostId, _, err := cachedRepository.GetPostMetadatasByUser("username1", "username2", "", "", 4)
+
+	assert.Nil(t, err)
+	assert.Equal(t, posts, result)
+	assert.Equal(t, "post1", lastPostId)
+	assert.Equal(t, uint64(1), cachedRepositoryCache.Stats().Hits)
+}
+
+func TestInvalidatePostDropsAListingKeptAliveByHits(t *testing.T) {
+	setUpCachedRepository(t, 3)
+	posts := []*post.PostMetadata{{PostId: "post1", Username: "username1"}}
+	cachedRepositoryRepository.EXPECT().GetPostMetadatasByUser("username1", "username2", "", "", 4).Return(posts, "", "", nil).Times(2)
+	cachedRepository.GetPostMetadatasByUser("username1", "username2", "", "", 4)
+	cachedRepositoryCache.Set("other1", "value1")
+	cachedRepository.GetPostMetadatasByUser("username1", "username2", "", "", 4)
+	cachedRepositoryCache.Set("other2", "value2")
+
+	cache.InvalidatePost(cachedRepositoryCache, "post1")
+
+	_, _, _, err := cachedRepository.GetPostMetadatasByUser("username1", "username2", "", "", 4)
+	assert.Nil(t, err)
+}
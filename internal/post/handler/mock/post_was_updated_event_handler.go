@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: post_was_updated_event_handler.go
+
+// Package mock_post_handler is a generated GoMock package.
+package mock_post_handler
+
+import (
+	post "readmodels/internal/post"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPostWasUpdatedEventService is a mock of PostWasUpdatedEventService interface.
+type MockPostWasUpdatedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostWasUpdatedEventServiceMockRecorder
+}
+
+// MockPostWasUpdatedEventServiceMockRecorder is the mock recorder for MockPostWasUpdatedEventService.
+type MockPostWasUpdatedEventServiceMockRecorder struct {
+	mock *MockPostWasUpdatedEventService
+}
+
+// NewMockPostWasUpdatedEventService creates a new mock instance.
+func NewMockPostWasUpdatedEventService(ctrl *gomock.Controller) *MockPostWasUpdatedEventService {
+	mock := &MockPostWasUpdatedEventService{ctrl: ctrl}
+	mock.recorder = &MockPostWasUpdatedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostWasUpdatedEventService) EXPECT() *MockPostWasUpdatedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// UpdatePostMetadata mocks base method.
+func (m *MockPostWasUpdatedEventService) UpdatePostMetadata(data *post.PostMetadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePostMetadata", data)
+}
+
+// UpdatePostMetadata indicates an expected call of UpdatePostMetadata.
+func (mr *MockPostWasUpdatedEventServiceMockRecorder) UpdatePostMetadata(data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostMetadata", reflect.TypeOf((*MockPostWasUpdatedEventService)(nil).UpdatePostMetadata), data)
+}
@@ -2,6 +2,7 @@ package post_handler
 
 import (
 	common_data "readmodels/internal/common/data"
+	"readmodels/internal/events"
 	"readmodels/internal/model"
 	"readmodels/internal/post"
 	"time"
@@ -12,19 +13,26 @@ import (
 //go:generate mockgen -source=post_was_created_event_handler.go -destination=mock/post_was_created_event_handler.go
 
 type Metadata struct {
-	Username    string `json:"username"`
-	Type        string `json:"type"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	CreatedAt   string `json:"createdAt"`
-	LastUpdated string `json:"lastUpdated"`
+	Username    string `json:"username" validate:"id"`
+	Type        string `json:"type" validate:"required,max=50"`
+	Title       string `json:"title" validate:"max=255"`
+	Description string `json:"description" validate:"max=5000"`
+	CreatedAt   string `json:"createdAt" validate:"timestamp"`
+	LastUpdated string `json:"lastUpdated" validate:"timestamp"`
 }
 
 type PostWasCreatedEvent struct {
-	PostId   string   `json:"post_id"`
+	PostId   string   `json:"postId" validate:"id"`
 	Metadata Metadata `json:"metadata"`
 }
 
+// Version 1 of PostWasCreatedEvent named the post id post_id.
+const PostWasCreatedEventVersion = 2
+
+var PostWasCreatedEventUpcasters = map[int]events.Upcaster{
+	1: events.RenameField("post_id", "postId"),
+}
+
 type PostWasCreatedEventService interface {
 	CreateNewPostMetadata(data *post.PostMetadata)
 }
@@ -0,0 +1,72 @@
+package post_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	"readmodels/internal/post"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=post_was_updated_event_handler.go -destination=mock/post_was_updated_event_handler.go
+
+type UpdatedMetadata struct {
+	Type        string `json:"type" validate:"required,max=50"`
+	Title       string `json:"title" validate:"max=255"`
+	Description string `json:"description" validate:"max=5000"`
+	LastUpdated string `json:"lastUpdated" validate:"timestamp"`
+}
+
+type PostWasUpdatedEvent struct {
+	PostId   string          `json:"postId" validate:"id"`
+	Metadata UpdatedMetadata `json:"metadata"`
+}
+
+type PostWasUpdatedEventService interface {
+	UpdatePostMetadata(data *post.PostMetadata)
+}
+
+type PostWasUpdatedEventHandler struct {
+	service PostWasUpdatedEventService
+}
+
+func NewPostWasUpdatedEventHandler(service PostWasUpdatedEventService) *PostWasUpdatedEventHandler {
+	return &PostWasUpdatedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *PostWasUpdatedEventHandler) Handle(event []byte) {
+	var postWasUpdatedEvent PostWasUpdatedEvent
+	log.Info().Msg("Handling PostWasUpdatedEvent")
+
+	err := common_data.DeserializeData(event, &postWasUpdatedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	data, err := mapUpdateEventData(postWasUpdatedEvent)
+	if err != nil {
+		return
+	}
+
+	handler.service.UpdatePostMetadata(data)
+}
+
+func mapUpdateEventData(event PostWasUpdatedEvent) (*post.PostMetadata, error) {
+	parsedLastUpdatedAt, err := time.Parse(model.TimeLayout, event.Metadata.LastUpdated)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error parsing time LastUpdated")
+		return nil, err
+	}
+
+	return &post.PostMetadata{
+		PostId:      event.PostId,
+		Type:        event.Metadata.Type,
+		Title:       event.Metadata.Title,
+		Description: event.Metadata.Description,
+		LastUpdated: parsedLastUpdatedAt,
+	}, nil
+}
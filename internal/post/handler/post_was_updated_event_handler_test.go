@@ -0,0 +1,79 @@
+package post_handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"readmodels/internal/model"
+	"readmodels/internal/post"
+	post_handler "readmodels/internal/post/handler"
+	mock_post "readmodels/internal/post/handler/mock"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+var postWasUpdatedEventHandlerLoggerOutput bytes.Buffer
+var postWasUpdatedEventService *mock_post.MockPostWasUpdatedEventService
+var postWasUpdatedEventHandler *post_handler.PostWasUpdatedEventHandler
+
+func setUpPostWasUpdatedEventHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	postWasUpdatedEventService = mock_post.NewMockPostWasUpdatedEventService(ctrl)
+	log.Logger = log.Output(&postWasUpdatedEventHandlerLoggerOutput)
+	postWasUpdatedEventHandler = post_handler.NewPostWasUpdatedEventHandler(postWasUpdatedEventService)
+}
+
+func TestHandlePostWasUpdatedEvent(t *testing.T) {
+	setUpPostWasUpdatedEventHandler(t)
+	timeNow := time.Now().UTC().Format(model.TimeLayout)
+	data := &post_handler.PostWasUpdatedEvent{
+		PostId: "123456",
+		Metadata: post_handler.UpdatedMetadata{
+			Type:        "TEXT",
+			Title:       "Novo título",
+			Description: "Nova descrición",
+			LastUpdated: timeNow,
+		},
+	}
+	event, _ := json.Marshal(data)
+	expectedTime, _ := time.Parse(model.TimeLayout, timeNow)
+	expectedPostMetadata := &post.PostMetadata{
+		PostId:      "123456",
+		Type:        "TEXT",
+		Title:       "Novo título",
+		Description: "Nova descrición",
+		LastUpdated: expectedTime,
+	}
+	postWasUpdatedEventService.EXPECT().UpdatePostMetadata(expectedPostMetadata)
+
+	postWasUpdatedEventHandler.Handle(event)
+}
+
+func TestHandlePostWasUpdatedEvent_ErrorInvalidData(t *testing.T) {
+	setUpPostWasUpdatedEventHandler(t)
+	invalidData := "invalid data"
+	event, _ := json.Marshal(invalidData)
+
+	postWasUpdatedEventHandler.Handle(event)
+
+	assert.Contains(t, postWasUpdatedEventHandlerLoggerOutput.String(), "Invalid event data")
+}
+
+func TestHandlePostWasUpdatedEvent_ErrorParsingLastUpdated(t *testing.T) {
+	setUpPostWasUpdatedEventHandler(t)
+	data := &post_handler.PostWasUpdatedEvent{
+		PostId: "123456",
+		Metadata: post_handler.UpdatedMetadata{
+			Title:       "Novo título",
+			LastUpdated: "invalid time",
+		},
+	}
+	event, _ := json.Marshal(data)
+
+	postWasUpdatedEventHandler.Handle(event)
+
+	assert.Contains(t, postWasUpdatedEventHandlerLoggerOutput.String(), "Error parsing time LastUpdated")
+}
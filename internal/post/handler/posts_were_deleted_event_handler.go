@@ -8,8 +8,8 @@ import (
 )
 
 type PostsWereDeletedEvent struct {
-	Username string   `json:"username"`
-	PostIds  []string `json:"postIds"`
+	Username string   `json:"username" validate:"id"`
+	PostIds  []string `json:"postIds" validate:"min=1,dive,id"`
 }
 
 type PostsWereDeletedEventService interface {
@@ -3,6 +3,7 @@ package post_handler_test
 import (
 	"bytes"
 	"encoding/json"
+	"readmodels/internal/post"
 	post_handler "readmodels/internal/post/handler"
 	mock_post "readmodels/internal/post/mock"
 	"testing"
@@ -32,6 +33,7 @@ func TestHandlePostsWereDeletedEvent(t *testing.T) {
 		PostIds:  postIds,
 	}
 	event, _ := json.Marshal(data)
+	postsWereDeletedEventHandlerRepository.EXPECT().RemovePostDependencies(gomock.Any()).Return(&post.RemovedDependencies{}, nil).Times(len(postIds))
 	postsWereDeletedEventHandlerRepository.EXPECT().RemovePostMetadata(username, postIds).Return(nil)
 
 	postsWereDeletedEventHandler.Handle(event)
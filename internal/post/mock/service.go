@@ -48,6 +48,21 @@ func (mr *MockRepositoryMockRecorder) AddNewPostMetadata(data interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNewPostMetadata", reflect.TypeOf((*MockRepository)(nil).AddNewPostMetadata), data)
 }
 
+// GetPostMetadata mocks base method.
+func (m *MockRepository) GetPostMetadata(postId string) (*post.PostMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostMetadata", postId)
+	ret0, _ := ret[0].(*post.PostMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostMetadata indicates an expected call of GetPostMetadata.
+func (mr *MockRepositoryMockRecorder) GetPostMetadata(postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostMetadata", reflect.TypeOf((*MockRepository)(nil).GetPostMetadata), postId)
+}
+
 // GetPostMetadatasByUser mocks base method.
 func (m *MockRepository) GetPostMetadatasByUser(username, currentUsername, lastPostId, lastPostCreatedAt string, limit int) ([]*post.PostMetadata, string, string, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +80,21 @@ func (mr *MockRepositoryMockRecorder) GetPostMetadatasByUser(username, currentUs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostMetadatasByUser", reflect.TypeOf((*MockRepository)(nil).GetPostMetadatasByUser), username, currentUsername, lastPostId, lastPostCreatedAt, limit)
 }
 
+// RemovePostDependencies mocks base method.
+func (m *MockRepository) RemovePostDependencies(postId string) (*post.RemovedDependencies, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePostDependencies", postId)
+	ret0, _ := ret[0].(*post.RemovedDependencies)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemovePostDependencies indicates an expected call of RemovePostDependencies.
+func (mr *MockRepositoryMockRecorder) RemovePostDependencies(postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePostDependencies", reflect.TypeOf((*MockRepository)(nil).RemovePostDependencies), postId)
+}
+
 // RemovePostMetadata mocks base method.
 func (m *MockRepository) RemovePostMetadata(username string, postIds []string) error {
 	m.ctrl.T.Helper()
@@ -78,3 +108,18 @@ func (mr *MockRepositoryMockRecorder) RemovePostMetadata(username, postIds inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePostMetadata", reflect.TypeOf((*MockRepository)(nil).RemovePostMetadata), username, postIds)
 }
+
+// UpdatePostMetadata mocks base method.
+func (m *MockRepository) UpdatePostMetadata(data *post.PostMetadata) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePostMetadata", data)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePostMetadata indicates an expected call of UpdatePostMetadata.
+func (mr *MockRepositoryMockRecorder) UpdatePostMetadata(data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostMetadata", reflect.TypeOf((*MockRepository)(nil).UpdatePostMetadata), data)
+}
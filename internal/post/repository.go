@@ -2,13 +2,15 @@ package post
 
 import database "readmodels/internal/db"
 
+const dependenciesPageSize = 100
+
 type PostRepository database.Database
 
 func (r PostRepository) AddNewPostMetadata(data *PostMetadata) error {
 	userprofileKey := &database.UserProfileKey{
 		Username: data.Username,
 	}
-	return r.Client.InsertDataAndIncreaseCounter("PostMetadata", data, "UserProfile", userprofileKey, "PostsAmount")
+	return r.Client.InsertDataAndIncreaseCounter(database.PostMetadataTable, mapToDatabase(data), database.UserProfileTable, userprofileKey, "PostsAmount")
 }
 
 func (r PostRepository) GetPostMetadatasByUser(username string, currentUsername string, lastPostId, lastPostCreatedAt string, limit int) ([]*PostMetadata, string, string, error) {
@@ -25,6 +27,41 @@ func (r PostRepository) GetPostMetadatasByUser(username string, currentUsername
 	return posts, lastPostId, lastPostCreatedAt, nil
 }
 
+func (r PostRepository) GetPostMetadata(postId string) (*PostMetadata, error) {
+	postKey := &database.PostMetadataKey{
+		PostId: postId,
+	}
+	var data database.PostMetadata
+	err := r.Client.GetData(database.PostMetadataTable, postKey, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToDomain(&data), nil
+}
+
+// UpdatePostMetadata only sets the editable fields so the counters, which
+// change concurrently through other events, are preserved. The update is
+// conditioned on the Revision of the stored post, set on creation and by
+// migration 18 for older posts. It reports false when a later update is
+// already stored.
+func (r PostRepository) UpdatePostMetadata(data *PostMetadata) (bool, error) {
+	postKey := &database.PostMetadataKey{
+		PostId: data.PostId,
+	}
+
+	revision := database.PostRevision(data.LastUpdated)
+	updateAttributes := map[string]interface{}{
+		"Type":        data.Type,
+		"Title":       data.Title,
+		"Description": data.Description,
+		"LastUpdated": data.LastUpdated,
+		"Revision":    revision,
+	}
+
+	return r.Client.UpdateDataIfLower(database.PostMetadataTable, postKey, updateAttributes, "Revision", revision)
+}
+
 func (r PostRepository) RemovePostMetadata(username string, postIds []string) error {
 	postKeys := make([]any, len(postIds))
 	for i, v := range postIds {
@@ -37,7 +74,168 @@ func (r PostRepository) RemovePostMetadata(username string, postIds []string) er
 		Username: username,
 	}
 
-	return r.Client.RemoveMultipleDataAndDecreaseCounter("PostMetadata", postKeys, "UserProfile", userprofileKey, "PostsAmount")
+	return r.Client.RemoveMultipleDataAndDecreaseCounter(database.PostMetadataTable, postKeys, database.UserProfileTable, userprofileKey, "PostsAmount")
+}
+
+// RemovePostDependencies removes the comments, reviews, likes and superlikes of
+// the post page by page. On failure the amounts removed so far are returned
+// along with the error.
+func (r PostRepository) RemovePostDependencies(postId string) (*RemovedDependencies, error) {
+	removed := &RemovedDependencies{}
+	var err error
+
+	removed.Comments, err = r.removeComments(postId)
+	if err != nil {
+		return removed, err
+	}
+	removed.Reviews, err = r.removeReviews(postId)
+	if err != nil {
+		return removed, err
+	}
+	removed.Likes, err = r.removeLikes(postId)
+	if err != nil {
+		return removed, err
+	}
+	removed.Superlikes, err = r.removeSuperlikes(postId)
+	if err != nil {
+		return removed, err
+	}
+
+	return removed, nil
+}
+
+func (r PostRepository) removeComments(postId string) (int, error) {
+	removed := 0
+	var lastCommentId uint64
+	for {
+		comments, nextCommentId, err := r.Client.GetCommentsByIndexPostId(postId, lastCommentId, dependenciesPageSize)
+		if err != nil {
+			return removed, err
+		}
+
+		commentKeys := make([]any, len(comments))
+		for i, comment := range comments {
+			commentKeys[i] = &database.CommentKey{
+				CommentId: comment.CommentId,
+			}
+		}
+		err = r.Client.RemoveMultipleData(database.CommentsTable, commentKeys)
+		if err != nil {
+			return removed, err
+		}
+		removed += len(commentKeys)
+
+		if nextCommentId == 0 {
+			return removed, nil
+		}
+		lastCommentId = nextCommentId
+	}
+}
+
+func (r PostRepository) removeReviews(postId string) (int, error) {
+	removed := 0
+	var lastReviewId uint64
+	for {
+		reviews, nextReviewId, err := r.Client.GetReviewsByIndexPostId(postId, lastReviewId, dependenciesPageSize)
+		if err != nil {
+			return removed, err
+		}
+
+		reviewKeys := make([]any, len(reviews))
+		for i, review := range reviews {
+			reviewKeys[i] = &database.ReviewKey{
+				ReviewId: review.ReviewId,
+			}
+		}
+		err = r.Client.RemoveMultipleData(database.ReviewsTable, reviewKeys)
+		if err != nil {
+			return removed, err
+		}
+		removed += len(reviewKeys)
+
+		if nextReviewId == 0 {
+			return removed, nil
+		}
+		lastReviewId = nextReviewId
+	}
+}
+
+func (r PostRepository) removeLikes(postId string) (int, error) {
+	removed := 0
+	lastUsername := ""
+	for {
+		likes, nextUsername, err := r.Client.GetPostLikesByIndexPostId(postId, lastUsername, dependenciesPageSize)
+		if err != nil {
+			return removed, err
+		}
+
+		likeKeys := make([]any, len(likes))
+		for i, like := range likes {
+			likeKeys[i] = &database.PostLikeKey{
+				PostId:   postId,
+				Username: like.Username,
+			}
+		}
+		err = r.Client.RemoveMultipleData(database.PostLikesTable, likeKeys)
+		if err != nil {
+			return removed, err
+		}
+		removed += len(likeKeys)
+
+		if nextUsername == "" {
+			return removed, nil
+		}
+		lastUsername = nextUsername
+	}
+}
+
+func (r PostRepository) removeSuperlikes(postId string) (int, error) {
+	removed := 0
+	lastUsername := ""
+	for {
+		superlikes, nextUsername, err := r.Client.GetPostSuperlikesByIndexPostId(postId, lastUsername, dependenciesPageSize)
+		if err != nil {
+			return removed, err
+		}
+
+		superlikeKeys := make([]any, len(superlikes))
+		for i, superlike := range superlikes {
+			superlikeKeys[i] = &database.PostSuperlikeKey{
+				PostId:   postId,
+				Username: superlike.Username,
+			}
+		}
+		err = r.Client.RemoveMultipleData(database.PostSuperlikesTable, superlikeKeys)
+		if err != nil {
+			return removed, err
+		}
+		removed += len(superlikeKeys)
+
+		if nextUsername == "" {
+			return removed, nil
+		}
+		lastUsername = nextUsername
+	}
+}
+
+func mapToDatabase(data *PostMetadata) *database.PostMetadata {
+	return &database.PostMetadata{
+		PostId:                    data.PostId,
+		Username:                  data.Username,
+		Type:                      data.Type,
+		Title:                     data.Title,
+		Description:               data.Description,
+		Reviews:                   data.Reviews,
+		IsReviewedByCurrentUser:   data.IsReviewedByCurrentUser,
+		Comments:                  data.Comments,
+		Likes:                     data.Likes,
+		IsLikedByCurrentUser:      data.IsLikedByCurrentUser,
+		Superlikes:                data.Superlikes,
+		IsSuperlikedByCurrentUser: data.IsSuperlikedByCurrentUser,
+		CreatedAt:                 data.CreatedAt,
+		LastUpdated:               data.LastUpdated,
+		Revision:                  database.PostRevision(data.LastUpdated),
+	}
 }
 
 func mapToDomain(data *database.PostMetadata) *PostMetadata {
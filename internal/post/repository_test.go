@@ -1,8 +1,10 @@
 package post_test
 
 import (
+	"errors"
 	database "readmodels/internal/db"
 	mock_database "readmodels/internal/db/test/mock"
+	"readmodels/internal/model"
 	"readmodels/internal/post"
 	"testing"
 	"time"
@@ -32,10 +34,20 @@ func TestAddNewPostMetadataInRepository(t *testing.T) {
 		CreatedAt:   timeNow,
 		LastUpdated: timeNow,
 	}
+	expectedData := &database.PostMetadata{
+		PostId:      "123456",
+		Username:    "user123",
+		Type:        "TEXT",
+		Title:       "Exemplo de Título",
+		Description: "Exemplo de Descrição",
+		CreatedAt:   timeNow,
+		LastUpdated: timeNow,
+		Revision:    timeNow.UnixMicro(),
+	}
 	expectedUserporfileKey := &database.UserProfileKey{
 		Username: data.Username,
 	}
-	client.EXPECT().InsertDataAndIncreaseCounter("PostMetadata", data, "UserProfile", expectedUserporfileKey, "PostsAmount")
+	client.EXPECT().InsertDataAndIncreaseCounter("PostMetadata", expectedData, "UserProfile", expectedUserporfileKey, "PostsAmount")
 
 	postRepository.AddNewPostMetadata(data)
 }
@@ -122,3 +134,65 @@ func TestRemovePostMetadataInRepository(t *testing.T) {
 
 	postRepository.RemovePostMetadata(username, postIds)
 }
+
+func TestRemovePostDependenciesInRepository(t *testing.T) {
+	setUp(t)
+	postId := "123456"
+	client.EXPECT().GetCommentsByIndexPostId(postId, uint64(0), 100).Return([]*model.Comment{{CommentId: 1}, {CommentId: 2}}, uint64(2), nil)
+	client.EXPECT().RemoveMultipleData("readmodels.comments", []any{&database.CommentKey{CommentId: 1}, &database.CommentKey{CommentId: 2}})
+	client.EXPECT().GetCommentsByIndexPostId(postId, uint64(2), 100).Return([]*model.Comment{{CommentId: 3}}, uint64(0), nil)
+	client.EXPECT().RemoveMultipleData("readmodels.comments", []any{&database.CommentKey{CommentId: 3}})
+	client.EXPECT().GetReviewsByIndexPostId(postId, uint64(0), 100).Return([]*model.Review{}, uint64(0), nil)
+	client.EXPECT().RemoveMultipleData("readmodels.reviews", []any{})
+	client.EXPECT().GetPostLikesByIndexPostId(postId, "", 100).Return([]*model.UserMetadata{{Username: "user1"}}, "", nil)
+	client.EXPECT().RemoveMultipleData("readmodels.postLikes", []any{&database.PostLikeKey{PostId: postId, Username: "user1"}})
+	client.EXPECT().GetPostSuperlikesByIndexPostId(postId, "", 100).Return([]*model.UserMetadata{{Username: "user2"}}, "", nil)
+	client.EXPECT().RemoveMultipleData("readmodels.postSuperlikes", []any{&database.PostSuperlikeKey{PostId: postId, Username: "user2"}})
+
+	removed, err := postRepository.RemovePostDependencies(postId)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &post.RemovedDependencies{Comments: 3, Reviews: 0, Likes: 1, Superlikes: 1}, removed)
+}
+
+func TestErrorOnRemovePostDependenciesInRepository(t *testing.T) {
+	setUp(t)
+	postId := "123456"
+	client.EXPECT().GetCommentsByIndexPostId(postId, uint64(0), 100).Return([]*model.Comment{{CommentId: 1}}, uint64(0), nil)
+	client.EXPECT().RemoveMultipleData("readmodels.comments", []any{&database.CommentKey{CommentId: 1}})
+	client.EXPECT().GetReviewsByIndexPostId(postId, uint64(0), 100).Return(nil, uint64(0), errors.New("some error"))
+
+	removed, err := postRepository.RemovePostDependencies(postId)
+
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, &post.RemovedDependencies{Comments: 1}, removed)
+}
+
+func TestUpdatePostMetadataInRepository(t *testing.T) {
+	setUp(t)
+	timeNow := time.Now().UTC()
+	data := &post.PostMetadata{
+		PostId:      "123456",
+		Username:    "user123",
+		Type:        "TEXT",
+		Title:       "Novo título",
+		Description: "Nova descrición",
+		LastUpdated: timeNow,
+	}
+	expectedPostKey := &database.PostMetadataKey{
+		PostId: data.PostId,
+	}
+	expectedAttributes := map[string]interface{}{
+		"Type":        data.Type,
+		"Title":       data.Title,
+		"Description": data.Description,
+		"LastUpdated": data.LastUpdated,
+		"Revision":    timeNow.UnixMicro(),
+	}
+	client.EXPECT().UpdateDataIfLower("PostMetadata", expectedPostKey, expectedAttributes, "Revision", timeNow.UnixMicro()).Return(false, nil)
+
+	updated, err := postRepository.UpdatePostMetadata(data)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, updated)
+}
@@ -12,6 +12,9 @@ type Repository interface {
 	AddNewPostMetadata(data *PostMetadata) error
 	GetPostMetadatasByUser(username string, currentUsername string, lastPostId, lastPostCreatedAt string, limit int) ([]*PostMetadata, string, string, error)
 	RemovePostMetadata(username string, postIds []string) error
+	RemovePostDependencies(postId string) (*RemovedDependencies, error)
+	GetPostMetadata(postId string) (*PostMetadata, error)
+	UpdatePostMetadata(data *PostMetadata) (bool, error)
 }
 
 type PostService struct {
@@ -35,6 +38,14 @@ type PostMetadata struct {
 	LastUpdated               time.Time `json:"last_updated"`
 }
 
+// RemovedDependencies counts the records of a post removed along with it.
+type RemovedDependencies struct {
+	Comments   int
+	Reviews    int
+	Likes      int
+	Superlikes int
+}
+
 func NewPostService(repository Repository) *PostService {
 	return &PostService{
 		repository: repository,
@@ -51,6 +62,31 @@ func (s *PostService) CreateNewPostMetadata(data *PostMetadata) {
 	log.Info().Msgf("Post metadata for id %s was added", data.PostId)
 }
 
+// UpdatePostMetadata applies the new title, description and type of the post
+// unless the stored post was already updated by a later event. The stored
+// post is only read for its owner.
+func (s *PostService) UpdatePostMetadata(data *PostMetadata) {
+	stored, err := s.repository.GetPostMetadata(data.PostId)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting post metadata for id %s", data.PostId)
+		return
+	}
+
+	data.Username = stored.Username
+	updated, err := s.repository.UpdatePostMetadata(data)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error updating post metadata for id %s", data.PostId)
+		return
+	}
+	if !updated {
+		log.Warn().Msgf("Out of order update for post metadata with id %s was ignored, a later update than %s is stored",
+			data.PostId, data.LastUpdated.Format(time.RFC3339Nano))
+		return
+	}
+
+	log.Info().Msgf("Post metadata for id %s was updated", data.PostId)
+}
+
 func (s *PostService) GetPostMetadatasByUser(username string, currentUsername string, lastPostId, lastPostCreatedAt string, limit int) ([]*PostMetadata, string, string, error) {
 	postMetadatas, lastPostId, lastPostCreatedAt, err := s.repository.GetPostMetadatasByUser(username, currentUsername, lastPostId, lastPostCreatedAt, limit)
 	if err != nil {
@@ -61,12 +97,40 @@ func (s *PostService) GetPostMetadatasByUser(username string, currentUsername st
 	return postMetadatas, lastPostId, lastPostCreatedAt, nil
 }
 
+// RemovePostMetadata removes the posts together with their comments, reviews,
+// likes and superlikes. The metadata of a post is only removed once all its
+// dependencies are, so a post whose cleanup failed stays listed and handling
+// the same deletion again resumes its cleanup.
 func (s *PostService) RemovePostMetadata(username string, postIds []string) {
-	err := s.repository.RemovePostMetadata(username, postIds)
+	total := &RemovedDependencies{}
+	cleanedPostIds := make([]string, 0, len(postIds))
+	for _, postId := range postIds {
+		removed, err := s.repository.RemovePostDependencies(postId)
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Error removing dependencies of post %s, its metadata is kept", postId)
+		} else {
+			cleanedPostIds = append(cleanedPostIds, postId)
+		}
+		if removed == nil {
+			continue
+		}
+
+		log.Info().Msgf("Removed %d comments, %d reviews, %d likes and %d superlikes of post %s", removed.Comments, removed.Reviews, removed.Likes, removed.Superlikes, postId)
+		total.Comments += removed.Comments
+		total.Reviews += removed.Reviews
+		total.Likes += removed.Likes
+		total.Superlikes += removed.Superlikes
+	}
+	if len(cleanedPostIds) == 0 {
+		return
+	}
+
+	err := s.repository.RemovePostMetadata(username, cleanedPostIds)
 	if err != nil {
-		log.Error().Stack().Err(err).Msgf("Error removing %s's post metadatas for ids %v", username, postIds)
+		log.Error().Stack().Err(err).Msgf("Error removing %s's post metadatas for ids %v", username, cleanedPostIds)
 		return
 	}
 
-	log.Info().Msgf("%s's post metadatas for ids %v were removed", username, postIds)
+	log.Info().Msgf("%s's post metadatas for ids %v were removed with %d comments, %d reviews, %d likes and %d superlikes",
+		username, cleanedPostIds, total.Comments, total.Reviews, total.Likes, total.Superlikes)
 }
@@ -114,21 +114,119 @@ func TestErrorOnGetPostMetadatasByUserWithService(t *testing.T) {
 func TestRemovePostMetadataWithService(t *testing.T) {
 	setUpService(t)
 	username := "username1"
-	postIds := []string{"123456", "abcdef", "1a2b3e"}
+	postIds := []string{"123456", "abcdef"}
+	serviceRepository.EXPECT().RemovePostDependencies("123456").Return(&post.RemovedDependencies{Comments: 2, Reviews: 1, Likes: 3, Superlikes: 0}, nil)
+	serviceRepository.EXPECT().RemovePostDependencies("abcdef").Return(&post.RemovedDependencies{Comments: 1, Likes: 1}, nil)
 	serviceRepository.EXPECT().RemovePostMetadata(username, postIds)
 
 	postService.RemovePostMetadata(username, postIds)
 
-	assert.Contains(t, serviceLoggerOutput.String(), fmt.Sprintf("%s's post metadatas for ids %v were removed", username, postIds))
+	assert.Contains(t, serviceLoggerOutput.String(), "Removed 2 comments, 1 reviews, 3 likes and 0 superlikes of post 123456")
+	assert.Contains(t, serviceLoggerOutput.String(), fmt.Sprintf("%s's post metadatas for ids %v were removed with 3 comments, 1 reviews, 4 likes and 0 superlikes", username, postIds))
 }
 
 func TestRemovePostMetadataWithService_Error(t *testing.T) {
 	setUpService(t)
 	username := "username1"
 	postIds := []string{"123456", "abcdef", "1a2b3e"}
+	serviceRepository.EXPECT().RemovePostDependencies(gomock.Any()).Return(&post.RemovedDependencies{}, nil).Times(3)
 	serviceRepository.EXPECT().RemovePostMetadata(username, postIds).Return(errors.New("some error"))
 
 	postService.RemovePostMetadata(username, postIds)
 
 	assert.Contains(t, serviceLoggerOutput.String(), fmt.Sprintf("Error removing %s's post metadatas for ids %v", username, postIds))
 }
+
+func TestRemovePostMetadataWithService_ErrorRemovingDependencies(t *testing.T) {
+	setUpService(t)
+	username := "username1"
+	postIds := []string{"123456", "abcdef"}
+	serviceRepository.EXPECT().RemovePostDependencies("123456").Return(&post.RemovedDependencies{Comments: 1}, errors.New("some error"))
+	serviceRepository.EXPECT().RemovePostDependencies("abcdef").Return(&post.RemovedDependencies{Likes: 1}, nil)
+	serviceRepository.EXPECT().RemovePostMetadata(username, []string{"abcdef"})
+
+	postService.RemovePostMetadata(username, postIds)
+
+	assert.Contains(t, serviceLoggerOutput.String(), "Error removing dependencies of post 123456, its metadata is kept")
+	assert.Contains(t, serviceLoggerOutput.String(), "Removed 1 comments, 0 reviews, 0 likes and 0 superlikes of post 123456")
+}
+
+func TestRemovePostMetadataWithService_ErrorRemovingEveryDependencies(t *testing.T) {
+	setUpService(t)
+	username := "username1"
+	postIds := []string{"123456"}
+	serviceRepository.EXPECT().RemovePostDependencies("123456").Return(nil, errors.New("some error"))
+
+	postService.RemovePostMetadata(username, postIds)
+
+	assert.Contains(t, serviceLoggerOutput.String(), "Error removing dependencies of post 123456, its metadata is kept")
+}
+
+func TestUpdatePostMetadataWithService(t *testing.T) {
+	setUpService(t)
+	timeNow := time.Now().UTC()
+	stored := &post.PostMetadata{
+		PostId:      "123456",
+		Username:    "username1",
+		Title:       "Título",
+		Likes:       3,
+		LastUpdated: timeNow.Add(-time.Hour),
+	}
+	data := &post.PostMetadata{
+		PostId:      "123456",
+		Title:       "Novo título",
+		Description: "Nova descrición",
+		Type:        "TEXT",
+		LastUpdated: timeNow,
+	}
+	expectedData := &post.PostMetadata{
+		PostId:      "123456",
+		Username:    "username1",
+		Title:       "Novo título",
+		Description: "Nova descrición",
+		Type:        "TEXT",
+		LastUpdated: timeNow,
+	}
+	serviceRepository.EXPECT().GetPostMetadata("123456").Return(stored, nil)
+	serviceRepository.EXPECT().UpdatePostMetadata(expectedData).Return(true, nil)
+
+	postService.UpdatePostMetadata(data)
+
+	assert.Contains(t, serviceLoggerOutput.String(), "Post metadata for id 123456 was updated")
+}
+
+func TestUpdatePostMetadataWithService_IgnoresOutOfOrderUpdate(t *testing.T) {
+	setUpService(t)
+	timeNow := time.Now().UTC()
+	stored := &post.PostMetadata{
+		PostId:      "123456",
+		Username:    "username1",
+		LastUpdated: timeNow,
+	}
+	data := &post.PostMetadata{
+		PostId:      "123456",
+		Title:       "Título vello",
+		LastUpdated: timeNow.Add(-time.Minute),
+	}
+	serviceRepository.EXPECT().GetPostMetadata("123456").Return(stored, nil)
+	serviceRepository.EXPECT().UpdatePostMetadata(data).Return(false, nil)
+
+	postService.UpdatePostMetadata(data)
+
+	assert.Contains(t, serviceLoggerOutput.String(), "Out of order update for post metadata with id 123456 was ignored")
+}
+
+func TestUpdatePostMetadataWithService_Error(t *testing.T) {
+	setUpService(t)
+	timeNow := time.Now().UTC()
+	data := &post.PostMetadata{
+		PostId:      "123456",
+		LastUpdated: timeNow,
+	}
+	serviceRepository.EXPECT().GetPostMetadata("123456").Return(&post.PostMetadata{PostId: "123456"}, nil)
+	serviceRepository.EXPECT().UpdatePostMetadata(gomock.Any()).Return(false, errors.New("some error"))
+
+	postService.UpdatePostMetadata(data)
+
+	assert.Contains(t, serviceLoggerOutput.String(), "Error updating post metadata for id 123456")
+}
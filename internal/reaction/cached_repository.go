@@ -0,0 +1,55 @@
+package reaction
+
+import (
+	"readmodels/internal/cache"
+	"readmodels/internal/model"
+)
+
+type CachedRepository struct {
+	repository Repository
+	cache      cache.Cache
+}
+
+func NewCachedRepository(repository Repository, cache cache.Cache) *CachedRepository {
+	return &CachedRepository{
+		repository: repository,
+		cache:      cache,
+	}
+}
+
+func (r *CachedRepository) CreatePostLike(data *model.PostLike) error {
+	defer cache.InvalidatePost(r.cache, data.PostId)
+	return r.repository.CreatePostLike(data)
+}
+
+func (r *CachedRepository) CreatePostSuperlike(data *model.PostSuperlike) error {
+	defer cache.InvalidatePost(r.cache, data.PostId)
+	return r.repository.CreatePostSuperlike(data)
+}
+
+func (r *CachedRepository) CreateReview(data *model.Review) error {
+	defer cache.InvalidatePost(r.cache, data.PostId)
+	return r.repository.CreateReview(data)
+}
+
+func (r *CachedRepository) GetLikesMetadataByPostId(postId, lastUsername string, limit int) ([]*model.UserMetadata, string, error) {
+	return r.repository.GetLikesMetadataByPostId(postId, lastUsername, limit)
+}
+
+func (r *CachedRepository) GetSuperlikesMetadataByPostId(postId, lastUsername string, limit int) ([]*model.UserMetadata, string, error) {
+	return r.repository.GetSuperlikesMetadataByPostId(postId, lastUsername, limit)
+}
+
+func (r *CachedRepository) GetReviewsByPostId(postId string, lastReviewId uint64, limit int) ([]*model.Review, uint64, error) {
+	return r.repository.GetReviewsByPostId(postId, lastReviewId, limit)
+}
+
+func (r *CachedRepository) DeletePostLike(data *model.PostLike) error {
+	defer cache.InvalidatePost(r.cache, data.PostId)
+	return r.repository.DeletePostLike(data)
+}
+
+func (r *CachedRepository) DeletePostSuperlike(data *model.PostSuperlike) error {
+	defer cache.InvalidatePost(r.cache, data.PostId)
+	return r.repository.DeletePostSuperlike(data)
+}
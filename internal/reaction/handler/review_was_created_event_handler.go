@@ -11,13 +11,13 @@ import (
 //go:generate mockgen -source=review_was_created_event_handler.go -destination=test/mock/review_was_created_event_handler.go
 
 type ReviewWasCreatedEvent struct {
-	ReviewId  uint64 `json:"reviewId"`
-	Username  string `json:"username"`
-	PostId    string `json:"postId"`
-	Title     string `json:"title"`
-	Content   string `json:"content"`
-	Rating    int    `json:"rating"`
-	CreatedAt string `json:"createdAt"`
+	ReviewId  uint64 `json:"reviewId" validate:"required"`
+	Username  string `json:"username" validate:"id"`
+	PostId    string `json:"postId" validate:"id"`
+	Title     string `json:"title" validate:"max=255"`
+	Content   string `json:"content" validate:"max=5000"`
+	Rating    int    `json:"rating" validate:"min=1,max=5"`
+	CreatedAt string `json:"createdAt" validate:"timestamp"`
 }
 
 type ReviewWasCreatedEventService interface {
@@ -10,8 +10,8 @@ import (
 //go:generate mockgen -source=user_liked_post_event_handler.go -destination=test/mock/user_liked_post_event_handler.go
 
 type UserLikedPostEvent struct {
-	Username string `json:"username"`
-	PostId   string `json:"postId"`
+	Username string `json:"username" validate:"id"`
+	PostId   string `json:"postId" validate:"id"`
 }
 
 type UserLikedPostEventService interface {
@@ -30,7 +30,7 @@ func (r *ReactionRepository) CreatePostLike(postLike *model.PostLike) error {
 	postKey := &database.PostMetadataKey{
 		PostId: postLikeMetadata.PostId,
 	}
-	return r.database.Client.InsertDataAndIncreaseCounter("readmodels.postLikes", postLikeMetadata, "PostMetadata", postKey, "Likes")
+	return r.database.Client.InsertDataAndIncreaseCounter(database.PostLikesTable, postLikeMetadata, database.PostMetadataTable, postKey, "Likes")
 }
 
 func (r *ReactionRepository) CreatePostSuperlike(postSuperlike *model.PostSuperlike) error {
@@ -48,14 +48,14 @@ func (r *ReactionRepository) CreatePostSuperlike(postSuperlike *model.PostSuperl
 	postKey := &database.PostMetadataKey{
 		PostId: postSuperlike.PostId,
 	}
-	return r.database.Client.InsertDataAndIncreaseCounter("readmodels.postSuperlikes", postSuperlikeMetadata, "PostMetadata", postKey, "Superlikes")
+	return r.database.Client.InsertDataAndIncreaseCounter(database.PostSuperlikesTable, postSuperlikeMetadata, database.PostMetadataTable, postKey, "Superlikes")
 }
 
 func (r ReactionRepository) CreateReview(data *model.Review) error {
 	postKey := &database.PostMetadataKey{
 		PostId: data.PostId,
 	}
-	return r.database.Client.InsertDataAndIncreaseCounter("readmodels.reviews", data, "PostMetadata", postKey, "Reviews")
+	return r.database.Client.InsertDataAndIncreaseCounter(database.ReviewsTable, data, database.PostMetadataTable, postKey, "Reviews")
 }
 
 func (r *ReactionRepository) GetLikesMetadataByPostId(postId string, lastUsername string, limit int) ([]*model.UserMetadata, string, error) {
@@ -94,7 +94,7 @@ func (r *ReactionRepository) DeletePostLike(postLike *model.PostLike) error {
 		PostId:   postLike.PostId,
 		Username: postLike.User.Username,
 	}
-	return r.database.Client.RemoveDataAndDecreaseCounter("readmodels.postLikes", postLikeMetadataKey, "PostMetadata", postKey, "Likes")
+	return r.database.Client.RemoveDataAndDecreaseCounter(database.PostLikesTable, postLikeMetadataKey, database.PostMetadataTable, postKey, "Likes")
 }
 
 func (r *ReactionRepository) DeletePostSuperlike(postSuperLike *model.PostSuperlike) error {
@@ -105,7 +105,7 @@ func (r *ReactionRepository) DeletePostSuperlike(postSuperLike *model.PostSuperl
 	postKey := &database.PostMetadataKey{
 		PostId: postSuperLike.PostId,
 	}
-	return r.database.Client.RemoveDataAndDecreaseCounter("readmodels.postSuperlikes", postSuperLikeMetadataKey, "PostMetadata", postKey, "Superlikes")
+	return r.database.Client.RemoveDataAndDecreaseCounter(database.PostSuperlikesTable, postSuperLikeMetadataKey, database.PostMetadataTable, postKey, "Superlikes")
 }
 
 func (r *ReactionRepository) getUserFullname(username string) (string, error) {
@@ -117,7 +117,7 @@ func (r *ReactionRepository) getUserFullname(username string) (string, error) {
 		Name string `json:"name"`
 	}{}
 
-	err := r.database.Client.GetData("UserProfile", userKey, userFullname)
+	err := r.database.Client.GetData(database.UserProfileTable, userKey, userFullname)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,57 @@
+package reconciliation
+
+import (
+	"readmodels/internal/cache"
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+)
+
+// CachedRepository invalidates the cache of the process it runs in. The
+// reconcile command shares it with the running service only through Redis:
+// with the default in-process cache, every running instance keeps serving
+// the drifted counters until their entries expire after the cache TTL.
+type CachedRepository struct {
+	repository Repository
+	cache      cache.Cache
+}
+
+func NewCachedRepository(repository Repository, cache cache.Cache) *CachedRepository {
+	return &CachedRepository{
+		repository: repository,
+		cache:      cache,
+	}
+}
+
+func (r *CachedRepository) GetPostsPage(cursor string) ([]*database.PostMetadata, string, error) {
+	return r.repository.GetPostsPage(cursor)
+}
+
+func (r *CachedRepository) GetUserProfilesPage(cursor string) ([]*model.UserProfile, string, error) {
+	return r.repository.GetUserProfilesPage(cursor)
+}
+
+func (r *CachedRepository) GetLikedPostIdsPage(cursor string) ([]string, string, error) {
+	return r.repository.GetLikedPostIdsPage(cursor)
+}
+
+func (r *CachedRepository) GetSuperlikedPostIdsPage(cursor string) ([]string, string, error) {
+	return r.repository.GetSuperlikedPostIdsPage(cursor)
+}
+
+func (r *CachedRepository) GetCommentedPostIdsPage(cursor string) ([]string, string, error) {
+	return r.repository.GetCommentedPostIdsPage(cursor)
+}
+
+func (r *CachedRepository) GetReviewedPostIdsPage(cursor string) ([]string, string, error) {
+	return r.repository.GetReviewedPostIdsPage(cursor)
+}
+
+func (r *CachedRepository) UpdatePostCounters(post *database.PostMetadata, counters map[string]any) error {
+	defer cache.InvalidatePostsByUser(r.cache, post.Username)
+	return r.repository.UpdatePostCounters(post, counters)
+}
+
+func (r *CachedRepository) UpdateUserProfileCounters(username string, counters map[string]any) error {
+	defer cache.InvalidateUserProfile(r.cache, username)
+	return r.repository.UpdateUserProfileCounters(username, counters)
+}
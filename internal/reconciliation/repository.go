@@ -0,0 +1,102 @@
+package reconciliation
+
+import (
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+)
+
+type ReconciliationRepository struct {
+	database *database.Database
+}
+
+func NewReconciliationRepository(database *database.Database) *ReconciliationRepository {
+	return &ReconciliationRepository{
+		database: database,
+	}
+}
+
+// The tables are scanned in pages of pageSize items, so reconciling doesn't
+// hold whole tables in memory
+const pageSize = 500
+
+func (r *ReconciliationRepository) GetPostsPage(cursor string) ([]*database.PostMetadata, string, error) {
+	posts := []*database.PostMetadata{}
+	cursor, err := r.database.Client.ScanPage(database.PostMetadataTable, cursor, pageSize, &posts)
+	return posts, cursor, err
+}
+
+func (r *ReconciliationRepository) GetUserProfilesPage(cursor string) ([]*model.UserProfile, string, error) {
+	userProfiles := []*model.UserProfile{}
+	cursor, err := r.database.Client.ScanPage(database.UserProfileTable, cursor, pageSize, &userProfiles)
+	return userProfiles, cursor, err
+}
+
+func (r *ReconciliationRepository) GetLikedPostIdsPage(cursor string) ([]string, string, error) {
+	likes := []*database.PostLikeMetadata{}
+	cursor, err := r.database.Client.ScanPage(database.PostLikesTable, cursor, pageSize, &likes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	postIds := make([]string, len(likes))
+	for i, like := range likes {
+		postIds[i] = like.PostId
+	}
+	return postIds, cursor, nil
+}
+
+func (r *ReconciliationRepository) GetSuperlikedPostIdsPage(cursor string) ([]string, string, error) {
+	superlikes := []*database.PostSuperlikeMetadata{}
+	cursor, err := r.database.Client.ScanPage(database.PostSuperlikesTable, cursor, pageSize, &superlikes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	postIds := make([]string, len(superlikes))
+	for i, superlike := range superlikes {
+		postIds[i] = superlike.PostId
+	}
+	return postIds, cursor, nil
+}
+
+func (r *ReconciliationRepository) GetCommentedPostIdsPage(cursor string) ([]string, string, error) {
+	comments := []*model.Comment{}
+	cursor, err := r.database.Client.ScanPage(database.CommentsTable, cursor, pageSize, &comments)
+	if err != nil {
+		return nil, "", err
+	}
+
+	postIds := make([]string, len(comments))
+	for i, comment := range comments {
+		postIds[i] = comment.PostId
+	}
+	return postIds, cursor, nil
+}
+
+func (r *ReconciliationRepository) GetReviewedPostIdsPage(cursor string) ([]string, string, error) {
+	reviews := []*model.Review{}
+	cursor, err := r.database.Client.ScanPage(database.ReviewsTable, cursor, pageSize, &reviews)
+	if err != nil {
+		return nil, "", err
+	}
+
+	postIds := make([]string, len(reviews))
+	for i, review := range reviews {
+		postIds[i] = review.PostId
+	}
+	return postIds, cursor, nil
+}
+
+func (r *ReconciliationRepository) UpdatePostCounters(post *database.PostMetadata, counters map[string]any) error {
+	postKey := &database.PostMetadataKey{
+		PostId: post.PostId,
+	}
+	return r.database.Client.UpdateData(database.PostMetadataTable, postKey, counters)
+}
+
+func (r *ReconciliationRepository) UpdateUserProfileCounters(username string, counters map[string]any) error {
+	userProfileKey := &database.UserProfileKey{
+		Username: username,
+	}
+	return r.database.Client.UpdateData(database.UserProfileTable, userProfileKey, counters)
+}
@@ -0,0 +1,206 @@
+package reconciliation
+
+import (
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=service.go -destination=test/mock/service.go
+
+type Repository interface {
+	GetPostsPage(cursor string) ([]*database.PostMetadata, string, error)
+	GetUserProfilesPage(cursor string) ([]*model.UserProfile, string, error)
+	GetLikedPostIdsPage(cursor string) ([]string, string, error)
+	GetSuperlikedPostIdsPage(cursor string) ([]string, string, error)
+	GetCommentedPostIdsPage(cursor string) ([]string, string, error)
+	GetReviewedPostIdsPage(cursor string) ([]string, string, error)
+	UpdatePostCounters(post *database.PostMetadata, counters map[string]any) error
+	UpdateUserProfileCounters(username string, counters map[string]any) error
+}
+
+type Correction struct {
+	Table   string
+	Key     string
+	Counter string
+	Stored  int
+	Actual  int
+}
+
+type ReconciliationService struct {
+	repository Repository
+}
+
+func NewReconciliationService(repository Repository) *ReconciliationService {
+	return &ReconciliationService{
+		repository: repository,
+	}
+}
+
+// Reconcile recounts the post and user profile counters from the source
+// tables and overwrites the ones that drifted. With dryRun the corrections are
+// only reported. The tables are read page by page, only the counts per post
+// and per user are kept in memory. Events consumed while it runs can make a
+// recount stale, so it is meant to be run when the consumers are idle.
+func (s *ReconciliationService) Reconcile(dryRun bool) ([]*Correction, error) {
+	postCorrections, postsAmount, err := s.reconcilePosts(dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	userProfileCorrections, err := s.reconcileUserProfiles(postsAmount, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	corrections := append(postCorrections, userProfileCorrections...)
+	log.Info().Msgf("Counter reconciliation finished with %d corrections", len(corrections))
+	return corrections, nil
+}
+
+// reconcilePosts also returns the number of posts of every user.
+func (s *ReconciliationService) reconcilePosts(dryRun bool) ([]*Correction, map[string]int, error) {
+	likes, err := countByPostId(s.repository.GetLikedPostIdsPage)
+	if err != nil {
+		return nil, nil, err
+	}
+	superlikes, err := countByPostId(s.repository.GetSuperlikedPostIdsPage)
+	if err != nil {
+		return nil, nil, err
+	}
+	comments, err := countByPostId(s.repository.GetCommentedPostIdsPage)
+	if err != nil {
+		return nil, nil, err
+	}
+	reviews, err := countByPostId(s.repository.GetReviewedPostIdsPage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	corrections := []*Correction{}
+	postsAmount := map[string]int{}
+	err = forEachPage(s.repository.GetPostsPage, func(post *database.PostMetadata) error {
+		postsAmount[post.Username]++
+
+		postCorrections := []*Correction{}
+		postCorrections = appendIfDrifted(postCorrections, database.PostMetadataTable, post.PostId, "Likes", post.Likes, likes[post.PostId])
+		postCorrections = appendIfDrifted(postCorrections, database.PostMetadataTable, post.PostId, "Superlikes", post.Superlikes, superlikes[post.PostId])
+		postCorrections = appendIfDrifted(postCorrections, database.PostMetadataTable, post.PostId, "Comments", post.Comments, comments[post.PostId])
+		postCorrections = appendIfDrifted(postCorrections, database.PostMetadataTable, post.PostId, "Reviews", post.Reviews, reviews[post.PostId])
+		if len(postCorrections) == 0 {
+			return nil
+		}
+
+		if !dryRun {
+			err := s.repository.UpdatePostCounters(post, actualCounters(postCorrections))
+			if err != nil {
+				log.Error().Stack().Err(err).Msgf("Error reconciling counters of post %s", post.PostId)
+				return err
+			}
+		}
+		corrections = append(corrections, postCorrections...)
+		return nil
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error reconciling posts")
+		return nil, nil, err
+	}
+
+	sortCorrections(corrections)
+	return corrections, postsAmount, nil
+}
+
+func (s *ReconciliationService) reconcileUserProfiles(postsAmount map[string]int, dryRun bool) ([]*Correction, error) {
+	corrections := []*Correction{}
+	err := forEachPage(s.repository.GetUserProfilesPage, func(userProfile *model.UserProfile) error {
+		userProfileCorrections := appendIfDrifted([]*Correction{}, database.UserProfileTable, userProfile.Username, "PostsAmount", userProfile.PostsAmount, postsAmount[userProfile.Username])
+		if len(userProfileCorrections) == 0 {
+			return nil
+		}
+
+		if !dryRun {
+			err := s.repository.UpdateUserProfileCounters(userProfile.Username, actualCounters(userProfileCorrections))
+			if err != nil {
+				log.Error().Stack().Err(err).Msgf("Error reconciling counters of user %s", userProfile.Username)
+				return err
+			}
+		}
+		corrections = append(corrections, userProfileCorrections...)
+		return nil
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error reconciling user profiles")
+		return nil, err
+	}
+
+	sortCorrections(corrections)
+	return corrections, nil
+}
+
+// forEachPage calls handle with every item of the pages returned by getPage,
+// following the cursors until the last page.
+func forEachPage[T any](getPage func(cursor string) ([]T, string, error), handle func(item T) error) error {
+	cursor := ""
+	for {
+		items, next, err := getPage(cursor)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			err = handle(item)
+			if err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func countByPostId(getPage func(cursor string) ([]string, string, error)) (map[string]int, error) {
+	counts := map[string]int{}
+	err := forEachPage(getPage, func(postId string) error {
+		counts[postId]++
+		return nil
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error getting items to recount")
+		return nil, err
+	}
+	return counts, nil
+}
+
+// sortCorrections orders the corrections by key, the tables being scanned in
+// no particular order.
+func sortCorrections(corrections []*Correction) {
+	sort.SliceStable(corrections, func(i, j int) bool {
+		return corrections[i].Key < corrections[j].Key
+	})
+}
+
+func appendIfDrifted(corrections []*Correction, table, key, counter string, stored, actual int) []*Correction {
+	if stored == actual {
+		return corrections
+	}
+
+	log.Warn().Msgf("Counter %s of %s in %s drifted: stored %d, actual %d", counter, key, table, stored, actual)
+	return append(corrections, &Correction{
+		Table:   table,
+		Key:     key,
+		Counter: counter,
+		Stored:  stored,
+		Actual:  actual,
+	})
+}
+
+func actualCounters(corrections []*Correction) map[string]any {
+	counters := make(map[string]any, len(corrections))
+	for _, correction := range corrections {
+		counters[correction.Counter] = correction.Actual
+	}
+	return counters
+}
@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_reconciliation is a generated GoMock package.
+package mock_reconciliation
+
+import (
+	database "readmodels/internal/db"
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCommentedPostIdsPage mocks base method.
+func (m *MockRepository) GetCommentedPostIdsPage(cursor string) ([]string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentedPostIdsPage", cursor)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCommentedPostIdsPage indicates an expected call of GetCommentedPostIdsPage.
+func (mr *MockRepositoryMockRecorder) GetCommentedPostIdsPage(cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentedPostIdsPage", reflect.TypeOf((*MockRepository)(nil).GetCommentedPostIdsPage), cursor)
+}
+
+// GetLikedPostIdsPage mocks base method.
+func (m *MockRepository) GetLikedPostIdsPage(cursor string) ([]string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikedPostIdsPage", cursor)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLikedPostIdsPage indicates an expected call of GetLikedPostIdsPage.
+func (mr *MockRepositoryMockRecorder) GetLikedPostIdsPage(cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikedPostIdsPage", reflect.TypeOf((*MockRepository)(nil).GetLikedPostIdsPage), cursor)
+}
+
+// GetPostsPage mocks base method.
+func (m *MockRepository) GetPostsPage(cursor string) ([]*database.PostMetadata, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostsPage", cursor)
+	ret0, _ := ret[0].([]*database.PostMetadata)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPostsPage indicates an expected call of GetPostsPage.
+func (mr *MockRepositoryMockRecorder) GetPostsPage(cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostsPage", reflect.TypeOf((*MockRepository)(nil).GetPostsPage), cursor)
+}
+
+// GetReviewedPostIdsPage mocks base method.
+func (m *MockRepository) GetReviewedPostIdsPage(cursor string) ([]string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewedPostIdsPage", cursor)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetReviewedPostIdsPage indicates an expected call of GetReviewedPostIdsPage.
+func (mr *MockRepositoryMockRecorder) GetReviewedPostIdsPage(cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewedPostIdsPage", reflect.TypeOf((*MockRepository)(nil).GetReviewedPostIdsPage), cursor)
+}
+
+// GetSuperlikedPostIdsPage mocks base method.
+func (m *MockRepository) GetSuperlikedPostIdsPage(cursor string) ([]string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuperlikedPostIdsPage", cursor)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSuperlikedPostIdsPage indicates an expected call of GetSuperlikedPostIdsPage.
+func (mr *MockRepositoryMockRecorder) GetSuperlikedPostIdsPage(cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuperlikedPostIdsPage", reflect.TypeOf((*MockRepository)(nil).GetSuperlikedPostIdsPage), cursor)
+}
+
+// GetUserProfilesPage mocks base method.
+func (m *MockRepository) GetUserProfilesPage(cursor string) ([]*model.UserProfile, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfilesPage", cursor)
+	ret0, _ := ret[0].([]*model.UserProfile)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserProfilesPage indicates an expected call of GetUserProfilesPage.
+func (mr *MockRepositoryMockRecorder) GetUserProfilesPage(cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfilesPage", reflect.TypeOf((*MockRepository)(nil).GetUserProfilesPage), cursor)
+}
+
+// UpdatePostCounters mocks base method.
+func (m *MockRepository) UpdatePostCounters(post *database.PostMetadata, counters map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePostCounters", post, counters)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePostCounters indicates an expected call of UpdatePostCounters.
+func (mr *MockRepositoryMockRecorder) UpdatePostCounters(post, counters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostCounters", reflect.TypeOf((*MockRepository)(nil).UpdatePostCounters), post, counters)
+}
+
+// UpdateUserProfileCounters mocks base method.
+func (m *MockRepository) UpdateUserProfileCounters(username string, counters map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfileCounters", username, counters)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserProfileCounters indicates an expected call of UpdateUserProfileCounters.
+func (mr *MockRepositoryMockRecorder) UpdateUserProfileCounters(username, counters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfileCounters", reflect.TypeOf((*MockRepository)(nil).UpdateUserProfileCounters), username, counters)
+}
@@ -0,0 +1,21 @@
+package reconciliation_test
+
+import (
+	"bytes"
+	mock_database "readmodels/internal/db/test/mock"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog/log"
+)
+
+var ctrl *gomock.Controller
+var loggerOutput bytes.Buffer
+var client *mock_database.MockDatabaseClient
+
+func SetUp(t *testing.T) {
+	ctrl = gomock.NewController(t)
+	client = mock_database.NewMockDatabaseClient(ctrl)
+	loggerOutput.Reset()
+	log.Logger = log.Output(&loggerOutput)
+}
@@ -0,0 +1,75 @@
+package reconciliation_test
+
+import (
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"readmodels/internal/reconciliation"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var reconciliationRepository *reconciliation.ReconciliationRepository
+
+func setUpRepository(t *testing.T) {
+	SetUp(t)
+	reconciliationRepository = reconciliation.NewReconciliationRepository(database.NewDatabase(client))
+}
+
+func TestGetLikedPostIdsInRepository(t *testing.T) {
+	setUpRepository(t)
+	client.EXPECT().ScanPage("readmodels.postLikes", "cursor1", 500, gomock.Any()).DoAndReturn(func(tableName string, cursor string, limit int, results any) (string, error) {
+		*results.(*[]*database.PostLikeMetadata) = []*database.PostLikeMetadata{
+			{PostId: "post1", Username: "user1"},
+			{PostId: "post1", Username: "user2"},
+		}
+		return "cursor2", nil
+	})
+
+	postIds, cursor, err := reconciliationRepository.GetLikedPostIdsPage("cursor1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"post1", "post1"}, postIds)
+	assert.Equal(t, "cursor2", cursor)
+}
+
+func TestGetCommentedPostIdsInRepository(t *testing.T) {
+	setUpRepository(t)
+	client.EXPECT().ScanPage("readmodels.comments", "", 500, gomock.Any()).DoAndReturn(func(tableName string, cursor string, limit int, results any) (string, error) {
+		*results.(*[]*model.Comment) = []*model.Comment{
+			{CommentId: 1, PostId: "post1"},
+			{CommentId: 2, PostId: "post2"},
+		}
+		return "", nil
+	})
+
+	postIds, cursor, err := reconciliationRepository.GetCommentedPostIdsPage("")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"post1", "post2"}, postIds)
+	assert.Empty(t, cursor)
+}
+
+func TestUpdatePostCountersInRepository(t *testing.T) {
+	setUpRepository(t)
+	post := &database.PostMetadata{PostId: "post1", Username: "user1"}
+	counters := map[string]any{"Likes": 2, "Reviews": 0}
+	expectedPostKey := &database.PostMetadataKey{PostId: "post1"}
+	client.EXPECT().UpdateData("PostMetadata", expectedPostKey, counters)
+
+	err := reconciliationRepository.UpdatePostCounters(post, counters)
+
+	assert.Nil(t, err)
+}
+
+func TestUpdateUserProfileCountersInRepository(t *testing.T) {
+	setUpRepository(t)
+	counters := map[string]any{"PostsAmount": 3}
+	expectedUserProfileKey := &database.UserProfileKey{Username: "user1"}
+	client.EXPECT().UpdateData("UserProfile", expectedUserProfileKey, counters)
+
+	err := reconciliationRepository.UpdateUserProfileCounters("user1", counters)
+
+	assert.Nil(t, err)
+}
@@ -0,0 +1,112 @@
+package reconciliation_test
+
+import (
+	"errors"
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"readmodels/internal/reconciliation"
+	mock_reconciliation "readmodels/internal/reconciliation/test/mock"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var repository *mock_reconciliation.MockRepository
+var reconciliationService *reconciliation.ReconciliationService
+
+func setUpService(t *testing.T) {
+	SetUp(t)
+	repository = mock_reconciliation.NewMockRepository(ctrl)
+	reconciliationService = reconciliation.NewReconciliationService(repository)
+}
+
+func givenStoredCounters() ([]*database.PostMetadata, []*model.UserProfile) {
+	posts := []*database.PostMetadata{
+		{PostId: "post2", Username: "user1", Likes: 1, Superlikes: 0, Comments: 2, Reviews: 0},
+		{PostId: "post1", Username: "user1", Likes: -1, Superlikes: 1, Comments: 0, Reviews: 1},
+	}
+	userProfiles := []*model.UserProfile{
+		{Username: "user1", PostsAmount: 2},
+		{Username: "user2", PostsAmount: 3},
+	}
+	repository.EXPECT().GetPostsPage("").Return(posts[:1], "posts2", nil)
+	repository.EXPECT().GetPostsPage("posts2").Return(posts[1:], "", nil)
+	repository.EXPECT().GetUserProfilesPage("").Return(userProfiles, "", nil)
+	repository.EXPECT().GetLikedPostIdsPage("").Return([]string{"post2", "post1"}, "likes2", nil)
+	repository.EXPECT().GetLikedPostIdsPage("likes2").Return([]string{"post1"}, "", nil)
+	repository.EXPECT().GetSuperlikedPostIdsPage("").Return([]string{"post1"}, "", nil)
+	repository.EXPECT().GetCommentedPostIdsPage("").Return([]string{"post2", "post2"}, "", nil)
+	repository.EXPECT().GetReviewedPostIdsPage("").Return([]string{"post1", "post3"}, "", nil)
+	return posts, userProfiles
+}
+
+func TestReconcileFixesDriftedCounters(t *testing.T) {
+	setUpService(t)
+	posts, _ := givenStoredCounters()
+	expectedCorrections := []*reconciliation.Correction{
+		{Table: "PostMetadata", Key: "post1", Counter: "Likes", Stored: -1, Actual: 2},
+		{Table: "UserProfile", Key: "user2", Counter: "PostsAmount", Stored: 3, Actual: 0},
+	}
+	repository.EXPECT().UpdatePostCounters(posts[1], map[string]any{"Likes": 2})
+	repository.EXPECT().UpdateUserProfileCounters("user2", map[string]any{"PostsAmount": 0})
+
+	corrections, err := reconciliationService.Reconcile(false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedCorrections, corrections)
+	assert.Contains(t, loggerOutput.String(), "Counter Likes of post1 in PostMetadata drifted: stored -1, actual 2")
+	assert.Contains(t, loggerOutput.String(), "Counter PostsAmount of user2 in UserProfile drifted: stored 3, actual 0")
+}
+
+func TestReconcileWithDryRunDoesNotFixCounters(t *testing.T) {
+	setUpService(t)
+	givenStoredCounters()
+
+	corrections, err := reconciliationService.Reconcile(true)
+
+	assert.Nil(t, err)
+	assert.Len(t, corrections, 2)
+}
+
+func TestReconcileWithConsistentCounters(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPostsPage("").Return([]*database.PostMetadata{{PostId: "post1", Username: "user1", Likes: 1}}, "", nil)
+	repository.EXPECT().GetUserProfilesPage("").Return([]*model.UserProfile{{Username: "user1", PostsAmount: 1}}, "", nil)
+	repository.EXPECT().GetLikedPostIdsPage("").Return([]string{"post1"}, "", nil)
+	repository.EXPECT().GetSuperlikedPostIdsPage("").Return([]string{}, "", nil)
+	repository.EXPECT().GetCommentedPostIdsPage("").Return([]string{}, "", nil)
+	repository.EXPECT().GetReviewedPostIdsPage("").Return([]string{}, "", nil)
+
+	corrections, err := reconciliationService.Reconcile(false)
+
+	assert.Nil(t, err)
+	assert.Empty(t, corrections)
+}
+
+func TestErrorOnReconcileWhenUpdateFails(t *testing.T) {
+	setUpService(t)
+	posts, _ := givenStoredCounters()
+	repository.EXPECT().UpdatePostCounters(posts[1], map[string]any{"Likes": 2})
+	repository.EXPECT().UpdateUserProfileCounters("user2", map[string]any{"PostsAmount": 0}).Return(errors.New("some error"))
+
+	corrections, err := reconciliationService.Reconcile(false)
+
+	assert.NotNil(t, err)
+	assert.Nil(t, corrections)
+	assert.Contains(t, loggerOutput.String(), "Error reconciling counters of user user2")
+}
+
+func TestErrorOnReconcileWhenGettingPosts(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetLikedPostIdsPage("").Return([]string{}, "", nil)
+	repository.EXPECT().GetSuperlikedPostIdsPage("").Return([]string{}, "", nil)
+	repository.EXPECT().GetCommentedPostIdsPage("").Return([]string{}, "", nil)
+	repository.EXPECT().GetReviewedPostIdsPage("").Return([]string{}, "", nil)
+	repository.EXPECT().GetPostsPage("").Return(nil, "", errors.New("some error"))
+
+	corrections, err := reconciliationService.Reconcile(false)
+
+	assert.NotNil(t, err)
+	assert.Nil(t, corrections)
+	assert.Contains(t, loggerOutput.String(), "Error reconciling posts")
+}
@@ -0,0 +1,108 @@
+package search
+
+import (
+	"readmodels/internal/api"
+	"readmodels/internal/model"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=controller.go -destination=test/mock/controller.go
+
+type ControllerService interface {
+	SearchPosts(text string, offset, limit int) ([]*model.SearchPost, int, error)
+	SearchUsers(text string, offset, limit int) ([]*model.SearchUser, int, error)
+}
+
+type SearchController struct {
+	service ControllerService
+}
+
+type SearchPostsResponse struct {
+	Posts  []*model.SearchPost `json:"posts"`
+	Offset int                 `json:"offset"`
+	Limit  int                 `json:"limit"`
+	Total  int                 `json:"total"`
+}
+
+type SearchUsersResponse struct {
+	Users  []*model.SearchUser `json:"users"`
+	Offset int                 `json:"offset"`
+	Limit  int                 `json:"limit"`
+	Total  int                 `json:"total"`
+}
+
+func NewSearchController(service ControllerService) *SearchController {
+	return &SearchController{
+		service: service,
+	}
+}
+
+func (controller *SearchController) Routes(routerGroup *gin.RouterGroup) {
+	routerGroup.GET("/search/posts", controller.SearchPosts)
+	routerGroup.GET("/search/users", controller.SearchUsers)
+}
+
+func (controller *SearchController) SearchPosts(c *gin.Context) {
+	log.Info().Msg("Handling Request GET Search Posts")
+	text, offset, limit, ok := searchParameters(c)
+	if !ok {
+		return
+	}
+
+	posts, total, err := controller.service.SearchPosts(text, offset, limit)
+	if err != nil {
+		api.SendInternalServerError(c, err.Error())
+		return
+	}
+
+	api.SendOKWithResult(c, &SearchPostsResponse{
+		Posts:  posts,
+		Offset: offset,
+		Limit:  limit,
+		Total:  total,
+	})
+}
+
+func (controller *SearchController) SearchUsers(c *gin.Context) {
+	log.Info().Msg("Handling Request GET Search Users")
+	text, offset, limit, ok := searchParameters(c)
+	if !ok {
+		return
+	}
+
+	users, total, err := controller.service.SearchUsers(text, offset, limit)
+	if err != nil {
+		api.SendInternalServerError(c, err.Error())
+		return
+	}
+
+	api.SendOKWithResult(c, &SearchUsersResponse{
+		Users:  users,
+		Offset: offset,
+		Limit:  limit,
+		Total:  total,
+	})
+}
+
+// searchParameters reads the query and the page, answering with a bad
+// request when they are invalid.
+func searchParameters(c *gin.Context) (string, int, int, bool) {
+	text := strings.TrimSpace(c.DefaultQuery("q", ""))
+	offset, offsetErr := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	limit, limitErr := strconv.Atoi(c.DefaultQuery("limit", "12"))
+
+	if text == "" {
+		api.SendBadRequest(c, "Invalid search, q can't be empty")
+		return "", 0, 0, false
+	}
+	if offsetErr != nil || limitErr != nil || offset < 0 || limit <= 0 {
+		api.SendBadRequest(c, "Invalid pagination parameters, offset can't be negative and limit has to be greater than 0")
+		return "", 0, 0, false
+	}
+
+	return text, offset, limit, true
+}
@@ -0,0 +1,52 @@
+package search_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/model"
+	post_handler "readmodels/internal/post/handler"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=post_was_created_event_handler.go -destination=test/mock/post_was_created_event_handler.go
+
+type PostWasCreatedEventService interface {
+	IndexPost(post *model.SearchPost)
+}
+
+type PostWasCreatedEventHandler struct {
+	service PostWasCreatedEventService
+}
+
+func NewPostWasCreatedEventHandler(service PostWasCreatedEventService) *PostWasCreatedEventHandler {
+	return &PostWasCreatedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *PostWasCreatedEventHandler) Handle(event []byte) {
+	var postWasCreatedEvent post_handler.PostWasCreatedEvent
+	log.Info().Msg("Handling PostWasCreatedEvent for the search index")
+
+	err := common_data.DeserializeData(event, &postWasCreatedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	createdAt, err := time.Parse(model.TimeLayout, postWasCreatedEvent.Metadata.CreatedAt)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error parsing time CreatedAt")
+		return
+	}
+
+	handler.service.IndexPost(&model.SearchPost{
+		PostId:      postWasCreatedEvent.PostId,
+		Username:    postWasCreatedEvent.Metadata.Username,
+		Type:        postWasCreatedEvent.Metadata.Type,
+		Title:       postWasCreatedEvent.Metadata.Title,
+		Description: postWasCreatedEvent.Metadata.Description,
+		CreatedAt:   createdAt,
+	})
+}
@@ -0,0 +1,38 @@
+package search_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	post_handler "readmodels/internal/post/handler"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=post_was_updated_event_handler.go -destination=test/mock/post_was_updated_event_handler.go
+
+type PostWasUpdatedEventService interface {
+	UpdatePost(postId, postType, title, description string)
+}
+
+type PostWasUpdatedEventHandler struct {
+	service PostWasUpdatedEventService
+}
+
+func NewPostWasUpdatedEventHandler(service PostWasUpdatedEventService) *PostWasUpdatedEventHandler {
+	return &PostWasUpdatedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *PostWasUpdatedEventHandler) Handle(event []byte) {
+	var postWasUpdatedEvent post_handler.PostWasUpdatedEvent
+	log.Info().Msg("Handling PostWasUpdatedEvent for the search index")
+
+	err := common_data.DeserializeData(event, &postWasUpdatedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	metadata := postWasUpdatedEvent.Metadata
+	handler.service.UpdatePost(postWasUpdatedEvent.PostId, metadata.Type, metadata.Title, metadata.Description)
+}
@@ -0,0 +1,37 @@
+package search_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	post_handler "readmodels/internal/post/handler"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=posts_were_deleted_event_handler.go -destination=test/mock/posts_were_deleted_event_handler.go
+
+type PostsWereDeletedEventService interface {
+	RemovePosts(postIds []string)
+}
+
+type PostsWereDeletedEventHandler struct {
+	service PostsWereDeletedEventService
+}
+
+func NewPostsWereDeletedEventHandler(service PostsWereDeletedEventService) *PostsWereDeletedEventHandler {
+	return &PostsWereDeletedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *PostsWereDeletedEventHandler) Handle(event []byte) {
+	var postsWereDeletedEvent post_handler.PostsWereDeletedEvent
+	log.Info().Msg("Handling PostsWereDeletedEvent for the search index")
+
+	err := common_data.DeserializeData(event, &postsWereDeletedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.RemovePosts(postsWereDeletedEvent.PostIds)
+}
@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: post_was_created_event_handler.go
+
+// Package mock_search_handler is a generated GoMock package.
+package mock_search_handler
+
+import (
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPostWasCreatedEventService is a mock of PostWasCreatedEventService interface.
+type MockPostWasCreatedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostWasCreatedEventServiceMockRecorder
+}
+
+// MockPostWasCreatedEventServiceMockRecorder is the mock recorder for MockPostWasCreatedEventService.
+type MockPostWasCreatedEventServiceMockRecorder struct {
+	mock *MockPostWasCreatedEventService
+}
+
+// NewMockPostWasCreatedEventService creates a new mock instance.
+func NewMockPostWasCreatedEventService(ctrl *gomock.Controller) *MockPostWasCreatedEventService {
+	mock := &MockPostWasCreatedEventService{ctrl: ctrl}
+	mock.recorder = &MockPostWasCreatedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostWasCreatedEventService) EXPECT() *MockPostWasCreatedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// IndexPost mocks base method.
+func (m *MockPostWasCreatedEventService) IndexPost(post *model.SearchPost) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IndexPost", post)
+}
+
+// IndexPost indicates an expected call of IndexPost.
+func (mr *MockPostWasCreatedEventServiceMockRecorder) IndexPost(post interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexPost", reflect.TypeOf((*MockPostWasCreatedEventService)(nil).IndexPost), post)
+}
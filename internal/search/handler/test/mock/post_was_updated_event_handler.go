@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: post_was_updated_event_handler.go
+
+// Package mock_search_handler is a generated GoMock package.
+package mock_search_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPostWasUpdatedEventService is a mock of PostWasUpdatedEventService interface.
+type MockPostWasUpdatedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostWasUpdatedEventServiceMockRecorder
+}
+
+// MockPostWasUpdatedEventServiceMockRecorder is the mock recorder for MockPostWasUpdatedEventService.
+type MockPostWasUpdatedEventServiceMockRecorder struct {
+	mock *MockPostWasUpdatedEventService
+}
+
+// NewMockPostWasUpdatedEventService creates a new mock instance.
+func NewMockPostWasUpdatedEventService(ctrl *gomock.Controller) *MockPostWasUpdatedEventService {
+	mock := &MockPostWasUpdatedEventService{ctrl: ctrl}
+	mock.recorder = &MockPostWasUpdatedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostWasUpdatedEventService) EXPECT() *MockPostWasUpdatedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// UpdatePost mocks base method.
+func (m *MockPostWasUpdatedEventService) UpdatePost(postId, postType, title, description string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePost", postId, postType, title, description)
+}
+
+// UpdatePost indicates an expected call of UpdatePost.
+func (mr *MockPostWasUpdatedEventServiceMockRecorder) UpdatePost(postId, postType, title, description interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*MockPostWasUpdatedEventService)(nil).UpdatePost), postId, postType, title, description)
+}
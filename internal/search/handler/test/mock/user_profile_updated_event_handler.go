@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_profile_updated_event_handler.go
+
+// Package mock_search_handler is a generated GoMock package.
+package mock_search_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserProfileUpdatedEventService is a mock of UserProfileUpdatedEventService interface.
+type MockUserProfileUpdatedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserProfileUpdatedEventServiceMockRecorder
+}
+
+// MockUserProfileUpdatedEventServiceMockRecorder is the mock recorder for MockUserProfileUpdatedEventService.
+type MockUserProfileUpdatedEventServiceMockRecorder struct {
+	mock *MockUserProfileUpdatedEventService
+}
+
+// NewMockUserProfileUpdatedEventService creates a new mock instance.
+func NewMockUserProfileUpdatedEventService(ctrl *gomock.Controller) *MockUserProfileUpdatedEventService {
+	mock := &MockUserProfileUpdatedEventService{ctrl: ctrl}
+	mock.recorder = &MockUserProfileUpdatedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserProfileUpdatedEventService) EXPECT() *MockUserProfileUpdatedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// IndexUser mocks base method.
+func (m *MockUserProfileUpdatedEventService) IndexUser(username, name string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IndexUser", username, name)
+}
+
+// IndexUser indicates an expected call of IndexUser.
+func (mr *MockUserProfileUpdatedEventServiceMockRecorder) IndexUser(username, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexUser", reflect.TypeOf((*MockUserProfileUpdatedEventService)(nil).IndexUser), username, name)
+}
@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_was_registered_event_handler.go
+
+// Package mock_search_handler is a generated GoMock package.
+package mock_search_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserWasRegisteredEventService is a mock of UserWasRegisteredEventService interface.
+type MockUserWasRegisteredEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserWasRegisteredEventServiceMockRecorder
+}
+
+// MockUserWasRegisteredEventServiceMockRecorder is the mock recorder for MockUserWasRegisteredEventService.
+type MockUserWasRegisteredEventServiceMockRecorder struct {
+	mock *MockUserWasRegisteredEventService
+}
+
+// NewMockUserWasRegisteredEventService creates a new mock instance.
+func NewMockUserWasRegisteredEventService(ctrl *gomock.Controller) *MockUserWasRegisteredEventService {
+	mock := &MockUserWasRegisteredEventService{ctrl: ctrl}
+	mock.recorder = &MockUserWasRegisteredEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserWasRegisteredEventService) EXPECT() *MockUserWasRegisteredEventServiceMockRecorder {
+	return m.recorder
+}
+
+// IndexUser mocks base method.
+func (m *MockUserWasRegisteredEventService) IndexUser(username, name string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IndexUser", username, name)
+}
+
+// IndexUser indicates an expected call of IndexUser.
+func (mr *MockUserWasRegisteredEventServiceMockRecorder) IndexUser(username, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexUser", reflect.TypeOf((*MockUserWasRegisteredEventService)(nil).IndexUser), username, name)
+}
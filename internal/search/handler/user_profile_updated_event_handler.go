@@ -0,0 +1,37 @@
+package search_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	userprofile_handler "readmodels/internal/userprofile/handlers"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=user_profile_updated_event_handler.go -destination=test/mock/user_profile_updated_event_handler.go
+
+type UserProfileUpdatedEventService interface {
+	IndexUser(username, name string)
+}
+
+type UserProfileUpdatedEventHandler struct {
+	service UserProfileUpdatedEventService
+}
+
+func NewUserProfileUpdatedEventHandler(service UserProfileUpdatedEventService) *UserProfileUpdatedEventHandler {
+	return &UserProfileUpdatedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserProfileUpdatedEventHandler) Handle(event []byte) {
+	var userProfileUpdatedEvent userprofile_handler.UserProfileUpdatedEvent
+	log.Info().Msg("Handling UserProfileUpdatedEvent for the search index")
+
+	err := common_data.DeserializeData(event, &userProfileUpdatedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.IndexUser(userProfileUpdatedEvent.Username, userProfileUpdatedEvent.FullName)
+}
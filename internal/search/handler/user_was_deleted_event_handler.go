@@ -0,0 +1,37 @@
+package search_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	erasure_handler "readmodels/internal/erasure/handler"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=user_was_deleted_event_handler.go -destination=test/mock/user_was_deleted_event_handler.go
+
+type UserWasDeletedEventService interface {
+	RemoveUser(username string)
+}
+
+type UserWasDeletedEventHandler struct {
+	service UserWasDeletedEventService
+}
+
+func NewUserWasDeletedEventHandler(service UserWasDeletedEventService) *UserWasDeletedEventHandler {
+	return &UserWasDeletedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserWasDeletedEventHandler) Handle(event []byte) {
+	var userWasDeletedEvent erasure_handler.UserWasDeletedEvent
+	log.Info().Msg("Handling UserWasDeletedEvent for the search index")
+
+	err := common_data.DeserializeData(event, &userWasDeletedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.RemoveUser(userWasDeletedEvent.Username)
+}
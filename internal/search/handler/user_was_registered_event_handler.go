@@ -0,0 +1,37 @@
+package search_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	userprofile_handler "readmodels/internal/userprofile/handlers"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=user_was_registered_event_handler.go -destination=test/mock/user_was_registered_event_handler.go
+
+type UserWasRegisteredEventService interface {
+	IndexUser(username, name string)
+}
+
+type UserWasRegisteredEventHandler struct {
+	service UserWasRegisteredEventService
+}
+
+func NewUserWasRegisteredEventHandler(service UserWasRegisteredEventService) *UserWasRegisteredEventHandler {
+	return &UserWasRegisteredEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserWasRegisteredEventHandler) Handle(event []byte) {
+	var userWasRegisteredEvent userprofile_handler.UserWasRegisteredEvent
+	log.Info().Msg("Handling UserWasRegisteredEvent for the search index")
+
+	err := common_data.DeserializeData(event, &userWasRegisteredEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.IndexUser(userWasRegisteredEvent.Username, userWasRegisteredEvent.FullName)
+}
@@ -0,0 +1,28 @@
+package search
+
+import (
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+)
+
+type SearchRepository struct {
+	database *database.Database
+}
+
+func NewSearchRepository(database *database.Database) *SearchRepository {
+	return &SearchRepository{
+		database: database,
+	}
+}
+
+func (r *SearchRepository) GetPosts() ([]*database.PostMetadata, error) {
+	posts := []*database.PostMetadata{}
+	err := r.database.Client.GetAllData(database.PostMetadataTable, &posts)
+	return posts, err
+}
+
+func (r *SearchRepository) GetUserProfiles() ([]*model.UserProfile, error) {
+	userProfiles := []*model.UserProfile{}
+	err := r.database.Client.GetAllData(database.UserProfileTable, &userProfiles)
+	return userProfiles, err
+}
@@ -0,0 +1,182 @@
+package search
+
+import (
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=service.go -destination=test/mock/service.go
+
+const rebuildBatchSize = 500
+
+// Index is the full-text index of the posts and users. Indexing a document
+// replaces the one with the same id.
+type Index interface {
+	GetPost(postId string) (*model.SearchPost, error)
+	IndexPosts(posts []*model.SearchPost) error
+	RemovePosts(postIds []string) error
+	RemoveUserPosts(username string) error
+	IndexUsers(users []*model.SearchUser) error
+	RemoveUser(username string) error
+	SearchPosts(text string, offset, limit int) ([]*model.SearchPost, int, error)
+	SearchUsers(text string, offset, limit int) ([]*model.SearchUser, int, error)
+	Reset() error
+}
+
+type Repository interface {
+	GetPosts() ([]*database.PostMetadata, error)
+	GetUserProfiles() ([]*model.UserProfile, error)
+}
+
+// SearchService keeps the index up to date with the events. Rebuild fills it
+// again from the posts and user profiles projected in the database.
+type SearchService struct {
+	index      Index
+	repository Repository
+}
+
+func NewSearchService(index Index, repository Repository) *SearchService {
+	return &SearchService{
+		index:      index,
+		repository: repository,
+	}
+}
+
+func (s *SearchService) IndexPost(post *model.SearchPost) {
+	err := s.index.IndexPosts([]*model.SearchPost{post})
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error indexing post %s", post.PostId)
+	}
+}
+
+func (s *SearchService) UpdatePost(postId, postType, title, description string) {
+	post, err := s.index.GetPost(postId)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting indexed post %s", postId)
+		return
+	}
+	if post == nil {
+		log.Warn().Msgf("Post %s isn't indexed, its update is ignored", postId)
+		return
+	}
+
+	post.Type = postType
+	post.Title = title
+	post.Description = description
+	s.IndexPost(post)
+}
+
+func (s *SearchService) RemovePosts(postIds []string) {
+	err := s.index.RemovePosts(postIds)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error removing posts %v from the index", postIds)
+	}
+}
+
+func (s *SearchService) IndexUser(username, name string) {
+	err := s.index.IndexUsers([]*model.SearchUser{{Username: username, Name: name}})
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error indexing user %s", username)
+	}
+}
+
+// RemoveUser removes the user and their posts, so erased users can't be
+// found by their posts either.
+func (s *SearchService) RemoveUser(username string) {
+	err := s.index.RemoveUser(username)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error removing user %s from the index", username)
+		return
+	}
+
+	err = s.index.RemoveUserPosts(username)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error removing the posts of user %s from the index", username)
+	}
+}
+
+func (s *SearchService) SearchPosts(text string, offset, limit int) ([]*model.SearchPost, int, error) {
+	posts, total, err := s.index.SearchPosts(text, offset, limit)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error searching posts matching %s", text)
+		return nil, 0, err
+	}
+
+	return posts, total, nil
+}
+
+func (s *SearchService) SearchUsers(text string, offset, limit int) ([]*model.SearchUser, int, error) {
+	users, total, err := s.index.SearchUsers(text, offset, limit)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error searching users matching %s", text)
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// Rebuild empties the index and indexes every post and user profile again,
+// returning how many of each were indexed.
+func (s *SearchService) Rebuild() (int, int, error) {
+	posts, err := s.repository.GetPosts()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error getting the posts to index")
+		return 0, 0, err
+	}
+	userProfiles, err := s.repository.GetUserProfiles()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error getting the user profiles to index")
+		return 0, 0, err
+	}
+
+	err = s.index.Reset()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error resetting the index")
+		return 0, 0, err
+	}
+
+	searchPosts := make([]*model.SearchPost, len(posts))
+	for i, post := range posts {
+		searchPosts[i] = &model.SearchPost{
+			PostId:      post.PostId,
+			Username:    post.Username,
+			Type:        post.Type,
+			Title:       post.Title,
+			Description: post.Description,
+			CreatedAt:   post.CreatedAt,
+		}
+	}
+	err = inBatches(searchPosts, s.index.IndexPosts)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error indexing posts")
+		return 0, 0, err
+	}
+
+	searchUsers := make([]*model.SearchUser, len(userProfiles))
+	for i, userProfile := range userProfiles {
+		searchUsers[i] = &model.SearchUser{
+			Username: userProfile.Username,
+			Name:     userProfile.Name,
+		}
+	}
+	err = inBatches(searchUsers, s.index.IndexUsers)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error indexing users")
+		return 0, 0, err
+	}
+
+	return len(searchPosts), len(searchUsers), nil
+}
+
+func inBatches[T any](documents []T, index func([]T) error) error {
+	for start := 0; start < len(documents); start += rebuildBatchSize {
+		end := min(start+rebuildBatchSize, len(documents))
+		err := index(documents[start:end])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller.go
+
+// Package mock_search is a generated GoMock package.
+package mock_search
+
+import (
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockControllerService is a mock of ControllerService interface.
+type MockControllerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockControllerServiceMockRecorder
+}
+
+// MockControllerServiceMockRecorder is the mock recorder for MockControllerService.
+type MockControllerServiceMockRecorder struct {
+	mock *MockControllerService
+}
+
+// NewMockControllerService creates a new mock instance.
+func NewMockControllerService(ctrl *gomock.Controller) *MockControllerService {
+	mock := &MockControllerService{ctrl: ctrl}
+	mock.recorder = &MockControllerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockControllerService) EXPECT() *MockControllerServiceMockRecorder {
+	return m.recorder
+}
+
+// SearchPosts mocks base method.
+func (m *MockControllerService) SearchPosts(text string, offset, limit int) ([]*model.SearchPost, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPosts", text, offset, limit)
+	ret0, _ := ret[0].([]*model.SearchPost)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchPosts indicates an expected call of SearchPosts.
+func (mr *MockControllerServiceMockRecorder) SearchPosts(text, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPosts", reflect.TypeOf((*MockControllerService)(nil).SearchPosts), text, offset, limit)
+}
+
+// SearchUsers mocks base method.
+func (m *MockControllerService) SearchUsers(text string, offset, limit int) ([]*model.SearchUser, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsers", text, offset, limit)
+	ret0, _ := ret[0].([]*model.SearchUser)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockControllerServiceMockRecorder) SearchUsers(text, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockControllerService)(nil).SearchUsers), text, offset, limit)
+}
@@ -0,0 +1,220 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_search is a generated GoMock package.
+package mock_search
+
+import (
+	database "readmodels/internal/db"
+	model "readmodels/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIndex is a mock of Index interface.
+type MockIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndexMockRecorder
+}
+
+// MockIndexMockRecorder is the mock recorder for MockIndex.
+type MockIndexMockRecorder struct {
+	mock *MockIndex
+}
+
+// NewMockIndex creates a new mock instance.
+func NewMockIndex(ctrl *gomock.Controller) *MockIndex {
+	mock := &MockIndex{ctrl: ctrl}
+	mock.recorder = &MockIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndex) EXPECT() *MockIndexMockRecorder {
+	return m.recorder
+}
+
+// GetPost mocks base method.
+func (m *MockIndex) GetPost(postId string) (*model.SearchPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPost", postId)
+	ret0, _ := ret[0].(*model.SearchPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPost indicates an expected call of GetPost.
+func (mr *MockIndexMockRecorder) GetPost(postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPost", reflect.TypeOf((*MockIndex)(nil).GetPost), postId)
+}
+
+// IndexPosts mocks base method.
+func (m *MockIndex) IndexPosts(posts []*model.SearchPost) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexPosts", posts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexPosts indicates an expected call of IndexPosts.
+func (mr *MockIndexMockRecorder) IndexPosts(posts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexPosts", reflect.TypeOf((*MockIndex)(nil).IndexPosts), posts)
+}
+
+// IndexUsers mocks base method.
+func (m *MockIndex) IndexUsers(users []*model.SearchUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexUsers", users)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexUsers indicates an expected call of IndexUsers.
+func (mr *MockIndexMockRecorder) IndexUsers(users interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexUsers", reflect.TypeOf((*MockIndex)(nil).IndexUsers), users)
+}
+
+// RemovePosts mocks base method.
+func (m *MockIndex) RemovePosts(postIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePosts", postIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePosts indicates an expected call of RemovePosts.
+func (mr *MockIndexMockRecorder) RemovePosts(postIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePosts", reflect.TypeOf((*MockIndex)(nil).RemovePosts), postIds)
+}
+
+// RemoveUser mocks base method.
+func (m *MockIndex) RemoveUser(username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUser", username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUser indicates an expected call of RemoveUser.
+func (mr *MockIndexMockRecorder) RemoveUser(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUser", reflect.TypeOf((*MockIndex)(nil).RemoveUser), username)
+}
+
+// RemoveUserPosts mocks base method.
+func (m *MockIndex) RemoveUserPosts(username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserPosts", username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUserPosts indicates an expected call of RemoveUserPosts.
+func (mr *MockIndexMockRecorder) RemoveUserPosts(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserPosts", reflect.TypeOf((*MockIndex)(nil).RemoveUserPosts), username)
+}
+
+// Reset mocks base method.
+func (m *MockIndex) Reset() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockIndexMockRecorder) Reset() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockIndex)(nil).Reset))
+}
+
+// SearchPosts mocks base method.
+func (m *MockIndex) SearchPosts(text string, offset, limit int) ([]*model.SearchPost, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPosts", text, offset, limit)
+	ret0, _ := ret[0].([]*model.SearchPost)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchPosts indicates an expected call of SearchPosts.
+func (mr *MockIndexMockRecorder) SearchPosts(text, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPosts", reflect.TypeOf((*MockIndex)(nil).SearchPosts), text, offset, limit)
+}
+
+// SearchUsers mocks base method.
+func (m *MockIndex) SearchUsers(text string, offset, limit int) ([]*model.SearchUser, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsers", text, offset, limit)
+	ret0, _ := ret[0].([]*model.SearchUser)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockIndexMockRecorder) SearchUsers(text, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockIndex)(nil).SearchUsers), text, offset, limit)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPosts mocks base method.
+func (m *MockRepository) GetPosts() ([]*database.PostMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPosts")
+	ret0, _ := ret[0].([]*database.PostMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPosts indicates an expected call of GetPosts.
+func (mr *MockRepositoryMockRecorder) GetPosts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosts", reflect.TypeOf((*MockRepository)(nil).GetPosts))
+}
+
+// GetUserProfiles mocks base method.
+func (m *MockRepository) GetUserProfiles() ([]*model.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfiles")
+	ret0, _ := ret[0].([]*model.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfiles indicates an expected call of GetUserProfiles.
+func (mr *MockRepositoryMockRecorder) GetUserProfiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfiles", reflect.TypeOf((*MockRepository)(nil).GetUserProfiles))
+}
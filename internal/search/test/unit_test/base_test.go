@@ -0,0 +1,35 @@
+package search_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	mock_search "readmodels/internal/search/test/mock"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog/log"
+)
+
+var ctrl *gomock.Controller
+var loggerOutput bytes.Buffer
+var index *mock_search.MockIndex
+var repository *mock_search.MockRepository
+var apiResponse *httptest.ResponseRecorder
+var ginContext *gin.Context
+
+func SetUp(t *testing.T) {
+	ctrl = gomock.NewController(t)
+	index = mock_search.NewMockIndex(ctrl)
+	repository = mock_search.NewMockRepository(ctrl)
+	loggerOutput.Reset()
+	log.Logger = log.Output(&loggerOutput)
+	gin.SetMode(gin.TestMode)
+	apiResponse = httptest.NewRecorder()
+	ginContext, _ = gin.CreateTestContext(apiResponse)
+}
+
+func removeSpace(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(s, " ", ""), "\t", ""), "\n", "")
+}
@@ -0,0 +1,138 @@
+package search_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"readmodels/internal/model"
+	"readmodels/internal/search"
+	mock_search "readmodels/internal/search/test/mock"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+var controller *search.SearchController
+var controllerService *mock_search.MockControllerService
+
+func setUpController(t *testing.T) {
+	SetUp(t)
+	controllerService = mock_search.NewMockControllerService(ctrl)
+	controller = search.NewSearchController(controllerService)
+}
+
+func TestSearchPostsWithController_WhenSuccess(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/search/posts", nil)
+	u := url.Values{}
+	u.Add("q", " running shoes ")
+	u.Add("offset", "4")
+	u.Add("limit", "2")
+	ginContext.Request.URL.RawQuery = u.Encode()
+	expectedPosts := []*model.SearchPost{
+		{
+			PostId:      "post1",
+			Username:    "user1",
+			Type:        "TEXT",
+			Title:       "Running shoes",
+			Description: "Review",
+			CreatedAt:   time.Date(2024, 4, 30, 9, 0, 0, 123456000, time.UTC),
+		},
+	}
+	controllerService.EXPECT().SearchPosts("running shoes", 4, 2).Return(expectedPosts, 5, nil)
+	expectedBodyResponse := `{
+		"error": false,
+		"message": "200 OK",
+		"content": {
+			"posts": [
+				{
+					"postId": "post1",
+					"username": "user1",
+					"type": "TEXT",
+					"title": "Running shoes",
+					"description": "Review",
+					"createdAt": "2024-04-30T09:00:00.123456Z"
+				}
+			],
+			"offset": 4,
+			"limit": 2,
+			"total": 5
+		}
+	}`
+
+	controller.SearchPosts(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 200)
+	assert.Equal(t, removeSpace(apiResponse.Body.String()), removeSpace(expectedBodyResponse))
+}
+
+func TestSearchUsersWithController_WhenSuccess(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/search/users", nil)
+	u := url.Values{}
+	u.Add("q", "ali")
+	ginContext.Request.URL.RawQuery = u.Encode()
+	expectedUsers := []*model.SearchUser{
+		{Username: "alice", Name: "Alice Wonder"},
+	}
+	controllerService.EXPECT().SearchUsers("ali", 0, 12).Return(expectedUsers, 1, nil)
+	expectedBodyResponse := `{
+		"error": false,
+		"message": "200 OK",
+		"content": {
+			"users": [
+				{
+					"username": "alice",
+					"name": "AliceWonder"
+				}
+			],
+			"offset": 0,
+			"limit": 12,
+			"total": 1
+		}
+	}`
+
+	controller.SearchUsers(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 200)
+	assert.Equal(t, removeSpace(apiResponse.Body.String()), removeSpace(expectedBodyResponse))
+}
+
+func TestBadRequestOnSearchPostsWithController_WhenQueryIsEmpty(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/search/posts", nil)
+	u := url.Values{}
+	u.Add("q", "  ")
+	ginContext.Request.URL.RawQuery = u.Encode()
+
+	controller.SearchPosts(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 400)
+}
+
+func TestBadRequestOnSearchUsersWithController_WhenOffsetIsNegative(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/search/users", nil)
+	u := url.Values{}
+	u.Add("q", "ali")
+	u.Add("offset", "-1")
+	ginContext.Request.URL.RawQuery = u.Encode()
+
+	controller.SearchUsers(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 400)
+}
+
+func TestInternalServerErrorOnSearchPostsWithController(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/search/posts", nil)
+	u := url.Values{}
+	u.Add("q", "shoes")
+	ginContext.Request.URL.RawQuery = u.Encode()
+	controllerService.EXPECT().SearchPosts("shoes", 0, 12).Return(nil, 0, errors.New("some error"))
+
+	controller.SearchPosts(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 500)
+}
@@ -0,0 +1,46 @@
+package search_test
+
+import (
+	"encoding/json"
+	"readmodels/internal/model"
+	post_handler "readmodels/internal/post/handler"
+	search_handler "readmodels/internal/search/handler"
+	mock_search_handler "readmodels/internal/search/handler/test/mock"
+	"testing"
+	"time"
+)
+
+var postWasCreatedEventHandler *search_handler.PostWasCreatedEventHandler
+var postWasCreatedEventService *mock_search_handler.MockPostWasCreatedEventService
+
+func setUpPostWasCreatedEventHandler(t *testing.T) {
+	SetUp(t)
+	postWasCreatedEventService = mock_search_handler.NewMockPostWasCreatedEventService(ctrl)
+	postWasCreatedEventHandler = search_handler.NewPostWasCreatedEventHandler(postWasCreatedEventService)
+}
+
+func TestHandlePostWasCreatedEventForSearch(t *testing.T) {
+	setUpPostWasCreatedEventHandler(t)
+	data := &post_handler.PostWasCreatedEvent{
+		PostId: "post1",
+		Metadata: post_handler.Metadata{
+			Username:    "user1",
+			Type:        "TEXT",
+			Title:       "Running shoes",
+			Description: "Review",
+			CreatedAt:   "2024-04-30T09:00:00.123456Z",
+			LastUpdated: "2024-04-30T09:00:00.123456Z",
+		},
+	}
+	event, _ := json.Marshal(data)
+	postWasCreatedEventService.EXPECT().IndexPost(&model.SearchPost{
+		PostId:      "post1",
+		Username:    "user1",
+		Type:        "TEXT",
+		Title:       "Running shoes",
+		Description: "Review",
+		CreatedAt:   time.Date(2024, 4, 30, 9, 0, 0, 123456000, time.UTC),
+	})
+
+	postWasCreatedEventHandler.Handle(event)
+}
@@ -0,0 +1,93 @@
+package search_test
+
+import (
+	"errors"
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"readmodels/internal/search"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var searchService *search.SearchService
+
+func setUpService(t *testing.T) {
+	SetUp(t)
+	searchService = search.NewSearchService(index, repository)
+}
+
+func TestUpdatePostWithService(t *testing.T) {
+	setUpService(t)
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	index.EXPECT().GetPost("post1").Return(&model.SearchPost{PostId: "post1", Username: "user1", Type: "TEXT", Title: "Old", CreatedAt: createdAt}, nil)
+	index.EXPECT().IndexPosts([]*model.SearchPost{
+		{PostId: "post1", Username: "user1", Type: "IMAGE", Title: "New", Description: "Description", CreatedAt: createdAt},
+	}).Return(nil)
+
+	searchService.UpdatePost("post1", "IMAGE", "New", "Description")
+}
+
+func TestUpdatePostNotIndexedWithService(t *testing.T) {
+	setUpService(t)
+	index.EXPECT().GetPost("post1").Return(nil, nil)
+
+	searchService.UpdatePost("post1", "IMAGE", "New", "Description")
+
+	assert.Contains(t, loggerOutput.String(), "Post post1 isn't indexed, its update is ignored")
+}
+
+func TestRemoveUserWithService(t *testing.T) {
+	setUpService(t)
+	index.EXPECT().RemoveUser("user1").Return(nil)
+	index.EXPECT().RemoveUserPosts("user1").Return(nil)
+
+	searchService.RemoveUser("user1")
+}
+
+func TestErrorOnSearchUsersWithService(t *testing.T) {
+	setUpService(t)
+	index.EXPECT().SearchUsers("ali", 0, 12).Return(nil, 0, errors.New("some error"))
+
+	_, _, err := searchService.SearchUsers("ali", 0, 12)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, loggerOutput.String(), "Error searching users matching ali")
+}
+
+func TestRebuildWithService(t *testing.T) {
+	setUpService(t)
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	posts := make([]*database.PostMetadata, 501)
+	for i := range posts {
+		posts[i] = &database.PostMetadata{PostId: "post1", Username: "user1", Type: "TEXT", Title: "Title", Description: "Description", CreatedAt: createdAt, Likes: 3}
+	}
+	userProfiles := []*model.UserProfile{{Username: "user1", Name: "User One", Bio: "Bio"}}
+	repository.EXPECT().GetPosts().Return(posts, nil)
+	repository.EXPECT().GetUserProfiles().Return(userProfiles, nil)
+	gomock.InOrder(
+		index.EXPECT().Reset().Return(nil),
+		index.EXPECT().IndexPosts(gomock.Len(500)).Return(nil),
+		index.EXPECT().IndexPosts([]*model.SearchPost{
+			{PostId: "post1", Username: "user1", Type: "TEXT", Title: "Title", Description: "Description", CreatedAt: createdAt},
+		}).Return(nil),
+		index.EXPECT().IndexUsers([]*model.SearchUser{{Username: "user1", Name: "User One"}}).Return(nil),
+	)
+
+	indexedPosts, indexedUsers, err := searchService.Rebuild()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 501, indexedPosts)
+	assert.Equal(t, 1, indexedUsers)
+}
+
+func TestRebuildKeepsTheIndexWhenTheDatabaseFailsWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPosts().Return(nil, errors.New("some error"))
+
+	_, _, err := searchService.Rebuild()
+
+	assert.NotNil(t, err)
+}
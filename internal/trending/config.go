@@ -0,0 +1,75 @@
+package trending
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Engagement types, named after the PostMetadata counters they are
+// recomputed from.
+const (
+	Likes      = "Likes"
+	Superlikes = "Superlikes"
+	Comments   = "Comments"
+	Reviews    = "Reviews"
+)
+
+const (
+	defaultHalfLife          = 24 * time.Hour
+	defaultRecomputeInterval = 15 * time.Minute
+)
+
+// Config weights every engagement type. HalfLife is the age at which the
+// engagement of a post counts half, and RecomputeInterval how often every
+// score is rebuilt from the post counters.
+type Config struct {
+	Weights           map[string]float64
+	HalfLife          time.Duration
+	RecomputeInterval time.Duration
+}
+
+func NewConfig() *Config {
+	return &Config{
+		Weights: map[string]float64{
+			Likes:      1,
+			Superlikes: 3,
+			Comments:   2,
+			Reviews:    2,
+		},
+		HalfLife:          defaultHalfLife,
+		RecomputeInterval: defaultRecomputeInterval,
+	}
+}
+
+func (c *Config) Validate() error {
+	errs := []error{}
+	for engagementType, weight := range c.Weights {
+		if _, ok := NewConfig().Weights[engagementType]; !ok {
+			errs = append(errs, fmt.Errorf("unknown engagement type %s", engagementType))
+		}
+		if weight < 0 {
+			errs = append(errs, fmt.Errorf("weight of %s can't be negative", engagementType))
+		}
+	}
+	if c.HalfLife <= 0 {
+		errs = append(errs, errors.New("half-life must be positive"))
+	}
+	if c.RecomputeInterval <= 0 {
+		errs = append(errs, errors.New("recompute interval must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// rankingScore orders the posts like their decayed score at any instant,
+// as log2(engagement * 2^(-age/halfLife)) only differs between posts by
+// log2(engagement) + createdAt/halfLife. Unlike the decayed score, it doesn't
+// change with time, so it can be stored and indexed.
+func (c *Config) rankingScore(engagement float64, createdAt time.Time) float64 {
+	return math.Log2(engagement) + float64(createdAt.UnixMilli())/float64(c.HalfLife.Milliseconds())
+}
+
+func (c *Config) decayedScore(engagement float64, createdAt time.Time, now time.Time) float64 {
+	return engagement * math.Exp2(-float64(now.Sub(createdAt))/float64(c.HalfLife))
+}
@@ -0,0 +1,72 @@
+package trending
+
+import (
+	"readmodels/internal/api"
+	"readmodels/internal/model"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=controller.go -destination=test/mock/controller.go
+
+type ControllerService interface {
+	GetTrendingPosts(postType string, lastPostId, lastScore string, limit int) ([]*model.TrendingPost, string, string, error)
+}
+
+type TrendingController struct {
+	service ControllerService
+}
+
+type GetTrendingPostsResponse struct {
+	Posts      []*model.TrendingPost `json:"posts"`
+	Limit      int                   `json:"limit"`
+	LastPostId string                `json:"lastPostId"`
+	LastScore  string                `json:"lastScore"`
+}
+
+func NewTrendingController(service ControllerService) *TrendingController {
+	return &TrendingController{
+		service: service,
+	}
+}
+
+func (controller *TrendingController) Routes(routerGroup *gin.RouterGroup) {
+	routerGroup.GET("/posts/trending", controller.GetTrendingPosts)
+}
+
+func (controller *TrendingController) GetTrendingPosts(c *gin.Context) {
+	log.Info().Msg("Handling Request GET Trending Posts")
+	postType := c.DefaultQuery("type", "")
+	lastPostId := c.DefaultQuery("lastPostId", "")
+	lastScore := c.DefaultQuery("lastScore", "")
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "12"))
+
+	if err != nil || limit <= 0 {
+		api.SendBadRequest(c, "Invalid pagination parameters, limit has to be greater than 0")
+		return
+	}
+
+	if (lastPostId != "" && lastScore == "") || (lastPostId == "" && lastScore != "") {
+		api.SendBadRequest(c, "Invalid pagination parameters, lastPostId and lastScore both have to have value or both have to be empty")
+		return
+	}
+	if _, err := strconv.ParseFloat(lastScore, 64); lastScore != "" && err != nil {
+		api.SendBadRequest(c, "Invalid pagination parameters, lastScore has to be a number")
+		return
+	}
+
+	posts, lastPostId, lastScore, err := controller.service.GetTrendingPosts(postType, lastPostId, lastScore, limit)
+	if err != nil {
+		api.SendInternalServerError(c, err.Error())
+		return
+	}
+
+	api.SendOKWithResult(c, &GetTrendingPostsResponse{
+		Posts:      posts,
+		Limit:      limit,
+		LastPostId: lastPostId,
+		LastScore:  lastScore,
+	})
+}
@@ -0,0 +1,38 @@
+package trending_handler
+
+import (
+	comment_handler "readmodels/internal/comment/handler"
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/trending"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=comment_was_created_event_handler.go -destination=test/mock/comment_was_created_event_handler.go
+
+type CommentWasCreatedEventService interface {
+	AddEngagement(postId string, engagementType string, delta int)
+}
+
+type CommentWasCreatedEventHandler struct {
+	service CommentWasCreatedEventService
+}
+
+func NewCommentWasCreatedEventHandler(service CommentWasCreatedEventService) *CommentWasCreatedEventHandler {
+	return &CommentWasCreatedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *CommentWasCreatedEventHandler) Handle(event []byte) {
+	var commentWasCreatedEvent comment_handler.CommentWasCreatedEvent
+	log.Info().Msg("Handling CommentWasCreatedEvent for the trending posts")
+
+	err := common_data.DeserializeData(event, &commentWasCreatedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.AddEngagement(commentWasCreatedEvent.PostId, trending.Comments, 1)
+}
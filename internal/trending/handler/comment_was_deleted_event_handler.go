@@ -0,0 +1,38 @@
+package trending_handler
+
+import (
+	comment_handler "readmodels/internal/comment/handler"
+	common_data "readmodels/internal/common/data"
+	"readmodels/internal/trending"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=comment_was_deleted_event_handler.go -destination=test/mock/comment_was_deleted_event_handler.go
+
+type CommentWasDeletedEventService interface {
+	AddEngagement(postId string, engagementType string, delta int)
+}
+
+type CommentWasDeletedEventHandler struct {
+	service CommentWasDeletedEventService
+}
+
+func NewCommentWasDeletedEventHandler(service CommentWasDeletedEventService) *CommentWasDeletedEventHandler {
+	return &CommentWasDeletedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *CommentWasDeletedEventHandler) Handle(event []byte) {
+	var commentWasDeletedEvent comment_handler.CommentWasDeletedEvent
+	log.Info().Msg("Handling CommentWasDeletedEvent for the trending posts")
+
+	err := common_data.DeserializeData(event, &commentWasDeletedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.AddEngagement(commentWasDeletedEvent.PostId, trending.Comments, -1)
+}
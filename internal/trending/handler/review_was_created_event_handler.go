@@ -0,0 +1,38 @@
+package trending_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	reaction_handler "readmodels/internal/reaction/handler"
+	"readmodels/internal/trending"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=review_was_created_event_handler.go -destination=test/mock/review_was_created_event_handler.go
+
+type ReviewWasCreatedEventService interface {
+	AddEngagement(postId string, engagementType string, delta int)
+}
+
+type ReviewWasCreatedEventHandler struct {
+	service ReviewWasCreatedEventService
+}
+
+func NewReviewWasCreatedEventHandler(service ReviewWasCreatedEventService) *ReviewWasCreatedEventHandler {
+	return &ReviewWasCreatedEventHandler{
+		service: service,
+	}
+}
+
+func (handler *ReviewWasCreatedEventHandler) Handle(event []byte) {
+	var reviewWasCreatedEvent reaction_handler.ReviewWasCreatedEvent
+	log.Info().Msg("Handling ReviewWasCreatedEvent for the trending posts")
+
+	err := common_data.DeserializeData(event, &reviewWasCreatedEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.AddEngagement(reviewWasCreatedEvent.PostId, trending.Reviews, 1)
+}
@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment_was_deleted_event_handler.go
+
+// Package mock_trending_handler is a generated GoMock package.
+package mock_trending_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCommentWasDeletedEventService is a mock of CommentWasDeletedEventService interface.
+type MockCommentWasDeletedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentWasDeletedEventServiceMockRecorder
+}
+
+// MockCommentWasDeletedEventServiceMockRecorder is the mock recorder for MockCommentWasDeletedEventService.
+type MockCommentWasDeletedEventServiceMockRecorder struct {
+	mock *MockCommentWasDeletedEventService
+}
+
+// NewMockCommentWasDeletedEventService creates a new mock instance.
+func NewMockCommentWasDeletedEventService(ctrl *gomock.Controller) *MockCommentWasDeletedEventService {
+	mock := &MockCommentWasDeletedEventService{ctrl: ctrl}
+	mock.recorder = &MockCommentWasDeletedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentWasDeletedEventService) EXPECT() *MockCommentWasDeletedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// AddEngagement mocks base method.
+func (m *MockCommentWasDeletedEventService) AddEngagement(postId, engagementType string, delta int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddEngagement", postId, engagementType, delta)
+}
+
+// AddEngagement indicates an expected call of AddEngagement.
+func (mr *MockCommentWasDeletedEventServiceMockRecorder) AddEngagement(postId, engagementType, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEngagement", reflect.TypeOf((*MockCommentWasDeletedEventService)(nil).AddEngagement), postId, engagementType, delta)
+}
@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: posts_were_deleted_event_handler.go
+
+// Package mock_trending_handler is a generated GoMock package.
+package mock_trending_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPostsWereDeletedEventService is a mock of PostsWereDeletedEventService interface.
+type MockPostsWereDeletedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostsWereDeletedEventServiceMockRecorder
+}
+
+// MockPostsWereDeletedEventServiceMockRecorder is the mock recorder for MockPostsWereDeletedEventService.
+type MockPostsWereDeletedEventServiceMockRecorder struct {
+	mock *MockPostsWereDeletedEventService
+}
+
+// NewMockPostsWereDeletedEventService creates a new mock instance.
+func NewMockPostsWereDeletedEventService(ctrl *gomock.Controller) *MockPostsWereDeletedEventService {
+	mock := &MockPostsWereDeletedEventService{ctrl: ctrl}
+	mock.recorder = &MockPostsWereDeletedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostsWereDeletedEventService) EXPECT() *MockPostsWereDeletedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// RemovePosts mocks base method.
+func (m *MockPostsWereDeletedEventService) RemovePosts(postIds []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemovePosts", postIds)
+}
+
+// RemovePosts indicates an expected call of RemovePosts.
+func (mr *MockPostsWereDeletedEventServiceMockRecorder) RemovePosts(postIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePosts", reflect.TypeOf((*MockPostsWereDeletedEventService)(nil).RemovePosts), postIds)
+}
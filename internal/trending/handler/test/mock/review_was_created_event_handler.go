@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: review_was_created_event_handler.go
+
+// Package mock_trending_handler is a generated GoMock package.
+package mock_trending_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReviewWasCreatedEventService is a mock of ReviewWasCreatedEventService interface.
+type MockReviewWasCreatedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewWasCreatedEventServiceMockRecorder
+}
+
+// MockReviewWasCreatedEventServiceMockRecorder is the mock recorder for MockReviewWasCreatedEventService.
+type MockReviewWasCreatedEventServiceMockRecorder struct {
+	mock *MockReviewWasCreatedEventService
+}
+
+// NewMockReviewWasCreatedEventService creates a new mock instance.
+func NewMockReviewWasCreatedEventService(ctrl *gomock.Controller) *MockReviewWasCreatedEventService {
+	mock := &MockReviewWasCreatedEventService{ctrl: ctrl}
+	mock.recorder = &MockReviewWasCreatedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewWasCreatedEventService) EXPECT() *MockReviewWasCreatedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// AddEngagement mocks base method.
+func (m *MockReviewWasCreatedEventService) AddEngagement(postId, engagementType string, delta int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddEngagement", postId, engagementType, delta)
+}
+
+// AddEngagement indicates an expected call of AddEngagement.
+func (mr *MockReviewWasCreatedEventServiceMockRecorder) AddEngagement(postId, engagementType, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEngagement", reflect.TypeOf((*MockReviewWasCreatedEventService)(nil).AddEngagement), postId, engagementType, delta)
+}
@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_liked_post_event_handler.go
+
+// Package mock_trending_handler is a generated GoMock package.
+package mock_trending_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserLikedPostEventService is a mock of UserLikedPostEventService interface.
+type MockUserLikedPostEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserLikedPostEventServiceMockRecorder
+}
+
+// MockUserLikedPostEventServiceMockRecorder is the mock recorder for MockUserLikedPostEventService.
+type MockUserLikedPostEventServiceMockRecorder struct {
+	mock *MockUserLikedPostEventService
+}
+
+// NewMockUserLikedPostEventService creates a new mock instance.
+func NewMockUserLikedPostEventService(ctrl *gomock.Controller) *MockUserLikedPostEventService {
+	mock := &MockUserLikedPostEventService{ctrl: ctrl}
+	mock.recorder = &MockUserLikedPostEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserLikedPostEventService) EXPECT() *MockUserLikedPostEventServiceMockRecorder {
+	return m.recorder
+}
+
+// AddEngagement mocks base method.
+func (m *MockUserLikedPostEventService) AddEngagement(postId, engagementType string, delta int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddEngagement", postId, engagementType, delta)
+}
+
+// AddEngagement indicates an expected call of AddEngagement.
+func (mr *MockUserLikedPostEventServiceMockRecorder) AddEngagement(postId, engagementType, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEngagement", reflect.TypeOf((*MockUserLikedPostEventService)(nil).AddEngagement), postId, engagementType, delta)
+}
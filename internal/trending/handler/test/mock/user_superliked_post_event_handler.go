@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_superliked_post_event_handler.go
+
+// Package mock_trending_handler is a generated GoMock package.
+package mock_trending_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserSuperlikedPostEventService is a mock of UserSuperlikedPostEventService interface.
+type MockUserSuperlikedPostEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSuperlikedPostEventServiceMockRecorder
+}
+
+// MockUserSuperlikedPostEventServiceMockRecorder is the mock recorder for MockUserSuperlikedPostEventService.
+type MockUserSuperlikedPostEventServiceMockRecorder struct {
+	mock *MockUserSuperlikedPostEventService
+}
+
+// NewMockUserSuperlikedPostEventService creates a new mock instance.
+func NewMockUserSuperlikedPostEventService(ctrl *gomock.Controller) *MockUserSuperlikedPostEventService {
+	mock := &MockUserSuperlikedPostEventService{ctrl: ctrl}
+	mock.recorder = &MockUserSuperlikedPostEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSuperlikedPostEventService) EXPECT() *MockUserSuperlikedPostEventServiceMockRecorder {
+	return m.recorder
+}
+
+// AddEngagement mocks base method.
+func (m *MockUserSuperlikedPostEventService) AddEngagement(postId, engagementType string, delta int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddEngagement", postId, engagementType, delta)
+}
+
+// AddEngagement indicates an expected call of AddEngagement.
+func (mr *MockUserSuperlikedPostEventServiceMockRecorder) AddEngagement(postId, engagementType, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEngagement", reflect.TypeOf((*MockUserSuperlikedPostEventService)(nil).AddEngagement), postId, engagementType, delta)
+}
@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_unliked_post_event_handler.go
+
+// Package mock_trending_handler is a generated GoMock package.
+package mock_trending_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserUnlikedPostEventService is a mock of UserUnlikedPostEventService interface.
+type MockUserUnlikedPostEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserUnlikedPostEventServiceMockRecorder
+}
+
+// MockUserUnlikedPostEventServiceMockRecorder is the mock recorder for MockUserUnlikedPostEventService.
+type MockUserUnlikedPostEventServiceMockRecorder struct {
+	mock *MockUserUnlikedPostEventService
+}
+
+// NewMockUserUnlikedPostEventService creates a new mock instance.
+func NewMockUserUnlikedPostEventService(ctrl *gomock.Controller) *MockUserUnlikedPostEventService {
+	mock := &MockUserUnlikedPostEventService{ctrl: ctrl}
+	mock.recorder = &MockUserUnlikedPostEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserUnlikedPostEventService) EXPECT() *MockUserUnlikedPostEventServiceMockRecorder {
+	return m.recorder
+}
+
+// AddEngagement mocks base method.
+func (m *MockUserUnlikedPostEventService) AddEngagement(postId, engagementType string, delta int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddEngagement", postId, engagementType, delta)
+}
+
+// AddEngagement indicates an expected call of AddEngagement.
+func (mr *MockUserUnlikedPostEventServiceMockRecorder) AddEngagement(postId, engagementType, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEngagement", reflect.TypeOf((*MockUserUnlikedPostEventService)(nil).AddEngagement), postId, engagementType, delta)
+}
@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_unsuperliked_post_event_handler.go
+
+// Package mock_trending_handler is a generated GoMock package.
+package mock_trending_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserUnsuperlikedPostEventService is a mock of UserUnsuperlikedPostEventService interface.
+type MockUserUnsuperlikedPostEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserUnsuperlikedPostEventServiceMockRecorder
+}
+
+// MockUserUnsuperlikedPostEventServiceMockRecorder is the mock recorder for MockUserUnsuperlikedPostEventService.
+type MockUserUnsuperlikedPostEventServiceMockRecorder struct {
+	mock *MockUserUnsuperlikedPostEventService
+}
+
+// NewMockUserUnsuperlikedPostEventService creates a new mock instance.
+func NewMockUserUnsuperlikedPostEventService(ctrl *gomock.Controller) *MockUserUnsuperlikedPostEventService {
+	mock := &MockUserUnsuperlikedPostEventService{ctrl: ctrl}
+	mock.recorder = &MockUserUnsuperlikedPostEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserUnsuperlikedPostEventService) EXPECT() *MockUserUnsuperlikedPostEventServiceMockRecorder {
+	return m.recorder
+}
+
+// AddEngagement mocks base method.
+func (m *MockUserUnsuperlikedPostEventService) AddEngagement(postId, engagementType string, delta int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddEngagement", postId, engagementType, delta)
+}
+
+// AddEngagement indicates an expected call of AddEngagement.
+func (mr *MockUserUnsuperlikedPostEventServiceMockRecorder) AddEngagement(postId, engagementType, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEngagement", reflect.TypeOf((*MockUserUnsuperlikedPostEventService)(nil).AddEngagement), postId, engagementType, delta)
+}
@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_was_deleted_event_handler.go
+
+// Package mock_trending_handler is a generated GoMock package.
+package mock_trending_handler
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserWasDeletedEventService is a mock of UserWasDeletedEventService interface.
+type MockUserWasDeletedEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserWasDeletedEventServiceMockRecorder
+}
+
+// MockUserWasDeletedEventServiceMockRecorder is the mock recorder for MockUserWasDeletedEventService.
+type MockUserWasDeletedEventServiceMockRecorder struct {
+	mock *MockUserWasDeletedEventService
+}
+
+// NewMockUserWasDeletedEventService creates a new mock instance.
+func NewMockUserWasDeletedEventService(ctrl *gomock.Controller) *MockUserWasDeletedEventService {
+	mock := &MockUserWasDeletedEventService{ctrl: ctrl}
+	mock.recorder = &MockUserWasDeletedEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserWasDeletedEventService) EXPECT() *MockUserWasDeletedEventServiceMockRecorder {
+	return m.recorder
+}
+
+// RemoveUserPosts mocks base method.
+func (m *MockUserWasDeletedEventService) RemoveUserPosts(username string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveUserPosts", username)
+}
+
+// RemoveUserPosts indicates an expected call of RemoveUserPosts.
+func (mr *MockUserWasDeletedEventServiceMockRecorder) RemoveUserPosts(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserPosts", reflect.TypeOf((*MockUserWasDeletedEventService)(nil).RemoveUserPosts), username)
+}
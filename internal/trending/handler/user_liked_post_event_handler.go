@@ -0,0 +1,38 @@
+package trending_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	reaction_handler "readmodels/internal/reaction/handler"
+	"readmodels/internal/trending"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=user_liked_post_event_handler.go -destination=test/mock/user_liked_post_event_handler.go
+
+type UserLikedPostEventService interface {
+	AddEngagement(postId string, engagementType string, delta int)
+}
+
+type UserLikedPostEventHandler struct {
+	service UserLikedPostEventService
+}
+
+func NewUserLikedPostEventHandler(service UserLikedPostEventService) *UserLikedPostEventHandler {
+	return &UserLikedPostEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserLikedPostEventHandler) Handle(event []byte) {
+	var userLikedPostEvent reaction_handler.UserLikedPostEvent
+	log.Info().Msg("Handling UserLikedPostEvent for the trending posts")
+
+	err := common_data.DeserializeData(event, &userLikedPostEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.AddEngagement(userLikedPostEvent.PostId, trending.Likes, 1)
+}
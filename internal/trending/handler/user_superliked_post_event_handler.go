@@ -0,0 +1,38 @@
+package trending_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	reaction_handler "readmodels/internal/reaction/handler"
+	"readmodels/internal/trending"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=user_superliked_post_event_handler.go -destination=test/mock/user_superliked_post_event_handler.go
+
+type UserSuperlikedPostEventService interface {
+	AddEngagement(postId string, engagementType string, delta int)
+}
+
+type UserSuperlikedPostEventHandler struct {
+	service UserSuperlikedPostEventService
+}
+
+func NewUserSuperlikedPostEventHandler(service UserSuperlikedPostEventService) *UserSuperlikedPostEventHandler {
+	return &UserSuperlikedPostEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserSuperlikedPostEventHandler) Handle(event []byte) {
+	var userSuperlikedPostEvent reaction_handler.UserSuperlikedPostEvent
+	log.Info().Msg("Handling UserSuperlikedPostEvent for the trending posts")
+
+	err := common_data.DeserializeData(event, &userSuperlikedPostEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.AddEngagement(userSuperlikedPostEvent.PostId, trending.Superlikes, 1)
+}
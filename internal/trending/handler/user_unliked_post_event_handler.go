@@ -0,0 +1,38 @@
+package trending_handler
+
+import (
+	common_data "readmodels/internal/common/data"
+	reaction_handler "readmodels/internal/reaction/handler"
+	"readmodels/internal/trending"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=user_unliked_post_event_handler.go -destination=test/mock/user_unliked_post_event_handler.go
+
+type UserUnlikedPostEventService interface {
+	AddEngagement(postId string, engagementType string, delta int)
+}
+
+type UserUnlikedPostEventHandler struct {
+	service UserUnlikedPostEventService
+}
+
+func NewUserUnlikedPostEventHandler(service UserUnlikedPostEventService) *UserUnlikedPostEventHandler {
+	return &UserUnlikedPostEventHandler{
+		service: service,
+	}
+}
+
+func (handler *UserUnlikedPostEventHandler) Handle(event []byte) {
+	var userUnlikedPostEvent reaction_handler.UserUnlikedPostEvent
+	log.Info().Msg("Handling UserUnlikedPostEvent for the trending posts")
+
+	err := common_data.DeserializeData(event, &userUnlikedPostEvent)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Invalid event data")
+		return
+	}
+
+	handler.service.AddEngagement(userUnlikedPostEvent.PostId, trending.Likes, -1)
+}
@@ -0,0 +1,94 @@
+package trending
+
+import (
+	"errors"
+	database "readmodels/internal/db"
+	"time"
+)
+
+type TrendingRepository struct {
+	database *database.Database
+}
+
+func NewTrendingRepository(database *database.Database) *TrendingRepository {
+	return &TrendingRepository{
+		database: database,
+	}
+}
+
+// GetPost returns the post, or nil when it isn't projected.
+func (r *TrendingRepository) GetPost(postId string) (*database.PostMetadata, error) {
+	var post database.PostMetadata
+	err := r.database.Client.GetData(database.PostMetadataTable, &database.PostMetadataKey{PostId: postId}, &post)
+	var notFoundError *database.NotFoundError
+	if errors.As(err, &notFoundError) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+func (r *TrendingRepository) GetPosts() ([]*database.PostMetadata, error) {
+	posts := []*database.PostMetadata{}
+	err := r.database.Client.GetAllData(database.PostMetadataTable, &posts)
+	return posts, err
+}
+
+// AddEngagement adds the weighted engagement to the post and returns its
+// total engagement.
+func (r *TrendingRepository) AddEngagement(post *database.PostMetadata, weight float64) (float64, error) {
+	return r.database.Client.IncrementAndGet(database.TrendingTable, &database.TrendingKey{PostId: post.PostId}, "Engagement", weight, map[string]any{
+		"Username":  post.Username,
+		"PostType":  post.Type,
+		"Ranking":   database.TrendingRanking,
+		"CreatedAt": post.CreatedAt,
+	})
+}
+
+// UpdateScore stores the score computed from engagement, or removes it when
+// score is nil. It returns false when the engagement of the post changed
+// meanwhile, in which case the later change stores its own score.
+func (r *TrendingRepository) UpdateScore(postId string, engagement float64, score *float64) (bool, error) {
+	key := &database.TrendingKey{PostId: postId}
+	if score == nil {
+		return r.database.Client.UpdateDataIfEqual(database.TrendingTable, key, map[string]any{}, []string{"Score"}, "Engagement", engagement)
+	}
+	return r.database.Client.UpdateDataIfEqual(database.TrendingTable, key, map[string]any{"Score": *score}, []string{}, "Engagement", engagement)
+}
+
+func (r *TrendingRepository) GetTrendingRecords() ([]*database.TrendingRecord, error) {
+	records := []*database.TrendingRecord{}
+	err := r.database.Client.GetAllData(database.TrendingTable, &records)
+	return records, err
+}
+
+func (r *TrendingRepository) SaveTrendingRecord(record *database.TrendingRecord) error {
+	return r.database.Client.InsertData(database.TrendingTable, record)
+}
+
+func (r *TrendingRepository) RemoveTrendingRecords(postIds []string) error {
+	keys := make([]any, len(postIds))
+	for i, postId := range postIds {
+		keys[i] = &database.TrendingKey{PostId: postId}
+	}
+
+	return r.database.Client.RemoveMultipleData(database.TrendingTable, keys)
+}
+
+// GetUserTrendingRecords returns the trending records of the posts of the user.
+func (r *TrendingRepository) GetUserTrendingRecords(username string) ([]*database.TrendingRecord, error) {
+	records := []*database.TrendingRecord{}
+	err := r.database.Client.GetAllDataByIndex(database.TrendingTable, database.TrendingUserIndex, "Username", username, &records)
+	return records, err
+}
+
+func (r *TrendingRepository) GetTrendingRecordsByScore(postType string, lastPostId, lastScore string, limit int) ([]*database.TrendingRecord, string, string, error) {
+	return r.database.Client.GetTrendingPostsByIndex(postType, lastPostId, lastScore, limit)
+}
+
+func (r *TrendingRepository) AcquireRecomputeLock(owner string, ttl time.Duration) (bool, error) {
+	return r.database.Client.AcquireLock(database.TrendingTable, &database.TrendingKey{PostId: recomputeLockId}, owner, ttl)
+}
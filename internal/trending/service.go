@@ -0,0 +1,226 @@
+package trending
+
+import (
+	"context"
+	"fmt"
+	"os"
+	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=service.go -destination=test/mock/service.go
+
+const (
+	recomputeLockId = "recompute-lock"
+	// Engagement below it is float rounding left by retractions
+	minEngagement = 1e-9
+)
+
+type Repository interface {
+	GetPost(postId string) (*database.PostMetadata, error)
+	GetPosts() ([]*database.PostMetadata, error)
+	AddEngagement(post *database.PostMetadata, weight float64) (float64, error)
+	UpdateScore(postId string, engagement float64, score *float64) (bool, error)
+	GetTrendingRecords() ([]*database.TrendingRecord, error)
+	SaveTrendingRecord(record *database.TrendingRecord) error
+	RemoveTrendingRecords(postIds []string) error
+	GetUserTrendingRecords(username string) ([]*database.TrendingRecord, error)
+	GetTrendingRecordsByScore(postType string, lastPostId, lastScore string, limit int) ([]*database.TrendingRecord, string, string, error)
+	AcquireRecomputeLock(owner string, ttl time.Duration) (bool, error)
+}
+
+// TrendingService keeps the score of the posts up to date with every
+// reaction, comment and review. Events consumed twice count twice until the
+// next recompute, which rebuilds every score from the post counters.
+type TrendingService struct {
+	repository Repository
+	config     *Config
+	owner      string
+	now        func() time.Time
+}
+
+func NewTrendingService(repository Repository, config *Config) *TrendingService {
+	hostname, _ := os.Hostname()
+
+	return &TrendingService{
+		repository: repository,
+		config:     config,
+		owner:      fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		now:        time.Now,
+	}
+}
+
+// AddEngagement adds delta engagements of engagementType, negative when they
+// are undone, to the score of the post.
+func (s *TrendingService) AddEngagement(postId string, engagementType string, delta int) {
+	post, err := s.repository.GetPost(postId)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting post %s", postId)
+		return
+	}
+	if post == nil {
+		log.Warn().Msgf("Post %s doesn't exist, its %s are not scored", postId, engagementType)
+		return
+	}
+
+	engagement, err := s.repository.AddEngagement(post, s.config.Weights[engagementType]*float64(delta))
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error adding %s to the engagement of post %s", engagementType, postId)
+		return
+	}
+
+	updated, err := s.repository.UpdateScore(postId, engagement, s.rankingScore(engagement, post.CreatedAt))
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error updating the score of post %s", postId)
+		return
+	}
+	if !updated {
+		log.Debug().Msgf("Score of post %s was superseded by a later engagement", postId)
+		return
+	}
+
+	log.Info().Msgf("Engagement of post %s is now %g", postId, engagement)
+}
+
+func (s *TrendingService) RemovePosts(postIds []string) {
+	err := s.repository.RemoveTrendingRecords(postIds)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error removing %d posts from the trending posts", len(postIds))
+		return
+	}
+
+	log.Info().Msgf("%d posts were removed from the trending posts", len(postIds))
+}
+
+// RemoveUserPosts removes the posts of the deleted user from the trending
+// posts.
+func (s *TrendingService) RemoveUserPosts(username string) {
+	records, err := s.repository.GetUserTrendingRecords(username)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting the trending posts of %s", username)
+		return
+	}
+
+	postIds := make([]string, len(records))
+	for i, record := range records {
+		postIds[i] = record.PostId
+	}
+	s.RemovePosts(postIds)
+}
+
+// Recompute rebuilds the engagement and score of every post from its
+// counters, with the current weights and half-life. Only one instance
+// recomputes per interval.
+func (s *TrendingService) Recompute() error {
+	acquired, err := s.repository.AcquireRecomputeLock(s.owner, 2*s.config.RecomputeInterval)
+	if err != nil || !acquired {
+		return err
+	}
+
+	posts, err := s.repository.GetPosts()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error getting the posts to recompute their scores")
+		return err
+	}
+	records, err := s.repository.GetTrendingRecords()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error getting the trending posts to recompute")
+		return err
+	}
+
+	stale := map[string]bool{}
+	for _, record := range records {
+		if record.PostId != recomputeLockId {
+			stale[record.PostId] = true
+		}
+	}
+
+	scored := 0
+	for _, post := range posts {
+		engagement := s.config.Weights[Likes]*float64(post.Likes) +
+			s.config.Weights[Superlikes]*float64(post.Superlikes) +
+			s.config.Weights[Comments]*float64(post.Comments) +
+			s.config.Weights[Reviews]*float64(post.Reviews)
+		if engagement < minEngagement {
+			continue
+		}
+
+		err = s.repository.SaveTrendingRecord(&database.TrendingRecord{
+			PostId:     post.PostId,
+			Username:   post.Username,
+			PostType:   post.Type,
+			Ranking:    database.TrendingRanking,
+			CreatedAt:  post.CreatedAt,
+			Engagement: engagement,
+			Score:      s.rankingScore(engagement, post.CreatedAt),
+		})
+		if err != nil {
+			log.Error().Stack().Err(err).Msgf("Error saving the recomputed score of post %s", post.PostId)
+			return err
+		}
+		delete(stale, post.PostId)
+		scored++
+	}
+
+	staleIds := []string{}
+	for postId := range stale {
+		staleIds = append(staleIds, postId)
+	}
+	err = s.repository.RemoveTrendingRecords(staleIds)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error removing %d posts without engagement from the trending posts", len(staleIds))
+		return err
+	}
+
+	log.Info().Msgf("Trending scores recomputed, %d posts scored and %d removed", scored, len(staleIds))
+	return nil
+}
+
+// Run recomputes the scores every RecomputeInterval until ctx is done.
+func (s *TrendingService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.RecomputeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.Recompute()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// GetTrendingPosts returns the posts with the highest score first, only those
+// of postType when it isn't empty.
+func (s *TrendingService) GetTrendingPosts(postType string, lastPostId, lastScore string, limit int) ([]*model.TrendingPost, string, string, error) {
+	records, lastPostId, lastScore, err := s.repository.GetTrendingRecordsByScore(postType, lastPostId, lastScore, limit)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error getting the trending posts")
+		return nil, "", "", err
+	}
+
+	now := s.now()
+	posts := make([]*model.TrendingPost, len(records))
+	for i, record := range records {
+		posts[i] = &model.TrendingPost{
+			PostId:    record.PostId,
+			Username:  record.Username,
+			Type:      record.PostType,
+			CreatedAt: record.CreatedAt,
+			Score:     s.config.decayedScore(record.Engagement, record.CreatedAt, now),
+		}
+	}
+	return posts, lastPostId, lastScore, nil
+}
+
+func (s *TrendingService) rankingScore(engagement float64, createdAt time.Time) *float64 {
+	if engagement < minEngagement {
+		return nil
+	}
+	score := s.config.rankingScore(engagement, createdAt)
+	return &score
+}
@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_trending is a generated GoMock package.
+package mock_trending
+
+import (
+	database "readmodels/internal/db"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcquireRecomputeLock mocks base method.
+func (m *MockRepository) AcquireRecomputeLock(owner string, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireRecomputeLock", owner, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireRecomputeLock indicates an expected call of AcquireRecomputeLock.
+func (mr *MockRepositoryMockRecorder) AcquireRecomputeLock(owner, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireRecomputeLock", reflect.TypeOf((*MockRepository)(nil).AcquireRecomputeLock), owner, ttl)
+}
+
+// AddEngagement mocks base method.
+func (m *MockRepository) AddEngagement(post *database.PostMetadata, weight float64) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEngagement", post, weight)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEngagement indicates an expected call of AddEngagement.
+func (mr *MockRepositoryMockRecorder) AddEngagement(post, weight interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEngagement", reflect.TypeOf((*MockRepository)(nil).AddEngagement), post, weight)
+}
+
+// GetPost mocks base method.
+func (m *MockRepository) GetPost(postId string) (*database.PostMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPost", postId)
+	ret0, _ := ret[0].(*database.PostMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPost indicates an expected call of GetPost.
+func (mr *MockRepositoryMockRecorder) GetPost(postId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPost", reflect.TypeOf((*MockRepository)(nil).GetPost), postId)
+}
+
+// GetPosts mocks base method.
+func (m *MockRepository) GetPosts() ([]*database.PostMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPosts")
+	ret0, _ := ret[0].([]*database.PostMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPosts indicates an expected call of GetPosts.
+func (mr *MockRepositoryMockRecorder) GetPosts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosts", reflect.TypeOf((*MockRepository)(nil).GetPosts))
+}
+
+// GetTrendingRecords mocks base method.
+func (m *MockRepository) GetTrendingRecords() ([]*database.TrendingRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrendingRecords")
+	ret0, _ := ret[0].([]*database.TrendingRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrendingRecords indicates an expected call of GetTrendingRecords.
+func (mr *MockRepositoryMockRecorder) GetTrendingRecords() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrendingRecords", reflect.TypeOf((*MockRepository)(nil).GetTrendingRecords))
+}
+
+// GetTrendingRecordsByScore mocks base method.
+func (m *MockRepository) GetTrendingRecordsByScore(postType, lastPostId, lastScore string, limit int) ([]*database.TrendingRecord, string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrendingRecordsByScore", postType, lastPostId, lastScore, limit)
+	ret0, _ := ret[0].([]*database.TrendingRecord)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetTrendingRecordsByScore indicates an expected call of GetTrendingRecordsByScore.
+func (mr *MockRepositoryMockRecorder) GetTrendingRecordsByScore(postType, lastPostId, lastScore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrendingRecordsByScore", reflect.TypeOf((*MockRepository)(nil).GetTrendingRecordsByScore), postType, lastPostId, lastScore, limit)
+}
+
+// GetUserTrendingRecords mocks base method.
+func (m *MockRepository) GetUserTrendingRecords(username string) ([]*database.TrendingRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTrendingRecords", username)
+	ret0, _ := ret[0].([]*database.TrendingRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTrendingRecords indicates an expected call of GetUserTrendingRecords.
+func (mr *MockRepositoryMockRecorder) GetUserTrendingRecords(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTrendingRecords", reflect.TypeOf((*MockRepository)(nil).GetUserTrendingRecords), username)
+}
+
+// RemoveTrendingRecords mocks base method.
+func (m *MockRepository) RemoveTrendingRecords(postIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTrendingRecords", postIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTrendingRecords indicates an expected call of RemoveTrendingRecords.
+func (mr *MockRepositoryMockRecorder) RemoveTrendingRecords(postIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrendingRecords", reflect.TypeOf((*MockRepository)(nil).RemoveTrendingRecords), postIds)
+}
+
+// SaveTrendingRecord mocks base method.
+func (m *MockRepository) SaveTrendingRecord(record *database.TrendingRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTrendingRecord", record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTrendingRecord indicates an expected call of SaveTrendingRecord.
+func (mr *MockRepositoryMockRecorder) SaveTrendingRecord(record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTrendingRecord", reflect.TypeOf((*MockRepository)(nil).SaveTrendingRecord), record)
+}
+
+// UpdateScore mocks base method.
+func (m *MockRepository) UpdateScore(postId string, engagement float64, score *float64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScore", postId, engagement, score)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScore indicates an expected call of UpdateScore.
+func (mr *MockRepositoryMockRecorder) UpdateScore(postId, engagement, score interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScore", reflect.TypeOf((*MockRepository)(nil).UpdateScore), postId, engagement, score)
+}
@@ -0,0 +1,102 @@
+package trending_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"readmodels/internal/model"
+	"readmodels/internal/trending"
+	mock_trending "readmodels/internal/trending/test/mock"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+var controller *trending.TrendingController
+var controllerService *mock_trending.MockControllerService
+
+func setUpController(t *testing.T) {
+	SetUp(t)
+	controllerService = mock_trending.NewMockControllerService(ctrl)
+	controller = trending.NewTrendingController(controllerService)
+}
+
+func TestGetTrendingPostsWithController_WhenSuccess(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/posts/trending", nil)
+	u := url.Values{}
+	u.Add("type", "TEXT")
+	u.Add("lastPostId", "post9")
+	u.Add("lastScore", "20000.5")
+	u.Add("limit", "4")
+	ginContext.Request.URL.RawQuery = u.Encode()
+	expectedPosts := []*model.TrendingPost{
+		{
+			PostId:    "post1",
+			Username:  "user1",
+			Type:      "TEXT",
+			CreatedAt: time.Date(2024, 4, 30, 9, 0, 0, 123456000, time.UTC),
+			Score:     2.5,
+		},
+	}
+	controllerService.EXPECT().GetTrendingPosts("TEXT", "post9", "20000.5", 4).Return(expectedPosts, "", "", nil)
+	expectedBodyResponse := `{
+		"error": false,
+		"message": "200 OK",
+		"content": {
+			"posts": [
+				{
+					"postId": "post1",
+					"username": "user1",
+					"type": "TEXT",
+					"createdAt": "2024-04-30T09:00:00.123456Z",
+					"score": 2.5
+				}
+			],
+			"limit": 4,
+			"lastPostId": "",
+			"lastScore": ""
+		}
+	}`
+
+	controller.GetTrendingPosts(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 200)
+	assert.Equal(t, removeSpace(apiResponse.Body.String()), removeSpace(expectedBodyResponse))
+}
+
+func TestBadRequestOnGetTrendingPostsWithController_WhenLastScoreIsNotANumber(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/posts/trending", nil)
+	u := url.Values{}
+	u.Add("lastPostId", "post9")
+	u.Add("lastScore", "high")
+	ginContext.Request.URL.RawQuery = u.Encode()
+
+	controller.GetTrendingPosts(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 400)
+}
+
+func TestBadRequestOnGetTrendingPostsWithController_WhenOnlyOneCursorIsSet(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/posts/trending", nil)
+	u := url.Values{}
+	u.Add("lastPostId", "post9")
+	ginContext.Request.URL.RawQuery = u.Encode()
+
+	controller.GetTrendingPosts(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 400)
+}
+
+func TestInternalServerErrorOnGetTrendingPostsWithController(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/posts/trending", nil)
+	controllerService.EXPECT().GetTrendingPosts("", "", "", 12).Return(nil, "", "", errors.New("some error"))
+
+	controller.GetTrendingPosts(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 500)
+}
@@ -0,0 +1,82 @@
+package trending_test
+
+import (
+	database "readmodels/internal/db"
+	"readmodels/internal/trending"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var trendingRepository *trending.TrendingRepository
+
+func setUpRepository(t *testing.T) {
+	SetUp(t)
+	trendingRepository = trending.NewTrendingRepository(database.NewDatabase(client))
+}
+
+func TestAddEngagementInRepository(t *testing.T) {
+	setUpRepository(t)
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	post := &database.PostMetadata{PostId: "post1", Username: "user1", Type: "TEXT", CreatedAt: createdAt}
+	expectedKey := &database.TrendingKey{PostId: "post1"}
+	client.EXPECT().IncrementAndGet("readmodels.trending", expectedKey, "Engagement", float64(3), map[string]any{
+		"Username":  "user1",
+		"PostType":  "TEXT",
+		"Ranking":   "global",
+		"CreatedAt": createdAt,
+	}).Return(float64(7), nil)
+
+	engagement, err := trendingRepository.AddEngagement(post, 3)
+
+	assert.Nil(t, err)
+	assert.Equal(t, float64(7), engagement)
+}
+
+func TestUpdateScoreInRepository(t *testing.T) {
+	setUpRepository(t)
+	score := 20000.5
+	expectedKey := &database.TrendingKey{PostId: "post1"}
+	client.EXPECT().UpdateDataIfEqual("readmodels.trending", expectedKey, map[string]any{"Score": score}, []string{}, "Engagement", float64(7)).Return(true, nil)
+
+	updated, err := trendingRepository.UpdateScore("post1", 7, &score)
+
+	assert.Nil(t, err)
+	assert.True(t, updated)
+}
+
+func TestRemoveScoreInRepository(t *testing.T) {
+	setUpRepository(t)
+	expectedKey := &database.TrendingKey{PostId: "post1"}
+	client.EXPECT().UpdateDataIfEqual("readmodels.trending", expectedKey, map[string]any{}, []string{"Score"}, "Engagement", float64(0)).Return(false, nil)
+
+	updated, err := trendingRepository.UpdateScore("post1", 0, nil)
+
+	assert.Nil(t, err)
+	assert.False(t, updated)
+}
+
+func TestGetMissingPostInRepository(t *testing.T) {
+	setUpRepository(t)
+	expectedKey := &database.PostMetadataKey{PostId: "post1"}
+	client.EXPECT().GetData("PostMetadata", expectedKey, &database.PostMetadata{}).Return(database.NewNotFoundError("PostMetadata", expectedKey))
+
+	post, err := trendingRepository.GetPost("post1")
+
+	assert.Nil(t, err)
+	assert.Nil(t, post)
+}
+
+func TestGetUserTrendingRecordsInRepository(t *testing.T) {
+	setUpRepository(t)
+	records := []*database.TrendingRecord{
+		{PostId: "post1", Username: "user1", PostType: "TEXT", Ranking: "global", Engagement: 3},
+	}
+	client.EXPECT().GetAllDataByIndex("readmodels.trending", "UserIndex", "Username", "user1", &[]*database.TrendingRecord{}).SetArg(4, records).Return(nil)
+
+	result, err := trendingRepository.GetUserTrendingRecords("user1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, records, result)
+}
@@ -0,0 +1,192 @@
+package trending_test
+
+import (
+	"errors"
+	"math"
+	database "readmodels/internal/db"
+	"readmodels/internal/trending"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var trendingService *trending.TrendingService
+var trendingConfig *trending.Config
+
+func setUpService(t *testing.T) {
+	SetUp(t)
+	trendingConfig = trending.NewConfig()
+	trendingService = trending.NewTrendingService(repository, trendingConfig)
+}
+
+func TestAddSuperlikeWithService(t *testing.T) {
+	setUpService(t)
+	post := &database.PostMetadata{PostId: "post1", Username: "user1", Type: "TEXT", CreatedAt: time.Unix(0, 0).Add(48 * time.Hour)}
+	repository.EXPECT().GetPost("post1").Return(post, nil)
+	repository.EXPECT().AddEngagement(post, float64(3)).Return(float64(4), nil)
+	repository.EXPECT().UpdateScore("post1", float64(4), gomock.Any()).DoAndReturn(func(postId string, engagement float64, score *float64) (bool, error) {
+		// log2(4) + 2 half-lives since the Unix epoch
+		assert.InDelta(t, 4.0, *score, 1e-9)
+		return true, nil
+	})
+
+	trendingService.AddEngagement("post1", trending.Superlikes, 1)
+
+	assert.Contains(t, loggerOutput.String(), "Engagement of post post1 is now 4")
+}
+
+func TestUnlikeLeavingNoEngagementRemovesTheScoreWithService(t *testing.T) {
+	setUpService(t)
+	post := &database.PostMetadata{PostId: "post1", CreatedAt: time.Now()}
+	repository.EXPECT().GetPost("post1").Return(post, nil)
+	repository.EXPECT().AddEngagement(post, float64(-1)).Return(float64(0), nil)
+	repository.EXPECT().UpdateScore("post1", float64(0), nil).Return(true, nil)
+
+	trendingService.AddEngagement("post1", trending.Likes, -1)
+}
+
+func TestSupersededScoreWithService(t *testing.T) {
+	setUpService(t)
+	post := &database.PostMetadata{PostId: "post1", CreatedAt: time.Now()}
+	repository.EXPECT().GetPost("post1").Return(post, nil)
+	repository.EXPECT().AddEngagement(post, float64(2)).Return(float64(2), nil)
+	repository.EXPECT().UpdateScore("post1", float64(2), gomock.Any()).Return(false, nil)
+
+	trendingService.AddEngagement("post1", trending.Comments, 1)
+
+	assert.NotContains(t, loggerOutput.String(), "Engagement of post post1 is now")
+}
+
+func TestEngagementOfMissingPostIsIgnoredWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetPost("post1").Return(nil, nil)
+
+	trendingService.AddEngagement("post1", trending.Reviews, 1)
+
+	assert.Contains(t, loggerOutput.String(), "Post post1 doesn't exist, its Reviews are not scored")
+}
+
+func TestNewerPostRanksHigherWithTheSameEngagementWithService(t *testing.T) {
+	setUpService(t)
+	scores := []float64{}
+	for _, createdAt := range []time.Time{time.Now().Add(-time.Hour), time.Now()} {
+		post := &database.PostMetadata{PostId: "post1", CreatedAt: createdAt}
+		repository.EXPECT().GetPost("post1").Return(post, nil)
+		repository.EXPECT().AddEngagement(post, float64(1)).Return(float64(1), nil)
+		repository.EXPECT().UpdateScore("post1", float64(1), gomock.Any()).DoAndReturn(func(postId string, engagement float64, score *float64) (bool, error) {
+			scores = append(scores, *score)
+			return true, nil
+		})
+
+		trendingService.AddEngagement("post1", trending.Likes, 1)
+	}
+
+	assert.Greater(t, scores[1], scores[0])
+}
+
+func TestRecomputeWithService(t *testing.T) {
+	setUpService(t)
+	createdAt := time.Unix(0, 0).Add(24 * time.Hour)
+	posts := []*database.PostMetadata{
+		{PostId: "post1", Username: "user1", Type: "TEXT", Likes: 2, Superlikes: 1, Comments: 1, Reviews: 1, CreatedAt: createdAt},
+		{PostId: "post2", Username: "user2", Type: "IMAGE", CreatedAt: createdAt},
+	}
+	records := []*database.TrendingRecord{
+		{PostId: "post1"},
+		{PostId: "post2"},
+		{PostId: "post3"},
+		{PostId: "recompute-lock"},
+	}
+	repository.EXPECT().AcquireRecomputeLock(gomock.Any(), 2*trendingConfig.RecomputeInterval).Return(true, nil)
+	repository.EXPECT().GetPosts().Return(posts, nil)
+	repository.EXPECT().GetTrendingRecords().Return(records, nil)
+	repository.EXPECT().SaveTrendingRecord(gomock.Any()).DoAndReturn(func(record *database.TrendingRecord) error {
+		assert.Equal(t, "post1", record.PostId)
+		assert.Equal(t, "TEXT", record.PostType)
+		assert.Equal(t, database.TrendingRanking, record.Ranking)
+		assert.Equal(t, float64(9), record.Engagement)
+		assert.InDelta(t, math.Log2(9)+1, *record.Score, 1e-9)
+		return nil
+	})
+	repository.EXPECT().RemoveTrendingRecords(gomock.InAnyOrder([]string{"post2", "post3"})).Return(nil)
+
+	err := trendingService.Recompute()
+
+	assert.Nil(t, err)
+	assert.Contains(t, loggerOutput.String(), "Trending scores recomputed, 1 posts scored and 2 removed")
+}
+
+func TestRecomputeWithoutTheLockWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().AcquireRecomputeLock(gomock.Any(), gomock.Any()).Return(false, nil)
+
+	err := trendingService.Recompute()
+
+	assert.Nil(t, err)
+}
+
+func TestErrorOnRecomputeWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().AcquireRecomputeLock(gomock.Any(), gomock.Any()).Return(true, nil)
+	repository.EXPECT().GetPosts().Return(nil, errors.New("some error"))
+
+	err := trendingService.Recompute()
+
+	assert.NotNil(t, err)
+}
+
+func TestRemoveUserPostsWithService(t *testing.T) {
+	setUpService(t)
+	records := []*database.TrendingRecord{
+		{PostId: "post1", Username: "user1"},
+		{PostId: "post2", Username: "user1"},
+	}
+	repository.EXPECT().GetUserTrendingRecords("user1").Return(records, nil)
+	repository.EXPECT().RemoveTrendingRecords([]string{"post1", "post2"}).Return(nil)
+
+	trendingService.RemoveUserPosts("user1")
+
+	assert.Contains(t, loggerOutput.String(), "2 posts were removed from the trending posts")
+}
+
+func TestErrorOnRemoveUserPostsWithService(t *testing.T) {
+	setUpService(t)
+	repository.EXPECT().GetUserTrendingRecords("user1").Return(nil, errors.New("some error"))
+
+	trendingService.RemoveUserPosts("user1")
+
+	assert.Contains(t, loggerOutput.String(), "Error getting the trending posts of user1")
+}
+
+func TestGetTrendingPostsWithService(t *testing.T) {
+	setUpService(t)
+	createdAt := time.Now().Add(-trendingConfig.HalfLife)
+	records := []*database.TrendingRecord{
+		{PostId: "post1", Username: "user1", PostType: "TEXT", CreatedAt: createdAt, Engagement: 8},
+	}
+	repository.EXPECT().GetTrendingRecordsByScore("TEXT", "", "", 10).Return(records, "post1", "20000.5", nil)
+
+	posts, lastPostId, lastScore, err := trendingService.GetTrendingPosts("TEXT", "", "", 10)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "post1", posts[0].PostId)
+	assert.Equal(t, "TEXT", posts[0].Type)
+	assert.InDelta(t, 4.0, posts[0].Score, 0.01)
+	assert.Equal(t, "post1", lastPostId)
+	assert.Equal(t, "20000.5", lastScore)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	config := trending.NewConfig()
+	config.Weights["Shares"] = 1
+	config.Weights[trending.Likes] = -1
+	config.HalfLife = 0
+
+	err := config.Validate()
+
+	assert.ErrorContains(t, err, "unknown engagement type Shares")
+	assert.ErrorContains(t, err, "weight of Likes can't be negative")
+	assert.ErrorContains(t, err, "half-life must be positive")
+}
@@ -0,0 +1,31 @@
+package trending_test
+
+import (
+	"encoding/json"
+	reaction_handler "readmodels/internal/reaction/handler"
+	"readmodels/internal/trending"
+	trending_handler "readmodels/internal/trending/handler"
+	mock_trending_handler "readmodels/internal/trending/handler/test/mock"
+	"testing"
+)
+
+var userUnlikedPostEventHandler *trending_handler.UserUnlikedPostEventHandler
+var userUnlikedPostEventService *mock_trending_handler.MockUserUnlikedPostEventService
+
+func setUpUserUnlikedPostEventHandler(t *testing.T) {
+	SetUp(t)
+	userUnlikedPostEventService = mock_trending_handler.NewMockUserUnlikedPostEventService(ctrl)
+	userUnlikedPostEventHandler = trending_handler.NewUserUnlikedPostEventHandler(userUnlikedPostEventService)
+}
+
+func TestHandleUserUnlikedPostEventForTrending(t *testing.T) {
+	setUpUserUnlikedPostEventHandler(t)
+	data := &reaction_handler.UserUnlikedPostEvent{
+		Username: "user1",
+		PostId:   "post1",
+	}
+	event, _ := json.Marshal(data)
+	userUnlikedPostEventService.EXPECT().AddEngagement("post1", trending.Likes, -1)
+
+	userUnlikedPostEventHandler.Handle(event)
+}
@@ -0,0 +1,80 @@
+package userprofile
+
+import (
+	"readmodels/internal/cache"
+	"readmodels/internal/model"
+)
+
+type CachedRepository struct {
+	repository Repository
+	cache      cache.Cache
+}
+
+func NewCachedRepository(repository Repository, cache cache.Cache) *CachedRepository {
+	return &CachedRepository{
+		repository: repository,
+		cache:      cache,
+	}
+}
+
+func (r *CachedRepository) GetUserProfile(username string) (*model.UserProfile, error) {
+	var userProfile model.UserProfile
+	if r.cache.Get(cache.UserProfileKey(username), &userProfile) {
+		return &userProfile, nil
+	}
+
+	result, err := r.repository.GetUserProfile(username)
+	if err != nil {
+		return result, err
+	}
+
+	r.cache.Set(cache.UserProfileKey(username), result)
+	return result, nil
+}
+
+func (r *CachedRepository) GetUserProfilesByRegion(region, userType string, lastUsername, lastUserType string, limit int) ([]*model.UserProfile, string, string, error) {
+	return r.repository.GetUserProfilesByRegion(region, userType, lastUsername, lastUserType, limit)
+}
+
+func (r *CachedRepository) GetUserProfilesWithoutUserType() ([]*model.UserProfile, error) {
+	return r.repository.GetUserProfilesWithoutUserType()
+}
+
+func (r *CachedRepository) SetUserTypeAndRegion(username, userType, region string) (bool, error) {
+	defer r.invalidate(username)
+	return r.repository.SetUserTypeAndRegion(username, userType, region)
+}
+
+func (r *CachedRepository) AddNewUserProfile(data *model.UserProfile) error {
+	defer r.invalidate(data.Username)
+	return r.repository.AddNewUserProfile(data)
+}
+
+func (r *CachedRepository) UpdateUserProfile(data *model.UserProfile) error {
+	defer r.invalidate(data.Username)
+	return r.repository.UpdateUserProfile(data)
+}
+
+func (r *CachedRepository) IncreaseFollowers(username string) error {
+	defer r.invalidate(username)
+	return r.repository.IncreaseFollowers(username)
+}
+
+func (r *CachedRepository) IncreaseFollowees(username string) error {
+	defer r.invalidate(username)
+	return r.repository.IncreaseFollowees(username)
+}
+
+func (r *CachedRepository) DecreaseFollowers(username string) error {
+	defer r.invalidate(username)
+	return r.repository.DecreaseFollowers(username)
+}
+
+func (r *CachedRepository) DecreaseFollowees(username string) error {
+	defer r.invalidate(username)
+	return r.repository.DecreaseFollowees(username)
+}
+
+func (r *CachedRepository) invalidate(username string) {
+	r.cache.Delete(cache.UserProfileKey(username))
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"readmodels/internal/api"
 	database "readmodels/internal/db"
+	"readmodels/internal/model"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
@@ -13,6 +15,13 @@ type UserProfileController struct {
 	service *UserProfileService
 }
 
+type GetUserProfilesResponse struct {
+	Users        []*model.UserProfile `json:"users"`
+	Limit        int                  `json:"limit"`
+	LastUsername string               `json:"lastUsername"`
+	LastUserType string               `json:"lastUserType"`
+}
+
 func NewUserProfileController(repository Repository) *UserProfileController {
 	return &UserProfileController{
 		service: NewUserProfileService(repository),
@@ -21,6 +30,7 @@ func NewUserProfileController(repository Repository) *UserProfileController {
 
 func (controller *UserProfileController) Routes(routerGroup *gin.RouterGroup) {
 	routerGroup.GET("/userprofile/:username", controller.GetUserProfile)
+	routerGroup.GET("/users", controller.GetUserProfiles)
 }
 
 func (controller *UserProfileController) GetUserProfile(c *gin.Context) {
@@ -42,3 +52,40 @@ func (controller *UserProfileController) GetUserProfile(c *gin.Context) {
 
 	api.SendOKWithResult(c, &userProfile)
 }
+
+func (controller *UserProfileController) GetUserProfiles(c *gin.Context) {
+	log.Info().Msg("Handling Request GET UserProfiles")
+	region := c.DefaultQuery("region", "")
+	userType := c.DefaultQuery("type", "")
+	lastUsername := c.DefaultQuery("lastUsername", "")
+	lastUserType := c.DefaultQuery("lastUserType", "")
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "12"))
+
+	if region == "" {
+		api.SendBadRequest(c, "Invalid parameters, region is required")
+		return
+	}
+
+	if err != nil || limit <= 0 {
+		api.SendBadRequest(c, "Invalid pagination parameters, limit has to be greater than 0")
+		return
+	}
+
+	if (lastUsername != "" && lastUserType == "") || (lastUsername == "" && lastUserType != "") {
+		api.SendBadRequest(c, "Invalid pagination parameters, lastUsername and lastUserType both have to have value or both have to be empty")
+		return
+	}
+
+	userProfiles, lastUsername, lastUserType, err := controller.service.GetUserProfilesByRegion(region, userType, lastUsername, lastUserType, limit)
+	if err != nil {
+		api.SendInternalServerError(c, err.Error())
+		return
+	}
+
+	api.SendOKWithResult(c, &GetUserProfilesResponse{
+		Users:        userProfiles,
+		Limit:        limit,
+		LastUsername: lastUsername,
+		LastUserType: lastUserType,
+	})
+}
@@ -2,6 +2,7 @@ package userprofile_handler
 
 import (
 	common_data "readmodels/internal/common/data"
+	"readmodels/internal/events"
 	"readmodels/internal/model"
 	userprofile "readmodels/internal/userprofile"
 
@@ -9,10 +10,17 @@ import (
 )
 
 type UserProfileUpdatedEvent struct {
-	Username string `json:"username"`
-	Bio      string `json:"bio"`
-	Link     string `json:"link"`
-	FullName string `json:"full_name"`
+	Username string `json:"username" validate:"id"`
+	Bio      string `json:"bio" validate:"max=1000"`
+	Link     string `json:"link" validate:"max=2048"`
+	FullName string `json:"fullName" validate:"max=255"`
+}
+
+// Version 1 of UserProfileUpdatedEvent used snake case keys.
+const UserProfileUpdatedEventVersion = 2
+
+var UserProfileUpdatedEventUpcasters = map[int]events.Upcaster{
+	1: events.RenameField("full_name", "fullName"),
 }
 
 type UserProfileUpdatedEventService interface {
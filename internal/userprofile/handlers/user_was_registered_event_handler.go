@@ -2,6 +2,7 @@ package userprofile_handler
 
 import (
 	common_data "readmodels/internal/common/data"
+	"readmodels/internal/events"
 	"readmodels/internal/model"
 	userprofile "readmodels/internal/userprofile"
 
@@ -9,11 +10,21 @@ import (
 )
 
 type UserWasRegisteredEvent struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	UserType string `json:"user_type"`
-	Region   string `json:"region"`
-	FullName string `json:"full_name"`
+	Username string `json:"username" validate:"id"`
+	Email    string `json:"email" validate:"required,email,max=254"`
+	UserType string `json:"userType" validate:"required,max=50"`
+	Region   string `json:"region" validate:"max=100"`
+	FullName string `json:"fullName" validate:"max=255"`
+}
+
+// Version 1 of UserWasRegisteredEvent used snake case keys.
+const UserWasRegisteredEventVersion = 2
+
+var UserWasRegisteredEventUpcasters = map[int]events.Upcaster{
+	1: events.RenameFields(map[string]string{
+		"user_type": "userType",
+		"full_name": "fullName",
+	}),
 }
 
 type UserWasRegisteredEventService interface {
@@ -50,5 +61,7 @@ func mapData(event UserWasRegisteredEvent) *model.UserProfile {
 		Name:     event.FullName,
 		Bio:      "",
 		Link:     "",
+		UserType: event.UserType,
+		Region:   event.Region,
 	}
 }
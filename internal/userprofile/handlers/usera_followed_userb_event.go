@@ -8,8 +8,8 @@ import (
 )
 
 type UserAFollowedUserBEvent struct {
-	FollowerID string `json:"followerId"`
-	FolloweeID string `json:"followeeId"`
+	FollowerID string `json:"followerId" validate:"id"`
+	FolloweeID string `json:"followeeId" validate:"id,nefield=FollowerID"`
 }
 
 type UserAFollowedUserBEventService interface {
@@ -8,8 +8,8 @@ import (
 )
 
 type UserAUnfollowedUserBEvent struct {
-	FollowerID string `json:"followerId"`
-	FolloweeID string `json:"followeeId"`
+	FollowerID string `json:"followerId" validate:"id"`
+	FolloweeID string `json:"followeeId" validate:"id,nefield=FollowerID"`
 }
 
 type UserAUnfollowedUserBEventService interface {
@@ -12,13 +12,53 @@ func (r UserProfileRepository) GetUserProfile(username string) (*model.UserProfi
 		Username: username,
 	}
 	var userProfile model.UserProfile
-	err := r.Client.GetData("UserProfile", userProfileKey, &userProfile)
+	err := r.Client.GetData(database.UserProfileTable, userProfileKey, &userProfile)
 
 	return &userProfile, err
 }
 
+func (r UserProfileRepository) GetUserProfilesByRegion(region, userType string, lastUsername, lastUserType string, limit int) ([]*model.UserProfile, string, string, error) {
+	return r.Client.GetUserProfilesByIndexRegion(region, userType, lastUsername, lastUserType, limit)
+}
+
+// GetUserProfilesWithoutUserType scans the profiles created before their user
+// type and region were stored, which the region index leaves out.
+func (r UserProfileRepository) GetUserProfilesWithoutUserType() ([]*model.UserProfile, error) {
+	var userProfiles []*model.UserProfile
+	err := r.Client.GetAllData(database.UserProfileTable, &userProfiles)
+	if err != nil {
+		return nil, err
+	}
+
+	withoutUserType := []*model.UserProfile{}
+	for _, userProfile := range userProfiles {
+		if userProfile.UserType == "" {
+			withoutUserType = append(withoutUserType, userProfile)
+		}
+	}
+	return withoutUserType, nil
+}
+
+// SetUserTypeAndRegion stores the user type and region of an existing
+// profile, returning false when there is no profile for username.
+func (r UserProfileRepository) SetUserTypeAndRegion(username, userType, region string) (bool, error) {
+	userProfileKey := &database.UserProfileKey{
+		Username: username,
+	}
+
+	// The region is an index key, which can't be an empty string
+	updateAttributes := map[string]any{
+		"UserType": userType,
+	}
+	if region != "" {
+		updateAttributes["Region"] = region
+	}
+
+	return r.Client.UpdateDataIfEqual(database.UserProfileTable, userProfileKey, updateAttributes, []string{}, "Username", username)
+}
+
 func (r UserProfileRepository) AddNewUserProfile(data *model.UserProfile) error {
-	return r.Client.InsertData("UserProfile", data)
+	return r.Client.InsertData(database.UserProfileTable, data)
 }
 
 func (r UserProfileRepository) UpdateUserProfile(data *model.UserProfile) error {
@@ -32,7 +72,7 @@ func (r UserProfileRepository) UpdateUserProfile(data *model.UserProfile) error
 		"Link": data.Link,
 	}
 
-	return r.Client.UpdateData("UserProfile", userProfileKey, updateAttributes)
+	return r.Client.UpdateData(database.UserProfileTable, userProfileKey, updateAttributes)
 }
 
 func (r UserProfileRepository) IncreaseFollowers(username string) error {
@@ -40,7 +80,7 @@ func (r UserProfileRepository) IncreaseFollowers(username string) error {
 		Username: username,
 	}
 
-	return r.Client.IncrementCounter("UserProfile", userProfileKey, "FollowersAmount", 1)
+	return r.Client.IncrementCounter(database.UserProfileTable, userProfileKey, "FollowersAmount", 1)
 }
 
 func (r UserProfileRepository) IncreaseFollowees(username string) error {
@@ -48,7 +88,7 @@ func (r UserProfileRepository) IncreaseFollowees(username string) error {
 		Username: username,
 	}
 
-	return r.Client.IncrementCounter("UserProfile", userProfileKey, "FolloweesAmount", 1)
+	return r.Client.IncrementCounter(database.UserProfileTable, userProfileKey, "FolloweesAmount", 1)
 }
 
 func (r UserProfileRepository) DecreaseFollowers(username string) error {
@@ -56,7 +96,7 @@ func (r UserProfileRepository) DecreaseFollowers(username string) error {
 		Username: username,
 	}
 
-	return r.Client.IncrementCounter("UserProfile", userProfileKey, "FollowersAmount", -1)
+	return r.Client.IncrementCounter(database.UserProfileTable, userProfileKey, "FollowersAmount", -1)
 }
 
 func (r UserProfileRepository) DecreaseFollowees(username string) error {
@@ -64,5 +104,5 @@ func (r UserProfileRepository) DecreaseFollowees(username string) error {
 		Username: username,
 	}
 
-	return r.Client.IncrementCounter("UserProfile", userProfileKey, "FolloweesAmount", -1)
+	return r.Client.IncrementCounter(database.UserProfileTable, userProfileKey, "FolloweesAmount", -1)
 }
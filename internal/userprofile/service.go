@@ -12,6 +12,9 @@ type Repository interface {
 	AddNewUserProfile(data *model.UserProfile) error
 	UpdateUserProfile(data *model.UserProfile) error
 	GetUserProfile(username string) (*model.UserProfile, error)
+	GetUserProfilesByRegion(region, userType string, lastUsername, lastUserType string, limit int) ([]*model.UserProfile, string, string, error)
+	GetUserProfilesWithoutUserType() ([]*model.UserProfile, error)
+	SetUserTypeAndRegion(username, userType, region string) (bool, error)
 	IncreaseFollowers(username string) error
 	IncreaseFollowees(username string) error
 	DecreaseFollowers(username string) error
@@ -58,6 +61,40 @@ func (s *UserProfileService) GetUserProfile(username string) (*model.UserProfile
 	return userprofile, nil
 }
 
+func (s *UserProfileService) GetUserProfilesByRegion(region, userType string, lastUsername, lastUserType string, limit int) ([]*model.UserProfile, string, string, error) {
+	userProfiles, lastUsername, lastUserType, err := s.repository.GetUserProfilesByRegion(region, userType, lastUsername, lastUserType, limit)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error getting userprofiles for region %s and type %s", region, userType)
+		return nil, "", "", err
+	}
+
+	return userProfiles, lastUsername, lastUserType, nil
+}
+
+// BackfillUserTypeAndRegion stores the user type and region of a profile
+// created before they were kept, returning whether the profile exists.
+func (s *UserProfileService) BackfillUserTypeAndRegion(data *model.UserProfile) (bool, error) {
+	found, err := s.repository.SetUserTypeAndRegion(data.Username, data.UserType, data.Region)
+	if err != nil {
+		log.Error().Stack().Err(err).Msgf("Error setting the user type and region of user %s", data.Username)
+		return false, err
+	}
+
+	return found, nil
+}
+
+// GetUserProfilesWithoutUserType lists the profiles still missing their user
+// type and region, which don't show up by region.
+func (s *UserProfileService) GetUserProfilesWithoutUserType() ([]*model.UserProfile, error) {
+	userProfiles, err := s.repository.GetUserProfilesWithoutUserType()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("Error getting the userprofiles without user type")
+		return nil, err
+	}
+
+	return userProfiles, nil
+}
+
 func (s *UserProfileService) IncreaseFollowers(username string) {
 	err := s.repository.IncreaseFollowers(username)
 	if err != nil {
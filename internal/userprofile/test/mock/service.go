@@ -91,6 +91,38 @@ func (mr *MockRepositoryMockRecorder) GetUserProfile(username interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockRepository)(nil).GetUserProfile), username)
 }
 
+// GetUserProfilesByRegion mocks base method.
+func (m *MockRepository) GetUserProfilesByRegion(region, userType, lastUsername, lastUserType string, limit int) ([]*model.UserProfile, string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfilesByRegion", region, userType, lastUsername, lastUserType, limit)
+	ret0, _ := ret[0].([]*model.UserProfile)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetUserProfilesByRegion indicates an expected call of GetUserProfilesByRegion.
+func (mr *MockRepositoryMockRecorder) GetUserProfilesByRegion(region, userType, lastUsername, lastUserType, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfilesByRegion", reflect.TypeOf((*MockRepository)(nil).GetUserProfilesByRegion), region, userType, lastUsername, lastUserType, limit)
+}
+
+// GetUserProfilesWithoutUserType mocks base method.
+func (m *MockRepository) GetUserProfilesWithoutUserType() ([]*model.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfilesWithoutUserType")
+	ret0, _ := ret[0].([]*model.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfilesWithoutUserType indicates an expected call of GetUserProfilesWithoutUserType.
+func (mr *MockRepositoryMockRecorder) GetUserProfilesWithoutUserType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfilesWithoutUserType", reflect.TypeOf((*MockRepository)(nil).GetUserProfilesWithoutUserType))
+}
+
 // IncreaseFollowees mocks base method.
 func (m *MockRepository) IncreaseFollowees(username string) error {
 	m.ctrl.T.Helper()
@@ -119,6 +151,21 @@ func (mr *MockRepositoryMockRecorder) IncreaseFollowers(username interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseFollowers", reflect.TypeOf((*MockRepository)(nil).IncreaseFollowers), username)
 }
 
+// SetUserTypeAndRegion mocks base method.
+func (m *MockRepository) SetUserTypeAndRegion(username, userType, region string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserTypeAndRegion", username, userType, region)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserTypeAndRegion indicates an expected call of SetUserTypeAndRegion.
+func (mr *MockRepositoryMockRecorder) SetUserTypeAndRegion(username, userType, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserTypeAndRegion", reflect.TypeOf((*MockRepository)(nil).SetUserTypeAndRegion), username, userType, region)
+}
+
 // UpdateUserProfile mocks base method.
 func (m *MockRepository) UpdateUserProfile(data *model.UserProfile) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,82 @@
+package userprofile_test
+
+import (
+	"errors"
+	"readmodels/internal/cache"
+	"readmodels/internal/model"
+	"readmodels/internal/userprofile"
+	mock_userprofile "readmodels/internal/userprofile/test/mock"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var cachedRepositoryRepository *mock_userprofile.MockRepository
+var cachedRepositoryCache *cache.LRUCache
+var cachedRepository *userprofile.CachedRepository
+
+func setUpCachedRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cachedRepositoryRepository = mock_userprofile.NewMockRepository(ctrl)
+	cachedRepositoryCache = cache.NewLRUCache(10, time.Minute)
+	cachedRepository = userprofile.NewCachedRepository(cachedRepositoryRepository, cachedRepositoryCache)
+}
+
+func TestGetUserProfileIsReadThroughCache(t *testing.T) {
+	setUpCachedRepository(t)
+	username := "username1"
+	expectedUserProfile := &model.UserProfile{
+		Username: username,
+		Name:     "user name",
+	}
+	cachedRepositoryRepository.EXPECT().GetUserProfile(username).Return(expectedUserProfile, nil).Times(1)
+
+	firstResult, _ := cachedRepository.GetUserProfile(username)
+	secondResult, _ := cachedRepository.GetUserProfile(username)
+
+	assert.Equal(t, expectedUserProfile, firstResult)
+	assert.Equal(t, expectedUserProfile, secondResult)
+	assert.Equal(t, uint64(1), cachedRepositoryCache.Stats().Hits)
+	assert.Equal(t, uint64(1), cachedRepositoryCache.Stats().Misses)
+}
+
+func TestGetUserProfileDoesNotCacheErrors(t *testing.T) {
+	setUpCachedRepository(t)
+	username := "username1"
+	cachedRepositoryRepository.EXPECT().GetUserProfile(username).Return(&model.UserProfile{}, errors.New("some error")).Times(2)
+
+	cachedRepository.GetUserProfile(username)
+	_, err := cachedRepository.GetUserProfile(username)
+
+	assert.NotNil(t, err)
+}
+
+func TestUpdateUserProfileInvalidatesCache(t *testing.T) {
+	setUpCachedRepository(t)
+	username := "username1"
+	data := &model.UserProfile{
+		Username: username,
+		Name:     "new name",
+	}
+	cachedRepositoryCache.Set(cache.UserProfileKey(username), &model.UserProfile{Username: username})
+	cachedRepositoryRepository.EXPECT().UpdateUserProfile(data).Return(nil)
+	cachedRepositoryRepository.EXPECT().GetUserProfile(username).Return(data, nil)
+
+	cachedRepository.UpdateUserProfile(data)
+	result, _ := cachedRepository.GetUserProfile(username)
+
+	assert.Equal(t, data, result)
+}
+
+func TestIncreaseFollowersInvalidatesCache(t *testing.T) {
+	setUpCachedRepository(t)
+	username := "username1"
+	cachedRepositoryCache.Set(cache.UserProfileKey(username), &model.UserProfile{Username: username})
+	cachedRepositoryRepository.EXPECT().IncreaseFollowers(username).Return(nil)
+
+	cachedRepository.IncreaseFollowers(username)
+
+	assert.Equal(t, 0, cachedRepositoryCache.Stats().Entries)
+}
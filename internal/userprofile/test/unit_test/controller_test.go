@@ -3,7 +3,9 @@ package userprofile_test
 import (
 	"bytes"
 	"errors"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
 	database "readmodels/internal/db"
 	"readmodels/internal/model"
 	"readmodels/internal/userprofile"
@@ -42,6 +44,8 @@ func TestGetUserProfile(t *testing.T) {
 		Name:            "user name",
 		Bio:             "",
 		Link:            "",
+		UserType:        "UA",
+		Region:          "Vigo",
 		FollowersAmount: 10,
 		FolloweesAmount: 20,
 		PostsAmount:     60,
@@ -55,6 +59,8 @@ func TestGetUserProfile(t *testing.T) {
 			"name": "user name",
 			"bio": "",
 			"link": "",
+			"userType": "UA",
+			"region": "Vigo",
 			"followersAmount": 10,
 			"followeesAmount": 20,
 			"postsAmount": 60
@@ -119,6 +125,92 @@ func TestInternalServerErrorOnGetUserProfile(t *testing.T) {
 	assert.Equal(t, removeSpace(apiResponse.Body.String()), removeSpace(expectedBodyResponse))
 }
 
+func TestGetUserProfilesByRegion(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/users", nil)
+	u := url.Values{}
+	u.Add("region", "Vigo")
+	u.Add("type", "PROFESSIONAL")
+	u.Add("lastUsername", "username0")
+	u.Add("lastUserType", "PROFESSIONAL")
+	u.Add("limit", "1")
+	ginContext.Request.URL.RawQuery = u.Encode()
+	data := []*model.UserProfile{
+		{
+			Username: "username1",
+			Name:     "user name",
+			UserType: "PROFESSIONAL",
+			Region:   "Vigo",
+		},
+	}
+	controllerRepository.EXPECT().GetUserProfilesByRegion("Vigo", "PROFESSIONAL", "username0", "PROFESSIONAL", 1).Return(data, "username1", "PROFESSIONAL", nil)
+	expectedBodyResponse := `{
+		"error": false,
+		"message": "200 OK",
+		"content": {
+			"users": [
+				{
+					"username": "username1",
+					"name": "user name",
+					"bio": "",
+					"link": "",
+					"userType": "PROFESSIONAL",
+					"region": "Vigo",
+					"followersAmount": 0,
+					"followeesAmount": 0,
+					"postsAmount": 0
+				}
+			],
+			"limit": 1,
+			"lastUsername": "username1",
+			"lastUserType": "PROFESSIONAL"
+		}
+	}`
+
+	controller.GetUserProfiles(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 200)
+	assert.Equal(t, removeSpace(apiResponse.Body.String()), removeSpace(expectedBodyResponse))
+}
+
+func TestBadRequestOnGetUserProfilesByRegion_WhenRegionIsMissing(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/users", nil)
+	u := url.Values{}
+	u.Add("type", "PROFESSIONAL")
+	ginContext.Request.URL.RawQuery = u.Encode()
+
+	controller.GetUserProfiles(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 400)
+}
+
+func TestBadRequestOnGetUserProfilesByRegion_WhenOnlyLastUsernameIsSet(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/users", nil)
+	u := url.Values{}
+	u.Add("region", "Vigo")
+	u.Add("lastUsername", "username0")
+	ginContext.Request.URL.RawQuery = u.Encode()
+
+	controller.GetUserProfiles(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 400)
+}
+
+func TestInternalServerErrorOnGetUserProfilesByRegion(t *testing.T) {
+	setUpController(t)
+	ginContext.Request, _ = http.NewRequest("GET", "/users", nil)
+	u := url.Values{}
+	u.Add("region", "Vigo")
+	ginContext.Request.URL.RawQuery = u.Encode()
+	controllerRepository.EXPECT().GetUserProfilesByRegion("Vigo", "", "", "", 12).Return(nil, "", "", errors.New("some error"))
+
+	controller.GetUserProfiles(ginContext)
+
+	assert.Equal(t, apiResponse.Code, 500)
+}
+
 func removeSpace(s string) string {
 	return strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(s, " ", ""), "\t", ""), "\n", "")
 }
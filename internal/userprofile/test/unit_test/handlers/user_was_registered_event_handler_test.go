@@ -39,6 +39,8 @@ func TestHandleUserWasRegisteredEventHandler(t *testing.T) {
 		Name:     "user lastname",
 		Bio:      "",
 		Link:     "",
+		UserType: "UA",
+		Region:   "Vigo",
 	}
 	repository.EXPECT().AddNewUserProfile(expectedUserprofile)
 
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
 var client *mock_database.MockDatabaseClient
@@ -109,3 +110,56 @@ func TestDecreaseFolloweesFromRepository(t *testing.T) {
 
 	userProfileRepository.DecreaseFollowees(username)
 }
+
+func TestGetUserProfilesByRegionInRepository(t *testing.T) {
+	setUp(t)
+	expectedUserProfiles := []*model.UserProfile{
+		{Username: "username1", Name: "user name", UserType: "PROFESSIONAL", Region: "Vigo"},
+	}
+	client.EXPECT().GetUserProfilesByIndexRegion("Vigo", "PROFESSIONAL", "username0", "PROFESSIONAL", 4).Return(expectedUserProfiles, "username1", "PROFESSIONAL", nil)
+
+	userProfiles, lastUsername, lastUserType, err := userProfileRepository.GetUserProfilesByRegion("Vigo", "PROFESSIONAL", "username0", "PROFESSIONAL", 4)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedUserProfiles, userProfiles)
+	assert.Equal(t, "username1", lastUsername)
+	assert.Equal(t, "PROFESSIONAL", lastUserType)
+}
+
+func TestSetUserTypeAndRegionInRepository(t *testing.T) {
+	setUp(t)
+	expectedUserProfileKey := &database.UserProfileKey{Username: "username1"}
+	client.EXPECT().UpdateDataIfEqual("UserProfile", expectedUserProfileKey, map[string]any{"UserType": "PROFESSIONAL", "Region": "Vigo"}, []string{}, "Username", "username1").Return(true, nil)
+
+	found, err := userProfileRepository.SetUserTypeAndRegion("username1", "PROFESSIONAL", "Vigo")
+
+	assert.Nil(t, err)
+	assert.True(t, found)
+}
+
+func TestSetUserTypeWithoutRegionInRepository(t *testing.T) {
+	setUp(t)
+	expectedUserProfileKey := &database.UserProfileKey{Username: "username1"}
+	client.EXPECT().UpdateDataIfEqual("UserProfile", expectedUserProfileKey, map[string]any{"UserType": "PROFESSIONAL"}, []string{}, "Username", "username1").Return(false, nil)
+
+	found, err := userProfileRepository.SetUserTypeAndRegion("username1", "PROFESSIONAL", "")
+
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
+
+func TestGetUserProfilesWithoutUserTypeInRepository(t *testing.T) {
+	setUp(t)
+	client.EXPECT().GetAllData("UserProfile", gomock.Any()).DoAndReturn(func(tableName string, results any) error {
+		*results.(*[]*model.UserProfile) = []*model.UserProfile{
+			{Username: "username1", UserType: "PROFESSIONAL", Region: "Vigo"},
+			{Username: "username2"},
+		}
+		return nil
+	})
+
+	userProfiles, err := userProfileRepository.GetUserProfilesWithoutUserType()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.UserProfile{{Username: "username2"}}, userProfiles)
+}
@@ -190,3 +190,25 @@ func TestErrorOnDecreaseFolloweesWithService(t *testing.T) {
 
 	assert.Contains(t, serviceLoggerOutput.String(), "Error decreasing "+username+"'s followees")
 }
+
+func TestBackfillUserTypeAndRegionWithService(t *testing.T) {
+	setUpService(t)
+	data := &model.UserProfile{Username: "username1", UserType: "PROFESSIONAL", Region: "Vigo"}
+	serviceRepository.EXPECT().SetUserTypeAndRegion("username1", "PROFESSIONAL", "Vigo").Return(true, nil)
+
+	found, err := userProfileService.BackfillUserTypeAndRegion(data)
+
+	assert.Nil(t, err)
+	assert.True(t, found)
+}
+
+func TestErrorOnBackfillUserTypeAndRegionWithService(t *testing.T) {
+	setUpService(t)
+	data := &model.UserProfile{Username: "username1", UserType: "PROFESSIONAL", Region: "Vigo"}
+	serviceRepository.EXPECT().SetUserTypeAndRegion("username1", "PROFESSIONAL", "Vigo").Return(false, errors.New("some error"))
+
+	_, err := userProfileService.BackfillUserTypeAndRegion(data)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, serviceLoggerOutput.String(), "Error setting the user type and region of user username1")
+}
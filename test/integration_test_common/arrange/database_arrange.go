@@ -18,13 +18,13 @@ func CreateTestDatabase(t *testing.T, ctx context.Context) *database.Database {
 }
 
 func AddUserProfileToDatabase(t *testing.T, db *database.Database, data *model.UserProfile) {
-	err := db.Client.InsertData("UserProfile", data)
+	err := db.Client.InsertData(database.UserProfileTable, data)
 	assert.Nil(t, err)
 	userProfileKey := &database.UserProfileKey{
 		Username: data.Username,
 	}
 	var userProfile model.UserProfile
-	err = db.Client.GetData("UserProfile", userProfileKey, &userProfile)
+	err = db.Client.GetData(database.UserProfileTable, userProfileKey, &userProfile)
 	assert.Nil(t, err)
 	assert.Equal(t, userProfile.Username, data.Username)
 	assert.Equal(t, userProfile.Name, data.Name)
@@ -33,13 +33,13 @@ func AddUserProfileToDatabase(t *testing.T, db *database.Database, data *model.U
 }
 
 func AddCommentToDatabase(t *testing.T, db *database.Database, data *model.Comment) {
-	err := db.Client.InsertData("readmodels.comments", data)
+	err := db.Client.InsertData(database.CommentsTable, data)
 	assert.Nil(t, err)
 	commentKey := &database.CommentKey{
 		CommentId: data.CommentId,
 	}
 	var comment model.Comment
-	err = db.Client.GetData("readmodels.comments", commentKey, &comment)
+	err = db.Client.GetData(database.CommentsTable, commentKey, &comment)
 	assert.Nil(t, err)
 	assert.Equal(t, comment.CommentId, data.CommentId)
 	assert.Equal(t, comment.Username, data.Username)
@@ -52,26 +52,26 @@ func AddCommentToDatabase(t *testing.T, db *database.Database, data *model.Comme
 		Type:     "TEXT",
 		Comments: 1,
 	}
-	err = db.Client.InsertData("PostMetadata", post)
+	err = db.Client.InsertData(database.PostMetadataTable, post)
 	assert.Nil(t, err)
 	var existingPost database.PostMetadata
 	postKey := &database.PostMetadataKey{
 		PostId: post.PostId,
 	}
-	err = db.Client.GetData("PostMetadata", postKey, &existingPost)
+	err = db.Client.GetData(database.PostMetadataTable, postKey, &existingPost)
 	assert.Nil(t, err)
 	assert.Equal(t, existingPost.PostId, post.PostId)
 	assert.Equal(t, existingPost.Comments, 1)
 }
 
 func AddReviewToDatabase(t *testing.T, db *database.Database, data *model.Review) {
-	err := db.Client.InsertData("readmodels.reviews", data)
+	err := db.Client.InsertData(database.ReviewsTable, data)
 	assert.Nil(t, err)
 	reviewKey := &database.ReviewKey{
 		ReviewId: data.ReviewId,
 	}
 	var review model.Review
-	err = db.Client.GetData("readmodels.reviews", reviewKey, &review)
+	err = db.Client.GetData(database.ReviewsTable, reviewKey, &review)
 	assert.Nil(t, err)
 	assert.Equal(t, review.ReviewId, data.ReviewId)
 	assert.Equal(t, review.Username, data.Username)
@@ -84,26 +84,26 @@ func AddReviewToDatabase(t *testing.T, db *database.Database, data *model.Review
 		Type:     "TEXT",
 		Reviews:  1,
 	}
-	err = db.Client.InsertData("PostMetadata", post)
+	err = db.Client.InsertData(database.PostMetadataTable, post)
 	assert.Nil(t, err)
 	var existingPost database.PostMetadata
 	postKey := &database.PostMetadataKey{
 		PostId: post.PostId,
 	}
-	err = db.Client.GetData("PostMetadata", postKey, &existingPost)
+	err = db.Client.GetData(database.PostMetadataTable, postKey, &existingPost)
 	assert.Nil(t, err)
 	assert.Equal(t, existingPost.PostId, post.PostId)
 	assert.Equal(t, existingPost.Reviews, 1)
 }
 
 func AddPostToDatabase(t *testing.T, db *database.Database, data *database.PostMetadata) {
-	err := db.Client.InsertData("PostMetadata", data)
+	err := db.Client.InsertData(database.PostMetadataTable, data)
 	assert.Nil(t, err)
 	postKey := &database.PostMetadataKey{
 		PostId: data.PostId,
 	}
 	var post database.PostMetadata
-	err = db.Client.GetData("PostMetadata", postKey, &post)
+	err = db.Client.GetData(database.PostMetadataTable, postKey, &post)
 	assert.Nil(t, err)
 	assert.Equal(t, data.Title, post.Title)
 	assert.Equal(t, data.Username, post.Username)
@@ -114,14 +114,14 @@ func AddPostToDatabase(t *testing.T, db *database.Database, data *database.PostM
 }
 
 func AddPostLikeToDatabase(t *testing.T, db *database.Database, data *database.PostLikeMetadata) {
-	err := db.Client.InsertData("readmodels.postLikes", data)
+	err := db.Client.InsertData(database.PostLikesTable, data)
 	assert.Nil(t, err)
 	likeKey := &database.PostLikeKey{
 		PostId:   data.PostId,
 		Username: data.Username,
 	}
 	var postLike database.PostLikeMetadata
-	err = db.Client.GetData("readmodels.postLikes", likeKey, &postLike)
+	err = db.Client.GetData(database.PostLikesTable, likeKey, &postLike)
 	assert.Nil(t, err)
 	assert.Equal(t, postLike.PostId, data.PostId)
 	assert.Equal(t, postLike.Username, data.Username)
@@ -129,14 +129,14 @@ func AddPostLikeToDatabase(t *testing.T, db *database.Database, data *database.P
 }
 
 func AddPostSuperlikeToDatabase(t *testing.T, db *database.Database, data *database.PostSuperlikeMetadata) {
-	err := db.Client.InsertData("readmodels.postSuperlikes", data)
+	err := db.Client.InsertData(database.PostSuperlikesTable, data)
 	assert.Nil(t, err)
 	likeKey := &database.PostSuperlikeKey{
 		PostId:   data.PostId,
 		Username: data.Username,
 	}
 	var postSuperlike database.PostSuperlikeMetadata
-	err = db.Client.GetData("readmodels.postSuperlikes", likeKey, &postSuperlike)
+	err = db.Client.GetData(database.PostSuperlikesTable, likeKey, &postSuperlike)
 	assert.Nil(t, err)
 	assert.Equal(t, postSuperlike.PostId, data.PostId)
 	assert.Equal(t, postSuperlike.Username, data.Username)
@@ -14,7 +14,7 @@ func AssertCommentExists(t *testing.T, db *database.Database, expectedCommentId
 		CommentId: expectedCommentId,
 	}
 	var comment model.Comment
-	err := db.Client.GetData("readmodels.comments", commentKey, &comment)
+	err := db.Client.GetData(database.CommentsTable, commentKey, &comment)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedCommentId, comment.CommentId)
 	assert.Equal(t, expectedComment.PostId, comment.PostId)
@@ -27,7 +27,7 @@ func AssertCommentDoesNotExist(t *testing.T, db *database.Database, expectedComm
 		CommentId: expectedCommentId,
 	}
 	var comment model.Comment
-	err := db.Client.GetData("readmodels.comments", commentKey, &comment)
+	err := db.Client.GetData(database.CommentsTable, commentKey, &comment)
 	assert.NotNil(t, err)
 	assert.Equal(t, fmt.Sprintf("Data in table readmodels.comments not found for key %v", commentKey), err.Error())
 }
@@ -37,7 +37,7 @@ func AssertPostCommentsIncreased(t *testing.T, db *database.Database, postId str
 		PostId: postId,
 	}
 	var post database.PostMetadata
-	err := db.Client.GetData("PostMetadata", postKey, &post)
+	err := db.Client.GetData(database.PostMetadataTable, postKey, &post)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, post.Comments)
 }
@@ -47,7 +47,7 @@ func AssertPostCommentsDecreased(t *testing.T, db *database.Database, postId str
 		PostId: postId,
 	}
 	var post database.PostMetadata
-	err := db.Client.GetData("PostMetadata", postKey, &post)
+	err := db.Client.GetData(database.PostMetadataTable, postKey, &post)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, post.Comments)
 }
@@ -57,7 +57,7 @@ func AssertReviewExists(t *testing.T, db *database.Database, expectedReviewId ui
 		ReviewId: expectedReviewId,
 	}
 	var review model.Review
-	err := db.Client.GetData("readmodels.reviews", reviewKey, &review)
+	err := db.Client.GetData(database.ReviewsTable, reviewKey, &review)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedReviewId, review.ReviewId)
 	assert.Equal(t, expectedReview.PostId, review.PostId)
@@ -70,7 +70,7 @@ func AssertPostReviewsIncreased(t *testing.T, db *database.Database, postId stri
 		PostId: postId,
 	}
 	var post database.PostMetadata
-	err := db.Client.GetData("PostMetadata", postKey, &post)
+	err := db.Client.GetData(database.PostMetadataTable, postKey, &post)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, post.Reviews)
 }
@@ -81,7 +81,7 @@ func AssertPostLikeExists(t *testing.T, db *database.Database, expectedPostLike
 		Username: expectedPostLike.User.Username,
 	}
 	var postLike database.PostLikeMetadata
-	err := db.Client.GetData("readmodels.postLikes", postLikeKey, &postLike)
+	err := db.Client.GetData(database.PostLikesTable, postLikeKey, &postLike)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedPostLike.PostId, postLike.PostId)
 	assert.Equal(t, expectedPostLike.User.Username, postLike.Username)
@@ -94,7 +94,7 @@ func AssertPostLikeDoesNotExists(t *testing.T, db *database.Database, expectedPo
 		Username: expectedPostLike.User.Username,
 	}
 	var postLike database.PostLikeMetadata
-	err := db.Client.GetData("readmodels.postLikes", postLikeKey, &postLike)
+	err := db.Client.GetData(database.PostLikesTable, postLikeKey, &postLike)
 	assert.NotNil(t, err)
 	assert.Equal(t, fmt.Sprintf("Data in table readmodels.postLikes not found for key %v", postLikeKey), err.Error())
 }
@@ -104,7 +104,7 @@ func AssertPostLikesIncreased(t *testing.T, db *database.Database, postId string
 		PostId: postId,
 	}
 	var post database.PostMetadata
-	err := db.Client.GetData("PostMetadata", postKey, &post)
+	err := db.Client.GetData(database.PostMetadataTable, postKey, &post)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, post.Likes)
 }
@@ -114,7 +114,7 @@ func AssertPostLikesDecreased(t *testing.T, db *database.Database, postId string
 		PostId: postId,
 	}
 	var post database.PostMetadata
-	err := db.Client.GetData("PostMetadata", postKey, &post)
+	err := db.Client.GetData(database.PostMetadataTable, postKey, &post)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, post.Likes)
 }
@@ -125,7 +125,7 @@ func AssertPostSuperlikeExists(t *testing.T, db *database.Database, expectedPost
 		Username: expectedPostSuperlike.User.Username,
 	}
 	var postSuperlike database.PostSuperlikeMetadata
-	err := db.Client.GetData("readmodels.postSuperlikes", postSuperlikeKey, &postSuperlike)
+	err := db.Client.GetData(database.PostSuperlikesTable, postSuperlikeKey, &postSuperlike)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedPostSuperlike.PostId, postSuperlike.PostId)
 	assert.Equal(t, expectedPostSuperlike.User.Username, postSuperlike.Username)
@@ -138,7 +138,7 @@ func AssertPostSuperlikeDoesNotExists(t *testing.T, db *database.Database, expec
 		Username: expectedPostSuperlike.User.Username,
 	}
 	var postSuperlike database.PostSuperlikeMetadata
-	err := db.Client.GetData("readmodels.postSuperlikes", postLikeKey, &postSuperlike)
+	err := db.Client.GetData(database.PostSuperlikesTable, postLikeKey, &postSuperlike)
 	assert.NotNil(t, err)
 	assert.Equal(t, fmt.Sprintf("Data in table readmodels.postSuperlikes not found for key %v", postLikeKey), err.Error())
 }
@@ -148,7 +148,7 @@ func AssertPostSuperlikesIncreased(t *testing.T, db *database.Database, postId s
 		PostId: postId,
 	}
 	var post database.PostMetadata
-	err := db.Client.GetData("PostMetadata", postKey, &post)
+	err := db.Client.GetData(database.PostMetadataTable, postKey, &post)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, post.Superlikes)
 }
@@ -158,7 +158,7 @@ func AssertPostSuperlikesDecreased(t *testing.T, db *database.Database, postId s
 		PostId: postId,
 	}
 	var post database.PostMetadata
-	err := db.Client.GetData("PostMetadata", postKey, &post)
+	err := db.Client.GetData(database.PostMetadataTable, postKey, &post)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, post.Superlikes)
 }